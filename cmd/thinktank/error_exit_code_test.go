@@ -31,8 +31,10 @@ func handleTestError(ctx context.Context, err error, logger logutil.LoggerInterf
 		// Just calculate the exit code for testing purposes
 		exitCode := cli.ExitCodeGenericError
 
-		// Check if the error is an LLMError that implements CategorizedError
-		if catErr, ok := llm.IsCategorizedError(err); ok {
+		// Partial success takes precedence over the category of the individual failures
+		if errors.Is(err, thinktank.ErrPartialSuccess) {
+			exitCode = cli.ExitCodePartialSuccess
+		} else if catErr, ok := llm.IsCategorizedError(err); ok {
 			category := catErr.Category()
 
 			// Determine exit code based on error category
@@ -56,9 +58,6 @@ func handleTestError(ctx context.Context, err error, logger logutil.LoggerInterf
 			case llm.CategoryCancelled:
 				exitCode = cli.ExitCodeCancelled
 			}
-		} else if errors.Is(err, thinktank.ErrPartialSuccess) {
-			// Special case for partial success errors
-			exitCode = cli.ExitCodeGenericError
 		} else if errors.Is(err, thinktank.ErrInvalidConfiguration) ||
 			errors.Is(err, thinktank.ErrNoModelsProvided) ||
 			errors.Is(err, thinktank.ErrInvalidInstructions) ||
@@ -141,7 +140,7 @@ func TestHandleErrorExitCodes(t *testing.T) {
 		{
 			name:         "Partial success error",
 			err:          thinktank.ErrPartialSuccess,
-			expectedCode: cli.ExitCodeGenericError,
+			expectedCode: cli.ExitCodePartialSuccess,
 		},
 	}
 
@@ -218,7 +217,7 @@ func TestThinkTankSentinelErrorHandling(t *testing.T) {
 		{
 			name:         "ErrPartialSuccess",
 			err:          thinktank.ErrPartialSuccess,
-			expectedCode: cli.ExitCodeGenericError,
+			expectedCode: cli.ExitCodePartialSuccess,
 		},
 		{
 			name:         "ErrInvalidConfiguration",
@@ -0,0 +1,181 @@
+package auditlog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrAuditLoggerClosed is returned when logging to an AsyncAuditLogger after Close
+var ErrAuditLoggerClosed = errors.New("audit logger is closed")
+
+// OverflowPolicy determines what an AsyncAuditLogger does when its buffer is full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for room in the buffer, so no entries are lost
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDrop discards the entry and counts it; the count is written in a summary entry on Close
+	OverflowDrop
+)
+
+// asyncEntry is a queued entry along with the context it was logged under
+type asyncEntry struct {
+	ctx   context.Context
+	entry AuditEntry
+}
+
+// AsyncAuditLogger wraps another AuditLogger, queueing entries on a buffered channel
+// and writing them from a background goroutine so callers don't block on disk I/O.
+// Entries are written in the order they were logged.
+type AsyncAuditLogger struct {
+	inner   AuditLogger
+	entries chan asyncEntry
+	done    chan struct{}
+
+	mu     sync.RWMutex // Guards closed and policy against concurrent sends
+	closed bool
+	policy OverflowPolicy
+
+	dropped  atomic.Int64
+	writeErr error // First error from the inner logger; only touched by the writer goroutine until done
+}
+
+// NewAsyncAuditLogger starts a background writer for inner with room for bufferSize queued entries.
+// The logger blocks when the buffer is full; use SetOverflowPolicy to drop entries instead.
+func NewAsyncAuditLogger(inner AuditLogger, bufferSize int) *AsyncAuditLogger {
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+	l := &AsyncAuditLogger{
+		inner:   inner,
+		entries: make(chan asyncEntry, bufferSize),
+		done:    make(chan struct{}),
+	}
+	go l.run()
+	return l
+}
+
+// SetOverflowPolicy sets what happens when the buffer is full
+func (l *AsyncAuditLogger) SetOverflowPolicy(policy OverflowPolicy) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.policy = policy
+}
+
+// Dropped returns the number of entries discarded because the buffer was full
+func (l *AsyncAuditLogger) Dropped() int64 {
+	return l.dropped.Load()
+}
+
+// run writes queued entries until the channel is closed
+func (l *AsyncAuditLogger) run() {
+	defer close(l.done)
+	for queued := range l.entries {
+		if err := l.inner.Log(queued.ctx, queued.entry); err != nil && l.writeErr == nil {
+			l.writeErr = err
+		}
+	}
+}
+
+// Log queues an entry for writing. It returns once the entry is queued, not written.
+func (l *AsyncAuditLogger) Log(ctx context.Context, entry AuditEntry) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Callers may reuse their maps after Log returns, so queue copies
+	entry.Inputs = copyFields(entry.Inputs)
+	entry.Outputs = copyFields(entry.Outputs)
+	queued := asyncEntry{ctx: context.WithoutCancel(ctx), entry: entry}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.closed {
+		return ErrAuditLoggerClosed
+	}
+
+	if l.policy == OverflowDrop {
+		select {
+		case l.entries <- queued:
+		default:
+			l.dropped.Add(1)
+		}
+		return nil
+	}
+	l.entries <- queued
+	return nil
+}
+
+// LogContext queues an entry like Log, unless ctx is already done, in which case
+// it returns ctx.Err() without queueing
+func (l *AsyncAuditLogger) LogContext(ctx context.Context, entry AuditEntry) error {
+	if ctx != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return l.Log(ctx, entry)
+}
+
+// LogOp builds an entry the same way FileAuditLogger does, timestamped now, and queues it
+func (l *AsyncAuditLogger) LogOp(ctx context.Context, operation, status string, inputs map[string]interface{}, outputs map[string]interface{}, err error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return l.Log(ctx, NewOpEntry(ctx, operation, status, inputs, outputs, err))
+}
+
+// LogLegacy is the non-context version of Log for backward compatibility.
+func (l *AsyncAuditLogger) LogLegacy(entry AuditEntry) error {
+	return l.Log(context.Background(), entry)
+}
+
+// LogOpLegacy is the non-context version of LogOp for backward compatibility.
+func (l *AsyncAuditLogger) LogOpLegacy(operation, status string, inputs map[string]interface{}, outputs map[string]interface{}, err error) error {
+	return l.LogOp(context.Background(), operation, status, inputs, outputs, err)
+}
+
+// Close drains the queue, waits for the writer to finish, and closes the inner logger.
+// Under OverflowDrop a final summary entry records how many entries were dropped.
+// It returns the first write error, if any, along with any error closing the inner logger.
+func (l *AsyncAuditLogger) Close() error {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil
+	}
+	l.closed = true
+	policy := l.policy
+	close(l.entries)
+	l.mu.Unlock()
+
+	<-l.done
+
+	var summaryErr error
+	if policy == OverflowDrop {
+		outputs := map[string]interface{}{"dropped_entries": l.dropped.Load()}
+		summaryErr = l.inner.LogOp(context.Background(), "AuditLogSummary", "Success", nil, outputs, nil)
+	}
+
+	var writeErr error
+	if l.writeErr != nil {
+		writeErr = fmt.Errorf("failed to write queued audit entry: %w", l.writeErr)
+	}
+	return errors.Join(writeErr, summaryErr, l.inner.Close())
+}
+
+// copyFields returns a shallow copy of an entry's inputs or outputs
+func copyFields(fields map[string]interface{}) map[string]interface{} {
+	if fields == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		copied[k] = v
+	}
+	return copied
+}
+
+// Compile-time check to ensure AsyncAuditLogger satisfies the AuditLogger interface.
+var _ AuditLogger = (*AsyncAuditLogger)(nil)
@@ -0,0 +1,159 @@
+package auditlog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// blockingAuditLogger records entries, holding each write until release is closed
+type blockingAuditLogger struct {
+	mu      sync.Mutex
+	entries []AuditEntry
+	release chan struct{}
+	closed  bool
+	logErr  error
+}
+
+func (b *blockingAuditLogger) Log(ctx context.Context, entry AuditEntry) error {
+	if b.release != nil {
+		<-b.release
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries = append(b.entries, entry)
+	return b.logErr
+}
+
+func (b *blockingAuditLogger) LogOp(ctx context.Context, operation, status string, inputs, outputs map[string]interface{}, err error) error {
+	return b.Log(ctx, NewOpEntry(ctx, operation, status, inputs, outputs, err))
+}
+
+func (b *blockingAuditLogger) LogContext(ctx context.Context, entry AuditEntry) error {
+	return b.Log(ctx, entry)
+}
+
+func (b *blockingAuditLogger) LogLegacy(entry AuditEntry) error {
+	return b.Log(context.Background(), entry)
+}
+
+func (b *blockingAuditLogger) LogOpLegacy(operation, status string, inputs, outputs map[string]interface{}, err error) error {
+	return b.LogOp(context.Background(), operation, status, inputs, outputs, err)
+}
+
+func (b *blockingAuditLogger) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestAsyncAuditLogger_DrainsOnClose(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	fileLogger, err := NewFileAuditLogger(logPath, newMockLogger())
+	if err != nil {
+		t.Fatalf("Failed to create FileAuditLogger: %v", err)
+	}
+
+	logger := NewAsyncAuditLogger(fileLogger, 4)
+	const total = 50
+	for i := 0; i < total; i++ {
+		if err := logger.LogOp(context.Background(), fmt.Sprintf("Op%d", i), "Success", nil, nil, nil); err != nil {
+			t.Fatalf("LogOp failed: %v", err)
+		}
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	r, err := OpenReader(logPath)
+	if err != nil {
+		t.Fatalf("OpenReader failed: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	count := 0
+	for r.Next() {
+		if want := fmt.Sprintf("Op%d", count); r.Entry().Operation != want {
+			t.Fatalf("entry %d operation = %s, want %s (order not preserved)", count, r.Entry().Operation, want)
+		}
+		count++
+	}
+	if count != total {
+		t.Errorf("wrote %d entries, want %d", count, total)
+	}
+
+	if err := logger.Log(context.Background(), AuditEntry{Operation: "Late"}); !errors.Is(err, ErrAuditLoggerClosed) {
+		t.Errorf("Log after Close error = %v, want ErrAuditLoggerClosed", err)
+	}
+}
+
+func TestAsyncAuditLogger_DropPolicy(t *testing.T) {
+	inner := &blockingAuditLogger{release: make(chan struct{})}
+	logger := NewAsyncAuditLogger(inner, 1)
+	logger.SetOverflowPolicy(OverflowDrop)
+
+	// The writer holds one entry and the buffer holds another; the rest overflow
+	for i := 0; i < 10; i++ {
+		if err := logger.LogOp(context.Background(), "Op", "Success", nil, nil, nil); err != nil {
+			t.Fatalf("LogOp failed: %v", err)
+		}
+	}
+	if logger.Dropped() < 8 {
+		t.Errorf("Dropped() = %d, want at least 8", logger.Dropped())
+	}
+
+	close(inner.release)
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if !inner.closed {
+		t.Error("inner logger was not closed")
+	}
+
+	last := inner.entries[len(inner.entries)-1]
+	if last.Operation != "AuditLogSummary" {
+		t.Fatalf("last entry = %s, want AuditLogSummary", last.Operation)
+	}
+	if got := last.Outputs["dropped_entries"]; got != logger.Dropped() {
+		t.Errorf("summary dropped_entries = %v, want %d", got, logger.Dropped())
+	}
+	if written := len(inner.entries) - 1; int64(written)+logger.Dropped() != 10 {
+		t.Errorf("written %d + dropped %d != 10", written, logger.Dropped())
+	}
+}
+
+func TestAsyncAuditLogger_CopiesCallerMaps(t *testing.T) {
+	inner := &blockingAuditLogger{release: make(chan struct{})}
+	logger := NewAsyncAuditLogger(inner, 4)
+
+	outputs := map[string]interface{}{"status": "queued"}
+	if err := logger.LogOp(context.Background(), "Op", "Success", nil, outputs, nil); err != nil {
+		t.Fatalf("LogOp failed: %v", err)
+	}
+	outputs["status"] = "changed after logging"
+
+	close(inner.release)
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if got := inner.entries[0].Outputs["status"]; got != "queued" {
+		t.Errorf("queued entry saw later mutation: %v", got)
+	}
+}
+
+func TestAsyncAuditLogger_CloseReportsWriteError(t *testing.T) {
+	inner := &blockingAuditLogger{logErr: errors.New("disk full")}
+	logger := NewAsyncAuditLogger(inner, 4)
+
+	if err := logger.LogOp(context.Background(), "Op", "Success", nil, nil, nil); err != nil {
+		t.Fatalf("LogOp should not report write errors: %v", err)
+	}
+	if err := logger.Close(); err == nil || !errors.Is(err, inner.logErr) {
+		t.Errorf("Close error = %v, want it to wrap %v", err, inner.logErr)
+	}
+	if err := logger.Close(); err != nil {
+		t.Errorf("second Close error = %v, want nil", err)
+	}
+}
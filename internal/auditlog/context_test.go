@@ -4,6 +4,7 @@ package auditlog
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -96,6 +97,9 @@ func TestAuditLogger_Context(t *testing.T) {
 	if val, ok := parsedEntry.Inputs["correlation_id"]; !ok || val != "test-correlation-id" {
 		t.Errorf("Expected Inputs to contain correlation_id=test-correlation-id, got %v", parsedEntry.Inputs)
 	}
+	if parsedEntry.CorrelationID != "test-correlation-id" {
+		t.Errorf("Expected CorrelationID test-correlation-id, got %q", parsedEntry.CorrelationID)
+	}
 }
 
 // TestLogOp_Context tests the context-aware LogOp method
@@ -167,6 +171,103 @@ func TestLogOp_Context(t *testing.T) {
 	if val, ok := parsedEntry.Inputs["correlation_id"]; !ok || val != "logop-correlation-id" {
 		t.Errorf("Expected Inputs to contain correlation_id=logop-correlation-id, got %v", parsedEntry.Inputs)
 	}
+	if parsedEntry.CorrelationID != "logop-correlation-id" {
+		t.Errorf("Expected CorrelationID logop-correlation-id, got %q", parsedEntry.CorrelationID)
+	}
+}
+
+// TestAuditLogger_CorrelationIDField verifies how the CorrelationID field is filled in
+func TestAuditLogger_CorrelationIDField(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		ctxID string
+		entry AuditEntry
+		want  string
+	}{
+		{name: "taken from context", ctxID: "ctx-id", entry: AuditEntry{Operation: "Op"}, want: "ctx-id"},
+		{name: "explicit ID is kept", ctxID: "ctx-id", entry: AuditEntry{Operation: "Op", CorrelationID: "entry-id"}, want: "entry-id"},
+		{name: "omitted without an ID", entry: AuditEntry{Operation: "Op"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			logPath := filepath.Join(t.TempDir(), "audit.log")
+			logger, err := NewFileAuditLogger(logPath, newMockLogger())
+			if err != nil {
+				t.Fatalf("Failed to create FileAuditLogger: %v", err)
+			}
+
+			ctx := context.Background()
+			if tt.ctxID != "" {
+				ctx = logutil.WithCorrelationID(ctx, tt.ctxID)
+			}
+			if err := logger.Log(ctx, tt.entry); err != nil {
+				t.Fatalf("Log() error = %v", err)
+			}
+			if err := logger.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			content, err := os.ReadFile(logPath)
+			if err != nil {
+				t.Fatalf("Failed to read log file: %v", err)
+			}
+			var fields map[string]interface{}
+			if err := json.Unmarshal(content, &fields); err != nil {
+				t.Fatalf("Failed to parse JSON: %v\nContent: %s", err, content)
+			}
+			got, present := fields["correlation_id"]
+			if tt.want == "" {
+				if present {
+					t.Errorf("Expected no correlation_id field, got %v", got)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Expected correlation_id %q, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestFileAuditLogger_LogContextCancellation verifies that LogContext skips writing
+// once the context is done while Log still records the entry
+func TestFileAuditLogger_LogContextCancellation(t *testing.T) {
+	t.Parallel()
+
+	logPath := filepath.Join(t.TempDir(), "audit.log")
+	logger, err := NewFileAuditLogger(logPath, newMockLogger())
+	if err != nil {
+		t.Fatalf("Failed to create FileAuditLogger: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(logutil.WithCorrelationID(context.Background(), "cancelled-run"))
+	cancel()
+
+	if err := logger.LogContext(ctx, AuditEntry{Operation: "Skipped"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("LogContext() with cancelled context = %v, want context.Canceled", err)
+	}
+	if err := logger.Log(ctx, AuditEntry{Operation: "ExecuteEnd"}); err != nil {
+		t.Errorf("Log() with cancelled context = %v, want nil", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	var parsedEntry AuditEntry
+	if err := json.Unmarshal(content, &parsedEntry); err != nil {
+		t.Fatalf("Expected exactly one entry, got %q: %v", content, err)
+	}
+	if parsedEntry.Operation != "ExecuteEnd" || parsedEntry.CorrelationID != "cancelled-run" {
+		t.Errorf("Expected the ExecuteEnd entry with correlation ID cancelled-run, got %+v", parsedEntry)
+	}
 }
 
 // TestNoOpAuditLogger_Context tests the context-aware methods of NoOpAuditLogger
@@ -194,6 +295,10 @@ func TestNoOpAuditLogger_Context(t *testing.T) {
 	if err := logger.LogOp(ctx, "NoOpOp", "Success", nil, nil, nil); err != nil {
 		t.Errorf("NoOpAuditLogger.LogOp with context returned error: %v", err)
 	}
+
+	if err := logger.LogContext(ctx, entry); err != nil {
+		t.Errorf("NoOpAuditLogger.LogContext returned error: %v", err)
+	}
 }
 
 // TestLogLegacy verifies that the legacy methods work correctly
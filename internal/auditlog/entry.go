@@ -5,15 +5,19 @@ import "time"
 
 // AuditEntry defines the structure for a single audit log record.
 type AuditEntry struct {
-	Timestamp   time.Time              `json:"timestamp"`
-	Operation   string                 `json:"operation"`             // e.g., "ExecuteStart", "GatherContext", "GenerateContent", "SaveOutput", "ExecuteEnd"
-	Status      string                 `json:"status"`                // e.g., "Success", "Failure", "InProgress"
-	DurationMs  *int64                 `json:"duration_ms,omitempty"` // Optional duration in milliseconds
-	Inputs      map[string]interface{} `json:"inputs,omitempty"`      // CLI flags, file paths, etc.
-	Outputs     map[string]interface{} `json:"outputs,omitempty"`     // Result details, file paths written
-	TokenCounts *TokenCountInfo        `json:"token_counts,omitempty"`
-	Error       *ErrorInfo             `json:"error,omitempty"`
-	Message     string                 `json:"message,omitempty"` // Optional human-readable message
+	Timestamp     time.Time              `json:"timestamp"`
+	CorrelationID string                 `json:"correlation_id,omitempty"` // ID of the run, from logutil.WithCorrelationID on the logging context
+	Operation     string                 `json:"operation"`                // e.g., "ExecuteStart", "GatherContext", "GenerateContent", "SaveOutput", "ExecuteEnd"
+	Status        string                 `json:"status"`                   // e.g., "Success", "Failure", "InProgress"
+	DurationMs    *int64                 `json:"duration_ms,omitempty"`    // Optional duration in milliseconds
+	QueuedAt      *time.Time             `json:"queued_at,omitempty"`      // When a model was queued, on its completion entry
+	StartedAt     *time.Time             `json:"started_at,omitempty"`     // When the model's request was sent, if it was
+	CompletedAt   *time.Time             `json:"completed_at,omitempty"`   // When the model completed or failed
+	Inputs        map[string]interface{} `json:"inputs,omitempty"`         // CLI flags, file paths, etc.
+	Outputs       map[string]interface{} `json:"outputs,omitempty"`        // Result details, file paths written
+	TokenCounts   *TokenCountInfo        `json:"token_counts,omitempty"`
+	Error         *ErrorInfo             `json:"error,omitempty"`
+	Message       string                 `json:"message,omitempty"` // Optional human-readable message
 }
 
 // TokenCountInfo holds token count details.
@@ -402,6 +402,42 @@ func TestFileAuditLogger_Close(t *testing.T) {
 }
 
 // TestFileAuditLogger_Concurrency tests that FileAuditLogger is safe for concurrent use
+func TestFileAuditLogger_Sync(t *testing.T) {
+	t.Parallel()
+
+	logPath := filepath.Join(t.TempDir(), "audit.log")
+	logger, err := NewFileAuditLogger(logPath, newMockLogger())
+	if err != nil {
+		t.Fatalf("Failed to create FileAuditLogger: %v", err)
+	}
+
+	if err := logger.Log(context.Background(), AuditEntry{Operation: "BeforeSync", Status: "Success"}); err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+	if err := logger.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	// Sync leaves the log open for further entries
+	if err := logger.Log(context.Background(), AuditEntry{Operation: "AfterSync", Status: "Success"}); err != nil {
+		t.Fatalf("Log after Sync failed: %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := logger.Sync(); err != nil {
+		t.Errorf("Sync after Close should be a no-op, got %v", err)
+	}
+
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	if !strings.Contains(string(content), "BeforeSync") || !strings.Contains(string(content), "AfterSync") {
+		t.Errorf("audit log missing entries: %s", content)
+	}
+}
+
 func TestFileAuditLogger_Concurrency(t *testing.T) {
 	t.Parallel(
 	// Setup a temporary file for testing
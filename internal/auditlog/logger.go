@@ -23,6 +23,11 @@ type AuditLogger interface {
 	// NOTE: Prefer using the LogOp method instead of this method directly.
 	Log(ctx context.Context, entry AuditEntry) error
 
+	// LogContext records a single audit entry like Log, but honors cancellation:
+	// if ctx is already done it returns ctx.Err() without writing. Log ignores
+	// cancellation so that end-of-run entries are recorded even after a timeout.
+	LogContext(ctx context.Context, entry AuditEntry) error
+
 	// LogOp is a helper method for logging operations with minimal parameters.
 	// It creates an AuditEntry with the given operation, status, and optional data,
 	// sets a timestamp, and logs it. The method returns any error from logging.
@@ -48,9 +53,10 @@ type AuditLogger interface {
 
 // FileAuditLogger implements AuditLogger by writing JSON Lines to a file.
 type FileAuditLogger struct {
-	file   *os.File
-	mu     sync.Mutex
-	logger logutil.LoggerInterface // For logging errors within the audit logger itself
+	file     *os.File
+	mu       sync.Mutex
+	logger   logutil.LoggerInterface // For logging errors within the audit logger itself
+	redactor Redactor                // Optional hook run on each entry before serialization
 }
 
 // NewFileAuditLogger creates a new FileAuditLogger that writes to the specified file path.
@@ -72,14 +78,36 @@ func NewFileAuditLogger(filePath string, internalLogger logutil.LoggerInterface)
 	}, nil
 }
 
-// Log records a single audit entry by marshaling it to JSON and writing it to the log file.
-// It sets the entry timestamp if not already set and ensures thread safety with a mutex lock.
-// The context is used to extract correlation ID and for context-aware logging.
+// SetRedactor registers a function that scrubs each entry before it is written.
+// Passing nil disables redaction.
+func (l *FileAuditLogger) SetRedactor(redactor Redactor) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.redactor = redactor
+}
+
+// Log records a single audit entry even if ctx has been cancelled. It calls LogContext
+// with a copy of ctx that keeps its values, such as the correlation ID, but not its
+// cancellation.
 func (l *FileAuditLogger) Log(ctx context.Context, entry AuditEntry) error {
 	// Use default context if nil is provided for backward compatibility
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	return l.LogContext(context.WithoutCancel(ctx), entry)
+}
+
+// LogContext records a single audit entry by marshaling it to JSON and writing it to the log file.
+// It sets the entry timestamp if not already set and ensures thread safety with a mutex lock.
+// The context's correlation ID fills in CorrelationID when the entry doesn't carry one.
+// If ctx is already done, nothing is written and ctx.Err() is returned.
+func (l *FileAuditLogger) LogContext(ctx context.Context, entry AuditEntry) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
 	// Get a context-aware logger
 	contextLogger := l.logger.WithContext(ctx)
@@ -92,8 +120,12 @@ func (l *FileAuditLogger) Log(ctx context.Context, entry AuditEntry) error {
 		entry.Timestamp = time.Now().UTC()
 	}
 
-	// Add correlation ID from context if not already present in inputs
+	// Add correlation ID from context if the caller didn't set one, keeping the
+	// inputs copy for readers that look for it there
 	correlationID := logutil.GetCorrelationID(ctx)
+	if entry.CorrelationID == "" {
+		entry.CorrelationID = correlationID
+	}
 	if correlationID != "" {
 		if entry.Inputs == nil {
 			entry.Inputs = make(map[string]interface{})
@@ -104,6 +136,11 @@ func (l *FileAuditLogger) Log(ctx context.Context, entry AuditEntry) error {
 		}
 	}
 
+	// Scrub sensitive fields before anything is serialized
+	if l.redactor != nil {
+		l.redactor(&entry)
+	}
+
 	// Marshal entry to JSON
 	jsonData, err := json.Marshal(entry)
 	if err != nil {
@@ -134,6 +171,13 @@ func (l *FileAuditLogger) LogOp(ctx context.Context, operation, status string, i
 		ctx = context.Background()
 	}
 
+	// Log the entry with context
+	return l.Log(ctx, NewOpEntry(ctx, operation, status, inputs, outputs, err))
+}
+
+// NewOpEntry builds the AuditEntry recorded by LogOp, timestamped now. Callers
+// that need typed fields LogOp can't set fill them in and pass the entry to Log.
+func NewOpEntry(ctx context.Context, operation, status string, inputs map[string]interface{}, outputs map[string]interface{}, err error) AuditEntry {
 	// Make a copy of inputs to avoid modifying the original map
 	inputsCopy := make(map[string]interface{})
 	for k, v := range inputs {
@@ -151,11 +195,12 @@ func (l *FileAuditLogger) LogOp(ctx context.Context, operation, status string, i
 
 	// Create a new entry with current timestamp
 	entry := AuditEntry{
-		Timestamp: time.Now().UTC(),
-		Operation: operation,
-		Status:    status,
-		Inputs:    inputsCopy,
-		Outputs:   outputs,
+		Timestamp:     time.Now().UTC(),
+		CorrelationID: correlationID,
+		Operation:     operation,
+		Status:        status,
+		Inputs:        inputsCopy,
+		Outputs:       outputs,
 	}
 
 	// Add message based on status and operation
@@ -178,8 +223,7 @@ func (l *FileAuditLogger) LogOp(ctx context.Context, operation, status string, i
 
 		// Extract error category for categorized errors
 		if catErr, ok := llm.IsCategorizedError(err); ok {
-			category := catErr.Category()
-			errorType = fmt.Sprintf("Error:%s", category.String())
+			errorType = errorTypeForCategory(catErr.Category())
 		}
 
 		entry.Error = &ErrorInfo{
@@ -188,8 +232,12 @@ func (l *FileAuditLogger) LogOp(ctx context.Context, operation, status string, i
 		}
 	}
 
-	// Log the entry with context
-	return l.Log(ctx, entry)
+	return entry
+}
+
+// errorTypeForCategory returns the ErrorInfo.Type recorded for a categorized error
+func errorTypeForCategory(category llm.ErrorCategory) string {
+	return fmt.Sprintf("Error:%s", category.String())
 }
 
 // LogOpLegacy is the non-context version of LogOp for backward compatibility.
@@ -198,6 +246,21 @@ func (l *FileAuditLogger) LogOpLegacy(operation, status string, inputs map[strin
 	return l.LogOp(context.Background(), operation, status, inputs, outputs, err)
 }
 
+// Sync forces entries written so far to disk without closing the log, so they
+// survive the process being killed. It does nothing once the logger is closed.
+func (l *FileAuditLogger) Sync() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync audit log file %s: %w", l.file.Name(), err)
+	}
+	return nil
+}
+
 // Close properly closes the log file.
 // It ensures thread safety with a mutex lock and prevents double-closing.
 func (l *FileAuditLogger) Close() error {
@@ -234,6 +297,12 @@ func (l *NoOpAuditLogger) Log(ctx context.Context, entry AuditEntry) error {
 	return nil // Do nothing
 }
 
+// LogContext implements the AuditLogger interface but performs no action.
+// It always returns nil (no error).
+func (l *NoOpAuditLogger) LogContext(ctx context.Context, entry AuditEntry) error {
+	return nil // Do nothing
+}
+
 // LogLegacy implements the backward compatibility method.
 func (l *NoOpAuditLogger) LogLegacy(entry AuditEntry) error {
 	return nil // Do nothing
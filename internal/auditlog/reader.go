@@ -0,0 +1,153 @@
+package auditlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/misty-step/thinktank/internal/llm"
+)
+
+// maxEntrySize bounds a single JSON line; entries can embed large inputs or outputs
+const maxEntrySize = 16 * 1024 * 1024
+
+// MalformedLine records a line that could not be decoded as an AuditEntry
+type MalformedLine struct {
+	Line int   // 1-based line number
+	Err  error // Decoding error
+}
+
+// Reader streams AuditEntry values from a JSON Lines audit log, one line at a time.
+// Malformed lines are skipped and recorded rather than aborting the read.
+//
+// Usage:
+//
+//	r, err := auditlog.OpenReader("audit.jsonl")
+//	...
+//	defer r.Close()
+//	for r.Next() {
+//		entry := r.Entry()
+//	}
+//	if err := r.Err(); err != nil { ... }
+type Reader struct {
+	scanner   *bufio.Scanner
+	closer    io.Closer
+	entry     AuditEntry
+	line      int
+	malformed []MalformedLine
+	err       error
+}
+
+// OpenReader opens an audit log file for streaming
+func OpenReader(path string) (*Reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %w", path, err)
+	}
+	r := NewReader(file)
+	r.closer = file
+	return r, nil
+}
+
+// NewReader streams audit entries from r. The caller owns r.
+func NewReader(r io.Reader) *Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEntrySize)
+	return &Reader{scanner: scanner}
+}
+
+// Next advances to the next well-formed entry, returning false at end of input or on a read error
+func (r *Reader) Next() bool {
+	for r.scanner.Scan() {
+		r.line++
+		data := bytes.TrimSpace(r.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var entry AuditEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			r.malformed = append(r.malformed, MalformedLine{Line: r.line, Err: err})
+			continue
+		}
+		r.entry = entry
+		return true
+	}
+	if err := r.scanner.Err(); err != nil {
+		r.err = fmt.Errorf("failed to read audit log at line %d: %w", r.line+1, err)
+	}
+	return false
+}
+
+// Entry returns the entry read by the last successful call to Next
+func (r *Reader) Entry() AuditEntry {
+	return r.entry
+}
+
+// Err returns the first read error, if any. Malformed lines are not errors.
+func (r *Reader) Err() error {
+	return r.err
+}
+
+// Malformed returns the lines skipped so far because they could not be decoded
+func (r *Reader) Malformed() []MalformedLine {
+	return r.malformed
+}
+
+// Filter reads the remaining entries and returns those matching predicate
+func (r *Reader) Filter(predicate func(AuditEntry) bool) ([]AuditEntry, error) {
+	var matches []AuditEntry
+	for r.Next() {
+		if entry := r.Entry(); predicate(entry) {
+			matches = append(matches, entry)
+		}
+	}
+	return matches, r.Err()
+}
+
+// Close closes the underlying file when the Reader was created by OpenReader
+func (r *Reader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	err := r.closer.Close()
+	r.closer = nil
+	return err
+}
+
+// ByOperation matches entries for the named operation (e.g. "GenerateContent")
+func ByOperation(name string) func(AuditEntry) bool {
+	return func(entry AuditEntry) bool {
+		return entry.Operation == name
+	}
+}
+
+// ByStatus matches entries with the given status (e.g. "Failure")
+func ByStatus(status string) func(AuditEntry) bool {
+	return func(entry AuditEntry) bool {
+		return entry.Status == status
+	}
+}
+
+// ByErrorCategory matches entries whose error was categorized as category by LogOp
+func ByErrorCategory(category llm.ErrorCategory) func(AuditEntry) bool {
+	errorType := errorTypeForCategory(category)
+	return func(entry AuditEntry) bool {
+		return entry.Error != nil && entry.Error.Type == errorType
+	}
+}
+
+// AllOf matches entries that satisfy every predicate
+func AllOf(predicates ...func(AuditEntry) bool) func(AuditEntry) bool {
+	return func(entry AuditEntry) bool {
+		for _, predicate := range predicates {
+			if !predicate(entry) {
+				return false
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,140 @@
+package auditlog
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/misty-step/thinktank/internal/llm"
+)
+
+// writeTestAuditLog writes a small audit log via FileAuditLogger and returns its path
+func writeTestAuditLog(t *testing.T) string {
+	t.Helper()
+	logPath := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	logger, err := NewFileAuditLogger(logPath, newMockLogger())
+	if err != nil {
+		t.Fatalf("Failed to create FileAuditLogger: %v", err)
+	}
+
+	ctx := context.Background()
+	rateLimited := llm.New("openrouter", "429", 429, "slow down", "", errors.New("too many requests"), llm.CategoryRateLimit)
+	authFailed := llm.New("openrouter", "401", 401, "bad key", "", errors.New("unauthorized"), llm.CategoryAuth)
+
+	ops := []struct {
+		operation string
+		status    string
+		err       error
+	}{
+		{"GatherContext", "Success", nil},
+		{"GenerateContent", "Success", nil},
+		{"GenerateContent", "Failure", rateLimited},
+		{"GenerateContent", "Failure", authFailed},
+		{"SaveOutput", "Failure", errors.New("disk full")},
+	}
+	for _, op := range ops {
+		if err := logger.LogOp(ctx, op.operation, op.status, map[string]interface{}{"model": "m"}, nil, op.err); err != nil {
+			t.Fatalf("LogOp failed: %v", err)
+		}
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	return logPath
+}
+
+func TestReader_StreamsEntries(t *testing.T) {
+	r, err := OpenReader(writeTestAuditLog(t))
+	if err != nil {
+		t.Fatalf("OpenReader failed: %v", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	var operations []string
+	for r.Next() {
+		operations = append(operations, r.Entry().Operation)
+	}
+	if err := r.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	want := "GatherContext,GenerateContent,GenerateContent,GenerateContent,SaveOutput"
+	if got := strings.Join(operations, ","); got != want {
+		t.Errorf("operations = %s, want %s", got, want)
+	}
+	if len(r.Malformed()) != 0 {
+		t.Errorf("Malformed() = %v, want none", r.Malformed())
+	}
+}
+
+func TestReader_Filter(t *testing.T) {
+	logPath := writeTestAuditLog(t)
+
+	tests := []struct {
+		name      string
+		predicate func(AuditEntry) bool
+		wantCount int
+	}{
+		{"by operation", ByOperation("GenerateContent"), 3},
+		{"by status", ByStatus("Failure"), 3},
+		{"by error category", ByErrorCategory(llm.CategoryRateLimit), 1},
+		{"uncategorized errors never match a category", ByErrorCategory(llm.CategoryServer), 0},
+		{"combined", AllOf(ByOperation("GenerateContent"), ByStatus("Failure")), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := OpenReader(logPath)
+			if err != nil {
+				t.Fatalf("OpenReader failed: %v", err)
+			}
+			defer func() { _ = r.Close() }()
+
+			matches, err := r.Filter(tt.predicate)
+			if err != nil {
+				t.Fatalf("Filter failed: %v", err)
+			}
+			if len(matches) != tt.wantCount {
+				t.Errorf("Filter matched %d entries, want %d", len(matches), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestReader_SkipsMalformedLines(t *testing.T) {
+	input := strings.Join([]string{
+		`{"operation":"GatherContext","status":"Success"}`,
+		`not json`,
+		``,
+		`{"operation":"SaveOutput","status":"Failure"`,
+		`{"operation":"GenerateContent","status":"Success"}`,
+	}, "\n")
+
+	r := NewReader(strings.NewReader(input))
+	entries, err := r.Filter(func(AuditEntry) bool { return true })
+	if err != nil {
+		t.Fatalf("Filter failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("read %d entries, want 2", len(entries))
+	}
+
+	malformed := r.Malformed()
+	if len(malformed) != 2 {
+		t.Fatalf("Malformed() returned %d lines, want 2", len(malformed))
+	}
+	if malformed[0].Line != 2 || malformed[1].Line != 4 {
+		t.Errorf("malformed lines = %d and %d, want 2 and 4", malformed[0].Line, malformed[1].Line)
+	}
+}
+
+func TestOpenReader_MissingFile(t *testing.T) {
+	_, err := OpenReader(filepath.Join(t.TempDir(), "missing.jsonl"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("OpenReader error = %v, want os.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,75 @@
+package auditlog
+
+import "regexp"
+
+// Redactor rewrites an entry in place before it is serialized.
+// Redactors must not mutate maps shared with the caller; replace them instead.
+type Redactor func(entry *AuditEntry)
+
+// redactedPlaceholder replaces secret values in redacted entries
+const redactedPlaceholder = "[REDACTED]"
+
+// secretPatterns match common credential formats that end up pasted into prompts
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),                         // AWS access key IDs
+	regexp.MustCompile(`(?i)aws_secret_access_key\s*[=:]\s*[A-Za-z0-9/+=]{40}`), // AWS secret keys in config form
+	regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{20,}`),                               // OpenAI-style keys (sk-, sk-proj-, sk-or-)
+	regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/-]+=*`),                   // Bearer tokens
+}
+
+// DefaultSecretRedactor masks values matching common API key formats (AWS, OpenAI sk-, bearer tokens)
+// in the entry's inputs, outputs, message, and error message.
+func DefaultSecretRedactor(entry *AuditEntry) {
+	entry.Inputs = redactMap(entry.Inputs)
+	entry.Outputs = redactMap(entry.Outputs)
+	entry.Message = redactString(entry.Message)
+	if entry.Error != nil {
+		redacted := *entry.Error
+		redacted.Message = redactString(redacted.Message)
+		entry.Error = &redacted
+	}
+}
+
+// redactString masks every secret pattern in s
+func redactString(s string) string {
+	for _, pattern := range secretPatterns {
+		s = pattern.ReplaceAllString(s, redactedPlaceholder)
+	}
+	return s
+}
+
+// redactMap returns a redacted copy of m, leaving the original untouched
+func redactMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	redacted := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		redacted[k] = redactValue(v)
+	}
+	return redacted
+}
+
+// redactValue redacts strings, including those nested in maps and slices
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case string:
+		return redactString(val)
+	case []string:
+		out := make([]string, len(val))
+		for i, s := range val {
+			out[i] = redactString(s)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, item := range val {
+			out[i] = redactValue(item)
+		}
+		return out
+	case map[string]interface{}:
+		return redactMap(val)
+	default:
+		return v
+	}
+}
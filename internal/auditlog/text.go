@@ -0,0 +1,37 @@
+package auditlog
+
+// TextCapture controls how much prompt and response text audit entries record.
+// The zero value records only lengths.
+type TextCapture struct {
+	Full          bool // Record the complete text (--audit-verbose)
+	PreviewLength int  // Otherwise record at most this many characters (0 = length only)
+}
+
+// Record adds text to fields under key, cut to PreviewLength characters unless
+// Full is set, and its length in bytes under key+"_length". A cut preview also
+// sets key+"_truncated".
+func (c TextCapture) Record(fields map[string]interface{}, key, text string) {
+	fields[key+"_length"] = len(text)
+	switch {
+	case c.Full:
+		fields[key] = text
+	case c.PreviewLength > 0:
+		preview, truncated := previewText(text, c.PreviewLength)
+		fields[key] = preview
+		if truncated {
+			fields[key+"_truncated"] = true
+		}
+	}
+}
+
+// previewText returns the first limit runes of text and whether anything was cut
+func previewText(text string, limit int) (string, bool) {
+	count := 0
+	for i := range text {
+		if count == limit {
+			return text[:i], true
+		}
+		count++
+	}
+	return text, false
+}
@@ -0,0 +1,58 @@
+package auditlog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTextCapture_Record(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		capture TextCapture
+		text    string
+		want    map[string]interface{}
+	}{
+		{
+			name: "zero value records length only",
+			text: "hello world",
+			want: map[string]interface{}{"prompt_length": 11},
+		},
+		{
+			name:    "full text",
+			capture: TextCapture{Full: true, PreviewLength: 3},
+			text:    "hello world",
+			want:    map[string]interface{}{"prompt": "hello world", "prompt_length": 11},
+		},
+		{
+			name:    "preview truncates",
+			capture: TextCapture{PreviewLength: 5},
+			text:    "hello world",
+			want:    map[string]interface{}{"prompt": "hello", "prompt_length": 11, "prompt_truncated": true},
+		},
+		{
+			name:    "preview counts characters, not bytes",
+			capture: TextCapture{PreviewLength: 2},
+			text:    "héllo",
+			want:    map[string]interface{}{"prompt": "hé", "prompt_length": 6, "prompt_truncated": true},
+		},
+		{
+			name:    "short text is not truncated",
+			capture: TextCapture{PreviewLength: 20},
+			text:    "hello",
+			want:    map[string]interface{}{"prompt": "hello", "prompt_length": 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fields := map[string]interface{}{}
+			tt.capture.Record(fields, "prompt", tt.text)
+			if !reflect.DeepEqual(fields, tt.want) {
+				t.Errorf("Record() fields = %v, want %v", fields, tt.want)
+			}
+		})
+	}
+}
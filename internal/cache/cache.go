@@ -0,0 +1,104 @@
+// Package cache stores model responses on disk so unchanged runs can reuse them
+// instead of spending tokens on identical requests.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Cache is a directory of responses, one file per key.
+// Only successful generations should be stored; anything unreadable is a miss.
+type Cache struct {
+	dir string
+}
+
+// New returns a cache rooted at dir, creating the directory if needed
+func New(dir string) (*Cache, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("cache directory cannot be empty")
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory %s: %w", dir, err)
+	}
+	return &Cache{dir: dir}, nil
+}
+
+// Key returns the SHA-256 of a request's model name, resolved prompt, and model
+// parameters as a hex string. Parameter order does not affect the key.
+func Key(modelName, prompt string, params map[string]interface{}) string {
+	// encoding/json sorts map keys, so equal parameters always encode identically
+	encodedParams, err := json.Marshal(params)
+	if err != nil {
+		encodedParams = []byte(fmt.Sprintf("%v", params))
+	}
+
+	hash := sha256.New()
+	for _, part := range [][]byte{[]byte(modelName), []byte(prompt), encodedParams} {
+		// Length-prefix each part so different splits of the same bytes differ
+		fmt.Fprintf(hash, "%d:", len(part))
+		hash.Write(part)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Get returns the content stored under key, and false on a miss or read error
+func (c *Cache) Get(key string) (string, bool) {
+	if !validKey(key) {
+		return "", false
+	}
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// Put stores content under key. The file is written to a temporary name and
+// renamed into place so readers never see a partial entry.
+func (c *Cache) Put(key, content string) error {
+	if !validKey(key) {
+		return fmt.Errorf("invalid cache key %q", key)
+	}
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.WriteString(content); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		return fmt.Errorf("failed to store cache entry: %w", err)
+	}
+	return nil
+}
+
+// Dir returns the directory backing the cache
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// validKey reports whether key is a hex digest as returned by Key, keeping
+// entries inside the cache directory
+func validKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	_, err := hex.DecodeString(key)
+	return err == nil
+}
+
+// path returns the file holding key's entry
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key)
+}
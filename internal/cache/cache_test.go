@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey(t *testing.T) {
+	params := map[string]interface{}{"temperature": 0.7, "top_p": 0.9}
+	base := Key("model-a", "prompt", params)
+
+	assert.Len(t, base, 64)
+	assert.Equal(t, base, Key("model-a", "prompt", map[string]interface{}{"top_p": 0.9, "temperature": 0.7}),
+		"parameter order should not change the key")
+
+	tests := []struct {
+		name   string
+		model  string
+		prompt string
+		params map[string]interface{}
+	}{
+		{"different model", "model-b", "prompt", params},
+		{"different prompt", "model-a", "prompt2", params},
+		{"different params", "model-a", "prompt", map[string]interface{}{"temperature": 0.2, "top_p": 0.9}},
+		{"no params", "model-a", "prompt", nil},
+		{"shifted boundary", "model-ap", "rompt", params},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotEqual(t, base, Key(tt.model, tt.prompt, tt.params))
+		})
+	}
+}
+
+func TestCacheGetPut(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nested", "cache")
+	c, err := New(dir)
+	require.NoError(t, err)
+	assert.Equal(t, dir, c.Dir())
+
+	key := Key("model-a", "prompt", nil)
+	_, ok := c.Get(key)
+	assert.False(t, ok, "empty cache should miss")
+
+	require.NoError(t, c.Put(key, "response"))
+	content, ok := c.Get(key)
+	assert.True(t, ok)
+	assert.Equal(t, "response", content)
+
+	// Overwriting replaces the entry and leaves no temporary files behind
+	require.NoError(t, c.Put(key, "newer response"))
+	content, _ = c.Get(key)
+	assert.Equal(t, "newer response", content)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestCacheRejectsInvalidKeys(t *testing.T) {
+	c, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "../escape", "not-hex"} {
+		assert.Error(t, c.Put(key, "content"), "key %q", key)
+		_, ok := c.Get(key)
+		assert.False(t, ok, "key %q", key)
+	}
+}
+
+func TestNewRequiresDir(t *testing.T) {
+	_, err := New("")
+	assert.Error(t, err)
+}
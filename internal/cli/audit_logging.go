@@ -151,11 +151,12 @@ func auditModelSelection(
 		auditLogger = auditlog.NewNoOpAuditLogger()
 	} else {
 		auditLogPath := filepath.Join(minimalConfig.OutputDir, "audit.jsonl")
-		var err error
-		auditLogger, err = auditlog.NewFileAuditLogger(auditLogPath, logger)
+		fileAuditLogger, err := auditlog.NewFileAuditLogger(auditLogPath, logger)
 		if err != nil {
 			return fmt.Errorf("failed to create audit logger: %w", err)
 		}
+		fileAuditLogger.SetRedactor(auditlog.DefaultSecretRedactor)
+		auditLogger = fileAuditLogger
 		defer func() {
 			if closeErr := auditLogger.Close(); closeErr != nil {
 				logger.ErrorContext(ctx, "Failed to close audit logger: %v", closeErr)
@@ -198,18 +199,9 @@ func performModelSelectionWithDetails(
 		return nil, thinktank.TokenCountingRequest{}, fmt.Errorf("no API keys available")
 	}
 
-	// Read instructions content for TokenCountingService
-	var instructionsContent string
-	if content, err := os.ReadFile(simplifiedConfig.InstructionsFile); err == nil {
-		instructionsContent = string(content)
-	} else {
-		// Fallback to empty instructions if file read fails
-		instructionsContent = ""
-	}
-
 	// Create token counting request
 	tokenReq := thinktank.TokenCountingRequest{
-		Instructions:        instructionsContent,
+		Instructions:        readInstructionsText(simplifiedConfig),
 		Files:               []thinktank.FileContent{}, // Empty for now - will be enhanced later
 		SafetyMarginPercent: simplifiedConfig.SafetyMargin,
 	}
@@ -224,6 +216,18 @@ func performModelSelectionWithDetails(
 	return compatibleModels, tokenReq, nil
 }
 
+// readInstructionsText returns the --instructions-inline text or the instructions
+// file's content, or "" if the file can't be read
+func readInstructionsText(simplifiedConfig *SimplifiedConfig) string {
+	if inline := simplifiedConfig.GetOptions().InstructionsInline; inline != "" {
+		return inline
+	}
+	if content, err := os.ReadFile(simplifiedConfig.InstructionsFile); err == nil {
+		return string(content)
+	}
+	return ""
+}
+
 // LogModelSelectionStructured logs model selection summary to the main structured logger (thinktank.log)
 // according to Phase 7.2 requirements for enhanced structured logging.
 func LogModelSelectionStructured(
@@ -164,6 +164,10 @@ func (m *MockAuditLogger) Log(ctx context.Context, entry auditlog.AuditEntry) er
 	return nil
 }
 
+func (m *MockAuditLogger) LogContext(ctx context.Context, entry auditlog.AuditEntry) error {
+	return m.Log(ctx, entry)
+}
+
 func (m *MockAuditLogger) LogLegacy(entry auditlog.AuditEntry) error {
 	return m.Log(context.Background(), entry)
 }
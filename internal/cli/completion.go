@@ -0,0 +1,271 @@
+// Package cli provides the command-line interface logic for the thinktank tool
+package cli
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/misty-step/thinktank/internal/models"
+)
+
+// completionArg describes what kind of value a flag takes, for completion purposes
+type completionArg int
+
+const (
+	completionArgNone  completionArg = iota // Boolean flag
+	completionArgModel                      // Model name from the models package
+	completionArgDir                        // Directory path
+	completionArgFile                       // File path
+	completionArgValue                      // Free-form value
+	completionArgShell                      // Supported completion shell
+)
+
+// completionFlag is a single flag offered by shell completion
+type completionFlag struct {
+	name        string
+	description string
+	arg         completionArg
+}
+
+// completionShells lists the shells supported by --completion and the completion subcommand
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// completionFlags lists the flags offered by shell completion.
+// Keep in sync with ParseSimpleArgsWithArgs and helpText.
+var completionFlags = []completionFlag{
+	{"--help", "Show help and exit", completionArgNone},
+	{"--version", "Print version information and exit", completionArgNone},
+	{"--list-models", "List supported models and exit", completionArgNone},
+	{"--dry-run", "Preview without making API calls", completionArgNone},
+	{"--dry-run-json", "Preview as a JSON object on stdout", completionArgNone},
+	{"--verbose", "Enable detailed output", completionArgNone},
+	{"--synthesis", "Force synthesis mode", completionArgNone},
+	{"--no-synthesis", "Skip synthesis; write individual outputs only", completionArgNone},
+	{"--debug", "Enable debug logging", completionArgNone},
+	{"--quiet", "Suppress non-essential output", completionArgNone},
+	{"--silent", "Write nothing to stdout; errors go to stderr", completionArgNone},
+	{"--json-logs", "Write JSON logs to stderr", completionArgNone},
+	{"--no-progress", "Disable progress indicators", completionArgNone},
+	{"--normalize-newlines", "Convert CRLF to LF in context files", completionArgNone},
+	{"--embed-instructions", "Prepend instructions to output files", completionArgNone},
+	{"--instructions-inline", "Instructions text to use instead of a file", completionArgValue},
+	{"--prompt-order", "Prompt layout: default, instructions-last, or by-directory", completionArgValue},
+	{"--fence-code", "Fence file contents in the prompt by language", completionArgNone},
+	{"--file-separator", "Text placed between files in the prompt", completionArgValue},
+	{"--dir-perms", "Octal permissions for output directories", completionArgValue},
+	{"--file-perms", "Octal permissions for output files", completionArgValue},
+	{"--strict-output-dir", "Never fall back to the temp directory for outputs", completionArgNone},
+	{"--force", "Overwrite existing files in --output-dir", completionArgNone},
+	{"--skip-missing-paths", "Skip listed paths that don't exist", completionArgNone},
+	{"--no-cache", "Ignore the response cache", completionArgNone},
+	{"--follow-symlinks", "Walk into symlinked directories", completionArgNone},
+	{"--exclude-generated", "Skip files marked as generated code", completionArgNone},
+	{"--include-hidden", "Gather dotfiles and dot-directories", completionArgNone},
+	{"--explain-excludes", "List each excluded path and why (with --dry-run)", completionArgNone},
+	{"--auto-trim", "Drop files to fit each model's context window", completionArgNone},
+	{"--partial-success-ok", "Exit 0 if at least one model succeeds", completionArgNone},
+	{"--fail-fast", "Cancel remaining models on the first failure", completionArgNone},
+	{"--error-json", "Report failures as JSON on stderr", completionArgNone},
+	{"--preflight", "Check providers are reachable before the run", completionArgNone},
+	{"--openrouter-base-url", "Send OpenRouter requests to a custom base URL", completionArgValue},
+	{"--model-param", "Override a model parameter: model:key=value", completionArgValue},
+	{"--model", "Select AI model", completionArgModel},
+	{"--synthesis-model", "Model that combines results", completionArgModel},
+	{"--models", "Comma-separated models to run instead of auto-selection", completionArgModel},
+	{"--select", "Choose models by strategy: cheapest, largest, or fastest", completionArgValue},
+	{"--repeat", "Run each model this many times", completionArgValue},
+	{"--output-dir", "Set output directory", completionArgDir},
+	{"--metrics-output", "Write metrics to file", completionArgFile},
+	{"--otel", "Emit OpenTelemetry spans and metrics for the run", completionArgNone},
+	{"--output-name-template", "Output file name template, e.g. {timestamp}-{model}.txt", completionArgValue},
+	{"--combined-output", "Write all model outputs to one file", completionArgFile},
+	{"--post-process", "Shell command run on each output file", completionArgValue},
+	{"--summary-file", "Also write the run summary to a markdown file", completionArgFile},
+	{"--token-safety-margin", "Percent of context reserved for output", completionArgValue},
+	{"--output-format", "Summary format: text or json", completionArgValue},
+	{"--progress", "Progress format: text or json events", completionArgValue},
+	{"--progress-fd", "File descriptor for JSON progress events", completionArgValue},
+	{"--color", "Color output: auto, always, or never", completionArgValue},
+	{"--theme", "Color theme: default or high-contrast", completionArgValue},
+	{"--include-glob", "Only include files matching a glob", completionArgValue},
+	{"--priority-glob", "Keep files matching a glob when trimming the context", completionArgValue},
+	{"--paths-from-file", "Read target paths from a file", completionArgFile},
+	{"--cache-dir", "Reuse cached responses from this directory", completionArgDir},
+	{"--template-vars", "Fill {{.key}} in the instructions with key=value", completionArgValue},
+	{"--profile", "Use defaults from a named profile", completionArgValue},
+	{"--timeout", "Time limit for the whole run", completionArgValue},
+	{"--model-timeout", "Time limit for each model", completionArgValue},
+	{"--gather-timeout", "Time limit for scanning files", completionArgValue},
+	{"--gather-workers", "Parallel workers for scanning files", completionArgValue},
+	{"--max-file-size", "Skip context files larger than this", completionArgValue},
+	{"--max-context-tokens", "Cap the estimated tokens of the whole context", completionArgValue},
+	{"--max-retries", "Retries after transient model errors", completionArgValue},
+	{"--concurrency", "Model requests in flight at once (0 = unlimited)", completionArgValue},
+	{"--retry-budget", "Retries allowed across all models combined", completionArgValue},
+	{"--retry-base-delay", "Wait before the first retry", completionArgValue},
+	{"--audit-verbose", "Record complete prompts and responses in the audit log", completionArgNone},
+	{"--audit-preview-length", "Characters of each prompt and response kept in the audit log", completionArgValue},
+	{"--checkpoint-interval", "Log progress at this interval", completionArgValue},
+	{"--max-output-file-size", "Truncate output files beyond this size", completionArgValue},
+	{"--rate-limit-wait-budget", "Fail after this much total rate-limit waiting", completionArgValue},
+	{"--completion", "Print shell completion script", completionArgShell},
+}
+
+// isCompletionRequested returns the requested shell if args ask for a completion script,
+// either as "thinktank completion <shell>" or with --completion anywhere.
+// Like --version, this is checked before full argument parsing.
+func isCompletionRequested(args []string) (string, bool) {
+	if shell, ok := completionSubcommand(args); ok {
+		return shell, true
+	}
+	for i, arg := range args {
+		if arg == "--completion" {
+			if i+1 < len(args) {
+				return args[i+1], true
+			}
+			return "", true
+		}
+		if strings.HasPrefix(arg, "--completion=") {
+			return strings.TrimPrefix(arg, "--completion="), true
+		}
+	}
+	return "", false
+}
+
+// completionSubcommand recognizes "thinktank completion [shell]". The subcommand takes
+// at most one argument, and only a supported shell name; anything else, such as
+// "thinktank completion src", is left to normal argument parsing as an instructions
+// file and target paths.
+func completionSubcommand(args []string) (string, bool) {
+	if len(args) < 2 || args[1] != "completion" {
+		return "", false
+	}
+	switch {
+	case len(args) == 2:
+		return "", true
+	case len(args) == 3 && slices.Contains(completionShells, args[2]):
+		return args[2], true
+	}
+	return "", false
+}
+
+// GenerateCompletion writes a completion script for the given shell to w
+func GenerateCompletion(w io.Writer, shell string) error {
+	modelNames := models.ListAllModels()
+
+	var script string
+	switch shell {
+	case "bash":
+		script = bashCompletion(modelNames)
+	case "zsh":
+		script = zshCompletion(modelNames)
+	case "fish":
+		script = fishCompletion(modelNames)
+	case "":
+		return fmt.Errorf("completion requires a shell (supported: %s)", strings.Join(completionShells, ", "))
+	default:
+		return fmt.Errorf("unsupported shell %q (supported: %s)", shell, strings.Join(completionShells, ", "))
+	}
+
+	_, err := io.WriteString(w, script)
+	return err
+}
+
+// bashCompletion renders the bash completion script
+func bashCompletion(modelNames []string) string {
+	flagNames := make([]string, len(completionFlags))
+	for i, f := range completionFlags {
+		flagNames[i] = f.name
+	}
+
+	var b strings.Builder
+	b.WriteString("# bash completion for thinktank\n")
+	b.WriteString("_thinktank() {\n")
+	b.WriteString("    local cur prev\n")
+	b.WriteString("    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	b.WriteString("    prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	b.WriteString("    case \"$prev\" in\n")
+	for _, f := range completionFlags {
+		switch f.arg {
+		case completionArgModel:
+			fmt.Fprintf(&b, "        %s) COMPREPLY=($(compgen -W \"%s\" -- \"$cur\")); return ;;\n", f.name, strings.Join(modelNames, " "))
+		case completionArgDir:
+			fmt.Fprintf(&b, "        %s) COMPREPLY=($(compgen -d -- \"$cur\")); return ;;\n", f.name)
+		case completionArgFile:
+			fmt.Fprintf(&b, "        %s) COMPREPLY=($(compgen -f -- \"$cur\")); return ;;\n", f.name)
+		case completionArgValue:
+			fmt.Fprintf(&b, "        %s) return ;;\n", f.name)
+		case completionArgShell:
+			fmt.Fprintf(&b, "        %s) COMPREPLY=($(compgen -W \"%s\" -- \"$cur\")); return ;;\n", f.name, strings.Join(completionShells, " "))
+		}
+	}
+	b.WriteString("    esac\n")
+	b.WriteString("    if [[ \"$cur\" == -* ]]; then\n")
+	fmt.Fprintf(&b, "        COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(flagNames, " "))
+	b.WriteString("    else\n")
+	b.WriteString("        COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+	b.WriteString("    fi\n")
+	b.WriteString("}\n")
+	b.WriteString("complete -o filenames -F _thinktank thinktank\n")
+	return b.String()
+}
+
+// zshCompletion renders the zsh completion script
+func zshCompletion(modelNames []string) string {
+	escaped := make([]string, len(modelNames))
+	for i, name := range modelNames {
+		escaped[i] = strings.ReplaceAll(name, ":", "\\:")
+	}
+
+	var b strings.Builder
+	b.WriteString("#compdef thinktank\n")
+	b.WriteString("# zsh completion for thinktank\n")
+	b.WriteString("_thinktank() {\n")
+	b.WriteString("    _arguments \\\n")
+	for _, f := range completionFlags {
+		switch f.arg {
+		case completionArgNone:
+			fmt.Fprintf(&b, "        '%s[%s]' \\\n", f.name, f.description)
+		case completionArgModel:
+			fmt.Fprintf(&b, "        '%s[%s]:model:(%s)' \\\n", f.name, f.description, strings.Join(escaped, " "))
+		case completionArgDir:
+			fmt.Fprintf(&b, "        '%s[%s]:directory:_files -/' \\\n", f.name, f.description)
+		case completionArgFile:
+			fmt.Fprintf(&b, "        '%s[%s]:file:_files' \\\n", f.name, f.description)
+		case completionArgValue:
+			fmt.Fprintf(&b, "        '%s[%s]:value:' \\\n", f.name, f.description)
+		case completionArgShell:
+			fmt.Fprintf(&b, "        '%s[%s]:shell:(%s)' \\\n", f.name, f.description, strings.Join(completionShells, " "))
+		}
+	}
+	b.WriteString("        '*:path:_files'\n")
+	b.WriteString("}\n")
+	b.WriteString("compdef _thinktank thinktank\n")
+	return b.String()
+}
+
+// fishCompletion renders the fish completion script
+func fishCompletion(modelNames []string) string {
+	var b strings.Builder
+	b.WriteString("# fish completion for thinktank\n")
+	for _, f := range completionFlags {
+		long := strings.TrimPrefix(f.name, "--")
+		switch f.arg {
+		case completionArgNone:
+			fmt.Fprintf(&b, "complete -c thinktank -l %s -d '%s'\n", long, f.description)
+		case completionArgModel:
+			fmt.Fprintf(&b, "complete -c thinktank -l %s -x -a '%s' -d '%s'\n", long, strings.Join(modelNames, " "), f.description)
+		case completionArgDir:
+			fmt.Fprintf(&b, "complete -c thinktank -l %s -x -a '(__fish_complete_directories)' -d '%s'\n", long, f.description)
+		case completionArgFile:
+			fmt.Fprintf(&b, "complete -c thinktank -l %s -r -F -d '%s'\n", long, f.description)
+		case completionArgValue:
+			fmt.Fprintf(&b, "complete -c thinktank -l %s -x -d '%s'\n", long, f.description)
+		case completionArgShell:
+			fmt.Fprintf(&b, "complete -c thinktank -l %s -x -a '%s' -d '%s'\n", long, strings.Join(completionShells, " "), f.description)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/misty-step/thinktank/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCompletionRequested(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		wantShell string
+		wantOK    bool
+	}{
+		{name: "space separated", args: []string{"thinktank", "--completion", "bash"}, wantShell: "bash", wantOK: true},
+		{name: "equals form", args: []string{"thinktank", "--completion=fish"}, wantShell: "fish", wantOK: true},
+		{name: "missing shell", args: []string{"thinktank", "--completion"}, wantShell: "", wantOK: true},
+		{name: "subcommand", args: []string{"thinktank", "completion", "zsh"}, wantShell: "zsh", wantOK: true},
+		{name: "subcommand missing shell", args: []string{"thinktank", "completion"}, wantShell: "", wantOK: true},
+		{name: "instructions file named completion", args: []string{"thinktank", "completion", "./src"}, wantShell: "", wantOK: false},
+		{name: "instructions file named completion with bare target", args: []string{"thinktank", "completion", "src"}, wantShell: "", wantOK: false},
+		{name: "subcommand with extra paths", args: []string{"thinktank", "completion", "src", "lib"}, wantShell: "", wantOK: false},
+		{name: "not requested", args: []string{"thinktank", "task.md", "./src"}, wantShell: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shell, ok := isCompletionRequested(tt.args)
+			assert.Equal(t, tt.wantShell, shell)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestGenerateCompletion(t *testing.T) {
+	t.Parallel()
+	modelName := models.ListAllModels()[0]
+
+	for _, shell := range completionShells {
+		t.Run(shell, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, GenerateCompletion(&buf, shell))
+
+			script := buf.String()
+			assert.Contains(t, script, "thinktank")
+			assert.Contains(t, script, "dry-run")
+			assert.Contains(t, script, modelName, "script should complete known model names")
+		})
+	}
+}
+
+func TestGenerateCompletion_Errors(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+
+	err := GenerateCompletion(&buf, "powershell")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported shell")
+
+	err = GenerateCompletion(&buf, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires a shell")
+
+	assert.Empty(t, buf.String())
+}
+
+func TestGenerateCompletion_BashSyntax(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not available")
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, GenerateCompletion(&buf, "bash"))
+
+	scriptPath := filepath.Join(t.TempDir(), "thinktank.bash")
+	require.NoError(t, os.WriteFile(scriptPath, buf.Bytes(), 0644))
+
+	out, err := exec.Command(bash, "-n", scriptPath).CombinedOutput()
+	assert.NoError(t, err, "bash reported syntax errors: %s", strings.TrimSpace(string(out)))
+}
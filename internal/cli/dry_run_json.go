@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/misty-step/thinktank/internal/config"
+	"github.com/misty-step/thinktank/internal/thinktank/interfaces"
+)
+
+// dryRunReport is the object --dry-run-json (or --dry-run with --output-format json)
+// writes to stdout, for tooling that gates on what a run would send
+type dryRunReport struct {
+	InstructionsFile string   `json:"instructions_file"`
+	TargetPaths      []string `json:"target_paths"`
+	Models           []string `json:"models"`
+	SynthesisModel   string   `json:"synthesis_model,omitempty"`
+	FileCount        int      `json:"file_count"`
+	CharCount        int      `json:"char_count"`
+	LineCount        int      `json:"line_count"`
+	Files            []string `json:"files"` // Every file that would be processed
+}
+
+// newDryRunReport builds the report from the configuration and the gathered context stats
+func newDryRunReport(cfg *config.MinimalConfig, stats *interfaces.ContextStats) dryRunReport {
+	report := dryRunReport{
+		InstructionsFile: cfg.InstructionsFile,
+		TargetPaths:      append([]string{}, cfg.TargetPaths...),
+		Models:           append([]string{}, cfg.ModelNames...),
+		SynthesisModel:   cfg.SynthesisModel,
+		Files:            []string{},
+	}
+	if stats != nil {
+		report.FileCount = stats.ProcessedFilesCount
+		report.CharCount = stats.CharCount
+		report.LineCount = stats.LineCount
+		report.Files = append(report.Files, stats.ProcessedFiles...)
+	}
+	return report
+}
+
+// writeDryRunJSON writes the report as a single indented JSON object
+func writeDryRunJSON(w io.Writer, report dryRunReport) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -2,13 +2,19 @@
 package cli
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/misty-step/thinktank/internal/config"
+	"github.com/misty-step/thinktank/internal/fileutil"
 	"github.com/misty-step/thinktank/internal/logutil"
+	"github.com/misty-step/thinktank/internal/thinktank/interfaces"
 )
 
 func TestRunDryRun(t *testing.T) {
@@ -51,3 +57,129 @@ func TestRunDryRun(t *testing.T) {
 		t.Errorf("runDryRun() failed: %v", err)
 	}
 }
+
+func TestPrintContentExcluded(t *testing.T) {
+	var buf bytes.Buffer
+	printContentExcluded(&buf, nil)
+	if buf.Len() != 0 {
+		t.Errorf("expected no output without excluded files, got %q", buf.String())
+	}
+
+	printContentExcluded(&buf, []fileutil.ContentExcludedFile{
+		{Path: "/repo/api/api.pb.go", Pattern: "@generated"},
+	})
+	want := "\nExcluded by content: 1\n  - /repo/api/api.pb.go (matches @generated)\n"
+	if buf.String() != want {
+		t.Errorf("printContentExcluded() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintTokenBudgetDropped(t *testing.T) {
+	var buf bytes.Buffer
+	printTokenBudgetDropped(&buf, []string{"/repo/z.go"}, 5000)
+	want := "\nDropped by the 5000 token budget: 1\n  - /repo/z.go\n"
+	if buf.String() != want {
+		t.Errorf("printTokenBudgetDropped() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintSkippedPaths(t *testing.T) {
+	var buf bytes.Buffer
+	printSkippedPaths(&buf, []fileutil.SkippedPath{
+		{Path: "/repo/.git", IsDir: true, Reason: fileutil.SkipGitDirectory},
+		{Path: "/repo/app.log", Reason: fileutil.SkipExcludedExtension},
+	})
+	want := "\nExcluded paths: 2\n  - /repo/.git" + string(filepath.Separator) + ": git directory\n  - /repo/app.log: excluded extension\n"
+	if buf.String() != want {
+		t.Errorf("printSkippedPaths() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestPrintCostEstimate(t *testing.T) {
+	cfg := &config.MinimalConfig{
+		ModelNames:     []string{"gemini-3-flash", "unknown-model"},
+		SynthesisModel: "gemini-3-pro",
+	}
+
+	var buf bytes.Buffer
+	printCostEstimate(&buf, cfg, 10000, 1000)
+	output := buf.String()
+
+	// gemini-3-flash: 10 * 0.0005 + 4 * 0.003 = 0.017
+	// synthesis on gemini-3-pro: (1000 + 2*4000) / 1000 * 0.002 + 4 * 0.012 = 0.066
+	for _, want := range []string{
+		"gemini-3-flash: $0.0170",
+		"unknown-model: unavailable",
+		"gemini-3-pro (synthesis): $0.0660",
+		"Total: $0.0830",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("cost estimate missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestPrintTokenUsage(t *testing.T) {
+	var buf bytes.Buffer
+	// 2,000,000 characters: ~500,000 tokens for gpt-5.2 and ~571,429 for gemini-3-pro
+	printTokenUsage(&buf, []string{"gpt-5.2", "gemini-3-pro", "unknown-model"}, strings.Repeat("a", 2_000_000))
+	output := buf.String()
+
+	for _, want := range []string{
+		"Estimated prompt tokens by model:",
+		"gpt-5.2: ~500000 of 400000 tokens (125.0%) - exceeds context window",
+		"gemini-3-pro: ~571429 of 1048576 tokens (54.5%)\n",
+		"unknown-model: unavailable",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("token usage missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestWriteDryRunJSON(t *testing.T) {
+	cfg := &config.MinimalConfig{
+		InstructionsFile: "task.md",
+		TargetPaths:      []string{"./src", "./docs"},
+		ModelNames:       []string{"gemini-3-flash", "gpt-5.2"},
+		SynthesisModel:   "gemini-3-pro",
+	}
+	stats := &interfaces.ContextStats{
+		ProcessedFilesCount: 2,
+		CharCount:           1200,
+		LineCount:           40,
+		ProcessedFiles:      []string{"src/main.go", "docs/README.md"},
+	}
+
+	var out bytes.Buffer
+	if err := writeDryRunJSON(&out, newDryRunReport(cfg, stats)); err != nil {
+		t.Fatalf("writeDryRunJSON() error = %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("output is not a JSON object: %v\n%s", err, out.String())
+	}
+	want := map[string]interface{}{
+		"instructions_file": "task.md",
+		"target_paths":      []interface{}{"./src", "./docs"},
+		"models":            []interface{}{"gemini-3-flash", "gpt-5.2"},
+		"synthesis_model":   "gemini-3-pro",
+		"file_count":        float64(2),
+		"char_count":        float64(1200),
+		"line_count":        float64(40),
+		"files":             []interface{}{"src/main.go", "docs/README.md"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dry run JSON =\n%v\nwant\n%v", got, want)
+	}
+
+	// With nothing gathered, lists are empty rather than null
+	out.Reset()
+	if err := writeDryRunJSON(&out, newDryRunReport(&config.MinimalConfig{}, nil)); err != nil {
+		t.Fatalf("writeDryRunJSON() error = %v", err)
+	}
+	if strings.Contains(out.String(), "null") || strings.Contains(out.String(), "synthesis_model") {
+		t.Errorf("empty report should have empty lists and no synthesis model, got %s", out.String())
+	}
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/misty-step/thinktank/internal/llm"
+	"github.com/misty-step/thinktank/internal/thinktank/orchestrator"
+)
+
+// errorReport is the object --error-json writes to stderr when thinktank exits
+// nonzero, so wrapping tools don't have to parse the human-readable message
+type errorReport struct {
+	ExitCode      int                  `json:"exit_code"`
+	Category      string               `json:"category"` // llm.ErrorCategory or CLIErrorType name
+	Message       string               `json:"message"`
+	ModelFailures []modelFailureReport `json:"model_failures,omitempty"`
+}
+
+// modelFailureReport is one model's failure within an errorReport
+type modelFailureReport struct {
+	Model    string `json:"model"`
+	Category string `json:"category"`
+	Message  string `json:"message"`
+}
+
+// newErrorReport classifies err the way getExitCode and getUserMessage do
+func newErrorReport(err error, exitCode int, message string) errorReport {
+	report := errorReport{
+		ExitCode: exitCode,
+		Category: errorCategoryName(err),
+		Message:  message,
+	}
+	for _, failure := range orchestrator.ModelFailures(err) {
+		report.ModelFailures = append(report.ModelFailures, modelFailureReport{
+			Model:    failure.Model,
+			Category: failure.Category.String(),
+			Message:  failure.Message,
+		})
+	}
+	return report
+}
+
+// errorCategoryName names the category of err: the LLM error category when
+// there is one, else the CLI error type, else Cancelled or Unknown
+func errorCategoryName(err error) string {
+	var llmErr *llm.LLMError
+	if errors.As(err, &llmErr) {
+		return llmErr.ErrorCategory.String()
+	}
+	if cliErr, ok := IsCLIError(err); ok {
+		return cliErr.Type.String()
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return llm.CategoryCancelled.String()
+	}
+	return llm.CategoryUnknown.String()
+}
+
+// writeErrorJSON writes the report as a single line of JSON
+func writeErrorJSON(w io.Writer, report errorReport) error {
+	return json.NewEncoder(w).Encode(report)
+}
+
+// reportError writes err to w as "Error: message", or as an errorReport when
+// jsonOutput is set (falling back to text if encoding fails)
+func reportError(w io.Writer, err error, exitCode int, message string, jsonOutput bool) {
+	if jsonOutput && writeErrorJSON(w, newErrorReport(err, exitCode, message)) == nil {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Error: %s\n", message)
+}
+
+// reportArgumentError reports an error in the command line itself: as text with
+// a pointer to --help, or as an errorReport categorized as an invalid value
+func reportArgumentError(w io.Writer, err error, jsonOutput bool) {
+	if jsonOutput {
+		if _, ok := IsCLIError(err); !ok {
+			err = WrapCLIError(err, CLIErrorInvalidValue, err.Error(), "", "")
+		}
+		reportError(w, err, ExitCodeInvalidRequest, err.Error(), true)
+		return
+	}
+	reportError(w, err, ExitCodeInvalidRequest, err.Error(), false)
+	_, _ = fmt.Fprintln(w, "\nRun 'thinktank --help' for usage information.")
+}
+
+// isErrorJSONRequested checks if --error-json is present in args, so that
+// errors found while parsing them can honor it too
+func isErrorJSONRequested(args []string) bool {
+	for _, arg := range args {
+		if arg == "--error-json" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/misty-step/thinktank/internal/llm"
+)
+
+func TestErrorCategoryName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "llm error", err: fmt.Errorf("run: %w", llm.New("openrouter", "", 401, "bad key", "", errors.New("unauthorized"), llm.CategoryAuth)), want: "Auth"},
+		{name: "cli error", err: ErrMissingTargetPath, want: "MissingRequired"},
+		{name: "context cancelled", err: fmt.Errorf("gather: %w", context.Canceled), want: "Cancelled"},
+		{name: "plain error", err: errors.New("boom"), want: "Unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, errorCategoryName(tt.err))
+		})
+	}
+}
+
+func TestReportError(t *testing.T) {
+	t.Parallel()
+	err := NewCLIError(CLIErrorInvalidValue, "invalid model specified", "")
+
+	var text bytes.Buffer
+	reportError(&text, err, ExitCodeInvalidRequest, "invalid model specified", false)
+	assert.Equal(t, "Error: invalid model specified\n", text.String())
+
+	var out bytes.Buffer
+	reportError(&out, err, ExitCodeInvalidRequest, "invalid model specified", true)
+	var report map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report), "output: %s", out.String())
+	assert.Equal(t, map[string]interface{}{
+		"exit_code": float64(ExitCodeInvalidRequest),
+		"category":  "InvalidValue",
+		"message":   "invalid model specified",
+	}, report)
+}
+
+func TestReportArgumentError(t *testing.T) {
+	t.Parallel()
+	args := []string{"thinktank", "instructions.md", "./src", "--error-json", "--timeout", "soon"}
+	require.True(t, isErrorJSONRequested(args))
+	require.False(t, isErrorJSONRequested(args[:3]))
+
+	_, parseErr := ParseSimpleArgsWithArgs(args)
+	require.Error(t, parseErr)
+
+	var text bytes.Buffer
+	reportArgumentError(&text, parseErr, false)
+	assert.Equal(t, "Error: "+parseErr.Error()+"\n\nRun 'thinktank --help' for usage information.\n", text.String())
+
+	var out bytes.Buffer
+	reportArgumentError(&out, parseErr, true)
+	var report map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report), "output: %s", out.String())
+	assert.Equal(t, map[string]interface{}{
+		"exit_code": float64(ExitCodeInvalidRequest),
+		"category":  "InvalidValue",
+		"message":   parseErr.Error(),
+	}, report)
+}
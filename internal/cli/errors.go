@@ -47,6 +47,24 @@ const (
 	CLIErrorAuthentication
 )
 
+// String returns a name for the error type, in the style of llm.ErrorCategory
+func (t CLIErrorType) String() string {
+	switch t {
+	case CLIErrorMissingRequired:
+		return "MissingRequired"
+	case CLIErrorInvalidValue:
+		return "InvalidValue"
+	case CLIErrorFileAccess:
+		return "FileAccess"
+	case CLIErrorConfiguration:
+		return "Configuration"
+	case CLIErrorAuthentication:
+		return "Auth"
+	default:
+		return "Unknown"
+	}
+}
+
 // CLIError represents a CLI-specific error with context and user-friendly messaging
 type CLIError struct {
 	Type        CLIErrorType
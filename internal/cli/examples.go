@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"text/tabwriter"
+)
+
+// exampleFiles holds the bundled example instructions, one Markdown file per example.
+// The first line of each file is a "# " heading describing it.
+//
+//go:embed examples/*.md
+var exampleFiles embed.FS
+
+// examplesUsage is reported when the examples subcommand is misused
+const examplesUsage = "usage: thinktank examples [list | show <name>]"
+
+// examplesSubcommand recognizes "thinktank examples [list | show <name>]" and returns
+// the arguments after "examples". As with completion, an instructions file named
+// "examples" still works when it is followed by target paths.
+func examplesSubcommand(args []string) ([]string, bool) {
+	if len(args) < 2 || args[1] != "examples" {
+		return nil, false
+	}
+	rest := args[2:]
+	if len(rest) == 0 || rest[0] == "list" || rest[0] == "show" {
+		return rest, true
+	}
+	return nil, false
+}
+
+// runExamples lists the bundled examples or writes the named one to w
+func runExamples(w io.Writer, args []string) error {
+	switch {
+	case len(args) == 0, len(args) == 1 && args[0] == "list":
+		return listExamples(w)
+	case len(args) == 2 && args[0] == "show":
+		return showExample(w, args[1])
+	case args[0] == "show":
+		return fmt.Errorf("examples show requires one example name (%s)", examplesUsage)
+	default:
+		return fmt.Errorf("%s", examplesUsage)
+	}
+}
+
+// exampleNames returns the names of the bundled examples, sorted
+func exampleNames() []string {
+	entries, err := fs.ReadDir(exampleFiles, "examples")
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".md"))
+	}
+	return names
+}
+
+// readExample returns the named example's instructions; the .md extension is optional
+func readExample(name string) (string, error) {
+	name = strings.TrimSuffix(name, ".md")
+	content, err := exampleFiles.ReadFile(path.Join("examples", name+".md"))
+	if err != nil {
+		return "", fmt.Errorf("unknown example %q (available: %s)", name, strings.Join(exampleNames(), ", "))
+	}
+	return string(content), nil
+}
+
+// listExamples writes a table of the bundled examples and what each is for
+func listExamples(w io.Writer) error {
+	// The tabwriter buffers rows, so write errors surface from Flush
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tDESCRIPTION")
+	for _, name := range exampleNames() {
+		content, err := readExample(name)
+		if err != nil {
+			return err
+		}
+		heading, _, _ := strings.Cut(content, "\n")
+		_, _ = fmt.Fprintf(tw, "%s\t%s\n", name, strings.TrimPrefix(heading, "# "))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w, "\nRun 'thinktank examples show <name> > instructions.md' to start from one.")
+	return err
+}
+
+// showExample writes the named example's instructions to w, so they can be
+// redirected to a file and edited
+func showExample(w io.Writer, name string) error {
+	content, err := readExample(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, content)
+	return err
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExamplesSubcommand(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		args     []string
+		wantArgs []string
+		wantOK   bool
+	}{
+		{name: "bare", args: []string{"thinktank", "examples"}, wantArgs: []string{}, wantOK: true},
+		{name: "list", args: []string{"thinktank", "examples", "list"}, wantArgs: []string{"list"}, wantOK: true},
+		{name: "show", args: []string{"thinktank", "examples", "show", "code-review"}, wantArgs: []string{"show", "code-review"}, wantOK: true},
+		{name: "instructions file named examples", args: []string{"thinktank", "examples", "./src"}, wantOK: false},
+		{name: "not requested", args: []string{"thinktank", "task.md", "./src"}, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, ok := examplesSubcommand(tt.args)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestRunExamplesList(t *testing.T) {
+	t.Parallel()
+	names := exampleNames()
+	require.NotEmpty(t, names, "examples should be embedded")
+
+	for _, args := range [][]string{{}, {"list"}} {
+		var buf bytes.Buffer
+		require.NoError(t, runExamples(&buf, args))
+		output := buf.String()
+		assert.True(t, strings.HasPrefix(output, "NAME"), "list should start with a header: %q", output)
+		for _, name := range names {
+			assert.Contains(t, output, name)
+		}
+		assert.Contains(t, output, "Review the code for correctness and maintainability")
+		assert.NotContains(t, output, "# ", "descriptions should drop the heading marker")
+	}
+}
+
+func TestRunExamplesShow(t *testing.T) {
+	t.Parallel()
+	for _, name := range exampleNames() {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, runExamples(&buf, []string{"show", name}))
+			assert.True(t, strings.HasPrefix(buf.String(), "# "), "each example should start with a heading")
+		})
+	}
+
+	var withExtension bytes.Buffer
+	require.NoError(t, runExamples(&withExtension, []string{"show", "code-review.md"}))
+	assert.Contains(t, withExtension.String(), "Review the code")
+}
+
+func TestRunExamplesErrors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		args        []string
+		errContains string
+	}{
+		{name: "unknown example", args: []string{"show", "nope"}, errContains: `unknown example "nope" (available: architecture, bug-hunt`},
+		{name: "path outside examples", args: []string{"show", "../help.go"}, errContains: "unknown example"},
+		{name: "show without name", args: []string{"show"}, errContains: "requires one example name"},
+		{name: "list with extra args", args: []string{"list", "code-review"}, errContains: examplesUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := runExamples(&buf, tt.args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+			assert.Empty(t, buf.String())
+		})
+	}
+}
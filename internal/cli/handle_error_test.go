@@ -54,7 +54,7 @@ func TestHandleError(t *testing.T) {
 		{
 			name:         "partial success error",
 			err:          thinktank.ErrPartialSuccess,
-			expectedCode: ExitCodeGenericError,
+			expectedCode: ExitCodePartialSuccess,
 		},
 		{
 			name:         "generic error",
@@ -25,6 +25,8 @@ const helpText = `thinktank - AI-powered code analysis and synthesis tool
 
 USAGE:
     thinktank instructions.txt target_path... [flags]
+    thinktank --instructions-inline TEXT target_path... [flags]
+    thinktank examples [list | show NAME]
 
 DESCRIPTION:
     Thinktank analyzes codebases and generates responses based on your
@@ -42,28 +44,59 @@ ARGUMENTS:
 FLAGS:
     --help, -h         Show this help message and exit
 
+    --version, -V      Print version, git commit, and build date, then exit
+
+    --list-models      List supported models with provider, context window,
+                       and whether each API key is set, then exit
+
     --dry-run          Preview what would be processed without making API calls
                        Shows file list, accurate token count, and model selection
                        Uses accurate tokenization for all models via OpenRouter
 
+    --dry-run-json     Like --dry-run, but write one JSON object to stdout with the
+                       instructions, targets, models, counts, and every file
+
     --verbose          Enable detailed output and debug logging
                        Includes API responses and processing details
 
     --synthesis        Force synthesis mode with multiple models
                        Combines responses from different models for better results
 
+    --synthesis-model MODEL  Model that combines results (implies --synthesis)
+                             Default: one from a provider with an API key set
+
+    --no-synthesis     Write only the individual model outputs, never a synthesis
+                       Cannot be combined with --synthesis or --synthesis-model
+
     --model MODEL      Select specific AI model (default: gemini-3-flash)
                        Available: gemini-3-flash, gpt-5.2, o3, and more
 
     --output-dir DIR   Set output directory (default: auto-generated timestamp)
-                       Created if it doesn't exist
+                       Created if it doesn't exist; must be writable
+                       Fails if the run would overwrite files already there
 
     --quiet            Suppress non-essential console output
                        Only shows errors and final results
 
+    --silent           Write nothing to stdout, not even the summary
+                       Errors and warnings go to stderr; overrides --quiet
+
     --json-logs        Output structured JSON logs to stderr
                        Useful for debugging and integration
 
+    --output-format FORMAT  Final summary format: text (default) or json
+                            json writes one object to stdout and nothing else
+
+    --progress FORMAT  Model progress format: text (default) or json
+                       json also writes one event per line to stderr, even with --quiet
+
+    --progress-fd N    Write --progress=json events to file descriptor N instead of stderr
+
+    --color WHEN       Color output: auto (default), always, or never
+                       auto honors NO_COLOR and FORCE_COLOR
+
+    --theme NAME       Color theme: default or high-contrast
+
     --no-progress      Disable progress indicators
                        Helpful for CI environments or log capture
 
@@ -73,6 +106,171 @@ FLAGS:
     --metrics-output FILE  Write execution metrics to FILE in JSON Lines format
                            Captures timing, throughput, and error data for analysis
 
+    --otel             Emit OpenTelemetry spans for the run and each model, and
+                       counters of model outcomes and tokens. Both go to an OTLP
+                       collector (OTEL_EXPORTER_OTLP_ENDPOINT, _PROTOCOL grpc or
+                       http/protobuf); OTEL_TRACES_EXPORTER=console|none and
+                       OTEL_METRICS_EXPORTER=console|none change where they go
+
+    --normalize-newlines   Convert CRLF line endings to LF in context files
+                           Gives identical prompts and token counts across platforms
+
+    --embed-instructions   Prepend the instructions to each output file
+                           Keeps results self-describing when shared
+
+    --instructions-inline TEXT, -i TEXT
+                           Use TEXT as the instructions instead of a file; every
+                           positional argument is then a target path
+
+    --prompt-order ORDER   Prompt layout: default (instructions, then files in
+                           gather order), instructions-last, or by-directory
+
+    --fence-code           Wrap each file's content in a fenced code block
+                           tagged with a language inferred from its extension
+                           or name (Dockerfile, Makefile)
+
+    --file-separator TEXT  Place TEXT between files in the prompt instead of a
+                           blank line; escapes such as \n and \t are decoded
+
+    --dir-perms MODE        Octal permissions for created output directories
+                            (default: 0755)
+
+    --file-perms MODE       Octal permissions for output files (default: 0644)
+
+    --strict-output-dir     Fail if the output directory can't be created in the
+                            working directory (default: fall back to temp dir)
+
+    --force                 Overwrite existing files in --output-dir instead of
+                            failing before any model runs
+
+    --output-name-template TEMPLATE  Name output files using {model}, {provider},
+                                     {timestamp}, and {ext} (default: {model}.{ext})
+                                     A / in TEMPLATE creates subdirectories
+
+    --combined-output FILE  Write every model's output to FILE under a "## model"
+                            heading, in model order, instead of one file per model
+                            (relative to the output directory); no LLM is involved
+
+    --post-process COMMAND  Run the shell COMMAND on each output file once it is
+                            written, with the file's path as its last argument and
+                            its content on stdin; failures are warnings
+
+    --summary-file FILE     Also write the run summary to FILE as markdown,
+                            whatever --output-format prints to stdout
+
+    --include-glob PATTERN  Only include files matching PATTERN (e.g. 'src/**/*.go'),
+                            relative to the input path it was found under; repeatable
+
+    --priority-glob PATTERN  Keep files matching PATTERN when --auto-trim or
+                             --max-context-tokens must drop files; repeatable,
+                             earlier patterns are kept longest
+
+    --paths-from-file FILE  Read additional target paths from FILE, one per line
+                            Blank lines and # comments are ignored
+
+    --skip-missing-paths    Warn about and skip paths in --paths-from-file
+                            that don't exist instead of failing
+
+    --cache-dir DIR         Reuse responses stored in DIR when the model, prompt,
+                            and parameters are unchanged (failures are never cached)
+
+    --no-cache              Call every model, ignoring any configured cache directory
+
+    --follow-symlinks       Walk into symlinked directories (each directory once)
+
+    --exclude-generated     Skip files marked as generated code in their first
+                            kilobyte (e.g. "// Code generated ... DO NOT EDIT.")
+
+    --include-hidden        Gather dotfiles and dot-directories such as .github/
+                            (exclude lists and .gitignore still apply; .git never)
+
+    --explain-excludes      With --dry-run, list every excluded file and directory
+                            with the filter that excluded it
+
+    --models LIST           Run exactly these comma-separated models instead of
+                            the automatic selection (e.g. gpt-5.2,gemini-3-flash)
+
+    --select STRATEGY       Choose the automatically selected models by strategy:
+                            cheapest, largest (context window), or fastest;
+                            ignored with --models
+
+    --repeat N              Run each model N times to compare its answers, writing
+                            <model>.1.md through <model>.N.md (default: 1)
+
+    --preflight             Before gathering context, check that each provider
+                            accepts its API key and is reachable; fail fast if not
+
+    --openrouter-base-url URL  Send OpenRouter requests to URL instead, such as a
+                               self-hosted gateway or proxy (any provider's name
+                               works in --PROVIDER-base-url)
+
+    --model-param MODEL:KEY=VALUE  Override one parameter for one model, such as
+                               gpt-5.2:temperature=0.2 (repeatable; other
+                               parameters keep the model's defaults)
+
+    --auto-trim             For models the context would overflow, drop the largest
+                            files (directory contents before named files) until it fits;
+                            --priority-glob files are dropped last
+
+    --template-vars KEY=VALUE  Treat the instructions file as a Go template and
+                               replace .KEY actions with VALUE (repeatable)
+
+    --profile NAME          Use defaults from the named profile in
+                            $XDG_CONFIG_HOME/thinktank/profiles.json
+                            (~/.config/thinktank/profiles.json); flags override it
+
+    --timeout DURATION      Limit the whole run (default: 10m)
+
+    --model-timeout DURATION  Limit each model's generation; a model that runs
+                              over fails on its own while the others continue
+
+    --gather-timeout DURATION  Limit time spent scanning files (e.g. 30s, 2m)
+                               Defaults to the overall run timeout
+
+    --gather-workers N      Files scanned and read in parallel (default: CPU count, max 32)
+
+    --max-file-size SIZE    Skip context files larger than SIZE (e.g. 500K, 2MB, 1GiB;
+                            KB/MB/GB are powers of 1000, KiB/MiB/GiB and K/M/G of 1024)
+
+    --max-context-tokens N  Stop adding files once the instructions plus the files
+                            so far would exceed N estimated tokens
+
+    --max-output-file-size SIZE  Truncate each output file beyond SIZE (e.g. 1MiB)
+                                 and append a truncation notice (default: unlimited)
+
+    --rate-limit-wait-budget DURATION  Fail with exit code 3 once models have spent
+                                       DURATION in total waiting on rate limits
+
+    --partial-success-ok    Exit 0 when some models fail but others succeed
+                            (default: exit code 11); failures are still reported
+
+    --fail-fast             Cancel the remaining models as soon as one fails
+                            (default: run every model and report all failures)
+
+    --error-json            On failure, write a JSON object to stderr with the exit
+                            code, error category, message, and per-model failures
+
+    --max-retries N         Retry a model up to N times after a transient server,
+                            network, or rate limit error (default: 2, 0 = off)
+
+    --retry-budget N        Allow at most N retries across all models combined;
+                            once spent, failures are final (default: unlimited)
+
+    --concurrency N         Run at most N model requests at once (default: 5,
+                            0 = unlimited); the per-minute rate limit still applies
+
+    --retry-base-delay DURATION  Wait before the first retry (default: 1s)
+                                 Doubles for each retry, up to 30s
+
+    --audit-verbose         Record complete prompts and responses in audit.jsonl
+                            (default: the first 200 characters of each)
+
+    --audit-preview-length N  Characters of each prompt and response kept in
+                              audit.jsonl without --audit-verbose (0 = lengths only)
+
+    --checkpoint-interval DURATION  Log progress every DURATION while models run
+                                    (e.g. 30s); makes stalled runs easy to spot
+
 EXAMPLES:
     # Basic usage - analyze a single directory
     thinktank instructions.md ./src
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// templateVarName matches keys usable as {{.key}} in an instructions template
+var templateVarName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// templateErrorLine extracts the line number text/template reports, as in
+// "template: task.md:3:14: executing ..." or "template: task.md:3: unexpected ..."
+var templateErrorLine = regexp.MustCompile(`^template: [^:]*:(\d+)(?::\d+)?: (.*)$`)
+
+// parseTemplateVar splits a --template-vars value of the form key=value
+func parseTemplateVar(value string) (string, string, error) {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok {
+		return "", "", fmt.Errorf("invalid --template-vars value %q: expected key=value", value)
+	}
+	if !templateVarName.MatchString(key) {
+		return "", "", fmt.Errorf("invalid --template-vars key %q: use letters, digits, and underscores, not starting with a digit", key)
+	}
+	return key, val, nil
+}
+
+// renderInstructionsTemplate executes the instructions as a text/template, substituting
+// {{.key}} with vars. Referencing a variable that wasn't supplied is an error rather
+// than silently producing "<no value>". Errors name the file and the offending line.
+func renderInstructionsTemplate(path, instructions string, vars map[string]string) (string, error) {
+	tmpl, err := template.New(filepath.Base(path)).Option("missingkey=error").Parse(instructions)
+	if err != nil {
+		return "", instructionsTemplateError(path, instructions, err)
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, vars); err != nil {
+		return "", instructionsTemplateError(path, instructions, err)
+	}
+	return rendered.String(), nil
+}
+
+// instructionsTemplateError reports a template failure as an invalid input, quoting
+// the line text/template points at when it names one
+func instructionsTemplateError(path, instructions string, err error) error {
+	message := fmt.Sprintf("invalid instructions template: %v", err)
+	if match := templateErrorLine.FindStringSubmatch(err.Error()); match != nil {
+		lineNumber, _ := strconv.Atoi(match[1])
+		lines := strings.Split(instructions, "\n")
+		message = fmt.Sprintf("invalid instructions template at line %d: %s", lineNumber, match[2])
+		if lineNumber >= 1 && lineNumber <= len(lines) {
+			message += fmt.Sprintf("\n  %d | %s", lineNumber, strings.TrimRight(lines[lineNumber-1], "\r"))
+		}
+	}
+	return WrapCLIError(err, CLIErrorInvalidValue, message, "", path)
+}
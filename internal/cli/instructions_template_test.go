@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTemplateVar(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value     string
+		wantKey   string
+		wantValue string
+		wantErr   string
+	}{
+		{value: "repo=api", wantKey: "repo", wantValue: "api"},
+		{value: "query=a=b", wantKey: "query", wantValue: "a=b"},
+		{value: "empty=", wantKey: "empty", wantValue: ""},
+		{value: "repo", wantErr: "expected key=value"},
+		{value: "=api", wantErr: "invalid --template-vars key"},
+		{value: "my-repo=api", wantErr: "invalid --template-vars key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+			key, value, err := parseTemplateVar(tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseTemplateVar(%q) error = %v, want %q", tt.value, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || key != tt.wantKey || value != tt.wantValue {
+				t.Errorf("parseTemplateVar(%q) = %q, %q, %v; want %q, %q", tt.value, key, value, err, tt.wantKey, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestRenderInstructionsTemplate(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]string{"repo": "api", "date": "2026-10-16"}
+
+	tests := []struct {
+		name         string
+		instructions string
+		want         string
+		wantErr      []string
+	}{
+		{
+			name:         "substitutes variables",
+			instructions: "Review {{.repo}} as of {{.date}}.\n",
+			want:         "Review api as of 2026-10-16.\n",
+		},
+		{
+			name:         "missing variable names the line",
+			instructions: "Review {{.repo}}.\nFocus on {{.area}}.\n",
+			wantErr:      []string{"at line 2", "2 | Focus on {{.area}}."},
+		},
+		{
+			name:         "parse error",
+			instructions: "Review {{.repo}}.\n\nThen {{if .date}}check it.\n",
+			wantErr:      []string{"invalid instructions template"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := renderInstructionsTemplate("task.md", tt.instructions, vars)
+			if len(tt.wantErr) > 0 {
+				if err == nil {
+					t.Fatal("Expected an error, got nil")
+				}
+				cliErr, ok := IsCLIError(err)
+				if !ok || cliErr.Type != CLIErrorInvalidValue {
+					t.Errorf("Expected an invalid value CLIError, got %T: %v", err, err)
+				}
+				for _, want := range tt.wantErr {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("Error %q does not contain %q", err.Error(), want)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("renderInstructionsTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/misty-step/thinktank/internal/models"
+)
+
+// printModelList writes a table of supported models and whether each one's API key is set.
+// Test models are listed only when THINKTANK_ENABLE_TEST_MODELS=true, matching model selection.
+func printModelList(w io.Writer, getenv func(string) string) error {
+	showTestModels := getenv("THINKTANK_ENABLE_TEST_MODELS") == "true"
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODEL\tPROVIDER\tCONTEXT\tAPI KEY")
+	for _, info := range models.ListModels() {
+		if info.Provider == "test" && !showTestModels {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", info.Name, info.Provider, info.ContextWindow, apiKeyStatus(info, getenv))
+	}
+	return tw.Flush()
+}
+
+// apiKeyStatus describes whether the API key for a model's provider is available
+func apiKeyStatus(info models.ModelInfo, getenv func(string) string) string {
+	envVar := info.APIKeyEnvVar
+	if envVar == "" {
+		envVar = models.GetAPIKeyEnvVar(info.Provider)
+	}
+	switch {
+	case envVar == "":
+		return "not required"
+	case getenv(envVar) != "":
+		return fmt.Sprintf("set (%s)", envVar)
+	default:
+		return fmt.Sprintf("missing (%s)", envVar)
+	}
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSimpleArgs_ListModels(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		{"thinktank", "--list-models"},
+		{"thinktank", "instructions.txt", "src/", "--list-models"},
+	} {
+		config, err := ParseSimpleArgsWithArgs(args)
+		require.NoError(t, err, "args: %v", args)
+		assert.True(t, config.ListModelsRequested(), "args: %v", args)
+		assert.False(t, config.HelpRequested(), "args: %v", args)
+	}
+
+	assert.False(t, (&SimplifiedConfig{}).ListModelsRequested())
+	assert.False(t, (&SimplifiedConfig{Options: &AdvancedOptions{}}).ListModelsRequested())
+}
+
+func TestPrintModelList(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		env         map[string]string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "key missing",
+			env:         map[string]string{},
+			contains:    []string{"MODEL", "PROVIDER", "CONTEXT", "API KEY", "gemini-3-flash", "missing (OPENROUTER_API_KEY)"},
+			notContains: []string{"model1", "set (OPENROUTER_API_KEY)"},
+		},
+		{
+			name:     "key set",
+			env:      map[string]string{"OPENROUTER_API_KEY": "sk-test"},
+			contains: []string{"set (OPENROUTER_API_KEY)"},
+		},
+		{
+			name:     "test models enabled",
+			env:      map[string]string{"THINKTANK_ENABLE_TEST_MODELS": "true"},
+			contains: []string{"model1", "not required"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			getenv := func(key string) string { return tt.env[key] }
+
+			require.NoError(t, printModelList(&buf, getenv))
+
+			output := buf.String()
+			for _, want := range tt.contains {
+				assert.Contains(t, output, want)
+			}
+			for _, unwanted := range tt.notContains {
+				assert.NotContains(t, output, unwanted)
+			}
+			assert.True(t, strings.HasPrefix(output, "MODEL"), "header should come first")
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -14,21 +15,30 @@ import (
 	"github.com/google/uuid"
 	"github.com/misty-step/thinktank/internal/auditlog"
 	"github.com/misty-step/thinktank/internal/config"
+	"github.com/misty-step/thinktank/internal/fileutil"
 	"github.com/misty-step/thinktank/internal/llm"
 	"github.com/misty-step/thinktank/internal/logutil"
 	"github.com/misty-step/thinktank/internal/metrics"
 	"github.com/misty-step/thinktank/internal/models"
 	"github.com/misty-step/thinktank/internal/pathutil"
 	"github.com/misty-step/thinktank/internal/ratelimit"
+	"github.com/misty-step/thinktank/internal/telemetry"
 	"github.com/misty-step/thinktank/internal/thinktank"
 	"github.com/misty-step/thinktank/internal/thinktank/interfaces"
 	"github.com/misty-step/thinktank/internal/thinktank/orchestrator"
+	"github.com/misty-step/thinktank/internal/thinktank/prompt"
 	"github.com/misty-step/thinktank/internal/version"
 )
 
 // Variable to allow mocking os.Exit in tests
 var osExit = os.Exit
 
+// auditBufferSize is how many audit entries may queue before logging blocks
+const auditBufferSize = 256
+
+// defaultSynthesisModel is used whenever results from multiple models need combining
+const defaultSynthesisModel = "gemini-3-pro"
+
 // Exit codes for different error types
 const (
 	ExitCodeSuccess             = 0
@@ -42,6 +52,7 @@ const (
 	ExitCodeContentFiltered     = 8
 	ExitCodeInsufficientCredits = 9
 	ExitCodeCancelled           = 10
+	ExitCodePartialSuccess      = 11 // Some models failed; outputs from the rest were written
 )
 
 // Main is the entry point for the thinktank CLI
@@ -52,11 +63,31 @@ func Main() {
 		osExit(ExitCodeSuccess)
 	}
 
+	// --error-json also covers errors in the arguments themselves
+	argsErrorJSON := isErrorJSONRequested(os.Args)
+
+	// Handle completion early (hidden meta-command for shell completion scripts)
+	if shell, ok := isCompletionRequested(os.Args); ok {
+		if err := GenerateCompletion(os.Stdout, shell); err != nil {
+			reportError(os.Stderr, err, ExitCodeInvalidRequest, err.Error(), argsErrorJSON)
+			osExit(ExitCodeInvalidRequest)
+		}
+		osExit(ExitCodeSuccess)
+	}
+
+	// Handle the examples subcommand (bundled instruction templates)
+	if exampleArgs, ok := examplesSubcommand(os.Args); ok {
+		if err := runExamples(os.Stdout, exampleArgs); err != nil {
+			reportError(os.Stderr, err, ExitCodeInvalidRequest, err.Error(), argsErrorJSON)
+			osExit(ExitCodeInvalidRequest)
+		}
+		osExit(ExitCodeSuccess)
+	}
+
 	// Parse simplified arguments directly
 	simplifiedConfig, err := ParseSimpleArgs()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
-		fmt.Fprintln(os.Stderr, "\nRun 'thinktank --help' for usage information.")
+		reportArgumentError(os.Stderr, err, argsErrorJSON)
 		osExit(ExitCodeInvalidRequest)
 	}
 
@@ -66,32 +97,67 @@ func Main() {
 		osExit(ExitCodeSuccess)
 	}
 
+	// Handle model listing (no network access or configuration needed)
+	if simplifiedConfig.ListModelsRequested() {
+		if err := printModelList(os.Stdout, os.Getenv); err != nil {
+			reportError(os.Stderr, err, ExitCodeGenericError, err.Error(), simplifiedConfig.GetOptions().ErrorJSON)
+			osExit(ExitCodeGenericError)
+		}
+		osExit(ExitCodeSuccess)
+	}
+
+	// With --error-json, failures from here on are reported as a JSON object
+	errorJSON := simplifiedConfig.GetOptions().ErrorJSON
+
+	// Load project-local defaults from .thinktank.json (flags take precedence)
+	projectConfig, err := config.LoadProjectConfig(".")
+	if err != nil {
+		reportError(os.Stderr, err, ExitCodeInvalidRequest, err.Error(), errorJSON)
+		osExit(ExitCodeInvalidRequest)
+	}
+
 	// Setup configuration using extracted function
 	tokenService := thinktank.NewTokenCountingService()
 	minimalConfig, err := setupConfiguration(simplifiedConfig, tokenService)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		reportError(os.Stderr, err, ExitCodeInvalidRequest, err.Error(), errorJSON)
+		osExit(ExitCodeInvalidRequest)
+	}
+	applyProjectConfig(minimalConfig, projectConfig, simplifiedConfig)
+	if err := applyProfile(minimalConfig, projectConfig, simplifiedConfig, os.Getenv); err != nil {
+		reportError(os.Stderr, err, ExitCodeInvalidRequest, err.Error(), errorJSON)
 		osExit(ExitCodeInvalidRequest)
 	}
 
 	// Validate configuration early in the flow
 	if err := validateConfig(minimalConfig); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		reportError(os.Stderr, err, ExitCodeInvalidRequest, err.Error(), errorJSON)
 		osExit(ExitCodeInvalidRequest)
 	}
 
 	// Execute the application
 	err = executeApplication(minimalConfig, simplifiedConfig, tokenService)
+	err = acceptPartialSuccess(minimalConfig, err, os.Stderr)
 	if err != nil {
 		// Handle error using the original error handling logic
 		exitCode := getExitCode(err)
 		userMessage := getUserMessage(err)
 
-		fmt.Fprintf(os.Stderr, "Error: %s\n", userMessage)
+		reportError(os.Stderr, err, exitCode, userMessage, errorJSON)
 		osExit(exitCode)
 	}
 }
 
+// acceptPartialSuccess returns nil for a partial success when --partial-success-ok
+// is set, after reporting which models failed to w. Other errors pass through.
+func acceptPartialSuccess(cfg *config.MinimalConfig, err error, w io.Writer) error {
+	if err == nil || !cfg.PartialSuccessOk || !errors.Is(err, thinktank.ErrPartialSuccess) {
+		return err
+	}
+	_, _ = fmt.Fprintf(w, "Warning: %v\n", err)
+	return nil
+}
+
 // executeApplication handles the execution orchestration phase following extracted configuration and validation
 // This function manages logger setup, context creation, output directory creation, and application execution
 func executeApplication(minimalConfig *config.MinimalConfig, simplifiedConfig *SimplifiedConfig, tokenService thinktank.TokenCountingService) error {
@@ -111,26 +177,30 @@ func executeApplication(minimalConfig *config.MinimalConfig, simplifiedConfig *S
 	ctx = logutil.WithCorrelationID(ctx, correlationID)
 	contextLogger := logger.WithContext(ctx)
 
-	// Create output directory if not set
-	if minimalConfig.OutputDir == "" {
-		outputManager := NewOutputManager(contextLogger)
-		outputDir, err := outputManager.CreateOutputDirectory("", 0755)
-		if err != nil {
-			contextLogger.ErrorContext(ctx, "Failed to create output directory: %v", err)
-			return fmt.Errorf("failed to create output directory: %w", err)
-		}
-		minimalConfig.OutputDir = outputDir
-
-		// Now that we have output directory, recreate logger with proper file routing
-		// Close the previous logger wrapper first
-		_ = loggerWrapper.Close()
-		logger, loggerWrapper = createLoggerWithRouting(minimalConfig, outputDir)
-		defer func() { _ = loggerWrapper.Close() }()
-		contextLogger = logger.WithContext(ctx)
+	// Use the --output-dir directory, or create a new one
+	outputManager := NewOutputManager(contextLogger)
+	var outputDir string
+	var err error
+	if minimalConfig.OutputDir != "" {
+		outputDir, err = outputManager.UseOutputDirectory(minimalConfig.OutputDir, dirPermissions(minimalConfig))
+	} else {
+		outputDir, err = createOutputDirWithFallback(ctx, outputManager, contextLogger, "", dirPermissions(minimalConfig), minimalConfig.StrictOutputDir)
+	}
+	if err != nil {
+		contextLogger.ErrorContext(ctx, "Failed to create output directory: %v", err)
+		return fmt.Errorf("failed to create output directory: %w", err)
 	}
+	minimalConfig.OutputDir = outputDir
+
+	// Now that we have output directory, recreate logger with proper file routing
+	// Close the previous logger wrapper first
+	_ = loggerWrapper.Close()
+	logger, loggerWrapper = createLoggerWithRouting(minimalConfig, outputDir)
+	defer func() { _ = loggerWrapper.Close() }()
+	contextLogger = logger.WithContext(ctx)
 
 	// Re-run model selection with audit logging now that we have context and audit logger
-	err := auditModelSelection(ctx, minimalConfig, contextLogger, simplifiedConfig, tokenService)
+	err = auditModelSelection(ctx, minimalConfig, contextLogger, simplifiedConfig, tokenService)
 	if err != nil {
 		contextLogger.WarnContext(ctx, "Model selection audit logging failed: %v", err)
 		// Continue with execution even if audit logging fails
@@ -149,15 +219,31 @@ func executeApplication(minimalConfig *config.MinimalConfig, simplifiedConfig *S
 // setupConfiguration builds the MinimalConfig from simplified CLI configuration
 // This is a pure function that handles configuration setup logic without I/O operations
 func setupConfiguration(simplifiedConfig *SimplifiedConfig, tokenService thinktank.TokenCountingService) (*config.MinimalConfig, error) {
-	// Determine model selection strategy using accurate tokenization
-	modelNames, synthesisModel := selectModelsForConfigWithService(simplifiedConfig, tokenService)
+	// Use the models named with --models, or else select them using accurate tokenization
+	var modelNames []string
+	var synthesisModel string
+	explicitModels := simplifiedConfig.GetOptions().Models
+	if len(explicitModels) > 0 {
+		modelNames = append(modelNames, explicitModels...)
+	} else {
+		modelNames, synthesisModel = selectModelsForConfigWithService(simplifiedConfig, tokenService)
+	}
+
+	// Running the same model twice would collide on output filenames
+	modelNames, duplicates := dedupeModelNames(modelNames)
+
+	// Explicit models are synthesized on the same terms as selected ones
+	if len(explicitModels) > 0 && (len(modelNames) > 1 || synthesisRequested(simplifiedConfig)) && !simplifiedConfig.GetOptions().NoSynthesis {
+		synthesisModel = chooseSynthesisModel(simplifiedConfig, models.GetAvailableProviders())
+	}
 
 	// Convert to MinimalConfig
 	minimalConfig := &config.MinimalConfig{
 		InstructionsFile:  simplifiedConfig.InstructionsFile,
 		TargetPaths:       strings.Fields(simplifiedConfig.TargetPath), // Split space-joined paths
 		ModelNames:        modelNames,
-		OutputDir:         "", // Will be set by output manager
+		DuplicateModels:   duplicates,
+		OutputDir:         simplifiedConfig.GetOptions().OutputDir, // Empty = created by output manager
 		DryRun:            simplifiedConfig.HasFlag(FlagDryRun),
 		Verbose:           simplifiedConfig.HasFlag(FlagVerbose),
 		SynthesisModel:    synthesisModel, // Set by intelligent selection
@@ -172,6 +258,92 @@ func setupConfiguration(simplifiedConfig *SimplifiedConfig, tokenService thinkta
 		TokenSafetyMargin: simplifiedConfig.SafetyMargin,
 	}
 
+	// Apply advanced options
+	options := simplifiedConfig.GetOptions()
+	minimalConfig.NormalizeLineEndings = options.NormalizeLineEndings
+	minimalConfig.IncludeGlobs = options.IncludeGlobs
+	minimalConfig.PriorityGlobs = options.PriorityGlobs
+	minimalConfig.GatherWorkers = options.GatherWorkers
+	minimalConfig.MaxFileSize = options.MaxFileSize
+	minimalConfig.FollowSymlinks = options.FollowSymlinks
+	minimalConfig.ExcludeGenerated = options.ExcludeGenerated
+	minimalConfig.IncludeHidden = options.IncludeHidden
+	minimalConfig.ExplainExcludes = options.ExplainExcludes
+	minimalConfig.MaxContextTokens = options.MaxContextTokens
+	minimalConfig.AutoTrim = options.AutoTrim
+	minimalConfig.OutputFormat = options.OutputFormat
+	minimalConfig.ProgressFormat = options.ProgressFormat
+	minimalConfig.ProgressFD = options.ProgressFD
+	minimalConfig.ColorMode = options.ColorMode
+	minimalConfig.Theme = options.Theme
+	minimalConfig.EmbedInstructions = options.EmbedInstructions
+	minimalConfig.CheckpointInterval = options.CheckpointInterval
+	minimalConfig.MaxOutputFileSize = options.MaxOutputFileSize
+	minimalConfig.RateLimitWaitBudget = options.RateLimitWaitBudget
+	minimalConfig.StrictOutputDir = options.StrictOutputDir
+	minimalConfig.NoOverwrite = options.OutputDir != "" && !options.Force
+	minimalConfig.DirPermissions = options.DirPerms
+	minimalConfig.FilePermissions = options.FilePerms
+	minimalConfig.OutputNameTemplate = options.OutputNameTemplate
+	minimalConfig.CombinedOutput = options.CombinedOutput
+	minimalConfig.PostProcess = options.PostProcess
+	minimalConfig.SummaryFile = options.SummaryFile
+	minimalConfig.CacheDir = options.CacheDir
+	minimalConfig.NoCache = options.NoCache
+	minimalConfig.PartialSuccessOk = options.PartialSuccessOk
+	minimalConfig.FailFast = options.FailFast
+	minimalConfig.PromptOrder = options.PromptOrder
+	minimalConfig.FenceCode = options.FenceCode
+	minimalConfig.FileSeparator = options.FileSeparator
+	if options.Silent {
+		// Silent overrides quiet: everything quiet hides stays hidden, and more
+		minimalConfig.Silent = true
+		minimalConfig.Quiet = true
+	}
+	minimalConfig.Preflight = options.Preflight
+	minimalConfig.OTel = options.OTel
+
+	// Retries are on by default; --max-retries 0 turns them off
+	minimalConfig.MaxRetries = config.DefaultMaxRetries
+	if options.MaxRetries != nil {
+		minimalConfig.MaxRetries = *options.MaxRetries
+	}
+	minimalConfig.RetryBudget = options.RetryBudget
+	minimalConfig.Repeat = options.Repeat
+	if options.Concurrency != nil {
+		// --concurrency 0 lifts the limit
+		minimalConfig.MaxConcurrentRequests = *options.Concurrency
+		if *options.Concurrency == 0 {
+			minimalConfig.MaxConcurrentRequests = config.UnlimitedConcurrentRequests
+		}
+	}
+	minimalConfig.RetryBaseDelay = config.DefaultRetryBaseDelay
+	if options.RetryBaseDelay > 0 {
+		minimalConfig.RetryBaseDelay = options.RetryBaseDelay
+	}
+
+	// Audit entries keep a short preview of prompts and responses unless told otherwise
+	minimalConfig.AuditVerbose = options.AuditVerbose
+	minimalConfig.AuditPreviewLength = config.DefaultAuditPreviewLength
+	if options.AuditPreviewLength != nil {
+		minimalConfig.AuditPreviewLength = *options.AuditPreviewLength
+	}
+
+	if options.Timeout > 0 {
+		minimalConfig.Timeout = options.Timeout
+	}
+	minimalConfig.ModelTimeout = options.ModelTimeout
+	minimalConfig.TemplateVars = options.TemplateVars
+	minimalConfig.ProviderBaseURLs = options.ProviderBaseURLs
+	minimalConfig.InstructionsInline = options.InstructionsInline
+	minimalConfig.ModelParams = options.ModelParams
+
+	// Context gathering gets its own budget, never more than the whole run
+	minimalConfig.GatherTimeout = minimalConfig.Timeout
+	if options.GatherTimeout > 0 && options.GatherTimeout < minimalConfig.Timeout {
+		minimalConfig.GatherTimeout = options.GatherTimeout
+	}
+
 	// Apply environment variables
 	if err := applyEnvironmentVars(minimalConfig); err != nil {
 		return nil, fmt.Errorf("environment variable application failed: %w", err)
@@ -185,6 +357,151 @@ func setupConfiguration(simplifiedConfig *SimplifiedConfig, tokenService thinkta
 	return minimalConfig, nil
 }
 
+// createOutputDirWithFallback creates the output directory under basePath (empty = working directory).
+// If that fails and strict is false, it falls back to the system temp directory with a warning.
+func createOutputDirWithFallback(ctx context.Context, outputManager *OutputManager, logger logutil.LoggerInterface, basePath string, permissions os.FileMode, strict bool) (string, error) {
+	outputDir, err := outputManager.CreateOutputDirectory(basePath, permissions)
+	if err == nil || strict {
+		return outputDir, err
+	}
+
+	// A read-only working directory shouldn't stop the run when temp is writable
+	logger.WarnContext(ctx, "Failed to create output directory: %v", err)
+	outputDir, tempErr := outputManager.CreateOutputDirectory(os.TempDir(), permissions)
+	if tempErr != nil {
+		return "", fmt.Errorf("%w (temp directory fallback also failed: %v)", err, tempErr)
+	}
+	logger.WarnContext(ctx, "Writing outputs to temporary directory instead: %s", outputDir)
+	fmt.Fprintf(os.Stderr, "Warning: could not create output directory; outputs will be saved to %s\n", outputDir)
+	return outputDir, nil
+}
+
+// dirPermissions returns the --dir-perms mode, or 0755 when unset
+func dirPermissions(cfg *config.MinimalConfig) os.FileMode {
+	if cfg.DirPermissions != 0 {
+		return cfg.DirPermissions
+	}
+	return 0755
+}
+
+// filePermissions returns the --file-perms mode, or 0644 when unset
+func filePermissions(cfg *config.MinimalConfig) os.FileMode {
+	if cfg.FilePermissions != 0 {
+		return cfg.FilePermissions
+	}
+	return 0644
+}
+
+// applyProjectConfig merges project-local defaults into cfg.
+// Values only fill in what CLI flags left at their defaults; excludes extend the built-in lists.
+func applyProjectConfig(cfg *config.MinimalConfig, project *config.ProjectConfig, simplifiedConfig *SimplifiedConfig) {
+	if project == nil {
+		return
+	}
+
+	applyConfiguredModels(cfg, project.Models, project.Synthesis, simplifiedConfig)
+
+	if project.Exclude != "" {
+		cfg.Exclude = cfg.Exclude + "," + project.Exclude
+	}
+	if project.ExcludeNames != "" {
+		cfg.ExcludeNames = cfg.ExcludeNames + "," + project.ExcludeNames
+	}
+
+	if project.Concurrency > 0 && cfg.MaxConcurrentRequests == 0 {
+		cfg.MaxConcurrentRequests = project.Concurrency
+	}
+
+	if project.CacheDir != "" && cfg.CacheDir == "" {
+		cfg.CacheDir = project.CacheDir
+	}
+}
+
+// applyProfile applies the profile selected with --profile to the settings that
+// flags and .thinktank.json did not set; those take precedence over the profile.
+func applyProfile(cfg *config.MinimalConfig, project *config.ProjectConfig, simplifiedConfig *SimplifiedConfig, getenv func(string) string) error {
+	name := simplifiedConfig.GetOptions().Profile
+	if name == "" {
+		return nil
+	}
+
+	path, err := config.ProfilesPath(getenv)
+	if err != nil {
+		return err
+	}
+	profile, err := config.LoadProfile(path, name)
+	if err != nil {
+		return err
+	}
+
+	profileModels := profile.Models
+	if project != nil && len(project.Models) > 0 {
+		profileModels = nil
+	}
+	synthesis := profile.Synthesis != nil && *profile.Synthesis
+	applyConfiguredModels(cfg, profileModels, synthesis, simplifiedConfig)
+
+	// "synthesis": false turns synthesis off unless a flag or .thinktank.json asks for it
+	if profile.Synthesis != nil && !*profile.Synthesis && !synthesisRequested(simplifiedConfig) && (project == nil || !project.Synthesis) {
+		cfg.SynthesisModel = ""
+	}
+
+	profile.MergeInto(cfg, explicitSettings(simplifiedConfig, project))
+	return nil
+}
+
+// explicitSettings returns the profile settings, by their profiles.json name, that
+// a flag or .thinktank.json set. Value flags leave their option at its zero value
+// unless given, so this holds even when a flag is given its default value.
+func explicitSettings(simplifiedConfig *SimplifiedConfig, project *config.ProjectConfig) map[string]bool {
+	options := simplifiedConfig.GetOptions()
+	explicit := map[string]bool{
+		"quiet":              simplifiedConfig.HasFlag(FlagQuiet) || options.Silent,
+		"verbose":            simplifiedConfig.HasFlag(FlagVerbose),
+		"no_progress":        simplifiedConfig.HasFlag(FlagNoProgress),
+		"json_logs":          simplifiedConfig.HasFlag(FlagJsonLogs),
+		"partial_success_ok": options.PartialSuccessOk,
+		"output_format":      options.OutputFormat != "",
+		"color":              options.ColorMode != "",
+		"theme":              options.Theme != "",
+		"timeout":            options.Timeout > 0,
+		"model_timeout":      options.ModelTimeout > 0,
+		"gather_timeout":     options.GatherTimeout > 0,
+		"max_retries":        options.MaxRetries != nil,
+		"concurrency":        options.Concurrency != nil,
+		"cache_dir":          options.CacheDir != "",
+	}
+	if project != nil {
+		explicit["concurrency"] = explicit["concurrency"] || project.Concurrency > 0
+		explicit["cache_dir"] = explicit["cache_dir"] || project.CacheDir != ""
+	}
+	return explicit
+}
+
+// applyConfiguredModels replaces the selected models with those from a config file,
+// choosing a synthesis model when there are several or synthesis is requested.
+// Models named with --models are never replaced.
+func applyConfiguredModels(cfg *config.MinimalConfig, modelNames []string, synthesis bool, simplifiedConfig *SimplifiedConfig) {
+	if len(modelNames) > 0 && len(simplifiedConfig.GetOptions().Models) == 0 {
+		modelNames, duplicates := dedupeModelNames(modelNames)
+		cfg.ModelNames = modelNames
+		cfg.DuplicateModels = duplicates
+		cfg.SynthesisModel = ""
+		if len(modelNames) > 1 || synthesisRequested(simplifiedConfig) {
+			cfg.SynthesisModel = chooseSynthesisModel(simplifiedConfig, models.GetAvailableProviders())
+		}
+	}
+
+	if synthesis && cfg.SynthesisModel == "" {
+		cfg.SynthesisModel = chooseSynthesisModel(simplifiedConfig, models.GetAvailableProviders())
+	}
+
+	// --no-synthesis wins over config files and profiles
+	if simplifiedConfig.GetOptions().NoSynthesis {
+		cfg.SynthesisModel = ""
+	}
+}
+
 // applyEnvironmentVars applies environment variables to MinimalConfig
 // Only handles essential environment variables - API keys are handled elsewhere during validation
 func applyEnvironmentVars(cfg *config.MinimalConfig) error {
@@ -194,9 +511,18 @@ func applyEnvironmentVars(cfg *config.MinimalConfig) error {
 	return nil
 }
 
-// setupGracefulShutdown sets up signal handling for graceful shutdown
+// errInterrupted is the cancellation cause of a run stopped by SIGINT or SIGTERM
+var errInterrupted = errors.New("interrupted by signal")
+
+// isInterrupted reports whether ctx was cancelled by setupGracefulShutdown's signal handler
+func isInterrupted(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), errInterrupted)
+}
+
+// setupGracefulShutdown sets up signal handling for graceful shutdown. On a signal
+// the returned context is cancelled with errInterrupted as its cause.
 func setupGracefulShutdown(ctx context.Context, logger logutil.LoggerInterface) context.Context {
-	ctx, cancel := context.WithCancel(ctx)
+	ctx, cancel := context.WithCancelCause(ctx)
 
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
@@ -206,7 +532,7 @@ func setupGracefulShutdown(ctx context.Context, logger logutil.LoggerInterface)
 		case sig := <-sigChan:
 			logger.InfoContext(ctx, "Received signal %v, initiating graceful shutdown", sig)
 			fmt.Fprintln(os.Stderr, "\nReceived interrupt signal. Shutting down gracefully...")
-			cancel()
+			cancel(fmt.Errorf("%w: %v", errInterrupted, sig))
 		case <-ctx.Done():
 			// Context cancelled by other means
 		}
@@ -215,6 +541,63 @@ func setupGracefulShutdown(ctx context.Context, logger logutil.LoggerInterface)
 	return ctx
 }
 
+// newConsoleWriter builds the console writer for a run from the output flags.
+// --output-format json replaces the console output with a JSON summary on stdout;
+// --progress=json additionally writes model progress events to stderr or --progress-fd.
+func newConsoleWriter(cfg *config.MinimalConfig) (logutil.ConsoleWriter, error) {
+	consoleWriter := logutil.NewConsoleWriterWithOptions(consoleWriterOptions(cfg))
+	if cfg.OutputFormat == config.OutputFormatJSON {
+		// Machine-readable mode: stdout carries only the summary document
+		consoleWriter = logutil.NewJSONConsoleWriter(os.Stdout, os.Stderr)
+	}
+	consoleWriter.SetQuiet(cfg.Quiet)
+	consoleWriter.SetNoProgress(cfg.NoProgress)
+	consoleWriter.SetSilent(cfg.Silent)
+
+	if cfg.ProgressFormat != config.OutputFormatJSON {
+		return consoleWriter, nil
+	}
+	progressOut := io.Writer(os.Stderr)
+	if cfg.ProgressFD > 0 {
+		progressFile := os.NewFile(uintptr(cfg.ProgressFD), "progress")
+		if progressFile == nil {
+			return nil, fmt.Errorf("invalid --progress-fd %d", cfg.ProgressFD)
+		}
+		if _, err := progressFile.Stat(); err != nil {
+			return nil, fmt.Errorf("--progress-fd %d is not open: %w", cfg.ProgressFD, err)
+		}
+		progressOut = progressFile
+	}
+	return logutil.NewProgressEventWriter(consoleWriter, progressOut), nil
+}
+
+// consoleWriterOptions returns the console color settings chosen by --color and --theme
+func consoleWriterOptions(cfg *config.MinimalConfig) logutil.ConsoleWriterOptions {
+	return logutil.ConsoleWriterOptions{ColorMode: cfg.ColorMode, Theme: cfg.Theme}
+}
+
+// runPreflight checks every provider used by the selected and synthesis models.
+// API services that can't run a preflight check are used as is.
+func runPreflight(ctx context.Context, apiService interfaces.APIService, cfg *config.MinimalConfig, logger logutil.LoggerInterface) error {
+	preflighter, ok := apiService.(interfaces.ProviderPreflighter)
+	if !ok {
+		logger.WarnContext(ctx, "--preflight is not supported by this API service; skipping")
+		return nil
+	}
+
+	modelNames := append([]string{}, cfg.ModelNames...)
+	if cfg.SynthesisModel != "" {
+		modelNames = append(modelNames, cfg.SynthesisModel)
+	}
+
+	logger.InfoContext(ctx, "Running provider preflight checks for %d model(s)", len(modelNames))
+	if err := preflighter.Preflight(ctx, modelNames); err != nil {
+		return err
+	}
+	logger.InfoContext(ctx, "Provider preflight checks passed")
+	return nil
+}
+
 // runApplication executes the core application logic with MinimalConfig
 func runApplication(ctx context.Context, cfg *config.MinimalConfig, logger logutil.LoggerInterface, tokenService thinktank.TokenCountingService, metricsOutputPath string) error {
 	// Create audit logger
@@ -225,11 +608,19 @@ func runApplication(ctx context.Context, cfg *config.MinimalConfig, logger logut
 	} else {
 		// Use file audit logger writing to a log file
 		auditLogPath := filepath.Join(cfg.OutputDir, "audit.jsonl")
-		var err error
-		auditLogger, err = auditlog.NewFileAuditLogger(auditLogPath, logger)
+		fileAuditLogger, err := auditlog.NewFileAuditLogger(auditLogPath, logger)
 		if err != nil {
 			return fmt.Errorf("failed to create audit logger: %w", err)
 		}
+		fileAuditLogger.SetRedactor(auditlog.DefaultSecretRedactor)
+
+		// Write entries in the background so model processing never waits on disk I/O
+		auditLogger = auditlog.NewAsyncAuditLogger(fileAuditLogger, auditBufferSize)
+		stopSync := syncAuditLogOnInterrupt(ctx, fileAuditLogger, logger)
+		defer func() {
+			stopSync()
+			closeAuditLogger(ctx, auditLogger, logger)
+		}()
 	}
 
 	// Create metrics collector
@@ -255,11 +646,18 @@ func runApplication(ctx context.Context, cfg *config.MinimalConfig, logger logut
 	logger.InfoContext(ctx, "Starting thinktank - AI-assisted content generation tool")
 
 	// Read instructions
-	instructionsContent, err := os.ReadFile(cfg.InstructionsFile)
+	instructions, err := readInstructions(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to read instructions file: %w", err)
+		return err
+	}
+
+	// With --template-vars the instructions are a text/template; otherwise they are used verbatim
+	if len(cfg.TemplateVars) > 0 {
+		instructions, err = renderInstructionsTemplate(instructionsSource(cfg), instructions, cfg.TemplateVars)
+		if err != nil {
+			return err
+		}
 	}
-	instructions := string(instructionsContent)
 
 	// In dry run mode, just show what would be processed
 	if cfg.DryRun {
@@ -267,10 +665,24 @@ func runApplication(ctx context.Context, cfg *config.MinimalConfig, logger logut
 	}
 
 	// Create necessary services
-	consoleWriter := logutil.NewConsoleWriter()
+	consoleWriter, err := newConsoleWriter(cfg)
+	if err != nil {
+		return err
+	}
+	warnDuplicateModels(consoleWriter, cfg)
 
 	// Create registry API service that works with multiple providers
-	apiService := thinktank.NewRegistryAPIService(logger)
+	apiService := thinktank.NewRegistryAPIServiceWithOptions(logger, thinktank.RegistryAPIServiceOptions{
+		BaseURLs:    cfg.ProviderBaseURLs,
+		ModelParams: cfg.ModelParams,
+	})
+
+	// With --preflight, fail before gathering context if a provider can't be used
+	if cfg.Preflight {
+		if err := runPreflight(ctx, apiService, cfg, logger); err != nil {
+			return err
+		}
+	}
 
 	// Create a dummy LLM client for context gatherer (it's only needed for dry run)
 	// In non-dry-run mode, the orchestrator will handle the actual client creation
@@ -283,7 +695,12 @@ func runApplication(ctx context.Context, cfg *config.MinimalConfig, logger logut
 	contextGatherer := thinktank.NewContextGatherer(logger, consoleWriter, cfg.DryRun, dummyClient, auditLogger)
 
 	// Create file writer
-	fileWriter := thinktank.NewFileWriter(logger, auditLogger, 0755, 0644)
+	fileWriter := thinktank.NewFileWriterWithOptions(logger, auditLogger, thinktank.FileWriterOptions{
+		DirPermissions:  dirPermissions(cfg),
+		FilePermissions: filePermissions(cfg),
+		MaxFileSize:     cfg.MaxOutputFileSize,
+		NoOverwrite:     cfg.NoOverwrite,
+	})
 
 	// Create rate limiter with smart defaults based on provider
 	rateLimiter := createRateLimiter(cfg)
@@ -292,6 +709,20 @@ func runApplication(ctx context.Context, cfg *config.MinimalConfig, logger logut
 	// This is temporary until we update orchestrator to use ConfigInterface
 	adapterConfig := createAdapterConfig(cfg)
 
+	// With --otel, trace the run and count model outcomes; the orchestrator's
+	// instruments come from the providers registered here
+	if cfg.OTel {
+		shutdownTelemetry, err := setupTelemetry(ctx)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if shutdownErr := shutdownTelemetry(context.WithoutCancel(ctx)); shutdownErr != nil {
+				logger.WarnContext(ctx, "Failed to flush telemetry: %v", shutdownErr)
+			}
+		}()
+	}
+
 	// Create orchestrator with adapters for type compatibility
 	orch := orchestrator.NewOrchestrator(orchestrator.OrchestratorDeps{
 		APIService:           apiService,
@@ -319,10 +750,88 @@ func runApplication(ctx context.Context, cfg *config.MinimalConfig, logger logut
 	return runErr
 }
 
+// setupTelemetry registers OpenTelemetry tracer and meter providers configured by
+// the OTEL_* environment; console exporters write to stderr. The returned function
+// flushes both.
+func setupTelemetry(ctx context.Context) (func(context.Context) error, error) {
+	shutdownTracing, err := telemetry.SetupTracing(ctx, os.Getenv, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	shutdownMetrics, err := telemetry.SetupMetrics(ctx, os.Getenv, os.Stderr)
+	if err != nil {
+		_ = shutdownTracing(ctx)
+		return nil, err
+	}
+	return func(ctx context.Context) error {
+		return errors.Join(shutdownTracing(ctx), shutdownMetrics(ctx))
+	}, nil
+}
+
+// syncAuditLogOnInterrupt forces the audit log to disk as soon as a signal
+// interrupts the run, so entries written so far survive even if the process is
+// killed before it finishes shutting down. The returned function stops watching.
+func syncAuditLogOnInterrupt(ctx context.Context, fileAuditLogger *auditlog.FileAuditLogger, logger logutil.LoggerInterface) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		select {
+		case <-ctx.Done():
+			if isInterrupted(ctx) {
+				if err := fileAuditLogger.Sync(); err != nil {
+					logger.WarnContext(ctx, "Failed to sync audit log after interrupt: %v", err)
+				}
+			}
+		case <-stop:
+		}
+	}()
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
+// closeAuditLogger flushes and closes the audit log at the end of a run. A run
+// stopped by a signal first gets a final "interrupted" entry.
+func closeAuditLogger(ctx context.Context, auditLogger auditlog.AuditLogger, logger logutil.LoggerInterface) {
+	if isInterrupted(ctx) {
+		if err := auditLogger.LogOp(context.WithoutCancel(ctx), "RunInterrupted", "Interrupted", nil, nil, context.Cause(ctx)); err != nil {
+			logger.WarnContext(ctx, "Failed to record interrupt in audit log: %v", err)
+		}
+	}
+	if closeErr := auditLogger.Close(); closeErr != nil {
+		logger.ErrorContext(ctx, "Failed to close audit logger: %v", closeErr)
+	}
+}
+
+// readInstructions returns the --instructions-inline text, or else the contents of the instructions file
+func readInstructions(cfg *config.MinimalConfig) (string, error) {
+	if cfg.InstructionsInline != "" {
+		return cfg.InstructionsInline, nil
+	}
+	content, err := os.ReadFile(cfg.InstructionsFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read instructions file: %w", err)
+	}
+	return string(content), nil
+}
+
+// instructionsSource names where the instructions came from, for messages
+func instructionsSource(cfg *config.MinimalConfig) string {
+	if cfg.InstructionsInline != "" {
+		return "--instructions-inline"
+	}
+	return cfg.InstructionsFile
+}
+
 // validateConfig validates the minimal configuration
 func validateConfig(cfg *config.MinimalConfig) error {
-	if cfg.InstructionsFile == "" {
-		return fmt.Errorf("instructions file is required")
+	if cfg.InstructionsFile == "" && cfg.InstructionsInline == "" {
+		return fmt.Errorf("instructions are required: pass an instructions file or --instructions-inline")
+	}
+	if cfg.InstructionsFile != "" && cfg.InstructionsInline != "" {
+		return fmt.Errorf("--instructions-inline conflicts with the instructions file %s", cfg.InstructionsFile)
 	}
 
 	if len(cfg.TargetPaths) == 0 {
@@ -330,8 +839,10 @@ func validateConfig(cfg *config.MinimalConfig) error {
 	}
 
 	// Check if instructions file exists
-	if _, err := os.Stat(cfg.InstructionsFile); err != nil {
-		return fmt.Errorf("instructions file not found: %w", err)
+	if cfg.InstructionsInline == "" {
+		if _, err := os.Stat(cfg.InstructionsFile); err != nil {
+			return fmt.Errorf("instructions file not found: %w", err)
+		}
 	}
 
 	// Check if target paths exist
@@ -344,12 +855,20 @@ func validateConfig(cfg *config.MinimalConfig) error {
 	// Validate API keys based on models
 	for _, model := range cfg.ModelNames {
 		provider := getProviderForModel(model)
-		apiKey := getAPIKeyForProvider(provider)
+		apiKey := getAPIKeyForModel(model, provider)
 		if apiKey == "" && !cfg.DryRun {
 			return fmt.Errorf("%s API key not set for model %s", provider, model)
 		}
 	}
 
+	// Synthesis runs last, so catch a missing key now rather than after every model has run
+	if cfg.SynthesisModel != "" && !cfg.DryRun {
+		provider := getProviderForModel(cfg.SynthesisModel)
+		if getAPIKeyForModel(cfg.SynthesisModel, provider) == "" {
+			return fmt.Errorf("%s API key not set for synthesis model %s (choose another with --synthesis-model)", provider, cfg.SynthesisModel)
+		}
+	}
+
 	return nil
 }
 
@@ -378,38 +897,63 @@ func getAPIKeyForProvider(provider string) string {
 	}
 }
 
-// createRateLimiter creates a rate limiter with smart defaults based on provider
-func createRateLimiter(cfg *config.MinimalConfig) *ratelimit.RateLimiter {
-	// Determine rate limits based on primary model provider
-	if len(cfg.ModelNames) == 0 {
-		return ratelimit.NewRateLimiter(5, 60) // Default
+// getAPIKeyForModel returns the API key for a model served by provider; custom
+// provider models read the variable they were registered with
+func getAPIKeyForModel(model, provider string) string {
+	if provider == models.CustomProvider {
+		return os.Getenv(models.GetAPIKeyEnvVarForModel(model))
 	}
+	return getAPIKeyForProvider(provider)
+}
 
-	primaryModel := cfg.ModelNames[0]
-	modelInfo, err := models.GetModelInfo(primaryModel)
-	if err != nil {
-		// Use conservative defaults
-		return ratelimit.NewRateLimiter(5, 60)
+// createRateLimiter creates a rate limiter with smart defaults based on provider.
+// Each provider in the run gets its own token bucket so that mixing providers
+// doesn't let a slow provider's limit throttle a fast one.
+func createRateLimiter(cfg *config.MinimalConfig) *ratelimit.RateLimiter {
+	maxConcurrent := maxConcurrentRequests(cfg)
+
+	// Models without a provider limit fall back to the primary model's provider default
+	defaultRPM := 60
+	if len(cfg.ModelNames) > 0 {
+		if provider, err := models.GetProviderForModel(cfg.ModelNames[0]); err == nil {
+			defaultRPM = models.GetProviderRateLimit(provider)
+		}
 	}
+	rateLimiter := ratelimit.NewRateLimiter(maxConcurrent, defaultRPM)
 
-	// Use provider-specific defaults
-	rpm := 60 // Default
-	switch modelInfo.Provider {
-	case "openrouter":
-		rpm = 20
-	case "test":
-		rpm = 1000 // Test provider has high limits for testing
+	for _, modelName := range cfg.ModelNames {
+		if provider, err := models.GetProviderForModel(modelName); err == nil {
+			rateLimiter.SetProviderLimit(provider, models.GetProviderRateLimit(provider))
+		}
 	}
+	return rateLimiter
+}
 
-	return ratelimit.NewRateLimiter(5, rpm)
+// maxConcurrentRequests returns the configured concurrency limit or the default.
+// Zero means no limit, as it does for ratelimit.NewSemaphore.
+func maxConcurrentRequests(cfg *config.MinimalConfig) int {
+	switch {
+	case cfg.MaxConcurrentRequests == config.UnlimitedConcurrentRequests:
+		return 0
+	case cfg.MaxConcurrentRequests > 0:
+		return cfg.MaxConcurrentRequests
+	}
+	return config.DefaultMaxConcurrentRequests
 }
 
-// runDryRun executes a dry run showing what would be processed
+// runDryRun executes a dry run showing what would be processed.
+// With --output-format json it writes a dryRunReport to stdout instead of text.
 func runDryRun(ctx context.Context, cfg *config.MinimalConfig, instructions string, logger logutil.LoggerInterface) error {
+	jsonOutput := cfg.OutputFormat == config.OutputFormatJSON
+
 	// Respect quiet flag
-	if !cfg.IsQuiet() {
+	if !cfg.IsQuiet() && !jsonOutput {
 		fmt.Println("=== DRY RUN MODE ===")
-		fmt.Printf("Instructions file: %s\n", cfg.InstructionsFile)
+		if cfg.InstructionsInline != "" {
+			fmt.Printf("Instructions: inline (%d characters)\n", len(instructions))
+		} else {
+			fmt.Printf("Instructions file: %s\n", cfg.InstructionsFile)
+		}
 		fmt.Printf("Target paths: %v\n", cfg.TargetPaths)
 		fmt.Printf("Models: %v\n", cfg.ModelNames)
 		fmt.Printf("Output directory: %s\n", pathutil.SanitizePathForDisplay(cfg.OutputDir))
@@ -434,12 +978,17 @@ func runDryRun(ctx context.Context, cfg *config.MinimalConfig, instructions stri
 		}
 	}
 
-	if !cfg.IsQuiet() && cfg.SynthesisModel != "" {
+	if !cfg.IsQuiet() && !jsonOutput && cfg.SynthesisModel != "" {
 		fmt.Printf("Synthesis model: %s\n", cfg.SynthesisModel)
 	}
 
 	// Create console writer and dummy client for dry run
-	consoleWriter := logutil.NewConsoleWriter()
+	consoleWriter := logutil.NewConsoleWriterWithOptions(consoleWriterOptions(cfg))
+	if jsonOutput {
+		// Keep stdout for the report alone
+		consoleWriter = logutil.NewJSONConsoleWriter(os.Stdout, os.Stderr)
+	}
+	warnDuplicateModels(consoleWriter, cfg)
 	dummyClient := &llm.MockLLMClient{}
 	noOpAuditLogger := auditlog.NewNoOpAuditLogger()
 
@@ -458,17 +1007,38 @@ func runDryRun(ctx context.Context, cfg *config.MinimalConfig, instructions stri
 	// Gather context
 	// Create gather config
 	gatherConfig := interfaces.GatherConfig{
-		Paths:        cfg.TargetPaths,
-		Format:       appConfig.Format,
-		Exclude:      appConfig.Excludes.Extensions,
-		ExcludeNames: appConfig.Excludes.Names,
+		Paths:                cfg.TargetPaths,
+		Format:               appConfig.Format,
+		Exclude:              appConfig.Excludes.Extensions,
+		ExcludeNames:         appConfig.Excludes.Names,
+		NormalizeLineEndings: cfg.NormalizeLineEndings,
+		IncludeGlobs:         cfg.IncludeGlobs,
+		PriorityGlobs:        cfg.PriorityGlobs,
+		Workers:              cfg.GatherWorkers,
+		MaxFileSizeBytes:     cfg.MaxFileSize,
+		FollowSymlinks:       cfg.FollowSymlinks,
+		IncludeHidden:        cfg.IncludeHidden,
+		ExplainExcludes:      cfg.ExplainExcludes,
+		MaxContextTokens:     cfg.MaxContextTokens,
+		Instructions:         instructions,
+		Prompt:               promptBuilder(cfg),
+		Timeout:              cfg.GatherTimeout,
+	}
+	if cfg.ExcludeGenerated {
+		gatherConfig.ExcludeContentPatterns = fileutil.GeneratedFilePatterns
+	}
+	if len(cfg.ModelNames) > 0 {
+		gatherConfig.TokenModel = cfg.ModelNames[0]
 	}
 
 	files, stats, err := contextGatherer.GatherContext(ctx, gatherConfig)
 	if err != nil {
 		return fmt.Errorf("failed to gather context: %w", err)
 	}
-	_ = files // Files list is available if needed
+
+	if jsonOutput {
+		return writeDryRunJSON(os.Stdout, newDryRunReport(cfg, stats))
+	}
 
 	if !cfg.IsQuiet() {
 		fmt.Printf("\nFiles that would be processed: %d\n", stats.ProcessedFilesCount)
@@ -489,37 +1059,183 @@ func runDryRun(ctx context.Context, cfg *config.MinimalConfig, instructions stri
 				fmt.Printf("  ... and %d more files\n", len(stats.ProcessedFiles)-count)
 			}
 		}
+		printContentExcluded(os.Stdout, stats.ContentExcludedFiles)
+		printTokenBudgetDropped(os.Stdout, stats.TokenBudgetDropped, cfg.MaxContextTokens)
+		printSkippedPaths(os.Stdout, stats.SkippedPaths)
+
+		// Token counts follow each model's tokenizer; cost uses the primary model's estimate
+		promptText := promptBuilder(cfg).Build(instructions, files)
+		printTokenUsage(os.Stdout, cfg.ModelNames, promptText)
+
+		inputTokens := models.EstimateTokensFromStats(stats.CharCount, instructions)
+		instructionTokens := models.EstimateTokensFromText(instructions)
+		if len(cfg.ModelNames) > 0 {
+			if tokens, err := models.CountTokensEstimate(cfg.ModelNames[0], promptText); err == nil {
+				inputTokens = tokens
+			}
+		}
+		if tokens, err := models.CountTokensEstimate(cfg.SynthesisModel, instructions); err == nil {
+			instructionTokens = tokens
+		}
+		printCostEstimate(os.Stdout, cfg, inputTokens, instructionTokens)
 	}
 
 	return nil
 }
 
+// printContentExcluded lists files skipped because their content matched an exclude pattern
+func printContentExcluded(w io.Writer, files []fileutil.ContentExcludedFile) {
+	if len(files) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\nExcluded by content: %d\n", len(files))
+	for _, file := range files {
+		_, _ = fmt.Fprintf(w, "  - %s (matches %s)\n", file.Path, file.Pattern)
+	}
+}
+
+// printTokenBudgetDropped lists files dropped once the --max-context-tokens budget was reached
+func printTokenBudgetDropped(w io.Writer, paths []string, budget int) {
+	if len(paths) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\nDropped by the %d token budget: %d\n", budget, len(paths))
+	for _, path := range paths {
+		_, _ = fmt.Fprintf(w, "  - %s\n", path)
+	}
+}
+
+// printSkippedPaths lists every path a filter left out with the reason, for --explain-excludes
+func printSkippedPaths(w io.Writer, paths []fileutil.SkippedPath) {
+	if len(paths) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\nExcluded paths: %d\n", len(paths))
+	for _, path := range paths {
+		_, _ = fmt.Fprintf(w, "  - %s: %s\n", path.DisplayPath(), path.Reason)
+	}
+}
+
+// printTokenUsage writes each model's estimated prompt tokens against its context window,
+// flagging models the prompt would overflow
+func printTokenUsage(w io.Writer, modelNames []string, promptText string) {
+	_, _ = fmt.Fprintln(w, "\nEstimated prompt tokens by model:")
+	for _, modelName := range modelNames {
+		tokens, err := models.CountTokensEstimate(modelName, promptText)
+		if err != nil {
+			_, _ = fmt.Fprintf(w, "  %s: unavailable (%v)\n", modelName, err)
+			continue
+		}
+		info, _ := models.GetModelInfo(modelName) // Known to succeed once the estimate did
+		usage := fmt.Sprintf("  %s: ~%d of %d tokens (%.1f%%)", modelName, tokens, info.ContextWindow,
+			float64(tokens)/float64(info.ContextWindow)*100)
+		if tokens > info.ContextWindow {
+			usage += " - exceeds context window"
+		}
+		_, _ = fmt.Fprintln(w, usage)
+	}
+}
+
+// dryRunOutputTokens is the response length assumed per model when estimating cost
+const dryRunOutputTokens = 4000
+
+// printCostEstimate writes the estimated cost of running the selected models on inputTokens
+// of prompt, plus synthesis if configured, assuming dryRunOutputTokens of output per model
+func printCostEstimate(w io.Writer, cfg *config.MinimalConfig, inputTokens, instructionTokens int) {
+	_, _ = fmt.Fprintf(w, "\nEstimated cost (~%d input tokens, ~%d output tokens per model):\n", inputTokens, dryRunOutputTokens)
+
+	var total float64
+	estimate := func(label, modelName string, input int) {
+		cost, err := models.EstimateCost(modelName, input, dryRunOutputTokens)
+		if err != nil {
+			_, _ = fmt.Fprintf(w, "  %s: unavailable (%v)\n", label, err)
+			return
+		}
+		total += cost
+		_, _ = fmt.Fprintf(w, "  %s: $%.4f\n", label, cost)
+	}
+
+	for _, modelName := range cfg.ModelNames {
+		estimate(modelName, modelName, inputTokens)
+	}
+	if cfg.SynthesisModel != "" {
+		// Synthesis reads the instructions plus every model's output, not the context
+		synthesisInput := instructionTokens + len(cfg.ModelNames)*dryRunOutputTokens
+		estimate(cfg.SynthesisModel+" (synthesis)", cfg.SynthesisModel, synthesisInput)
+	}
+	_, _ = fmt.Fprintf(w, "  Total: $%.4f\n", total)
+}
+
 // createAdapterConfig creates a temporary adapter that makes MinimalConfig work with current orchestrator
 // This will be removed once orchestrator is updated to use ConfigInterface
 func createAdapterConfig(cfg *config.MinimalConfig) *config.CliConfig {
 	return &config.CliConfig{
-		InstructionsFile:  cfg.InstructionsFile,
-		Paths:             cfg.TargetPaths,
-		ModelNames:        cfg.ModelNames,
-		OutputDir:         cfg.OutputDir,
-		DryRun:            cfg.DryRun,
-		Verbose:           cfg.Verbose,
-		SynthesisModel:    cfg.SynthesisModel,
-		LogLevel:          cfg.LogLevel,
-		Quiet:             cfg.Quiet,
-		NoProgress:        cfg.NoProgress,
-		Format:            cfg.Format,
-		Exclude:           cfg.Exclude,
-		ExcludeNames:      cfg.ExcludeNames,
-		Timeout:           cfg.Timeout,
-		TokenSafetyMargin: cfg.TokenSafetyMargin,
+		InstructionsFile:     cfg.InstructionsFile,
+		Paths:                cfg.TargetPaths,
+		ModelNames:           cfg.ModelNames,
+		OutputDir:            cfg.OutputDir,
+		DryRun:               cfg.DryRun,
+		Verbose:              cfg.Verbose,
+		SynthesisModel:       cfg.SynthesisModel,
+		LogLevel:             cfg.LogLevel,
+		Quiet:                cfg.Quiet,
+		Silent:               cfg.Silent,
+		NoProgress:           cfg.NoProgress,
+		Format:               cfg.Format,
+		Exclude:              cfg.Exclude,
+		ExcludeNames:         cfg.ExcludeNames,
+		Timeout:              cfg.Timeout,
+		TokenSafetyMargin:    cfg.TokenSafetyMargin,
+		AutoTrim:             cfg.AutoTrim,
+		OutputFormat:         cfg.OutputFormat,
+		ColorMode:            cfg.ColorMode,
+		NormalizeLineEndings: cfg.NormalizeLineEndings,
+		IncludeGlobs:         cfg.IncludeGlobs,
+		PriorityGlobs:        cfg.PriorityGlobs,
+		GatherWorkers:        cfg.GatherWorkers,
+		MaxFileSize:          cfg.MaxFileSize,
+		FollowSymlinks:       cfg.FollowSymlinks,
+		ExcludeGenerated:     cfg.ExcludeGenerated,
+		IncludeHidden:        cfg.IncludeHidden,
+		ExplainExcludes:      cfg.ExplainExcludes,
+		MaxContextTokens:     cfg.MaxContextTokens,
+		ModelTimeout:         cfg.ModelTimeout,
+		GatherTimeout:        cfg.GatherTimeout,
+		EmbedInstructions:    cfg.EmbedInstructions,
+		CheckpointInterval:   cfg.CheckpointInterval,
+		MaxOutputFileSize:    cfg.MaxOutputFileSize,
+		OutputNameTemplate:   cfg.OutputNameTemplate,
+		CombinedOutput:       cfg.CombinedOutput,
+		PostProcess:          cfg.PostProcess,
+		SummaryFile:          cfg.SummaryFile,
+		NoOverwrite:          cfg.NoOverwrite,
+		RateLimitWaitBudget:  cfg.RateLimitWaitBudget,
+		CacheDir:             responseCacheDir(cfg),
+		MaxRetries:           cfg.MaxRetries,
+		RetryBudget:          cfg.RetryBudget,
+		RetryBaseDelay:       cfg.RetryBaseDelay,
+		Repeat:               cfg.Repeat,
+		AuditVerbose:         cfg.AuditVerbose,
+		AuditPreviewLength:   cfg.AuditPreviewLength,
 		// Set smart defaults for other fields
-		MaxConcurrentRequests:      5,
+		MaxConcurrentRequests:      maxConcurrentRequests(cfg),
 		RateLimitRequestsPerMinute: 60,
-		DirPermissions:             0755,
-		FilePermissions:            0644,
-		PartialSuccessOk:           false,
+		DirPermissions:             dirPermissions(cfg),
+		FilePermissions:            filePermissions(cfg),
+		PartialSuccessOk:           cfg.PartialSuccessOk,
+		FailFast:                   cfg.FailFast,
+		PromptOrder:                cfg.PromptOrder,
+		FenceCode:                  cfg.FenceCode,
+		FileSeparator:              cfg.FileSeparator,
+	}
+}
+
+// responseCacheDir returns the response cache directory, or "" when --no-cache is set
+func responseCacheDir(cfg *config.MinimalConfig) string {
+	if cfg.NoCache {
+		return ""
 	}
+	return cfg.CacheDir
 }
 
 // handleError processes an error and exits with appropriate code
@@ -539,6 +1255,11 @@ func getExitCode(err error) int {
 		return ExitCodeSuccess
 	}
 
+	// Partial success takes precedence over the category of the individual failures
+	if errors.Is(err, thinktank.ErrPartialSuccess) {
+		return ExitCodePartialSuccess
+	}
+
 	// Check for specific error types
 	var llmErr *llm.LLMError
 	if errors.As(err, &llmErr) {
@@ -583,11 +1304,6 @@ func getExitCode(err error) int {
 		return ExitCodeCancelled
 	}
 
-	// Check for partial success
-	if errors.Is(err, thinktank.ErrPartialSuccess) {
-		return ExitCodeGenericError
-	}
-
 	return ExitCodeGenericError
 }
 
@@ -641,8 +1357,8 @@ func createLoggerWithRouting(cfg *config.MinimalConfig, outputDir string) (logut
 // Returns the list of model names and an optional synthesis model.
 // When no models are specified, uses the curated core council (8 best models by intelligence).
 func selectModelsForConfig(simplifiedConfig *SimplifiedConfig) ([]string, string) {
-	// Check if synthesis flag is explicitly set
-	forceSynthesis := simplifiedConfig.HasFlag(FlagSynthesis)
+	// Check if synthesis is explicitly requested
+	forceSynthesis := synthesisRequested(simplifiedConfig)
 
 	// Get available providers (those with API keys set)
 	availableProviders := models.GetAvailableProviders()
@@ -673,15 +1389,24 @@ func selectModelsForConfig(simplifiedConfig *SimplifiedConfig) ([]string, string
 		return []string{config.DefaultModel}, ""
 	}
 
+	// --select picks as many models as the council would run, but from every model
+	// that fits the instructions, preferring those the strategy ranks first
+	if strategy := models.SelectionStrategy(simplifiedConfig.GetOptions().SelectStrategy); strategy != models.SelectDefault {
+		estimatedTokens := models.EstimateTokensFromText(readInstructionsText(simplifiedConfig))
+		if ranked := models.SelectModelsForInputWithStrategy(estimatedTokens, availableProviders, strategy); len(ranked) > 0 {
+			selectedModels = ranked[:min(len(selectedModels), len(ranked))]
+		}
+	}
+
 	// Determine synthesis behavior
 	var synthesisModel string
 
 	// Use synthesis if:
 	// 1. Multiple models are selected, OR
-	// 2. --synthesis flag is explicitly set
-	if len(selectedModels) > 1 || forceSynthesis {
-		// Always use gemini-3-pro as the default synthesis model for predictable behavior
-		synthesisModel = "gemini-3-pro"
+	// 2. --synthesis or --synthesis-model is explicitly set
+	// unless --no-synthesis asks for the individual outputs only
+	if (len(selectedModels) > 1 || forceSynthesis) && !simplifiedConfig.GetOptions().NoSynthesis {
+		synthesisModel = chooseSynthesisModel(simplifiedConfig, availableProviders)
 	}
 
 	// If only one model and no forced synthesis, use single model mode
@@ -695,8 +1420,8 @@ func selectModelsForConfig(simplifiedConfig *SimplifiedConfig) ([]string, string
 // selectModelsForConfigWithService selects the default "core council" using TokenCountingService.
 // Uses accurate tokenization to verify core council models can handle the input.
 func selectModelsForConfigWithService(simplifiedConfig *SimplifiedConfig, tokenService thinktank.TokenCountingService) ([]string, string) {
-	// Check if synthesis flag is explicitly set
-	forceSynthesis := simplifiedConfig.HasFlag(FlagSynthesis)
+	// Check if synthesis is explicitly requested
+	forceSynthesis := synthesisRequested(simplifiedConfig)
 
 	// Get available providers (those with API keys set)
 	availableProviders := models.GetAvailableProviders()
@@ -727,15 +1452,24 @@ func selectModelsForConfigWithService(simplifiedConfig *SimplifiedConfig, tokenS
 		return []string{config.DefaultModel}, ""
 	}
 
+	// --select picks as many models as the council would run, but from every model
+	// that fits the instructions, preferring those the strategy ranks first
+	if strategy := models.SelectionStrategy(simplifiedConfig.GetOptions().SelectStrategy); strategy != models.SelectDefault {
+		estimatedTokens := models.EstimateTokensFromText(readInstructionsText(simplifiedConfig))
+		if ranked := models.SelectModelsForInputWithStrategy(estimatedTokens, availableProviders, strategy); len(ranked) > 0 {
+			selectedModels = ranked[:min(len(selectedModels), len(ranked))]
+		}
+	}
+
 	// Determine synthesis behavior
 	var synthesisModel string
 
 	// Use synthesis if:
 	// 1. Multiple models are selected, OR
-	// 2. --synthesis flag is explicitly set
-	if len(selectedModels) > 1 || forceSynthesis {
-		// Always use gemini-3-pro as the default synthesis model for predictable behavior
-		synthesisModel = "gemini-3-pro"
+	// 2. --synthesis or --synthesis-model is explicitly set
+	// unless --no-synthesis asks for the individual outputs only
+	if (len(selectedModels) > 1 || forceSynthesis) && !simplifiedConfig.GetOptions().NoSynthesis {
+		synthesisModel = chooseSynthesisModel(simplifiedConfig, availableProviders)
 	}
 
 	// If only one model and no forced synthesis, use single model mode
@@ -746,6 +1480,76 @@ func selectModelsForConfigWithService(simplifiedConfig *SimplifiedConfig, tokenS
 	return selectedModels, synthesisModel
 }
 
+// synthesisRequested reports whether synthesis was requested with --synthesis or --synthesis-model
+func synthesisRequested(simplifiedConfig *SimplifiedConfig) bool {
+	return simplifiedConfig.HasFlag(FlagSynthesis) || simplifiedConfig.GetOptions().SynthesisModel != ""
+}
+
+// chooseSynthesisModel returns the model named by --synthesis-model, or else
+// one from an available provider
+func chooseSynthesisModel(simplifiedConfig *SimplifiedConfig, availableProviders []string) string {
+	if explicit := simplifiedConfig.GetOptions().SynthesisModel; explicit != "" {
+		return explicit
+	}
+	return autoSynthesisModel(availableProviders)
+}
+
+// autoSynthesisModel picks the synthesis model when none was requested.
+// It prefers defaultSynthesisModel, then the first core council model whose
+// provider is available, so synthesis never depends on a key the user lacks.
+// Falls back to defaultSynthesisModel if no provider is available.
+func autoSynthesisModel(availableProviders []string) string {
+	providerSet := make(map[string]bool, len(availableProviders))
+	for _, p := range availableProviders {
+		providerSet[p] = true
+	}
+
+	candidates := append([]string{defaultSynthesisModel}, models.GetCoreCouncilModels()...)
+	for _, modelName := range candidates {
+		if info, err := models.GetModelInfo(modelName); err == nil && providerSet[info.Provider] {
+			return modelName
+		}
+	}
+	return defaultSynthesisModel
+}
+
+// promptBuilder returns the prompt layout selected by --prompt-order, --fence-code,
+// and --file-separator
+func promptBuilder(cfg *config.MinimalConfig) prompt.PromptBuilder {
+	return prompt.PromptBuilder{Order: prompt.Order(cfg.PromptOrder), FenceCode: cfg.FenceCode, FileSeparator: cfg.FileSeparator}
+}
+
+// warnDuplicateModels reports the model names dropped by dedupeModelNames, so a
+// repeated --models entry is not silently run only once
+func warnDuplicateModels(consoleWriter logutil.ConsoleWriter, cfg *config.MinimalConfig) {
+	if len(cfg.DuplicateModels) > 0 {
+		consoleWriter.WarningMessage(fmt.Sprintf("Ignoring duplicate model names: %s", strings.Join(cfg.DuplicateModels, ", ")))
+	}
+}
+
+// dedupeModelNames resolves aliases to canonical model IDs, then removes repeated
+// models while preserving first-seen order, so "flash" and "gemini-3-flash" run once.
+// Returns the unique canonical names and the duplicates that were dropped, as
+// given (in order of occurrence).
+func dedupeModelNames(names []string) ([]string, []string) {
+	seen := make(map[string]bool, len(names))
+	unique := make([]string, 0, len(names))
+	var duplicates []string
+	for _, given := range names {
+		name := given
+		if canonical, ok := models.ResolveAlias(given); ok {
+			name = canonical
+		}
+		if seen[name] {
+			duplicates = append(duplicates, given)
+			continue
+		}
+		seen[name] = true
+		unique = append(unique, name)
+	}
+	return unique, duplicates
+}
+
 // isVersionRequested checks if --version or -V flag is present in args.
 // This is checked before full argument parsing since version is a meta-command.
 func isVersionRequested(args []string) bool {
@@ -2,11 +2,13 @@ package cli
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/misty-step/thinktank/internal/auditlog"
 	"github.com/misty-step/thinktank/internal/config"
 	"github.com/misty-step/thinktank/internal/logutil"
 	"github.com/misty-step/thinktank/internal/thinktank"
@@ -105,6 +107,32 @@ func TestValidateConfigAdditionalCases(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "inline instructions without a file",
+			config: &config.MinimalConfig{
+				InstructionsInline: "Review for bugs",
+				TargetPaths:        []string{targetFile},
+			},
+			wantErr: false,
+		},
+		{
+			name: "no instructions",
+			config: &config.MinimalConfig{
+				TargetPaths: []string{targetFile},
+			},
+			wantErr:       true,
+			errorContains: "instructions are required",
+		},
+		{
+			name: "inline instructions and a file",
+			config: &config.MinimalConfig{
+				InstructionsFile:   instructionsFile,
+				InstructionsInline: "Review for bugs",
+				TargetPaths:        []string{targetFile},
+			},
+			wantErr:       true,
+			errorContains: "--instructions-inline conflicts with the instructions file",
+		},
 	}
 
 	for _, tt := range tests {
@@ -123,6 +151,43 @@ func TestValidateConfigAdditionalCases(t *testing.T) {
 	}
 }
 
+func TestValidateConfigSynthesisModelKey(t *testing.T) {
+	tempDir := t.TempDir()
+	instructionsFile := filepath.Join(tempDir, "instructions.txt")
+	if err := os.WriteFile(instructionsFile, []byte("test instructions"), 0644); err != nil {
+		t.Fatalf("Failed to create test instructions file: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		apiKey  string
+		dryRun  bool
+		wantErr bool
+	}{
+		{name: "missing key fails before generation", wantErr: true},
+		{name: "missing key allowed in dry run", dryRun: true},
+		{name: "key set", apiKey: "test-key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.OpenRouterAPIKeyEnvVar, tt.apiKey)
+			err := validateConfig(&config.MinimalConfig{
+				InstructionsFile: instructionsFile,
+				TargetPaths:      []string{tempDir},
+				SynthesisModel:   "gpt-5.2",
+				DryRun:           tt.dryRun,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !contains(err.Error(), "synthesis model gpt-5.2") {
+				t.Errorf("error should name the synthesis model, got: %v", err)
+			}
+		})
+	}
+}
+
 func TestSetupGracefulShutdownComplete(t *testing.T) {
 	t.Parallel()
 	logger := logutil.NewSlogLoggerFromLogLevel(nil, logutil.InfoLevel)
@@ -217,6 +282,17 @@ func TestRunApplicationDryRun(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "inline instructions dry run",
+			config: &config.MinimalConfig{
+				InstructionsInline: "test instructions",
+				TargetPaths:        []string{targetFile},
+				ModelNames:         []string{"gpt-5.2"},
+				OutputDir:          tempDir,
+				DryRun:             true,
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalid config - missing instructions",
 			config: &config.MinimalConfig{
@@ -272,3 +348,101 @@ func findInString(s, substr string) bool {
 	}
 	return false
 }
+
+func TestIsVersionRequested(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		args []string
+		want bool
+	}{
+		{name: "long flag", args: []string{"thinktank", "--version"}, want: true},
+		{name: "short flag", args: []string{"thinktank", "-V"}, want: true},
+		{name: "with other args", args: []string{"thinktank", "task.md", "./src", "--version"}, want: true},
+		{name: "not requested", args: []string{"thinktank", "task.md", "./src"}, want: false},
+		{name: "lowercase v is not version", args: []string{"thinktank", "-v"}, want: false},
+		{name: "empty args", args: []string{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isVersionRequested(tt.args); got != tt.want {
+				t.Errorf("isVersionRequested(%v) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCloseAuditLogger(t *testing.T) {
+	t.Parallel()
+	logger := logutil.NewSlogLoggerFromLogLevel(nil, logutil.InfoLevel)
+
+	tests := []struct {
+		name            string
+		cancel          func(context.CancelCauseFunc)
+		wantInterrupted bool
+	}{
+		{
+			name:            "interrupted by signal",
+			cancel:          func(cancel context.CancelCauseFunc) { cancel(fmt.Errorf("%w: interrupt", errInterrupted)) },
+			wantInterrupted: true,
+		},
+		{
+			name:   "cancelled without a signal",
+			cancel: func(cancel context.CancelCauseFunc) { cancel(nil) },
+		},
+		{
+			name:   "completed normally",
+			cancel: func(context.CancelCauseFunc) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancelCause(context.Background())
+			defer cancel(nil)
+			tt.cancel(cancel)
+
+			if got := isInterrupted(ctx); got != tt.wantInterrupted {
+				t.Errorf("isInterrupted() = %v, want %v", got, tt.wantInterrupted)
+			}
+
+			auditLogger := &MockAuditLogger{}
+			closeAuditLogger(ctx, auditLogger, logger)
+
+			if !tt.wantInterrupted {
+				if len(auditLogger.LoggedEntries) != 0 {
+					t.Errorf("logged %d entries, want none", len(auditLogger.LoggedEntries))
+				}
+				return
+			}
+			if len(auditLogger.LoggedEntries) != 1 {
+				t.Fatalf("logged %d entries, want 1 interrupted entry", len(auditLogger.LoggedEntries))
+			}
+			if entry := auditLogger.LoggedEntries[0]; entry.Operation != "RunInterrupted" || entry.Status != "Interrupted" {
+				t.Errorf("entry = %s/%s, want RunInterrupted/Interrupted", entry.Operation, entry.Status)
+			}
+		})
+	}
+}
+
+func TestSyncAuditLogOnInterrupt(t *testing.T) {
+	t.Parallel()
+	logger := logutil.NewSlogLoggerFromLogLevel(nil, logutil.InfoLevel)
+
+	fileAuditLogger, err := auditlog.NewFileAuditLogger(filepath.Join(t.TempDir(), "audit.jsonl"), logger)
+	if err != nil {
+		t.Fatalf("NewFileAuditLogger() error = %v", err)
+	}
+	defer func() { _ = fileAuditLogger.Close() }()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	stop := syncAuditLogOnInterrupt(ctx, fileAuditLogger, logger)
+	cancel(fmt.Errorf("%w: interrupt", errInterrupted))
+	stop()
+
+	// Stopping without an interrupt must return promptly too
+	stop = syncAuditLogOnInterrupt(context.Background(), fileAuditLogger, logger)
+	stop()
+}
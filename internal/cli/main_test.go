@@ -2,13 +2,20 @@
 package cli
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/misty-step/thinktank/internal/config"
 	"github.com/misty-step/thinktank/internal/llm"
 	"github.com/misty-step/thinktank/internal/logutil"
+	"github.com/misty-step/thinktank/internal/testutil"
+	"github.com/misty-step/thinktank/internal/thinktank"
 )
 
 func TestValidateConfig(t *testing.T) {
@@ -174,6 +181,26 @@ func TestCreateRateLimiter(t *testing.T) {
 	}
 }
 
+func TestMaxConcurrentRequests(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured int
+		want       int
+	}{
+		{name: "unset uses the default", configured: 0, want: config.DefaultMaxConcurrentRequests},
+		{name: "explicit limit", configured: 12, want: 12},
+		{name: "unlimited", configured: config.UnlimitedConcurrentRequests, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := maxConcurrentRequests(&config.MinimalConfig{MaxConcurrentRequests: tt.configured}); got != tt.want {
+				t.Errorf("maxConcurrentRequests() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGetExitCode(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -200,6 +227,11 @@ func TestGetExitCode(t *testing.T) {
 			err:      context.DeadlineExceeded,
 			expected: ExitCodeCancelled,
 		},
+		{
+			name:     "partial success wrapping a categorized failure",
+			err:      fmt.Errorf("%w: %w", thinktank.ErrPartialSuccess, &llm.LLMError{ErrorCategory: llm.CategoryServer}),
+			expected: ExitCodePartialSuccess,
+		},
 		{
 			name: "LLM auth error",
 			err: &llm.LLMError{
@@ -306,3 +338,169 @@ func TestSetupGracefulShutdown(t *testing.T) {
 }
 
 // Additional tests for main.go coverage are in apply_env_vars_test.go to avoid duplication
+
+func TestCreateOutputDirWithFallback(t *testing.T) {
+	// A path beneath a regular file can't be created, even with elevated permissions
+	blocker := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(blocker, []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to create blocker file: %v", err)
+	}
+	unwritable := filepath.Join(blocker, "base")
+
+	t.Run("falls back to temp directory", func(t *testing.T) {
+		logger := testutil.NewMockLogger()
+		dir, err := createOutputDirWithFallback(context.Background(), NewOutputManager(logger), logger, unwritable, 0755, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer func() { _ = os.RemoveAll(dir) }()
+
+		if !strings.HasPrefix(dir, os.TempDir()) {
+			t.Errorf("output dir = %s, want it under %s", dir, os.TempDir())
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			t.Errorf("fallback directory was not created: %v", err)
+		}
+		if !logger.ContainsMessage("Writing outputs to temporary directory instead") {
+			t.Error("expected a warning naming the fallback directory")
+		}
+	})
+
+	t.Run("strict mode fails", func(t *testing.T) {
+		logger := testutil.NewMockLogger()
+		_, err := createOutputDirWithFallback(context.Background(), NewOutputManager(logger), logger, unwritable, 0755, true)
+		if err == nil {
+			t.Fatal("expected an error in strict mode")
+		}
+	})
+
+	t.Run("no fallback when base is writable", func(t *testing.T) {
+		logger := testutil.NewMockLogger()
+		base := t.TempDir()
+		dir, err := createOutputDirWithFallback(context.Background(), NewOutputManager(logger), logger, base, 0755, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if filepath.Dir(dir) != base {
+			t.Errorf("output dir = %s, want it under %s", dir, base)
+		}
+	})
+}
+
+func TestOutputPermissions(t *testing.T) {
+	t.Parallel()
+
+	defaults := &config.MinimalConfig{}
+	if dir, file := dirPermissions(defaults), filePermissions(defaults); dir != 0755 || file != 0644 {
+		t.Errorf("default permissions = %o/%o, want 755/644", dir, file)
+	}
+
+	custom := &config.MinimalConfig{DirPermissions: 0770, FilePermissions: 0660}
+	adapterConfig := createAdapterConfig(custom)
+	if adapterConfig.DirPermissions != 0770 || adapterConfig.FilePermissions != 0660 {
+		t.Errorf("adapter permissions = %o/%o, want 770/660", adapterConfig.DirPermissions, adapterConfig.FilePermissions)
+	}
+}
+
+func TestAcceptPartialSuccess(t *testing.T) {
+	partialErr := fmt.Errorf("%w: model-b failed", thinktank.ErrPartialSuccess)
+	otherErr := errors.New("all models failed")
+
+	tests := []struct {
+		name        string
+		ok          bool
+		err         error
+		expectedErr error
+		warns       bool
+	}{
+		{name: "no error", ok: true, err: nil, expectedErr: nil},
+		{name: "partial success fails by default", ok: false, err: partialErr, expectedErr: partialErr},
+		{name: "partial success accepted", ok: true, err: partialErr, expectedErr: nil, warns: true},
+		{name: "other errors still fail", ok: true, err: otherErr, expectedErr: otherErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := acceptPartialSuccess(&config.MinimalConfig{PartialSuccessOk: tt.ok}, tt.err, &out)
+			if err != tt.expectedErr {
+				t.Errorf("acceptPartialSuccess() = %v, want %v", err, tt.expectedErr)
+			}
+			if tt.warns != strings.Contains(out.String(), "model-b failed") {
+				t.Errorf("unexpected warning output %q", out.String())
+			}
+		})
+	}
+}
+
+// warningRecorder is a ConsoleWriter that records warnings; other calls are unused
+type warningRecorder struct {
+	logutil.ConsoleWriter
+	warnings []string
+}
+
+func (w *warningRecorder) WarningMessage(message string) {
+	w.warnings = append(w.warnings, message)
+}
+
+func TestWarnDuplicateModels(t *testing.T) {
+	recorder := &warningRecorder{}
+	warnDuplicateModels(recorder, &config.MinimalConfig{ModelNames: []string{"gpt-5.2"}})
+	if len(recorder.warnings) != 0 {
+		t.Fatalf("warnings = %q, want none without duplicates", recorder.warnings)
+	}
+
+	warnDuplicateModels(recorder, &config.MinimalConfig{DuplicateModels: []string{"gpt-5.2", "o3"}})
+	want := []string{"Ignoring duplicate model names: gpt-5.2, o3"}
+	if strings.Join(recorder.warnings, "\n") != strings.Join(want, "\n") {
+		t.Errorf("warnings = %q, want %q", recorder.warnings, want)
+	}
+}
+
+func TestNewConsoleWriter(t *testing.T) {
+	progressFile, err := os.CreateTemp(t.TempDir(), "progress")
+	if err != nil {
+		t.Fatalf("CreateTemp: %v", err)
+	}
+	defer func() { _ = progressFile.Close() }()
+
+	tests := []struct {
+		name    string
+		cfg     config.MinimalConfig
+		wantErr string
+	}{
+		{name: "default console output", cfg: config.MinimalConfig{}},
+		{name: "json progress to stderr", cfg: config.MinimalConfig{ProgressFormat: "json", Quiet: true}},
+		{name: "json progress to an open descriptor", cfg: config.MinimalConfig{ProgressFormat: "json", ProgressFD: int(progressFile.Fd())}},
+		{name: "closed descriptor", cfg: config.MinimalConfig{ProgressFormat: "json", ProgressFD: 987}, wantErr: "--progress-fd 987 is not open"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer, err := newConsoleWriter(&tt.cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("newConsoleWriter() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || writer == nil {
+				t.Fatalf("newConsoleWriter() = %v, %v", writer, err)
+			}
+		})
+	}
+
+	// Events reach the chosen descriptor
+	writer, err := newConsoleWriter(&config.MinimalConfig{ProgressFormat: "json", ProgressFD: int(progressFile.Fd()), Quiet: true})
+	if err != nil {
+		t.Fatalf("newConsoleWriter: %v", err)
+	}
+	writer.ModelStarted(1, 1, "model-a")
+	content, err := os.ReadFile(progressFile.Name())
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !strings.Contains(string(content), `"event":"model_started"`) {
+		t.Errorf("progress file = %q, want a model_started event", content)
+	}
+}
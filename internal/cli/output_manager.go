@@ -381,6 +381,26 @@ func (om *OutputManager) CreateOutputDirectory(basePath string, permissions os.F
 	return fullPath, nil
 }
 
+// UseOutputDirectory prepares a user-chosen output directory: it is created
+// (with parents) if missing and must be a directory this process can write to
+func (om *OutputManager) UseOutputDirectory(path string, permissions os.FileMode) (string, error) {
+	path = filepath.Clean(path)
+	if err := os.MkdirAll(path, permissions); err != nil {
+		return "", fmt.Errorf("failed to create output directory %s: %w", path, err)
+	}
+
+	// Probe with a real file; permission bits alone miss read-only mounts and ACLs
+	probe, err := os.CreateTemp(path, ".thinktank-write-check-*")
+	if err != nil {
+		return "", fmt.Errorf("output directory %s is not writable: %w", path, err)
+	}
+	_ = probe.Close()
+	_ = os.Remove(probe.Name())
+
+	om.logger.Printf("Using output directory: %s", path)
+	return path, nil
+}
+
 func (om *OutputManager) generateAvailableMemorableDirName(basePath string, maxAttempts int, permissions os.FileMode) (string, error) {
 	for attempt := 0; attempt < maxAttempts; attempt++ {
 		dirName := om.GenerateMemorableDirName()
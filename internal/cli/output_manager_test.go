@@ -294,6 +294,59 @@ func TestCreateOutputDirectory(t *testing.T) {
 	})
 }
 
+func TestUseOutputDirectory(t *testing.T) {
+	t.Run("creates missing directories", func(t *testing.T) {
+		om := NewOutputManager(testutil.NewMockLogger())
+		want := filepath.Join(t.TempDir(), "results", "run1")
+
+		dirPath, err := om.UseOutputDirectory(want+"/", 0755)
+		require.NoError(t, err)
+		assert.Equal(t, want, dirPath)
+
+		info, err := os.Stat(dirPath)
+		require.NoError(t, err)
+		assert.True(t, info.IsDir())
+
+		// The write probe must not be left behind
+		entries, err := os.ReadDir(dirPath)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("uses an existing directory as is", func(t *testing.T) {
+		om := NewOutputManager(testutil.NewMockLogger())
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "keep.txt"), []byte("x"), 0644))
+
+		dirPath, err := om.UseOutputDirectory(dir, 0755)
+		require.NoError(t, err)
+		assert.Equal(t, dir, dirPath)
+		assert.FileExists(t, filepath.Join(dir, "keep.txt"))
+	})
+
+	t.Run("path is a file", func(t *testing.T) {
+		om := NewOutputManager(testutil.NewMockLogger())
+		file := filepath.Join(t.TempDir(), "file.txt")
+		require.NoError(t, os.WriteFile(file, []byte("x"), 0644))
+
+		_, err := om.UseOutputDirectory(file, 0755)
+		assert.ErrorContains(t, err, "failed to create output directory")
+	})
+
+	t.Run("read-only directory", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("root can write to read-only directories")
+		}
+		om := NewOutputManager(testutil.NewMockLogger())
+		dir := t.TempDir()
+		require.NoError(t, os.Chmod(dir, 0555))
+		t.Cleanup(func() { _ = os.Chmod(dir, 0755) })
+
+		_, err := om.UseOutputDirectory(dir, 0755)
+		assert.ErrorContains(t, err, "is not writable")
+	})
+}
+
 func TestIsThinktankOutputDir(t *testing.T) {
 	om := NewOutputManager(testutil.NewMockLogger())
 
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// readPathsFile reads newline-separated target paths from a list file, such as the
+// output of `git diff --name-only`. Blank lines and lines starting with # are ignored.
+// Paths are used as written, so relative paths resolve against the working directory.
+// Listed paths that don't exist are an error, or are reported to warn and skipped when skipMissing is set.
+func readPathsFile(listPath string, skipMissing bool, warn io.Writer) ([]string, error) {
+	file, err := os.Open(listPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open paths file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var paths []string
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		path := strings.TrimSpace(scanner.Text())
+		if path == "" || strings.HasPrefix(path, "#") {
+			continue
+		}
+
+		if _, err := os.Stat(path); err != nil {
+			if !skipMissing {
+				return nil, fmt.Errorf("%s:%d: target path not found: %s", listPath, lineNum, path)
+			}
+			_, _ = fmt.Fprintf(warn, "Warning: skipping missing path %s (%s:%d)\n", path, listPath, lineNum)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read paths file %s: %w", listPath, err)
+	}
+	return paths, nil
+}
@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadPathsFile(t *testing.T) {
+	tempDir := t.TempDir()
+	existingFile := filepath.Join(tempDir, "main.go")
+	existingDir := filepath.Join(tempDir, "pkg")
+	missing := filepath.Join(tempDir, "deleted.go")
+	if err := os.WriteFile(existingFile, []byte("package main"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if err := os.MkdirAll(existingDir, 0755); err != nil {
+		t.Fatalf("Failed to create test directory: %v", err)
+	}
+
+	writeList := func(t *testing.T, lines ...string) string {
+		t.Helper()
+		listPath := filepath.Join(t.TempDir(), "paths.txt")
+		if err := os.WriteFile(listPath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+			t.Fatalf("Failed to write paths file: %v", err)
+		}
+		return listPath
+	}
+
+	tests := []struct {
+		name        string
+		lines       []string
+		skipMissing bool
+		want        []string
+		wantWarning string
+		errContains string
+	}{
+		{
+			name:  "paths with comments and blank lines",
+			lines: []string{"# changed files", existingFile, "", "  " + existingDir + "  ", "   # indented comment"},
+			want:  []string{existingFile, existingDir},
+		},
+		{
+			name:        "missing path fails with line number",
+			lines:       []string{existingFile, missing},
+			errContains: "paths.txt:2: target path not found",
+		},
+		{
+			name:        "missing path skipped with warning",
+			lines:       []string{missing, existingFile},
+			skipMissing: true,
+			want:        []string{existingFile},
+			wantWarning: "skipping missing path " + missing,
+		},
+		{
+			name:  "only comments",
+			lines: []string{"# nothing here"},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var warn bytes.Buffer
+			got, err := readPathsFile(writeList(t, tt.lines...), tt.skipMissing, &warn)
+
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("readPathsFile() error = %v, want error containing %q", err, tt.errContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readPathsFile() unexpected error: %v", err)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("readPathsFile() = %v, want %v", got, tt.want)
+			}
+			if tt.wantWarning != "" && !strings.Contains(warn.String(), tt.wantWarning) {
+				t.Errorf("warning output = %q, want it to contain %q", warn.String(), tt.wantWarning)
+			}
+			if tt.wantWarning == "" && warn.Len() > 0 {
+				t.Errorf("unexpected warning output: %q", warn.String())
+			}
+		})
+	}
+}
+
+func TestReadPathsFile_MissingListFile(t *testing.T) {
+	_, err := readPathsFile(filepath.Join(t.TempDir(), "absent.txt"), false, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "failed to open paths file") {
+		t.Errorf("readPathsFile() error = %v, want open failure", err)
+	}
+}
+
+func TestParseSimpleArgsWithArgs_PathsFromFile(t *testing.T) {
+	tempDir := t.TempDir()
+	instructionsFile := filepath.Join(tempDir, "instructions.txt")
+	srcDir := filepath.Join(tempDir, "src")
+	listedFile := filepath.Join(tempDir, "listed.go")
+	listPath := filepath.Join(tempDir, "paths.txt")
+
+	if err := os.WriteFile(instructionsFile, []byte("test instructions"), 0644); err != nil {
+		t.Fatalf("Failed to create instructions file: %v", err)
+	}
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		t.Fatalf("Failed to create target directory: %v", err)
+	}
+	if err := os.WriteFile(listedFile, []byte("package listed"), 0644); err != nil {
+		t.Fatalf("Failed to create listed file: %v", err)
+	}
+	if err := os.WriteFile(listPath, []byte("# from git diff\n"+listedFile+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to create paths file: %v", err)
+	}
+
+	t.Run("complements positional paths", func(t *testing.T) {
+		cfg, err := ParseSimpleArgsWithArgs([]string{"thinktank", instructionsFile, srcDir, "--paths-from-file", listPath, "--dry-run"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := srcDir + " " + listedFile; cfg.TargetPath != want {
+			t.Errorf("TargetPath = %q, want %q", cfg.TargetPath, want)
+		}
+		if cfg.GetOptions().PathsFromFile != listPath {
+			t.Errorf("PathsFromFile = %q, want %q", cfg.GetOptions().PathsFromFile, listPath)
+		}
+	})
+
+	t.Run("replaces positional paths", func(t *testing.T) {
+		cfg, err := ParseSimpleArgsWithArgs([]string{"thinktank", instructionsFile, "--paths-from-file=" + listPath, "--dry-run"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.TargetPath != listedFile {
+			t.Errorf("TargetPath = %q, want %q", cfg.TargetPath, listedFile)
+		}
+	})
+
+	t.Run("missing value", func(t *testing.T) {
+		_, err := ParseSimpleArgsWithArgs([]string{"thinktank", instructionsFile, srcDir, "--paths-from-file"})
+		if err == nil || !strings.Contains(err.Error(), "--paths-from-file flag requires a value") {
+			t.Errorf("error = %v, want missing value error", err)
+		}
+	})
+}
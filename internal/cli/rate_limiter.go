@@ -14,7 +14,7 @@ import (
 	"github.com/misty-step/thinktank/internal/ratelimit"
 )
 
-// Provider-specific rate limit constants - matches models.GetProviderDefaultRateLimit()
+// Provider-specific rate limit constants - matches models.GetProviderRateLimit()
 const (
 	OpenAIDefaultRPM     = 3000 // OpenAI has high rate limits for paid accounts
 	GeminiDefaultRPM     = 60   // Gemini has moderate rate limits
@@ -157,7 +157,7 @@ func NewProviderRateLimiter(maxConcurrent int, providerOverrides map[string]int)
 	providers := []string{"openai", "gemini", "openrouter"}
 	for _, provider := range providers {
 		// Get rate limit: override > provider default
-		rateLimit := models.GetProviderDefaultRateLimit(provider)
+		rateLimit := models.GetProviderRateLimit(provider)
 		if override, exists := providerOverrides[provider]; exists && override > 0 {
 			rateLimit = override
 		}
@@ -695,3 +695,98 @@ func TestSynthesisFlagBehaviorConsistency(t *testing.T) {
 		})
 	}
 }
+
+func TestDedupeModelNames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		input              []string
+		expectedUnique     []string
+		expectedDuplicates []string
+	}{
+		{
+			name:               "no duplicates",
+			input:              []string{"gpt-5.2", "gemini-3-pro"},
+			expectedUnique:     []string{"gpt-5.2", "gemini-3-pro"},
+			expectedDuplicates: nil,
+		},
+		{
+			name:               "adjacent duplicate",
+			input:              []string{"gpt-4.1", "gpt-4.1"},
+			expectedUnique:     []string{"gpt-4.1"},
+			expectedDuplicates: []string{"gpt-4.1"},
+		},
+		{
+			name:               "preserves first-seen order",
+			input:              []string{"b", "a", "b", "c", "a"},
+			expectedUnique:     []string{"b", "a", "c"},
+			expectedDuplicates: []string{"b", "a"},
+		},
+		{
+			name:               "alias and canonical name",
+			input:              []string{"flash", "gpt-5.2", "gemini-3-flash", "gpt"},
+			expectedUnique:     []string{"gemini-3-flash", "gpt-5.2"},
+			expectedDuplicates: []string{"gemini-3-flash", "gpt"},
+		},
+		{
+			name:               "empty list",
+			input:              []string{},
+			expectedUnique:     []string{},
+			expectedDuplicates: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unique, duplicates := dedupeModelNames(tt.input)
+			assert.Equal(t, tt.expectedUnique, unique)
+			assert.Equal(t, tt.expectedDuplicates, duplicates)
+		})
+	}
+}
+
+func TestExplicitSynthesisModel(t *testing.T) {
+	// Note: Not using t.Parallel() due to environment variable isolation issues
+	cleanup := setupTestEnvironment(t, map[string]string{
+		"OPENROUTER_API_KEY": "test-key",
+	})
+	defer cleanup()
+
+	tempDir := t.TempDir()
+	instructionsFile := createTempInstructionsFile(t, tempDir, "Simple analysis task")
+
+	config := &SimplifiedConfig{
+		InstructionsFile: instructionsFile,
+		TargetPath:       tempDir,
+		Options:          &AdvancedOptions{SynthesisModel: "gpt-5.2"},
+	}
+
+	_, estimationSynthesis := selectModelsForConfig(config)
+	_, accurateSynthesis := selectModelsForConfigWithService(config, thinktank.NewTokenCountingService())
+
+	assert.Equal(t, "gpt-5.2", estimationSynthesis)
+	assert.Equal(t, "gpt-5.2", accurateSynthesis)
+	assert.True(t, synthesisRequested(config), "--synthesis-model should imply synthesis")
+}
+
+func TestAutoSynthesisModel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		providers []string
+		expected  string
+	}{
+		{"default provider available", []string{"openrouter"}, defaultSynthesisModel},
+		{"no providers available", nil, defaultSynthesisModel},
+		{"only unrelated providers", []string{"obsolete"}, defaultSynthesisModel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, autoSynthesisModel(tt.providers))
+		})
+	}
+}
@@ -3,13 +3,17 @@ package cli
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/misty-step/thinktank/internal/config"
 	"github.com/misty-step/thinktank/internal/logutil"
+	"github.com/misty-step/thinktank/internal/models"
 	"github.com/misty-step/thinktank/internal/thinktank"
 )
 
@@ -381,3 +385,506 @@ func TestSetupConfigurationLogLevelPrecedence(t *testing.T) {
 		})
 	}
 }
+
+func TestSetupConfiguration_ExplicitModels(t *testing.T) {
+	tests := []struct {
+		name           string
+		flags          uint8
+		models         []string
+		noSynthesis    bool
+		wantModels     []string
+		wantDuplicates []string
+		wantSynthesis  bool
+	}{
+		{name: "several models are synthesized", models: []string{"gpt-5.2", "gemini-3-flash"}, wantModels: []string{"gpt-5.2", "gemini-3-flash"}, wantSynthesis: true},
+		{name: "one model runs alone", models: []string{"gemini-3-flash"}, wantModels: []string{"gemini-3-flash"}},
+		{name: "one model with --synthesis", flags: FlagSynthesis, models: []string{"gemini-3-flash"}, wantModels: []string{"gemini-3-flash"}, wantSynthesis: true},
+		{name: "duplicates are dropped before deciding on synthesis", models: []string{"gpt-5.2", "gpt-5.2"}, wantModels: []string{"gpt-5.2"}, wantDuplicates: []string{"gpt-5.2"}},
+		{name: "several models with --no-synthesis", models: []string{"gpt-5.2", "gemini-3-flash"}, noSynthesis: true, wantModels: []string{"gpt-5.2", "gemini-3-flash"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			simplifiedConfig := &SimplifiedConfig{
+				InstructionsFile: "test.md",
+				TargetPath:       "src/",
+				Flags:            tt.flags | FlagDryRun,
+				Options:          &AdvancedOptions{Models: tt.models, NoSynthesis: tt.noSynthesis},
+			}
+
+			cfg, err := setupConfiguration(simplifiedConfig, &MockTokenCountingService{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantModels, cfg.ModelNames)
+			assert.Equal(t, tt.wantDuplicates, cfg.DuplicateModels)
+			assert.Equal(t, tt.wantSynthesis, cfg.SynthesisModel != "", "synthesis model = %q", cfg.SynthesisModel)
+		})
+	}
+}
+
+func TestSetupConfiguration_SelectStrategy(t *testing.T) {
+	t.Setenv("OPENROUTER_API_KEY", "test-key")
+
+	simplifiedConfig := &SimplifiedConfig{
+		InstructionsFile: "test.md",
+		TargetPath:       "src/",
+		Flags:            FlagDryRun,
+		Options:          &AdvancedOptions{SelectStrategy: "cheapest"},
+	}
+
+	// The strategy chooses as many models as the council has, from every model that fits
+	council := models.GetCoreCouncilModels()
+	for _, strategy := range []models.SelectionStrategy{models.SelectCheapest, models.SelectLargest} {
+		simplifiedConfig.Options.SelectStrategy = string(strategy)
+		cfg, err := setupConfiguration(simplifiedConfig, &MockTokenCountingService{})
+		require.NoError(t, err)
+		want := models.SelectModelsForInputWithStrategy(0, []string{"openrouter"}, strategy)[:len(council)]
+		assert.Equal(t, want, cfg.ModelNames, "strategy %s", strategy)
+		assert.NotSubset(t, council, cfg.ModelNames, "strategy %s should change which models run", strategy)
+	}
+
+	// Explicit models keep the order they were given in
+	simplifiedConfig.Options.Models = []string{"claude-opus-4.5", "deepseek-v3.2"}
+	cfg, err := setupConfiguration(simplifiedConfig, &MockTokenCountingService{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"claude-opus-4.5", "deepseek-v3.2"}, cfg.ModelNames)
+}
+
+func TestApplyProjectConfig(t *testing.T) {
+	t.Parallel()
+
+	baseConfig := func() *config.MinimalConfig {
+		return &config.MinimalConfig{
+			ModelNames:     []string{"gemini-3-flash"},
+			Exclude:        ".exe",
+			ExcludeNames:   ".git",
+			SynthesisModel: "",
+		}
+	}
+
+	tests := []struct {
+		name     string
+		project  *config.ProjectConfig
+		options  *AdvancedOptions
+		preset   func(cfg *config.MinimalConfig)
+		validate func(t *testing.T, cfg *config.MinimalConfig)
+	}{
+		{
+			name:    "nil project config leaves defaults",
+			project: nil,
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.Equal(t, baseConfig(), cfg)
+			},
+		},
+		{
+			name:    "multiple models enable synthesis and dedupe",
+			project: &config.ProjectConfig{Models: []string{"gpt-5.2", "gemini-3-pro", "gpt-5.2"}},
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.Equal(t, []string{"gpt-5.2", "gemini-3-pro"}, cfg.ModelNames)
+				assert.Equal(t, []string{"gpt-5.2"}, cfg.DuplicateModels)
+				assert.Equal(t, defaultSynthesisModel, cfg.SynthesisModel)
+			},
+		},
+		{
+			name:    "single model without synthesis",
+			project: &config.ProjectConfig{Models: []string{"gpt-5.2"}},
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.Equal(t, []string{"gpt-5.2"}, cfg.ModelNames)
+				assert.Empty(t, cfg.SynthesisModel)
+			},
+		},
+		{
+			name:    "synthesis from project config",
+			project: &config.ProjectConfig{Models: []string{"gpt-5.2"}, Synthesis: true},
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.Equal(t, defaultSynthesisModel, cfg.SynthesisModel)
+			},
+		},
+		{
+			name:    "synthesis model flag wins over default",
+			project: &config.ProjectConfig{Models: []string{"gpt-5.2"}},
+			options: &AdvancedOptions{SynthesisModel: "gpt-5.2"},
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.Equal(t, "gpt-5.2", cfg.SynthesisModel)
+			},
+		},
+		{
+			name:    "no-synthesis flag wins over project synthesis",
+			project: &config.ProjectConfig{Models: []string{"gpt-5.2", "gemini-3-pro"}, Synthesis: true},
+			options: &AdvancedOptions{NoSynthesis: true},
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.Equal(t, []string{"gpt-5.2", "gemini-3-pro"}, cfg.ModelNames)
+				assert.Empty(t, cfg.SynthesisModel)
+			},
+		},
+		{
+			name:    "models flag wins over project models",
+			project: &config.ProjectConfig{Models: []string{"gpt-5.2", "gemini-3-pro"}},
+			options: &AdvancedOptions{Models: []string{"gemini-3-flash"}},
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.Equal(t, []string{"gemini-3-flash"}, cfg.ModelNames)
+				assert.Empty(t, cfg.SynthesisModel)
+			},
+		},
+		{
+			name:    "excludes extend defaults",
+			project: &config.ProjectConfig{Exclude: ".md", ExcludeNames: "fixtures"},
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.Equal(t, ".exe,.md", cfg.Exclude)
+				assert.Equal(t, ".git,fixtures", cfg.ExcludeNames)
+			},
+		},
+		{
+			name:    "concurrency fills unset value",
+			project: &config.ProjectConfig{Concurrency: 2},
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.Equal(t, 2, cfg.MaxConcurrentRequests)
+			},
+		},
+		{
+			name:    "flag value wins over project concurrency",
+			project: &config.ProjectConfig{Concurrency: 2},
+			preset:  func(cfg *config.MinimalConfig) { cfg.MaxConcurrentRequests = 8 },
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.Equal(t, 8, cfg.MaxConcurrentRequests)
+			},
+		},
+		{
+			name:    "cache dir from project config",
+			project: &config.ProjectConfig{CacheDir: ".thinktank-cache"},
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.Equal(t, ".thinktank-cache", cfg.CacheDir)
+			},
+		},
+		{
+			name:    "flag value wins over project cache dir",
+			project: &config.ProjectConfig{CacheDir: ".thinktank-cache"},
+			preset:  func(cfg *config.MinimalConfig) { cfg.CacheDir = "/tmp/cache" },
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.Equal(t, "/tmp/cache", cfg.CacheDir)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			if tt.preset != nil {
+				tt.preset(cfg)
+			}
+			applyProjectConfig(cfg, tt.project, &SimplifiedConfig{Options: tt.options})
+			tt.validate(t, cfg)
+		})
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	t.Parallel()
+
+	configHome := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(configHome, "thinktank"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(configHome, "thinktank", config.ProfilesFileName),
+		[]byte(`{"ci":{"models":["gpt-5.2","gemini-3-pro"],"quiet":true,"cache_dir":"/profile-cache"},`+
+			`"relaxed":{"models":["gpt-5.2","gemini-3-pro"],"synthesis":false,"quiet":false,"no_progress":true,"timeout":"20m","max_retries":5}}`), 0644))
+	defaultRetries := config.DefaultMaxRetries
+	getenv := func(key string) string {
+		if key == "XDG_CONFIG_HOME" {
+			return configHome
+		}
+		return ""
+	}
+
+	tests := []struct {
+		name     string
+		profile  string
+		options  AdvancedOptions
+		flags    uint8
+		project  *config.ProjectConfig
+		errMsg   string
+		validate func(t *testing.T, cfg *config.MinimalConfig)
+	}{
+		{
+			name:    "no profile leaves the config alone",
+			profile: "",
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.Equal(t, []string{"gemini-3-flash"}, cfg.ModelNames)
+				assert.False(t, cfg.Quiet)
+			},
+		},
+		{
+			name:    "profile supplies models and defaults",
+			profile: "ci",
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.Equal(t, []string{"gpt-5.2", "gemini-3-pro"}, cfg.ModelNames)
+				assert.Equal(t, defaultSynthesisModel, cfg.SynthesisModel)
+				assert.True(t, cfg.Quiet)
+				assert.Equal(t, "/profile-cache", cfg.CacheDir)
+			},
+		},
+		{
+			name:    "project config takes precedence",
+			profile: "ci",
+			project: &config.ProjectConfig{Models: []string{"gemini-3-flash"}, CacheDir: ".thinktank-cache"},
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.Equal(t, []string{"gemini-3-flash"}, cfg.ModelNames)
+				assert.Equal(t, ".thinktank-cache", cfg.CacheDir)
+				assert.True(t, cfg.Quiet)
+			},
+		},
+		{
+			name:    "flags given their default values still win",
+			profile: "relaxed",
+			options: AdvancedOptions{Timeout: config.DefaultTimeout, MaxRetries: &defaultRetries},
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.Equal(t, config.DefaultTimeout, cfg.Timeout)
+				assert.Equal(t, config.DefaultMaxRetries, cfg.MaxRetries)
+				assert.True(t, cfg.NoProgress)
+			},
+		},
+		{
+			name:    "false settings turn options off",
+			profile: "relaxed",
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.Empty(t, cfg.SynthesisModel)
+				assert.False(t, cfg.Quiet)
+				assert.Equal(t, 20*time.Minute, cfg.Timeout)
+				assert.Equal(t, 5, cfg.MaxRetries)
+			},
+		},
+		{
+			name:    "boolean flags win over false settings",
+			profile: "relaxed",
+			flags:   FlagQuiet | FlagSynthesis,
+			validate: func(t *testing.T, cfg *config.MinimalConfig) {
+				assert.True(t, cfg.Quiet)
+				assert.Equal(t, defaultSynthesisModel, cfg.SynthesisModel)
+			},
+		},
+		{
+			name:    "unknown profile",
+			profile: "nightly",
+			errMsg:  `unknown profile "nightly"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &config.MinimalConfig{ModelNames: []string{"gemini-3-flash"}, MaxRetries: config.DefaultMaxRetries, Timeout: config.DefaultTimeout}
+			cfg.Quiet = tt.flags&FlagQuiet != 0
+			simplified := &SimplifiedConfig{InstructionsFile: "test.md", TargetPath: "src/", Flags: tt.flags}
+			if tt.profile != "" {
+				options := tt.options
+				options.Profile = tt.profile
+				simplified.Options = &options
+			}
+			applyProjectConfig(cfg, tt.project, simplified)
+
+			err := applyProfile(cfg, tt.project, simplified, getenv)
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			tt.validate(t, cfg)
+		})
+	}
+}
+
+func TestSetupConfigurationGatherTimeout(t *testing.T) {
+	tokenService := &MockTokenCountingService{models: []string{"gemini-3-flash"}}
+
+	tests := []struct {
+		name     string
+		options  *AdvancedOptions
+		expected time.Duration
+	}{
+		{
+			name:     "defaults to run timeout",
+			options:  nil,
+			expected: config.DefaultTimeout,
+		},
+		{
+			name:     "lower value is used",
+			options:  &AdvancedOptions{GatherTimeout: 30 * time.Second},
+			expected: 30 * time.Second,
+		},
+		{
+			name:     "capped at run timeout",
+			options:  &AdvancedOptions{GatherTimeout: config.DefaultTimeout * 2},
+			expected: config.DefaultTimeout,
+		},
+		{
+			name:     "capped at --timeout",
+			options:  &AdvancedOptions{Timeout: time.Minute, GatherTimeout: 2 * time.Minute},
+			expected: time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := setupConfiguration(&SimplifiedConfig{
+				InstructionsFile: "test.md",
+				TargetPath:       "src/",
+				Options:          tt.options,
+			}, tokenService)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg.GatherTimeout)
+		})
+	}
+}
+
+func TestSetupConfigurationTimeouts(t *testing.T) {
+	tokenService := &MockTokenCountingService{models: []string{"gemini-3-flash"}}
+
+	cfg, err := setupConfiguration(&SimplifiedConfig{
+		InstructionsFile: "test.md",
+		TargetPath:       "src/",
+	}, tokenService)
+	require.NoError(t, err)
+	assert.Equal(t, config.DefaultTimeout, cfg.Timeout)
+	assert.Zero(t, cfg.ModelTimeout, "models are bounded only by the run timeout by default")
+
+	cfg, err = setupConfiguration(&SimplifiedConfig{
+		InstructionsFile: "test.md",
+		TargetPath:       "src/",
+		Options:          &AdvancedOptions{Timeout: 20 * time.Minute, ModelTimeout: 3 * time.Minute},
+	}, tokenService)
+	require.NoError(t, err)
+	assert.Equal(t, 20*time.Minute, cfg.Timeout)
+	assert.Equal(t, 3*time.Minute, cfg.ModelTimeout)
+}
+
+func TestSetupConfigurationRetries(t *testing.T) {
+	tokenService := &MockTokenCountingService{models: []string{"gemini-3-flash"}}
+	noRetries := 0
+
+	tests := []struct {
+		name           string
+		options        *AdvancedOptions
+		expectedMax    int
+		expectedBudget int
+		expectedDelay  time.Duration
+	}{
+		{
+			name:          "defaults",
+			options:       nil,
+			expectedMax:   config.DefaultMaxRetries,
+			expectedDelay: config.DefaultRetryBaseDelay,
+		},
+		{
+			name:          "zero disables retries",
+			options:       &AdvancedOptions{MaxRetries: &noRetries},
+			expectedMax:   0,
+			expectedDelay: config.DefaultRetryBaseDelay,
+		},
+		{
+			name:           "retry budget",
+			options:        &AdvancedOptions{RetryBudget: 10},
+			expectedMax:    config.DefaultMaxRetries,
+			expectedBudget: 10,
+			expectedDelay:  config.DefaultRetryBaseDelay,
+		},
+		{
+			name:          "custom base delay",
+			options:       &AdvancedOptions{RetryBaseDelay: 250 * time.Millisecond},
+			expectedMax:   config.DefaultMaxRetries,
+			expectedDelay: 250 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := setupConfiguration(&SimplifiedConfig{
+				InstructionsFile: "test.md",
+				TargetPath:       "src/",
+				Options:          tt.options,
+			}, tokenService)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedMax, cfg.MaxRetries)
+			assert.Equal(t, tt.expectedBudget, cfg.RetryBudget)
+			assert.Equal(t, tt.expectedDelay, cfg.RetryBaseDelay)
+		})
+	}
+}
+
+func TestSetupConfigurationConcurrency(t *testing.T) {
+	tokenService := &MockTokenCountingService{models: []string{"gemini-3-flash"}}
+	limit, unlimited := 12, 0
+
+	tests := []struct {
+		name     string
+		options  *AdvancedOptions
+		expected int
+	}{
+		{name: "unset leaves the default", options: nil, expected: 0},
+		{name: "explicit limit", options: &AdvancedOptions{Concurrency: &limit}, expected: 12},
+		{name: "zero is unlimited", options: &AdvancedOptions{Concurrency: &unlimited}, expected: config.UnlimitedConcurrentRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := setupConfiguration(&SimplifiedConfig{
+				InstructionsFile: "test.md",
+				TargetPath:       "src/",
+				Options:          tt.options,
+			}, tokenService)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg.MaxConcurrentRequests)
+		})
+	}
+}
+
+func TestSetupConfigurationNoOverwrite(t *testing.T) {
+	tokenService := &MockTokenCountingService{models: []string{"gemini-3-flash"}}
+
+	tests := []struct {
+		name     string
+		options  *AdvancedOptions
+		expected bool
+	}{
+		{name: "generated output directory", options: nil, expected: false},
+		{name: "output dir protects existing files", options: &AdvancedOptions{OutputDir: "results"}, expected: true},
+		{name: "force allows overwriting", options: &AdvancedOptions{OutputDir: "results", Force: true}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := setupConfiguration(&SimplifiedConfig{
+				InstructionsFile: "test.md",
+				TargetPath:       "src/",
+				Options:          tt.options,
+			}, tokenService)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cfg.NoOverwrite)
+		})
+	}
+}
+
+func TestSetupConfigurationAuditCapture(t *testing.T) {
+	tokenService := &MockTokenCountingService{models: []string{"gemini-3-flash"}}
+
+	cfg, err := setupConfiguration(&SimplifiedConfig{InstructionsFile: "test.md", TargetPath: "src/"}, tokenService)
+	require.NoError(t, err)
+	assert.False(t, cfg.AuditVerbose)
+	assert.Equal(t, config.DefaultAuditPreviewLength, cfg.AuditPreviewLength)
+
+	cfg, err = setupConfiguration(&SimplifiedConfig{
+		InstructionsFile: "test.md",
+		TargetPath:       "src/",
+		Options:          &AdvancedOptions{AuditVerbose: true, AuditPreviewLength: new(int)},
+	}, tokenService)
+	require.NoError(t, err)
+	adapterConfig := createAdapterConfig(cfg)
+	assert.True(t, adapterConfig.AuditVerbose)
+	assert.Equal(t, 0, adapterConfig.AuditPreviewLength)
+}
+
+func TestResponseCacheDir(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ".cache", responseCacheDir(&config.MinimalConfig{CacheDir: ".cache"}))
+	assert.Empty(t, responseCacheDir(&config.MinimalConfig{CacheDir: ".cache", NoCache: true}),
+		"--no-cache should disable a configured cache")
+	assert.Empty(t, createAdapterConfig(&config.MinimalConfig{CacheDir: ".cache", NoCache: true}).CacheDir)
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/misty-step/thinktank/internal/models"
 )
@@ -19,6 +20,84 @@ type SimplifiedConfig struct {
 	MetricsOutput    string // Path for metrics output (empty = disabled)
 	Flags            uint8  // Bitfield for boolean flags
 	SafetyMargin     uint8  // Safety margin percentage (0-50%)
+
+	// Options holds settings that don't fit the flag bitfield.
+	// It stays nil unless one of those flags is given, keeping the common case compact.
+	Options *AdvancedOptions
+}
+
+// AdvancedOptions holds less common settings. Zero values mean "use the default".
+type AdvancedOptions struct {
+	NormalizeLineEndings bool          // Convert CRLF/CR line endings to LF when reading context files
+	Timeout              time.Duration // Bound on the whole run (0 = config.DefaultTimeout)
+	ModelTimeout         time.Duration // Bound on each model's generation (0 = only the run timeout)
+	GatherTimeout        time.Duration // Bound on context gathering (0 = the run timeout)
+	EmbedInstructions    bool          // Prepend the instructions to each output file
+	InstructionsInline   string        // Instructions text used in place of an instructions file
+	CheckpointInterval   time.Duration // How often to log progress while models run (0 = disabled)
+	MaxOutputFileSize    int64         // Truncate output files beyond this many bytes (0 = unlimited)
+	RateLimitWaitBudget  time.Duration // Fail once rate limit waits add up to this (0 = wait indefinitely)
+	PathsFromFile        string        // File listing additional target paths, one per line
+	SkipMissingPaths     bool          // Warn about and skip listed paths that don't exist
+	OutputDir            string        // Write outputs here instead of a new generated directory (empty = auto-create)
+	DirPerms             os.FileMode   // Permissions for created output directories (0 = 0755)
+	FilePerms            os.FileMode   // Permissions for written output files (0 = 0644)
+	StrictOutputDir      bool          // Fail rather than fall back to the temp directory for outputs
+	Force                bool          // Overwrite existing files in --output-dir
+	OutputNameTemplate   string        // Output file name template, e.g. "{timestamp}-{model}.txt" (empty = "{model}.{ext}")
+	CombinedOutput       string        // Write all model outputs to this one file instead of one file each
+	PostProcess          string        // Shell command run on each output file after it is written (empty = none)
+	SummaryFile          string        // Also write the run summary to this markdown file (empty = console only)
+	ListModels           bool          // Print supported models and exit
+	CacheDir             string        // Directory for cached model responses (empty = no caching)
+	NoCache              bool          // Disable response caching even if a cache directory is configured
+	SynthesisModel       string        // Model that combines results (empty = pick from available providers)
+	NoSynthesis          bool          // Never synthesize, even when several models run
+	PromptOrder          string        // Prompt layout: "instructions-last" or "by-directory" (empty = instructions first, gather order)
+	FenceCode            bool          // Wrap each context file in a fenced code block tagged with its language
+	FileSeparator        string        // Decoded text placed between context files in the prompt (empty = blank line)
+	Models               []string      // Models to run, in order, instead of the automatic selection (nil = auto-select)
+	SelectStrategy       string        // How models are chosen automatically: "cheapest", "largest", or "fastest" (empty = the core council)
+	Repeat               int           // Generations per model (0 = one)
+	MaxRetries           *int          // Retries for transient model errors (nil = config.DefaultMaxRetries)
+	RetryBudget          int           // Retries allowed across all models combined (0 = unlimited)
+	Concurrency          *int          // Model requests in flight at once (nil = config.DefaultMaxConcurrentRequests, 0 = unlimited)
+	RetryBaseDelay       time.Duration // Wait before the first retry (0 = config.DefaultRetryBaseDelay)
+	AuditVerbose         bool          // Record complete prompts and responses in the audit log
+	AuditPreviewLength   *int          // Characters of each prompt and response kept otherwise (nil = config.DefaultAuditPreviewLength)
+	PartialSuccessOk     bool          // Exit 0 when some models fail but others succeed
+	FailFast             bool          // Cancel the remaining models when the first one fails
+	Silent               bool          // Write nothing to stdout; errors and warnings go to stderr (implies --quiet)
+	ErrorJSON            bool          // On failure, write a JSON error object to stderr instead of a message
+	Preflight            bool          // Check each provider's API key and reachability before gathering context
+	OTel                 bool          // Emit OpenTelemetry spans and outcome metrics, configured by OTEL_* variables
+	IncludeGlobs         []string      // Only gather files matching one of these globs (repeatable flag)
+	PriorityGlobs        []string      // Keep files matching these globs when trimming the context (repeatable flag)
+	GatherWorkers        int           // Goroutines per context gathering stage (0 = runtime.NumCPU())
+	MaxFileSize          int64         // Skip context files larger than this many bytes (0 = unlimited)
+	FollowSymlinks       bool          // Walk into symlinked directories when gathering context
+	ExcludeGenerated     bool          // Skip context files with a generated-code marker in their header
+	IncludeHidden        bool          // Gather dotfiles and dot-directories (.git is always skipped)
+	ExplainExcludes      bool          // List each excluded path and the filter that excluded it (dry run only)
+	MaxContextTokens     int           // Stop adding context files past this many estimated tokens, instructions included (0 = unlimited)
+	Profile              string        // Named profile from profiles.json supplying defaults (empty = none)
+	AutoTrim             bool          // Drop context files to fit models whose window would overflow
+	OutputFormat         string        // Final summary format: "text" or "json"
+	ProgressFormat       string        // Progress event format: "text" (console only) or "json"
+	ProgressFD           int           // File descriptor for JSON progress events (0 = stderr)
+	ColorMode            string        // "auto", "always", or "never"
+	Theme                string        // Console color palette: "default" or "high-contrast"
+
+	// TemplateVars fills {{.key}} in the instructions (nil = use the file verbatim)
+	TemplateVars map[string]string
+
+	// ProviderBaseURLs sends each listed provider's requests to a custom base URL,
+	// keyed by provider name (nil = provider defaults)
+	ProviderBaseURLs map[string]string
+
+	// ModelParams overrides model parameters, keyed by canonical model name and
+	// then parameter name (nil = model defaults)
+	ModelParams map[string]map[string]any
 }
 
 // Flag constants for bitwise operations - O(1) validation
@@ -55,11 +134,32 @@ func (s *SimplifiedConfig) ClearFlag(flag uint8) {
 	s.Flags &^= flag
 }
 
+// GetOptions returns the advanced options, or zero-valued defaults when none were set
+func (s *SimplifiedConfig) GetOptions() AdvancedOptions {
+	if s.Options == nil {
+		return AdvancedOptions{}
+	}
+	return *s.Options
+}
+
+// ensureOptions returns the advanced options, allocating them on first use
+func (s *SimplifiedConfig) ensureOptions() *AdvancedOptions {
+	if s.Options == nil {
+		s.Options = &AdvancedOptions{}
+	}
+	return s.Options
+}
+
 // HelpRequested returns true if the help flag is set
 func (s *SimplifiedConfig) HelpRequested() bool {
 	return s.HasFlag(FlagHelp)
 }
 
+// ListModelsRequested returns true if --list-models was given
+func (s *SimplifiedConfig) ListModelsRequested() bool {
+	return s.Options != nil && s.Options.ListModels
+}
+
 // Validate performs essential validation with fail-fast behavior.
 // Target: <1ms for typical inputs, ordered by likelihood of failure.
 // Uses lazy evaluation to minimize syscalls and API key checks.
@@ -92,7 +192,12 @@ func (s *SimplifiedConfig) Validate() error {
 
 	// 2. Enhanced positional argument validation with file extension checks
 	// For dry-run mode, only validate if instructions file is provided
-	if s.HasFlag(FlagDryRun) {
+	if s.GetOptions().InstructionsInline != "" {
+		// Inline instructions replace the file, so only the target paths need checking
+		if err := validateTargetPaths(s.TargetPath); err != nil {
+			return err
+		}
+	} else if s.HasFlag(FlagDryRun) {
 		// In dry-run mode, validate positional arguments only if both are non-empty
 		if s.InstructionsFile != "" && s.TargetPath != "" {
 			if err := validatePositionalArgs(s.InstructionsFile, s.TargetPath); err != nil {
@@ -325,16 +430,8 @@ func validatePositionalArgs(instructionsFile, targetPath string) error {
 	}
 
 	// 2. Target path validation - validate each path if multiple
-	targetPaths := strings.Fields(targetPath)
-	if len(targetPaths) == 0 {
-		return fmt.Errorf("no target paths found after parsing")
-	}
-
-	// Validate each target path
-	for _, path := range targetPaths {
-		if err := validateTargetPathAccess(path); err != nil {
-			return err
-		}
+	if err := validateTargetPaths(targetPath); err != nil {
+		return err
 	}
 
 	// 3. Instructions file validation - filesystem checks first (directory check before extension)
@@ -350,6 +447,20 @@ func validatePositionalArgs(instructionsFile, targetPath string) error {
 	return nil
 }
 
+// validateTargetPaths checks that each of the space-joined target paths is accessible
+func validateTargetPaths(targetPath string) error {
+	targetPaths := strings.Fields(targetPath)
+	if len(targetPaths) == 0 {
+		return fmt.Errorf("no target paths found after parsing")
+	}
+	for _, path := range targetPaths {
+		if err := validateTargetPathAccess(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // validateInstructionsFileExtension checks file extension with user-friendly errors
 func validateInstructionsFileExtension(filePath string) error {
 	ext := strings.ToLower(filepath.Ext(filePath))
@@ -3,11 +3,18 @@ package cli
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/misty-step/thinktank/internal/config"
+	"github.com/misty-step/thinktank/internal/fileutil"
+	"github.com/misty-step/thinktank/internal/logutil"
 	"github.com/misty-step/thinktank/internal/models"
+	"github.com/misty-step/thinktank/internal/thinktank/orchestrator"
+	"github.com/misty-step/thinktank/internal/thinktank/prompt"
 )
 
 // ParseSimpleArgs parses the simplified command line interface in O(n) time using os.Args.
@@ -31,6 +38,11 @@ func ParseSimpleArgsWithArgs(args []string) (*SimplifiedConfig, error) {
 					Flags: FlagHelp,
 				}, nil
 			}
+			if arg == "--list-models" {
+				return &SimplifiedConfig{
+					Options: &AdvancedOptions{ListModels: true},
+				}, nil
+			}
 		}
 	}
 
@@ -49,9 +61,21 @@ func ParseSimpleArgsWithArgs(args []string) (*SimplifiedConfig, error) {
 	flags := uint8(0)
 	safetyMargin := uint8(10) // Default 10% safety margin
 
+	// Advanced options are allocated only when one of their flags appears
+	var options *AdvancedOptions
+	advanced := func() *AdvancedOptions {
+		if options == nil {
+			options = &AdvancedOptions{}
+		}
+		return options
+	}
+
 	// Track if we've seen the instructions file
 	seenInstructions := false
 
+	// --dry-run-json is --dry-run with --output-format json
+	dryRunJSON := false
+
 	// Single pass through all arguments - O(n) time complexity
 	for i := 1; i < len(args); i++ {
 		arg := args[i]
@@ -64,6 +88,10 @@ func ParseSimpleArgsWithArgs(args []string) (*SimplifiedConfig, error) {
 		case arg == "--dry-run":
 			flags |= FlagDryRun
 
+		case arg == "--dry-run-json":
+			flags |= FlagDryRun
+			dryRunJSON = true
+
 		case arg == "--verbose":
 			flags |= FlagVerbose
 
@@ -82,6 +110,60 @@ func ParseSimpleArgsWithArgs(args []string) (*SimplifiedConfig, error) {
 		case arg == "--no-progress":
 			flags |= FlagNoProgress
 
+		case arg == "--normalize-newlines":
+			advanced().NormalizeLineEndings = true
+
+		case arg == "--embed-instructions":
+			advanced().EmbedInstructions = true
+
+		case arg == "--skip-missing-paths":
+			advanced().SkipMissingPaths = true
+
+		case arg == "--strict-output-dir":
+			advanced().StrictOutputDir = true
+
+		case arg == "--force":
+			advanced().Force = true
+
+		case arg == "--no-cache":
+			advanced().NoCache = true
+
+		case arg == "--follow-symlinks":
+			advanced().FollowSymlinks = true
+
+		case arg == "--exclude-generated":
+			advanced().ExcludeGenerated = true
+
+		case arg == "--include-hidden":
+			advanced().IncludeHidden = true
+
+		case arg == "--explain-excludes":
+			advanced().ExplainExcludes = true
+
+		case arg == "--auto-trim":
+			advanced().AutoTrim = true
+
+		case arg == "--partial-success-ok":
+			advanced().PartialSuccessOk = true
+
+		case arg == "--fail-fast":
+			advanced().FailFast = true
+
+		case arg == "--no-synthesis":
+			advanced().NoSynthesis = true
+
+		case arg == "--fence-code":
+			advanced().FenceCode = true
+
+		case arg == "--silent":
+			advanced().Silent = true
+
+		case arg == "--error-json":
+			advanced().ErrorJSON = true
+
+		case arg == "--preflight":
+			advanced().Preflight = true
+
 		case arg == "--model":
 			// --model flag requires a value
 			if i+1 >= len(args) {
@@ -89,12 +171,12 @@ func ParseSimpleArgsWithArgs(args []string) (*SimplifiedConfig, error) {
 			}
 			i++ // Skip the model value - we use smart default in ToCliConfig()
 
-		case arg == "--output-dir":
-			// --output-dir flag requires a value
-			if i+1 >= len(args) {
-				return nil, fmt.Errorf("--output-dir flag requires a value")
+		case matchesValueFlag(arg, "--output-dir"):
+			value, err := flagValue(args, &i, "--output-dir")
+			if err != nil {
+				return nil, err
 			}
-			i++ // Skip the output dir value - we use smart default in ToCliConfig()
+			advanced().OutputDir = value
 
 		case arg == "--token-safety-margin":
 			// --token-safety-margin flag requires a value
@@ -116,13 +198,6 @@ func ParseSimpleArgsWithArgs(args []string) (*SimplifiedConfig, error) {
 				return nil, fmt.Errorf("--model flag requires a non-empty value%s", getModelSuggestion())
 			}
 
-		case strings.HasPrefix(arg, "--output-dir="):
-			// Handle --output-dir=value format
-			value := strings.TrimPrefix(arg, "--output-dir=")
-			if value == "" {
-				return nil, fmt.Errorf("--output-dir flag requires a non-empty value")
-			}
-
 		case strings.HasPrefix(arg, "--token-safety-margin="):
 			// Handle --token-safety-margin=value format
 			value := strings.TrimPrefix(arg, "--token-safety-margin=")
@@ -151,6 +226,453 @@ func ParseSimpleArgsWithArgs(args []string) (*SimplifiedConfig, error) {
 			}
 			metricsOutput = value
 
+		case matchesValueFlag(arg, "--template-vars"):
+			value, err := flagValue(args, &i, "--template-vars")
+			if err != nil {
+				return nil, err
+			}
+			key, val, err := parseTemplateVar(value)
+			if err != nil {
+				return nil, err
+			}
+			if advanced().TemplateVars == nil {
+				advanced().TemplateVars = make(map[string]string)
+			}
+			advanced().TemplateVars[key] = val
+
+		case matchesValueFlag(arg, "--profile"):
+			value, err := flagValue(args, &i, "--profile")
+			if err != nil {
+				return nil, err
+			}
+			if strings.TrimSpace(value) == "" {
+				return nil, fmt.Errorf("--profile flag requires a non-empty value")
+			}
+			advanced().Profile = value
+
+		case matchesValueFlag(arg, "--timeout"):
+			value, err := flagValue(args, &i, "--timeout")
+			if err != nil {
+				return nil, err
+			}
+			timeout, err := parsePositiveDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --timeout value: %w", err)
+			}
+			advanced().Timeout = timeout
+
+		case matchesValueFlag(arg, "--model-timeout"):
+			value, err := flagValue(args, &i, "--model-timeout")
+			if err != nil {
+				return nil, err
+			}
+			timeout, err := parsePositiveDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --model-timeout value: %w", err)
+			}
+			advanced().ModelTimeout = timeout
+
+		case matchesValueFlag(arg, "--gather-timeout"):
+			value, err := flagValue(args, &i, "--gather-timeout")
+			if err != nil {
+				return nil, err
+			}
+			timeout, err := parsePositiveDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --gather-timeout value: %w", err)
+			}
+			advanced().GatherTimeout = timeout
+
+		case matchesValueFlag(arg, "--checkpoint-interval"):
+			value, err := flagValue(args, &i, "--checkpoint-interval")
+			if err != nil {
+				return nil, err
+			}
+			interval, err := parsePositiveDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --checkpoint-interval value: %w", err)
+			}
+			advanced().CheckpointInterval = interval
+
+		case matchesValueFlag(arg, "--max-output-file-size"):
+			value, err := flagValue(args, &i, "--max-output-file-size")
+			if err != nil {
+				return nil, err
+			}
+			size, err := parseByteSize(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --max-output-file-size value: %w", err)
+			}
+			advanced().MaxOutputFileSize = size
+
+		case matchesValueFlag(arg, "--dir-perms"):
+			value, err := flagValue(args, &i, "--dir-perms")
+			if err != nil {
+				return nil, err
+			}
+			mode, err := parseFileMode(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --dir-perms value: %w", err)
+			}
+			advanced().DirPerms = mode
+
+		case matchesValueFlag(arg, "--file-perms"):
+			value, err := flagValue(args, &i, "--file-perms")
+			if err != nil {
+				return nil, err
+			}
+			mode, err := parseFileMode(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --file-perms value: %w", err)
+			}
+			advanced().FilePerms = mode
+
+		case matchesValueFlag(arg, "--max-file-size"):
+			value, err := flagValue(args, &i, "--max-file-size")
+			if err != nil {
+				return nil, err
+			}
+			size, err := parseByteSize(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --max-file-size value: %w", err)
+			}
+			advanced().MaxFileSize = size
+
+		case matchesValueFlag(arg, "--rate-limit-wait-budget"):
+			value, err := flagValue(args, &i, "--rate-limit-wait-budget")
+			if err != nil {
+				return nil, err
+			}
+			budget, err := parsePositiveDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --rate-limit-wait-budget value: %w", err)
+			}
+			advanced().RateLimitWaitBudget = budget
+
+		case matchesValueFlag(arg, "--max-retries"):
+			value, err := flagValue(args, &i, "--max-retries")
+			if err != nil {
+				return nil, err
+			}
+			retries, err := strconv.Atoi(value)
+			if err != nil || retries < 0 {
+				return nil, fmt.Errorf("invalid --max-retries value %q: must be a non-negative integer", value)
+			}
+			advanced().MaxRetries = &retries
+
+		case matchesValueFlag(arg, "--retry-budget"):
+			value, err := flagValue(args, &i, "--retry-budget")
+			if err != nil {
+				return nil, err
+			}
+			budget, err := strconv.Atoi(value)
+			if err != nil || budget < 1 {
+				return nil, fmt.Errorf("invalid --retry-budget value %q: must be a positive integer", value)
+			}
+			advanced().RetryBudget = budget
+
+		case matchesValueFlag(arg, "--concurrency"):
+			value, err := flagValue(args, &i, "--concurrency")
+			if err != nil {
+				return nil, err
+			}
+			concurrency, err := strconv.Atoi(value)
+			if err != nil || concurrency < 0 {
+				return nil, fmt.Errorf("invalid --concurrency value %q: must be a non-negative integer", value)
+			}
+			advanced().Concurrency = &concurrency
+
+		case arg == "--audit-verbose":
+			advanced().AuditVerbose = true
+
+		case arg == "--otel":
+			advanced().OTel = true
+
+		case matchesValueFlag(arg, "--audit-preview-length"):
+			value, err := flagValue(args, &i, "--audit-preview-length")
+			if err != nil {
+				return nil, err
+			}
+			length, err := strconv.Atoi(value)
+			if err != nil || length < 0 {
+				return nil, fmt.Errorf("invalid --audit-preview-length value %q: must be a non-negative integer", value)
+			}
+			advanced().AuditPreviewLength = &length
+
+		case matchesValueFlag(arg, "--retry-base-delay"):
+			value, err := flagValue(args, &i, "--retry-base-delay")
+			if err != nil {
+				return nil, err
+			}
+			delay, err := parsePositiveDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --retry-base-delay value: %w", err)
+			}
+			advanced().RetryBaseDelay = delay
+
+		case matchesValueFlag(arg, "--include-glob"):
+			value, err := flagValue(args, &i, "--include-glob")
+			if err != nil {
+				return nil, err
+			}
+			if err := fileutil.ValidateGlob(value); err != nil {
+				return nil, fmt.Errorf("invalid --include-glob pattern %q: %w", value, err)
+			}
+			advanced().IncludeGlobs = append(advanced().IncludeGlobs, value)
+
+		case matchesValueFlag(arg, "--priority-glob"):
+			value, err := flagValue(args, &i, "--priority-glob")
+			if err != nil {
+				return nil, err
+			}
+			if err := fileutil.ValidateGlob(value); err != nil {
+				return nil, fmt.Errorf("invalid --priority-glob pattern %q: %w", value, err)
+			}
+			advanced().PriorityGlobs = append(advanced().PriorityGlobs, value)
+
+		case matchesValueFlag(arg, "--gather-workers"):
+			value, err := flagValue(args, &i, "--gather-workers")
+			if err != nil {
+				return nil, err
+			}
+			workers, err := strconv.Atoi(value)
+			if err != nil || workers < 1 {
+				return nil, fmt.Errorf("invalid --gather-workers value %q: must be a positive integer", value)
+			}
+			advanced().GatherWorkers = workers
+
+		case matchesValueFlag(arg, "--max-context-tokens"):
+			value, err := flagValue(args, &i, "--max-context-tokens")
+			if err != nil {
+				return nil, err
+			}
+			tokens, err := strconv.Atoi(value)
+			if err != nil || tokens < 1 {
+				return nil, fmt.Errorf("invalid --max-context-tokens value %q: must be a positive integer", value)
+			}
+			advanced().MaxContextTokens = tokens
+
+		case matchesValueFlag(arg, "--output-format"):
+			value, err := flagValue(args, &i, "--output-format")
+			if err != nil {
+				return nil, err
+			}
+			if value != config.OutputFormatText && value != config.OutputFormatJSON {
+				return nil, fmt.Errorf("invalid --output-format value %q: must be %q or %q",
+					value, config.OutputFormatText, config.OutputFormatJSON)
+			}
+			advanced().OutputFormat = value
+
+		case matchesValueFlag(arg, "--progress"):
+			value, err := flagValue(args, &i, "--progress")
+			if err != nil {
+				return nil, err
+			}
+			if value != config.OutputFormatText && value != config.OutputFormatJSON {
+				return nil, fmt.Errorf("invalid --progress value %q: must be %q or %q",
+					value, config.OutputFormatText, config.OutputFormatJSON)
+			}
+			advanced().ProgressFormat = value
+
+		case matchesValueFlag(arg, "--progress-fd"):
+			value, err := flagValue(args, &i, "--progress-fd")
+			if err != nil {
+				return nil, err
+			}
+			fd, err := strconv.Atoi(value)
+			if err != nil || fd < 1 {
+				return nil, fmt.Errorf("invalid --progress-fd value %q: must be a positive integer", value)
+			}
+			advanced().ProgressFD = fd
+
+		case matchesValueFlag(arg, "--color"):
+			value, err := flagValue(args, &i, "--color")
+			if err != nil {
+				return nil, err
+			}
+			if value != logutil.ColorAuto && value != logutil.ColorAlways && value != logutil.ColorNever {
+				return nil, fmt.Errorf("invalid --color value %q: must be %q, %q, or %q",
+					value, logutil.ColorAuto, logutil.ColorAlways, logutil.ColorNever)
+			}
+			advanced().ColorMode = value
+
+		case matchesValueFlag(arg, "--theme"):
+			value, err := flagValue(args, &i, "--theme")
+			if err != nil {
+				return nil, err
+			}
+			if value != logutil.ThemeDefault && value != logutil.ThemeHighContrast {
+				return nil, fmt.Errorf("invalid --theme value %q: must be %q or %q",
+					value, logutil.ThemeDefault, logutil.ThemeHighContrast)
+			}
+			advanced().Theme = value
+
+		case matchesValueFlag(arg, "--output-name-template"):
+			value, err := flagValue(args, &i, "--output-name-template")
+			if err != nil {
+				return nil, err
+			}
+			if err := orchestrator.ValidateOutputNameTemplate(value); err != nil {
+				return nil, fmt.Errorf("invalid --output-name-template value: %w", err)
+			}
+			advanced().OutputNameTemplate = value
+
+		case matchesValueFlag(arg, "--combined-output"):
+			value, err := flagValue(args, &i, "--combined-output")
+			if err != nil {
+				return nil, err
+			}
+			if err := orchestrator.ValidateCombinedOutput(value); err != nil {
+				return nil, fmt.Errorf("invalid --combined-output value: %w", err)
+			}
+			advanced().CombinedOutput = value
+
+		case matchesValueFlag(arg, "--post-process"):
+			value, err := flagValue(args, &i, "--post-process")
+			if err != nil {
+				return nil, err
+			}
+			if strings.TrimSpace(value) == "" {
+				return nil, fmt.Errorf("--post-process flag requires a command")
+			}
+			advanced().PostProcess = value
+
+		case matchesValueFlag(arg, "--summary-file"):
+			value, err := flagValue(args, &i, "--summary-file")
+			if err != nil {
+				return nil, err
+			}
+			advanced().SummaryFile = value
+
+		case matchesValueFlag(arg, "--paths-from-file"):
+			value, err := flagValue(args, &i, "--paths-from-file")
+			if err != nil {
+				return nil, err
+			}
+			advanced().PathsFromFile = value
+
+		case matchesValueFlag(arg, "--cache-dir"):
+			value, err := flagValue(args, &i, "--cache-dir")
+			if err != nil {
+				return nil, err
+			}
+			advanced().CacheDir = value
+
+		case matchesValueFlag(arg, "--models"):
+			value, err := flagValue(args, &i, "--models")
+			if err != nil {
+				return nil, err
+			}
+			modelNames, err := parseModelList(value)
+			if err != nil {
+				return nil, err
+			}
+			advanced().Models = append(advanced().Models, modelNames...)
+
+		case matchesValueFlag(arg, "--select"):
+			value, err := flagValue(args, &i, "--select")
+			if err != nil {
+				return nil, err
+			}
+			strategy, err := models.ParseSelectionStrategy(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --select value: %w", err)
+			}
+			advanced().SelectStrategy = string(strategy)
+
+		case matchesValueFlag(arg, "--repeat"):
+			value, err := flagValue(args, &i, "--repeat")
+			if err != nil {
+				return nil, err
+			}
+			repeat, err := strconv.Atoi(value)
+			if err != nil || repeat < 1 {
+				return nil, fmt.Errorf("invalid --repeat value %q: must be a positive integer", value)
+			}
+			advanced().Repeat = repeat
+
+		case matchesValueFlag(arg, "--prompt-order"):
+			value, err := flagValue(args, &i, "--prompt-order")
+			if err != nil {
+				return nil, err
+			}
+			order, err := prompt.ParseOrder(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --prompt-order value: %w", err)
+			}
+			advanced().PromptOrder = string(order)
+
+		case matchesValueFlag(arg, "--file-separator"):
+			value, err := flagValue(args, &i, "--file-separator")
+			if err != nil {
+				return nil, err
+			}
+			separator, err := prompt.ParseFileSeparator(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --file-separator value: %w", err)
+			}
+			advanced().FileSeparator = separator
+
+		case matchesValueFlag(arg, "--synthesis-model"):
+			value, err := flagValue(args, &i, "--synthesis-model")
+			if err != nil {
+				return nil, err
+			}
+			if !models.IsModelSupported(value) {
+				return nil, fmt.Errorf("unknown synthesis model: %s%s", value, getModelSuggestion())
+			}
+			advanced().SynthesisModel = value
+
+		case baseURLFlagProvider(arg) != "":
+			provider := baseURLFlagProvider(arg)
+			name := "--" + provider + "-base-url"
+			if len(models.ListModelsForProvider(provider)) == 0 {
+				return nil, fmt.Errorf("unknown flag: %s (no supported models use provider %q)", name, provider)
+			}
+			value, err := flagValue(args, &i, name)
+			if err != nil {
+				return nil, err
+			}
+			baseURL, err := parseBaseURL(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s value: %w", name, err)
+			}
+			if advanced().ProviderBaseURLs == nil {
+				advanced().ProviderBaseURLs = make(map[string]string)
+			}
+			advanced().ProviderBaseURLs[provider] = baseURL
+
+		case matchesValueFlag(arg, "--model-param"):
+			value, err := flagValue(args, &i, "--model-param")
+			if err != nil {
+				return nil, err
+			}
+			model, key, param, err := parseModelParam(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --model-param value: %w", err)
+			}
+			if advanced().ModelParams == nil {
+				advanced().ModelParams = make(map[string]map[string]any)
+			}
+			if advanced().ModelParams[model] == nil {
+				advanced().ModelParams[model] = make(map[string]any)
+			}
+			advanced().ModelParams[model][key] = param
+
+		case arg == "-i" || matchesValueFlag(arg, "--instructions-inline"):
+			name := "--instructions-inline"
+			if arg == "-i" {
+				name = "-i"
+			}
+			value, err := flagValue(args, &i, name)
+			if err != nil {
+				return nil, err
+			}
+			if strings.TrimSpace(value) == "" {
+				return nil, fmt.Errorf("%s flag requires non-blank instructions", name)
+			}
+			advanced().InstructionsInline = value
+
 		case strings.HasPrefix(arg, "--"):
 			// Unknown flag - fail fast with clear error message
 			return nil, fmt.Errorf("unknown flag: %s", arg)
@@ -173,9 +695,49 @@ func ParseSimpleArgsWithArgs(args []string) (*SimplifiedConfig, error) {
 		}, nil
 	}
 
+	if dryRunJSON {
+		if options != nil && options.OutputFormat == config.OutputFormatText {
+			return nil, fmt.Errorf("--dry-run-json conflicts with --output-format text")
+		}
+		advanced().OutputFormat = config.OutputFormatJSON
+	}
+
+	if options != nil && options.NoSynthesis {
+		if flags&FlagSynthesis != 0 {
+			return nil, fmt.Errorf("--no-synthesis conflicts with --synthesis")
+		}
+		if options.SynthesisModel != "" {
+			return nil, fmt.Errorf("--no-synthesis conflicts with --synthesis-model")
+		}
+	}
+
+	if options != nil && options.ExplainExcludes && flags&FlagDryRun == 0 {
+		return nil, fmt.Errorf("--explain-excludes requires --dry-run")
+	}
+
+	if options != nil && options.ProgressFD > 0 && options.ProgressFormat != config.OutputFormatJSON {
+		return nil, fmt.Errorf("--progress-fd requires --progress=json")
+	}
+
+	// Paths from a list file complement the positional target paths
+	if options != nil && options.PathsFromFile != "" {
+		listed, err := readPathsFile(options.PathsFromFile, options.SkipMissingPaths, os.Stderr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --paths-from-file value: %w", err)
+		}
+		targetPaths = append(targetPaths, listed...)
+	}
+
+	// Inline instructions take the place of the instructions file, so every
+	// positional argument is a target path
+	if options != nil && options.InstructionsInline != "" && instructionsFile != "" {
+		targetPaths = append([]string{instructionsFile}, targetPaths...)
+		instructionsFile = ""
+	}
+
 	// Validate we have the required positional arguments
-	if instructionsFile == "" {
-		return nil, fmt.Errorf("instructions file required")
+	if instructionsFile == "" && (options == nil || options.InstructionsInline == "") {
+		return nil, fmt.Errorf("instructions file required (or pass them with --instructions-inline)")
 	}
 	if len(targetPaths) == 0 {
 		return nil, fmt.Errorf("at least one target path required")
@@ -191,6 +753,7 @@ func ParseSimpleArgsWithArgs(args []string) (*SimplifiedConfig, error) {
 		MetricsOutput:    metricsOutput,
 		Flags:            flags,
 		SafetyMargin:     safetyMargin,
+		Options:          options,
 	}
 
 	// Skip validation if help is requested
@@ -204,6 +767,117 @@ func ParseSimpleArgsWithArgs(args []string) (*SimplifiedConfig, error) {
 	return config, nil
 }
 
+// matchesValueFlag reports whether arg is the named flag in "--name" or "--name=value" form
+func matchesValueFlag(arg, name string) bool {
+	return arg == name || strings.HasPrefix(arg, name+"=")
+}
+
+// flagValue extracts the value of a flag given as "--name value" or "--name=value".
+// It advances i past a separate value argument and rejects empty values.
+func flagValue(args []string, i *int, name string) (string, error) {
+	if args[*i] == name {
+		if *i+1 >= len(args) {
+			return "", fmt.Errorf("%s flag requires a value", name)
+		}
+		*i++
+		return args[*i], nil
+	}
+	value := strings.TrimPrefix(args[*i], name+"=")
+	if value == "" {
+		return "", fmt.Errorf("%s flag requires a non-empty value", name)
+	}
+	return value, nil
+}
+
+// parsePositiveDuration parses a Go duration string (e.g. "30s", "2m") that must be greater than zero
+func parsePositiveDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration must be positive, got %v", d)
+	}
+	return d, nil
+}
+
+// parseFileMode parses octal permission bits such as 0755, 755, or 0o644
+func parseFileMode(value string) (os.FileMode, error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(value), "0o"), "0O")
+	bits, err := strconv.ParseUint(digits, 8, 32)
+	if err != nil || digits == "" {
+		return 0, fmt.Errorf("expected octal permissions such as 0755, got %q", value)
+	}
+	if bits == 0 || bits > 0777 {
+		return 0, fmt.Errorf("permissions must be between 0001 and 0777, got %q", value)
+	}
+	return os.FileMode(bits), nil
+}
+
+// parseByteSize parses a size flag with config.ParseByteSize and rejects zero
+func parseByteSize(value string) (int64, error) {
+	size, err := config.ParseByteSize(value)
+	if err != nil {
+		return 0, err
+	}
+	if size == 0 {
+		return 0, fmt.Errorf("size must be positive, got %q", value)
+	}
+	return size, nil
+}
+
+// baseURLFlagProvider returns the provider named by a --<provider>-base-url flag,
+// or "" when arg is not one
+func baseURLFlagProvider(arg string) string {
+	name, _, _ := strings.Cut(arg, "=")
+	if !strings.HasPrefix(name, "--") || !strings.HasSuffix(name, "-base-url") {
+		return ""
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(name, "--"), "-base-url")
+}
+
+// parseBaseURL validates an absolute http or https URL and drops any trailing
+// slash, since providers append their API paths to it
+func parseBaseURL(value string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(value))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("expected an http or https URL such as https://gateway.example.com/v1, got %q", value)
+	}
+	return strings.TrimRight(parsed.String(), "/"), nil
+}
+
+// parseModelParam parses a model:key=value override. The model may be an alias and
+// is returned by its canonical name. The value is typed as an integer, float, or
+// boolean when it parses as one and is otherwise kept as a string; the parameter
+// is checked with models.ValidateModelParams.
+func parseModelParam(value string) (model, key string, param any, err error) {
+	target, raw, hasValue := strings.Cut(value, "=")
+	sep := strings.LastIndex(target, ":")
+	if !hasValue || sep <= 0 || sep == len(target)-1 || strings.TrimSpace(raw) == "" {
+		return "", "", nil, fmt.Errorf("expected model:key=value such as gpt-5.2:temperature=0.2, got %q", value)
+	}
+	model, key, raw = strings.TrimSpace(target[:sep]), strings.TrimSpace(target[sep+1:]), strings.TrimSpace(raw)
+
+	info, err := models.GetModelInfo(model)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("%w%s", err, getModelSuggestion())
+	}
+
+	param = raw
+	if n, convErr := strconv.Atoi(raw); convErr == nil {
+		param = n
+	} else if f, convErr := strconv.ParseFloat(raw, 64); convErr == nil {
+		param = f
+	} else if raw == "true" || raw == "false" {
+		param = raw == "true"
+	}
+
+	if err := models.ValidateModelParams(info.Name, map[string]any{key: param}); err != nil {
+		return "", "", nil, err
+	}
+	return info.Name, key, param, nil
+}
+
 // getModelSuggestion returns a formatted suggestion of popular models
 func getModelSuggestion() string {
 	popularModels := models.GetCoreCouncilModels()
@@ -219,6 +893,31 @@ func getModelSuggestion() string {
 	return suggestion
 }
 
+// parseModelList splits a comma-separated --models value, requiring each name to be a
+// supported model or alias. Unknown names are reported together with the valid models.
+func parseModelList(value string) ([]string, error) {
+	var modelNames, unknown []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, err := models.GetModelInfo(name); err != nil {
+			unknown = append(unknown, name)
+			continue
+		}
+		modelNames = append(modelNames, name)
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown model(s) in --models: %s\n\nValid models:\n  %s",
+			strings.Join(unknown, ", "), strings.Join(models.ListAllModels(), "\n  "))
+	}
+	if len(modelNames) == 0 {
+		return nil, fmt.Errorf("--models requires at least one model name")
+	}
+	return modelNames, nil
+}
+
 // SimpleParseResult represents the outcome of argument parsing with structured error handling
 type SimpleParseResult struct {
 	Config *SimplifiedConfig
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 	"testing/quick"
+	"time"
 
 	"github.com/misty-step/thinktank/internal/testutil/perftest"
 )
@@ -92,6 +93,35 @@ func TestParseSimpleArgsWithArgs_Basic(t *testing.T) {
 	}
 }
 
+// TestParseSimpleArgsWithArgs_CompletionInstructionsFile tests that "thinktank
+// completion src" is parsed as an instructions file and target, not as a request
+// for completion in a shell named src
+func TestParseSimpleArgsWithArgs_CompletionInstructionsFile(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "completion"), []byte("test instructions"), 0644); err != nil {
+		t.Fatalf("Failed to create test instructions file: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(tempDir, "src"), 0755); err != nil {
+		t.Fatalf("Failed to create test target directory: %v", err)
+	}
+	t.Chdir(tempDir)
+
+	args := []string{"thinktank", "completion", "src"}
+	if shell, ok := isCompletionRequested(args); ok {
+		t.Fatalf("isCompletionRequested(%v) = %q, true; want normal argument parsing", args, shell)
+	}
+
+	// Instructions files need a .txt or .md extension, so parsing reports that
+	// rather than an unsupported shell
+	_, err := ParseSimpleArgsWithArgs(args)
+	if err == nil {
+		t.Fatalf("ParseSimpleArgsWithArgs() expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "instructions file missing extension") || !strings.Contains(err.Error(), "completion") {
+		t.Errorf("ParseSimpleArgsWithArgs() error = %q, want the instructions file error for completion", err.Error())
+	}
+}
+
 // TestParseSimpleArgsWithArgs_BooleanFlags tests all boolean flag combinations
 func TestParseSimpleArgsWithArgs_BooleanFlags(t *testing.T) {
 	// Create test files for validation
@@ -214,6 +244,7 @@ func TestParseSimpleArgsWithArgs_ValueFlags(t *testing.T) {
 	if err := os.MkdirAll(testTargetDir, 0755); err != nil {
 		t.Fatalf("Failed to create test target directory: %v", err)
 	}
+	concurrency := 16
 
 	tests := []struct {
 		name        string
@@ -250,6 +281,7 @@ func TestParseSimpleArgsWithArgs_ValueFlags(t *testing.T) {
 				TargetPath:       testTargetDir,
 				Flags:            FlagDryRun,
 				SafetyMargin:     10, // Default safety margin
+				Options:          &AdvancedOptions{OutputDir: "./out"},
 			},
 		},
 		{
@@ -260,6 +292,7 @@ func TestParseSimpleArgsWithArgs_ValueFlags(t *testing.T) {
 				TargetPath:       testTargetDir,
 				Flags:            FlagDryRun,
 				SafetyMargin:     10, // Default safety margin
+				Options:          &AdvancedOptions{OutputDir: "./out"},
 			},
 		},
 		{
@@ -270,8 +303,933 @@ func TestParseSimpleArgsWithArgs_ValueFlags(t *testing.T) {
 				TargetPath:       testTargetDir,
 				Flags:            FlagVerbose | FlagDryRun,
 				SafetyMargin:     10, // Default safety margin
+				Options:          &AdvancedOptions{OutputDir: "./out"},
+			},
+		},
+		{
+			name: "normalize_newlines_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--normalize-newlines", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10, // Default safety margin
+				Options:          &AdvancedOptions{NormalizeLineEndings: true},
+			},
+		},
+		{
+			name: "embed_instructions_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--embed-instructions", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10, // Default safety margin
+				Options:          &AdvancedOptions{EmbedInstructions: true},
+			},
+		},
+		{
+			name: "checkpoint_interval_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--checkpoint-interval=1m", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10, // Default safety margin
+				Options:          &AdvancedOptions{CheckpointInterval: time.Minute},
+			},
+		},
+		{
+			name: "max_output_file_size_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--max-output-file-size", "1048576", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10, // Default safety margin
+				Options:          &AdvancedOptions{MaxOutputFileSize: 1048576},
+			},
+		},
+		{
+			name: "max_output_file_size_human_size",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--max-output-file-size=1MiB", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10, // Default safety margin
+				Options:          &AdvancedOptions{MaxOutputFileSize: 1 << 20},
+			},
+		},
+		{
+			name: "strict_output_dir_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--strict-output-dir", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{StrictOutputDir: true},
+			},
+		},
+		{
+			name: "force_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--output-dir", testTargetDir, "--force", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{OutputDir: testTargetDir, Force: true},
+			},
+		},
+		{
+			name: "cache_flags",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--cache-dir", ".cache", "--no-cache", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{CacheDir: ".cache", NoCache: true},
+			},
+		},
+		{
+			name: "synthesis_model_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--synthesis-model", "gpt-5.2", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{SynthesisModel: "gpt-5.2"},
+			},
+		},
+		{
+			name: "retry_flags",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--max-retries", "0", "--retry-base-delay=500ms", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{MaxRetries: new(int), RetryBaseDelay: 500 * time.Millisecond},
+			},
+		},
+		{
+			name: "retry_budget_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--retry-budget", "10", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{RetryBudget: 10},
+			},
+		},
+		{
+			name: "repeat_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--repeat", "3", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{Repeat: 3},
+			},
+		},
+		{
+			name: "otel_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--otel", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{OTel: true},
+			},
+		},
+		{
+			name: "follow_symlinks_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--follow-symlinks", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{FollowSymlinks: true},
+			},
+		},
+		{
+			name: "auto_trim_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--auto-trim", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{AutoTrim: true},
+			},
+		},
+		{
+			name: "output_format_json",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--output-format=json", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{OutputFormat: "json"},
+			},
+		},
+		{
+			name: "progress_json_with_fd",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--progress=json", "--progress-fd", "3", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{ProgressFormat: "json", ProgressFD: 3},
+			},
+		},
+		{
+			name: "color_and_theme_flags",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--color", "never", "--theme=high-contrast", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{ColorMode: "never", Theme: "high-contrast"},
+			},
+		},
+		{
+			name: "timeout_flags",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--timeout", "20m", "--model-timeout=90s", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{Timeout: 20 * time.Minute, ModelTimeout: 90 * time.Second},
+			},
+		},
+		{
+			name: "profile_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--profile", "ci", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{Profile: "ci"},
+			},
+		},
+		{
+			name: "template_vars_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--template-vars", "repo=api", "--template-vars=date=2026-10-16", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{TemplateVars: map[string]string{"repo": "api", "date": "2026-10-16"}},
+			},
+		},
+		{
+			name: "output_name_template_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--output-name-template", "{timestamp}-{model}.txt", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{OutputNameTemplate: "{timestamp}-{model}.txt"},
+			},
+		},
+		{
+			name: "dry_run_json_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--dry-run-json"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{OutputFormat: "json"},
+			},
+		},
+		{
+			name: "preflight_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--preflight", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{Preflight: true},
+			},
+		},
+		{
+			name: "models_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--models", "gpt-5.2, gemini-3-flash", "--models=grok-4.1-fast", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{Models: []string{"gpt-5.2", "gemini-3-flash", "grok-4.1-fast"}},
 			},
 		},
+		{
+			name: "select_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--select=Cheapest", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{SelectStrategy: "cheapest"},
+			},
+		},
+		{
+			name: "error_json_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--error-json", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{ErrorJSON: true},
+			},
+		},
+		{
+			name: "combined_output_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--combined-output", "all.md", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{CombinedOutput: "all.md"},
+			},
+		},
+		{
+			name: "post_process_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--post-process", "prettier --write", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{PostProcess: "prettier --write"},
+			},
+		},
+		{
+			name: "summary_file_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--summary-file=summary.md", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{SummaryFile: "summary.md"},
+			},
+		},
+		{
+			name: "dir_and_file_perms_flags",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--dir-perms", "0775", "--file-perms=0o664", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{DirPerms: 0775, FilePerms: 0664},
+			},
+		},
+		{
+			name: "audit_verbose_and_preview_length_flags",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--audit-verbose", "--audit-preview-length=0", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{AuditVerbose: true, AuditPreviewLength: new(int)},
+			},
+		},
+		{
+			name: "provider_base_url_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--openrouter-base-url", "https://gateway.example.com/api/v1/", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{ProviderBaseURLs: map[string]string{"openrouter": "https://gateway.example.com/api/v1"}},
+			},
+		},
+		{
+			name: "partial_success_ok_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--partial-success-ok", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{PartialSuccessOk: true},
+			},
+		},
+		{
+			name: "fail_fast_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--fail-fast", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{FailFast: true},
+			},
+		},
+		{
+			name: "silent_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--silent", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{Silent: true},
+			},
+		},
+		{
+			name: "no_synthesis_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--no-synthesis", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{NoSynthesis: true},
+			},
+		},
+		{
+			name: "prompt_order_and_fence_code_flags",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--prompt-order=instructions-last", "--fence-code", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{PromptOrder: "instructions-last", FenceCode: true},
+			},
+		},
+		{
+			name: "file_separator_flag_decodes_escapes",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--file-separator", `\n---\n`, "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{FileSeparator: "\n---\n"},
+			},
+		},
+		{
+			name: "exclude_generated_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--exclude-generated", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{ExcludeGenerated: true},
+			},
+		},
+		{
+			name: "include_hidden_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--include-hidden", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{IncludeHidden: true},
+			},
+		},
+		{
+			name: "explain_excludes_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--dry-run", "--explain-excludes"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{ExplainExcludes: true},
+			},
+		},
+		{
+			name: "max_context_tokens_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--max-context-tokens=100000", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{MaxContextTokens: 100000},
+			},
+		},
+		{
+			name: "model_param_flag_repeats",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--model-param", "gpt-5.2:temperature=0.2", "--model-param=gpt:max_tokens=4000", "--model-param", "opus:reasoning=high", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options: &AdvancedOptions{ModelParams: map[string]map[string]any{
+					"gpt-5.2":         {"temperature": 0.2, "max_tokens": 4000},
+					"claude-opus-4.5": {"reasoning": "high"},
+				}},
+			},
+		},
+		{
+			name: "instructions_inline_flag",
+			args: []string{"thinktank", "--instructions-inline=Review for bugs", testTargetDir, "--dry-run"},
+			want: &SimplifiedConfig{
+				TargetPath:   testTargetDir,
+				Flags:        FlagDryRun,
+				SafetyMargin: 10,
+				Options:      &AdvancedOptions{InstructionsInline: "Review for bugs"},
+			},
+		},
+		{
+			name: "instructions_inline_short_flag_makes_every_positional_a_target",
+			args: []string{"thinktank", testTargetDir, testInstructionsFile, "-i", "Review", "--dry-run"},
+			want: &SimplifiedConfig{
+				TargetPath:   testTargetDir + " " + testInstructionsFile,
+				Flags:        FlagDryRun,
+				SafetyMargin: 10,
+				Options:      &AdvancedOptions{InstructionsInline: "Review"},
+			},
+		},
+		{
+			name: "concurrency_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--concurrency", "16", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{Concurrency: &concurrency},
+			},
+		},
+		{
+			name: "concurrency_flag_zero_is_unlimited",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--concurrency=0", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{Concurrency: new(int)},
+			},
+		},
+		{
+			name: "include_glob_flag_repeats",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--include-glob", "src/**/*.go", "--include-glob=**/*_test.go", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{IncludeGlobs: []string{"src/**/*.go", "**/*_test.go"}},
+			},
+		},
+		{
+			name: "priority_glob_flag_repeats",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--priority-glob", "src/core/**", "--priority-glob=**/*.go", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10,
+				Options:          &AdvancedOptions{PriorityGlobs: []string{"src/core/**", "**/*.go"}},
+			},
+		},
+		{
+			name: "gather_timeout_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--gather-timeout", "30s", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10, // Default safety margin
+				Options:          &AdvancedOptions{GatherTimeout: 30 * time.Second},
+			},
+		},
+		{
+			name: "gather_workers_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--gather-workers=4", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10, // Default safety margin
+				Options:          &AdvancedOptions{GatherWorkers: 4},
+			},
+		},
+		{
+			name: "max_file_size_human_size",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--max-file-size", "2MB", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10, // Default safety margin
+				Options:          &AdvancedOptions{MaxFileSize: 2_000_000},
+			},
+		},
+		{
+			name: "max_file_size_bytes",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--max-file-size=512k", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10, // Default safety margin
+				Options:          &AdvancedOptions{MaxFileSize: 512 << 10},
+			},
+		},
+		{
+			name:        "max_file_size_invalid",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--max-file-size", "lots"},
+			wantErr:     true,
+			errContains: "invalid --max-file-size value",
+		},
+		{
+			name:        "gather_workers_zero",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--gather-workers", "0"},
+			wantErr:     true,
+			errContains: "invalid --gather-workers value",
+		},
+		{
+			name:        "output_format_unknown",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--output-format", "yaml"},
+			wantErr:     true,
+			errContains: "invalid --output-format value",
+		},
+		{
+			name:        "progress_unknown_format",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--progress", "xml"},
+			wantErr:     true,
+			errContains: "invalid --progress value",
+		},
+		{
+			name:        "progress_fd_without_json",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--progress-fd=3"},
+			wantErr:     true,
+			errContains: "--progress-fd requires --progress=json",
+		},
+		{
+			name:        "color_unknown_mode",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--color=sometimes"},
+			wantErr:     true,
+			errContains: "invalid --color value",
+		},
+		{
+			name:        "theme_unknown",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--theme", "neon"},
+			wantErr:     true,
+			errContains: "invalid --theme value",
+		},
+		{
+			name:        "timeout_invalid_duration",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--timeout", "soon"},
+			wantErr:     true,
+			errContains: "invalid --timeout value",
+		},
+		{
+			name:        "model_timeout_not_positive",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--model-timeout=0s"},
+			wantErr:     true,
+			errContains: "invalid --model-timeout value",
+		},
+		{
+			name:        "profile_empty_name",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--profile", " "},
+			wantErr:     true,
+			errContains: "--profile flag requires a non-empty value",
+		},
+		{
+			name:        "template_vars_missing_equals",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--template-vars", "repo"},
+			wantErr:     true,
+			errContains: "expected key=value",
+		},
+		{
+			name:        "template_vars_invalid_key",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--template-vars=1st=api"},
+			wantErr:     true,
+			errContains: "invalid --template-vars key",
+		},
+		{
+			name:        "output_name_template_without_model",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--output-name-template={timestamp}.txt"},
+			wantErr:     true,
+			errContains: "invalid --output-name-template value",
+		},
+		{
+			name:        "dry_run_json_with_text_output_format",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--dry-run-json", "--output-format=text"},
+			wantErr:     true,
+			errContains: "--dry-run-json conflicts with --output-format text",
+		},
+		{
+			name:        "models_unknown_model",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--models", "gpt-5.2,not-a-model"},
+			wantErr:     true,
+			errContains: "unknown model(s) in --models: not-a-model",
+		},
+		{
+			name:        "models_empty_list",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--models", " , "},
+			wantErr:     true,
+			errContains: "--models requires at least one model name",
+		},
+		{
+			name:        "select_unknown_strategy",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--select", "smartest"},
+			wantErr:     true,
+			errContains: "invalid --select value",
+		},
+		{
+			name:        "combined_output_manifest_name",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--combined-output=manifest.json"},
+			wantErr:     true,
+			errContains: "invalid --combined-output value",
+		},
+		{
+			name:        "post_process_blank",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--post-process", "  "},
+			wantErr:     true,
+			errContains: "--post-process flag requires a command",
+		},
+		{
+			name:        "dir_perms_not_octal",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--dir-perms", "0789"},
+			wantErr:     true,
+			errContains: "invalid --dir-perms value: expected octal permissions",
+		},
+		{
+			name:        "file_perms_out_of_range",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--file-perms=01777"},
+			wantErr:     true,
+			errContains: "invalid --file-perms value: permissions must be between 0001 and 0777",
+		},
+		{
+			name:        "file_perms_missing_value",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--file-perms"},
+			wantErr:     true,
+			errContains: "--file-perms flag requires a value",
+		},
+		{
+			name:        "audit_preview_length_negative",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--audit-preview-length", "-5"},
+			wantErr:     true,
+			errContains: "invalid --audit-preview-length value",
+		},
+		{
+			name:        "base_url_unknown_provider",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--openai-base-url", "https://gateway.example.com/v1"},
+			wantErr:     true,
+			errContains: `unknown flag: --openai-base-url (no supported models use provider "openai")`,
+		},
+		{
+			name:        "base_url_not_http",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--openrouter-base-url=ftp://gateway.example.com"},
+			wantErr:     true,
+			errContains: "invalid --openrouter-base-url value",
+		},
+		{
+			name:        "base_url_missing_value",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--openrouter-base-url"},
+			wantErr:     true,
+			errContains: "--openrouter-base-url flag requires a value",
+		},
+		{
+			name:        "no_synthesis_conflicts_with_synthesis",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--synthesis", "--no-synthesis"},
+			wantErr:     true,
+			errContains: "--no-synthesis conflicts with --synthesis",
+		},
+		{
+			name:        "no_synthesis_conflicts_with_synthesis_model",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--no-synthesis", "--synthesis-model", "gpt-5.2"},
+			wantErr:     true,
+			errContains: "--no-synthesis conflicts with --synthesis-model",
+		},
+		{
+			name:        "file_separator_bad_escape",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--file-separator", `\q`},
+			wantErr:     true,
+			errContains: "invalid --file-separator value",
+		},
+		{
+			name:        "prompt_order_unknown",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--prompt-order", "random"},
+			wantErr:     true,
+			errContains: "invalid --prompt-order value",
+		},
+		{
+			name:        "explain_excludes_requires_dry_run",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--explain-excludes"},
+			wantErr:     true,
+			errContains: "--explain-excludes requires --dry-run",
+		},
+		{
+			name:        "max_context_tokens_not_positive",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--max-context-tokens", "0"},
+			wantErr:     true,
+			errContains: "invalid --max-context-tokens value",
+		},
+		{
+			name:        "model_param_malformed",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--model-param", "gpt-5.2=0.2"},
+			wantErr:     true,
+			errContains: "expected model:key=value",
+		},
+		{
+			name:        "model_param_unknown_model",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--model-param", "nope:temperature=0.2"},
+			wantErr:     true,
+			errContains: "unknown model: nope",
+		},
+		{
+			name:        "model_param_out_of_range",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--model-param", "opus:temperature=1.5"},
+			wantErr:     true,
+			errContains: "invalid model parameters for model claude-opus-4.5",
+		},
+		{
+			name:        "instructions_inline_blank",
+			args:        []string{"thinktank", "./src", "-i", "  "},
+			wantErr:     true,
+			errContains: "-i flag requires non-blank instructions",
+		},
+		{
+			name:        "instructions_inline_missing_value",
+			args:        []string{"thinktank", "./src", "--instructions-inline"},
+			wantErr:     true,
+			errContains: "--instructions-inline flag requires a value",
+		},
+		{
+			name:        "concurrency_negative",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--concurrency", "-1"},
+			wantErr:     true,
+			errContains: "invalid --concurrency value",
+		},
+		{
+			name:        "gather_timeout_invalid_duration",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--gather-timeout=soon"},
+			wantErr:     true,
+			errContains: "invalid --gather-timeout value",
+		},
+		{
+			name:        "gather_timeout_zero",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--gather-timeout", "0s"},
+			wantErr:     true,
+			errContains: "duration must be positive",
+		},
+		{
+			name:        "gather_timeout_missing_value",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--gather-timeout"},
+			wantErr:     true,
+			errContains: "--gather-timeout flag requires a value",
+		},
+		{
+			name:        "max_output_file_size_not_a_size",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--max-output-file-size=lots"},
+			wantErr:     true,
+			errContains: "invalid --max-output-file-size value",
+		},
+		{
+			name:        "max_output_file_size_zero",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--max-output-file-size", "0"},
+			wantErr:     true,
+			errContains: "size must be positive",
+		},
+		{
+			name: "rate_limit_wait_budget_flag",
+			args: []string{"thinktank", testInstructionsFile, testTargetDir, "--rate-limit-wait-budget", "2m", "--dry-run"},
+			want: &SimplifiedConfig{
+				InstructionsFile: testInstructionsFile,
+				TargetPath:       testTargetDir,
+				Flags:            FlagDryRun,
+				SafetyMargin:     10, // Default safety margin
+				Options:          &AdvancedOptions{RateLimitWaitBudget: 2 * time.Minute},
+			},
+		},
+		{
+			name:        "cache_dir_missing_value",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--cache-dir"},
+			wantErr:     true,
+			errContains: "--cache-dir",
+		},
+		{
+			name:        "synthesis_model_missing_value",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--synthesis-model"},
+			wantErr:     true,
+			errContains: "--synthesis-model",
+		},
+		{
+			name:        "synthesis_model_unknown",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--synthesis-model=not-a-model"},
+			wantErr:     true,
+			errContains: "unknown synthesis model: not-a-model",
+		},
+		{
+			name:        "max_retries_negative",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--max-retries=-1"},
+			wantErr:     true,
+			errContains: "invalid --max-retries value",
+		},
+		{
+			name:        "max_retries_not_a_number",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--max-retries", "few"},
+			wantErr:     true,
+			errContains: "invalid --max-retries value",
+		},
+		{
+			name:        "retry_budget_zero",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--retry-budget=0"},
+			wantErr:     true,
+			errContains: "invalid --retry-budget value",
+		},
+		{
+			name:        "repeat_zero",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--repeat=0"},
+			wantErr:     true,
+			errContains: "invalid --repeat value",
+		},
+		{
+			name:        "retry_base_delay_invalid",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--retry-base-delay", "0s"},
+			wantErr:     true,
+			errContains: "invalid --retry-base-delay value",
+		},
+		{
+			name:        "include_glob_malformed",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--include-glob", "src/["},
+			wantErr:     true,
+			errContains: "invalid --include-glob pattern",
+		},
+		{
+			name:        "priority_glob_invalid",
+			args:        []string{"thinktank", "instructions.txt", "./src", "--priority-glob", "src/["},
+			wantErr:     true,
+			errContains: "invalid --priority-glob pattern",
+		},
 		{
 			name:        "model_flag_missing_value",
 			args:        []string{"thinktank", "instructions.txt", "./src", "--model"},
@@ -378,6 +1336,7 @@ func TestParseSimpleArgsWithArgs_EdgeCases(t *testing.T) {
 				TargetPath:       testTargetDir,
 				Flags:            FlagDryRun | FlagVerbose | FlagSynthesis,
 				SafetyMargin:     10, // Default safety margin
+				Options:          &AdvancedOptions{OutputDir: "./results"},
 			},
 		},
 		{
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// byteSizeUnits maps size suffixes to multipliers, longest first so "KIB" wins over
+// "B". KB, MB, GB and TB are decimal (SI) units and KiB, MiB, GiB and TiB binary
+// ones. A bare K, M, G or T is binary, matching logutil.FormatFileSize, so a size
+// thinktank prints (e.g. "4.2K") parses back to about the same byte count.
+var byteSizeUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"KIB", 1 << 10}, {"MIB", 1 << 20}, {"GIB", 1 << 30}, {"TIB", 1 << 40},
+	{"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12},
+	{"K", 1 << 10}, {"M", 1 << 20}, {"G", 1 << 30}, {"T", 1 << 40},
+	{"B", 1},
+}
+
+// ParseByteSize parses a human-readable size such as "512", "512K", "2MB", "1GiB"
+// or "1.5G" into bytes. Suffixes are case-insensitive and may follow the number
+// after a space; a bare number is a byte count. KB, MB, GB and TB are powers of
+// 1000; KiB, MiB, GiB and TiB, and K, M, G and T on their own, are powers of 1024.
+// Fractional sizes are rounded to the nearest byte. Zero is allowed; callers that
+// need a positive size check for it.
+func ParseByteSize(value string) (int64, error) {
+	number, multiplier := strings.TrimSpace(value), int64(1)
+	upper := strings.ToUpper(number)
+	for _, unit := range byteSizeUnits {
+		if strings.HasSuffix(upper, unit.suffix) {
+			number, multiplier = strings.TrimSpace(number[:len(number)-len(unit.suffix)]), unit.multiplier
+			break
+		}
+	}
+
+	if strings.HasPrefix(number, "-") {
+		return 0, fmt.Errorf("size must not be negative, got %q", value)
+	}
+	if !isDecimalNumber(number) {
+		return 0, fmt.Errorf("expected a size such as 500K or 2MB, got %q", value)
+	}
+
+	// Whole numbers stay in integer arithmetic so large sizes are exact
+	if !strings.Contains(number, ".") {
+		n, err := strconv.ParseInt(number, 10, 64)
+		if err != nil || n > math.MaxInt64/multiplier {
+			return 0, fmt.Errorf("size %q is too large", value)
+		}
+		return n * multiplier, nil
+	}
+
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("expected a size such as 500K or 2MB, got %q", value)
+	}
+	bytes := math.Round(n * float64(multiplier))
+	if bytes >= math.MaxInt64 {
+		return 0, fmt.Errorf("size %q is too large", value)
+	}
+	return int64(bytes), nil
+}
+
+// isDecimalNumber reports whether s is digits with at most one decimal point,
+// which rules out the signs, exponents and hex forms strconv would accept
+func isDecimalNumber(s string) bool {
+	digits, points := 0, 0
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '.':
+			points++
+		default:
+			return false
+		}
+	}
+	return digits > 0 && points <= 1
+}
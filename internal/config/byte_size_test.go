@@ -0,0 +1,93 @@
+package config
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/misty-step/thinktank/internal/logutil"
+)
+
+func TestParseByteSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value       string
+		want        int64
+		errContains string
+	}{
+		// Valid sizes
+		{value: "0", want: 0},
+		{value: "512", want: 512},
+		{value: "512B", want: 512},
+		{value: "512K", want: 512 << 10},
+		{value: "512k", want: 512 << 10},
+		{value: "2KB", want: 2000},
+		{value: "2kib", want: 2 << 10},
+		{value: "2MB", want: 2_000_000},
+		{value: "2M", want: 2 << 20},
+		{value: "2MiB", want: 2 << 20},
+		{value: "1GiB", want: 1 << 30},
+		{value: "1gb", want: 1_000_000_000},
+		{value: "3T", want: 3 << 40},
+		{value: "1TiB", want: 1 << 40},
+		{value: "1.5K", want: 1536},
+		{value: "0.5M", want: 512 << 10},
+		{value: ".5K", want: 512},
+		{value: "1.", want: 1},
+		{value: "4.2K", want: 4301},
+		{value: " 2 MB ", want: 2_000_000},
+		{value: "1.5TB", want: 1_500_000_000_000},
+		{value: "2 MiB", want: 2 << 20},
+		{value: "9223372036854775807", want: math.MaxInt64},
+
+		// Invalid sizes
+		{value: "", errContains: "expected a size"},
+		{value: "MB", errContains: "expected a size"},
+		{value: "lots", errContains: "expected a size"},
+		{value: "2XB", errContains: "expected a size"},
+		{value: "2 M B", errContains: "expected a size"},
+		{value: "1.2.3K", errContains: "expected a size"},
+		{value: "1e3", errContains: "expected a size"},
+		{value: "0x10", errContains: "expected a size"},
+		{value: "+5K", errContains: "expected a size"},
+		{value: "-5K", errContains: "must not be negative"},
+		{value: "9223372036854775808", errContains: "too large"},
+		{value: "8388608T", errContains: "too large"},
+		{value: "8388608.5T", errContains: "too large"},
+		{value: "9223373TB", errContains: "too large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseByteSize(tt.value)
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("ParseByteSize(%q) = %d, %v; want error containing %q", tt.value, got, err, tt.errContains)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseByteSize(%q) = %d, %v; want %d", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
+
+// Sizes printed by logutil.FormatFileSize parse back to within the printed precision
+func TestParseByteSizeFormatFileSizeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, size := range []int64{0, 1, 1023, 1024, 1536, 4300, 2 << 20, 5<<30 + 123456, 3 << 40} {
+		printed := logutil.FormatFileSize(size)
+		got, err := ParseByteSize(printed)
+		if err != nil {
+			t.Fatalf("ParseByteSize(%q) returned error: %v", printed, err)
+		}
+		// One decimal place of the printed unit is the worst-case rounding error
+		if tolerance := float64(size) * 0.05; math.Abs(float64(got-size)) > tolerance {
+			t.Errorf("ParseByteSize(FormatFileSize(%d) = %q) = %d, off by more than %.0f", size, printed, got, tolerance)
+		}
+	}
+}
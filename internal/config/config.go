@@ -30,6 +30,9 @@ const (
 	// sweet spot for most provider limits: higher values risk rate limit errors,
 	// while lower values leave throughput on the table.
 	DefaultMaxConcurrentRequests = 5
+	// UnlimitedConcurrentRequests is the MinimalConfig.MaxConcurrentRequests value that
+	// lifts the concurrency limit, since 0 there means the default
+	UnlimitedConcurrentRequests = -1
 	// DefaultRateLimitRequestsPerMinute caps requests per minute per model. The
 	// default of 60 matches the OpenRouter free tier limit and is conservative
 	// across API tiers. Users with higher tiers can raise this via --rate-limit.
@@ -40,6 +43,19 @@ const (
 	// covers network delays, rate limit waiting, and processing time.
 	DefaultTimeout = 10 * time.Minute
 
+	// DefaultMaxRetries is how many times a model is retried after a transient
+	// server, network, or rate limit error. Two retries ride out brief provider
+	// blips without stretching a failing run out much longer.
+	DefaultMaxRetries = 2
+	// DefaultRetryBaseDelay is the wait before the first retry; it doubles for
+	// each retry after that.
+	DefaultRetryBaseDelay = 1 * time.Second
+
+	// DefaultAuditPreviewLength is how many characters of each prompt and
+	// response the audit log keeps without --audit-verbose. Enough to recognize
+	// a request without copying whole codebases into the log.
+	DefaultAuditPreviewLength = 200
+
 	// DefaultDirPermissions sets created output directories to 0750. This is more
 	// restrictive than 0755: owner gets full access, group gets read/execute, and
 	// others get none. It helps avoid accidental exposure of sensitive analysis.
@@ -59,6 +75,11 @@ const (
 	DefaultExcludeNames = ".git,.hg,.svn,node_modules,bower_components,vendor,target,dist,build," +
 		"out,tmp,coverage,__pycache__,*.pyc,*.pyo,.DS_Store,~$*,desktop.ini,Thumbs.db," +
 		"package-lock.json,yarn.lock,go.sum,go.work"
+
+	// OutputFormatText is the default human-readable console summary
+	OutputFormatText = "text"
+	// OutputFormatJSON writes the final summary to stdout as a single JSON object
+	OutputFormatJSON = "json"
 )
 
 // ExcludeConfig defines file exclusion configuration
@@ -134,6 +155,59 @@ type CliConfig struct {
 	DryRun       bool
 	Verbose      bool
 
+	// NormalizeLineEndings converts CRLF/CR line endings to LF when reading context files
+	NormalizeLineEndings bool
+
+	// IncludeGlobs limits context to files matching at least one glob (empty = all files)
+	IncludeGlobs []string
+
+	// PriorityGlobs marks files that trimming drops last, earlier globs ranking higher
+	PriorityGlobs []string
+
+	// GatherWorkers is the number of goroutines per gathering stage (0 = runtime.NumCPU())
+	GatherWorkers int
+
+	// MaxFileSize skips context files larger than this many bytes (0 = unlimited)
+	MaxFileSize int64
+
+	// FollowSymlinks walks into symlinked directories when gathering context
+	FollowSymlinks bool
+
+	// ExcludeGenerated skips context files carrying a generated-code marker
+	ExcludeGenerated bool
+
+	// IncludeHidden gathers dotfiles and dot-directories as context (.git is always skipped)
+	IncludeHidden bool
+
+	// ExplainExcludes lists each path left out of the context, and why, in the dry run
+	ExplainExcludes bool
+
+	// MaxContextTokens stops adding context files once the estimated tokens of the
+	// instructions plus the files so far would exceed it (0 = unlimited)
+	MaxContextTokens int
+
+	// Output options
+	EmbedInstructions bool  // Prepend the instructions to each output file
+	MaxOutputFileSize int64 // Truncate output files beyond this many bytes (0 = unlimited)
+
+	// OutputNameTemplate names output files, e.g. "{timestamp}-{model}.txt" (empty = "{model}.{ext}")
+	OutputNameTemplate string
+
+	// CombinedOutput writes every model's output to this one file, under a heading
+	// per model, instead of one file each (empty = individual files)
+	CombinedOutput string
+
+	// SummaryFile receives the run summary as markdown (empty = console only)
+	SummaryFile string
+
+	// PostProcess is a shell command run on each output file after it is written,
+	// given the file's path as its last argument and its content on stdin (empty = none)
+	PostProcess string
+
+	// NoOverwrite fails the run rather than replace files already in the output
+	// directory; set for a user-supplied --output-dir unless --force is given
+	NoOverwrite bool
+
 	// API configuration
 	APIKey      string
 	APIEndpoint string
@@ -150,6 +224,7 @@ type CliConfig struct {
 	LogLevel   logutil.LogLevel
 	SplitLogs  bool // Whether to split logs by level (INFO/DEBUG to stdout, WARN/ERROR to stderr)
 	Quiet      bool // Suppress console output (errors only)
+	Silent     bool // Write nothing to stdout, not even the summary (errors go to stderr)
 	JsonLogs   bool // Show JSON logs on stderr (preserves old behavior)
 	NoProgress bool // Disable progress indicators (show only start/complete)
 
@@ -157,13 +232,38 @@ type CliConfig struct {
 	MaxConcurrentRequests      int // Maximum number of concurrent API requests (0 = no limit)
 	RateLimitRequestsPerMinute int // Maximum requests per minute per model (0 = no limit)
 
+	// RateLimitWaitBudget caps the total time models may wait on rate limiters before
+	// the run fails with a rate limit error (0 = wait as long as needed)
+	RateLimitWaitBudget time.Duration
+
 	// Provider-specific rate limiting (overrides global rate limit for specific providers)
 	OpenAIRateLimit     int // OpenAI-specific rate limit (0 = use provider default)
 	GeminiRateLimit     int // Gemini-specific rate limit (0 = use provider default)
 	OpenRouterRateLimit int // OpenRouter-specific rate limit (0 = use provider default)
 
 	// Timeout configuration
-	Timeout time.Duration // Global timeout for the entire operation
+	Timeout       time.Duration // Global timeout for the entire operation
+	ModelTimeout  time.Duration // Timeout for each model's generation; expiry fails only that model (0 = bounded only by Timeout)
+	GatherTimeout time.Duration // Timeout for context gathering (0 = bounded only by Timeout)
+
+	// CheckpointInterval is how often to log progress while models run (0 = disabled)
+	CheckpointInterval time.Duration
+
+	// CacheDir stores successful model responses for reuse by identical requests (empty = no caching)
+	CacheDir string
+
+	// Retry configuration for transient model errors
+	MaxRetries     int           // Retries after a server, network, or rate limit error (0 = no retries)
+	RetryBudget    int           // Retries allowed across all models combined (0 = unlimited)
+	RetryBaseDelay time.Duration // Wait before the first retry, doubling each time (0 = DefaultRetryBaseDelay)
+
+	// Repeat runs each model this many times, writing <model>.1 through <model>.N
+	// outputs (0 or 1 = once)
+	Repeat int
+
+	// Audit text capture for prompts and responses
+	AuditVerbose       bool // Record complete prompts and responses
+	AuditPreviewLength int  // Otherwise keep at most this many characters of each (0 = lengths only)
 
 	// Permission configuration
 	DirPermissions  os.FileMode // Directory permissions
@@ -176,6 +276,21 @@ type CliConfig struct {
 	// failure results in a non-zero exit code.
 	PartialSuccessOk bool
 
+	// FailFast cancels the remaining models as soon as one fails, instead of
+	// running them all and aggregating the failures
+	FailFast bool
+
+	// PromptOrder arranges the prompt: "" (instructions first), "instructions-last"
+	// or "by-directory"; see prompt.Order
+	PromptOrder string
+
+	// FenceCode wraps each context file in a fenced code block tagged with its language
+	FenceCode bool
+
+	// FileSeparator is placed between context files in the prompt, escapes already
+	// decoded (empty = prompt.DefaultFileSeparator)
+	FileSeparator string
+
 	// Warning configuration
 	// SuppressDeprecationWarnings suppresses deprecation warnings in CI/automation environments
 	// where they are not actionable. When true, warnings are logged to debug but not shown to stderr.
@@ -184,6 +299,16 @@ type CliConfig struct {
 
 	// Token safety margin percentage (0-50%) - percentage of context window reserved for output
 	TokenSafetyMargin uint8
+
+	// AutoTrim drops context files for models whose window the prompt would overflow,
+	// instead of skipping those models
+	AutoTrim bool
+
+	// OutputFormat selects how the final summary is written: OutputFormatText or OutputFormatJSON
+	OutputFormat string
+
+	// ColorMode is "auto" (the default when empty), "always", or "never"
+	ColorMode string
 }
 
 // NewDefaultCliConfig returns a CliConfig with default values.
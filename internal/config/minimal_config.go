@@ -3,8 +3,10 @@
 package config
 
 import (
-	"github.com/misty-step/thinktank/internal/logutil"
+	"os"
 	"time"
+
+	"github.com/misty-step/thinktank/internal/logutil"
 )
 
 // MinimalConfig represents the essential configuration for thinktank execution.
@@ -12,10 +14,11 @@ import (
 // needed for the simplified CLI approach.
 type MinimalConfig struct {
 	// Core execution fields
-	InstructionsFile string   // Path to instructions file
-	TargetPaths      []string // Paths to analyze
-	ModelNames       []string // Models to use for generation
-	OutputDir        string   // Directory for output files
+	InstructionsFile   string   // Path to instructions file
+	InstructionsInline string   // Instructions text used instead of the file, when set
+	TargetPaths        []string // Paths to analyze
+	ModelNames         []string // Models to use for generation
+	OutputDir          string   // Directory for output files
 
 	// Execution modes
 	DryRun         bool   // Show what would be processed without calling API
@@ -23,19 +26,179 @@ type MinimalConfig struct {
 	SynthesisModel string // Optional model for synthesizing results
 
 	// Minimal additional fields that are actually used
-	LogLevel   logutil.LogLevel // Logging verbosity
-	Timeout    time.Duration    // Global timeout for operation
-	Quiet      bool             // Suppress non-error output
-	NoProgress bool             // Disable progress indicators
-	JsonLogs   bool             // Show JSON logs on stderr (preserves old behavior)
+	LogLevel      logutil.LogLevel // Logging verbosity
+	Timeout       time.Duration    // Global timeout for operation
+	ModelTimeout  time.Duration    // Timeout for each model's generation (0 = bounded only by Timeout)
+	GatherTimeout time.Duration    // Timeout for context gathering (never exceeds Timeout)
+	Quiet         bool             // Suppress non-error output
+	Silent        bool             // Write nothing to stdout; errors and warnings go to stderr
+	NoProgress    bool             // Disable progress indicators
+	JsonLogs      bool             // Show JSON logs on stderr (preserves old behavior)
 
 	// File handling (using smart defaults)
 	Format       string // Format string for file content
 	Exclude      string // File extensions to exclude
 	ExcludeNames string // File/dir names to exclude
 
+	// Maximum concurrent API requests (0 = DefaultMaxConcurrentRequests,
+	// UnlimitedConcurrentRequests = no limit)
+	MaxConcurrentRequests int
+
+	// NormalizeLineEndings converts CRLF/CR to LF in context files (off by default for exactness)
+	NormalizeLineEndings bool
+
+	// IncludeGlobs limits context to files matching at least one glob (empty = all files)
+	IncludeGlobs []string
+
+	// PriorityGlobs marks files that trimming drops last, earlier globs ranking higher
+	PriorityGlobs []string
+
+	// GatherWorkers is the number of goroutines per gathering stage (0 = runtime.NumCPU())
+	GatherWorkers int
+
+	// MaxFileSize skips context files larger than this many bytes (0 = unlimited)
+	MaxFileSize int64
+
+	// FollowSymlinks walks into symlinked directories when gathering context
+	FollowSymlinks bool
+
+	// ExcludeGenerated skips context files carrying a generated-code marker
+	ExcludeGenerated bool
+
+	// IncludeHidden gathers dotfiles and dot-directories as context (.git is always skipped)
+	IncludeHidden bool
+
+	// ExplainExcludes lists each path left out of the context, and why, in the dry run
+	ExplainExcludes bool
+
+	// MaxContextTokens stops adding context files once the estimated tokens of the
+	// instructions plus the files so far would exceed it (0 = unlimited)
+	MaxContextTokens int
+
+	// TemplateVars fills {{.key}} placeholders in the instructions (nil = use them verbatim)
+	TemplateVars map[string]string
+
+	// ProviderBaseURLs overrides the API base URL per provider, keyed by provider
+	// name, for gateways and proxies (nil = provider defaults)
+	ProviderBaseURLs map[string]string
+
+	// ModelParams overrides model parameters per model, keyed by canonical model
+	// name and then parameter name; other parameters keep the model defaults
+	ModelParams map[string]map[string]any
+
+	// DuplicateModels lists repeated model names dropped during selection, in the
+	// order they occurred, so the run can warn about them once output is set up
+	DuplicateModels []string
+
+	// CheckpointInterval is how often to log progress while models run (0 = disabled)
+	CheckpointInterval time.Duration
+
+	// RateLimitWaitBudget caps total rate limiter waiting before failing (0 = wait indefinitely)
+	RateLimitWaitBudget time.Duration
+
+	// MaxOutputFileSize truncates output files beyond this many bytes (0 = unlimited)
+	MaxOutputFileSize int64
+
+	// EmbedInstructions prepends the instructions to each output file
+	EmbedInstructions bool
+
+	// DirPermissions and FilePermissions set the modes of created output
+	// directories and files (0 = 0755 and 0644)
+	DirPermissions  os.FileMode
+	FilePermissions os.FileMode
+
+	// StrictOutputDir fails instead of falling back to the temp directory when
+	// the output directory can't be created in the working directory
+	StrictOutputDir bool
+
+	// NoOverwrite refuses to replace existing files in the output directory. It is
+	// set for a user-supplied --output-dir unless --force is given; a generated
+	// directory is new, so there is nothing to protect.
+	NoOverwrite bool
+
+	// OutputNameTemplate names output files using {model}, {provider}, {timestamp},
+	// and {ext} placeholders (empty = "{model}.{ext}")
+	OutputNameTemplate string
+
+	// CombinedOutput writes all successful model outputs to this single file
+	// (relative paths are inside the output directory; empty = one file per model)
+	CombinedOutput string
+
+	// PostProcess is a shell command run on each output file once it is written
+	PostProcess string
+
+	// SummaryFile is where the run summary is also written as markdown
+	SummaryFile string
+
+	// CacheDir stores successful model responses for reuse (empty = no caching)
+	CacheDir string
+
+	// NoCache disables response caching even when a cache directory is configured
+	NoCache bool
+
+	// PartialSuccessOk exits 0 when some models fail but others produce output
+	PartialSuccessOk bool
+
+	// FailFast cancels the remaining models at the first model failure
+	FailFast bool
+
+	// PromptOrder arranges the instructions and context files in the prompt
+	// (empty = instructions first, then files in gather order)
+	PromptOrder string
+
+	// FenceCode wraps each context file in a fenced code block tagged with its language
+	FenceCode bool
+
+	// FileSeparator is placed between context files in the prompt, escapes already
+	// decoded (empty = prompt.DefaultFileSeparator)
+	FileSeparator string
+
+	// Preflight checks each selected provider's API key and reachability before
+	// gathering context, failing fast if any provider can't be used
+	Preflight bool
+
+	// OTel emits OpenTelemetry spans for the run and each model, and counters of
+	// model outcomes, exported as the standard OTEL_* environment variables configure
+	OTel bool
+
+	// MaxRetries is how many times a transient model error is retried (0 = no retries)
+	MaxRetries int
+
+	// RetryBudget caps the retries taken by all models combined (0 = unlimited)
+	RetryBudget int
+
+	// Repeat is how many generations each model makes (0 or 1 = one)
+	Repeat int
+
+	// RetryBaseDelay is the wait before the first retry, doubling each time
+	RetryBaseDelay time.Duration
+
+	// AuditVerbose records complete prompts and responses in the audit log;
+	// otherwise they are cut to AuditPreviewLength characters (0 = lengths only)
+	AuditVerbose       bool
+	AuditPreviewLength int
+
 	// Token safety margin percentage (0-50%) - percentage of context window reserved for output
 	TokenSafetyMargin uint8
+
+	// AutoTrim drops context files for models whose window the prompt would overflow,
+	// instead of skipping those models
+	AutoTrim bool
+
+	// OutputFormat selects how the final summary is written: OutputFormatText or OutputFormatJSON
+	OutputFormat string
+
+	// ProgressFormat set to OutputFormatJSON writes a JSON event per model progress change
+	ProgressFormat string
+
+	// ProgressFD is the file descriptor progress events are written to (0 = stderr)
+	ProgressFD int
+
+	// ColorMode is "auto" (the default when empty), "always", or "never"
+	ColorMode string
+
+	// Theme names the console color palette: "default" or "high-contrast"
+	Theme string
 }
 
 // NewDefaultMinimalConfig returns a MinimalConfig with sensible defaults.
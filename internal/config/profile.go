@@ -0,0 +1,214 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/misty-step/thinktank/internal/logutil"
+)
+
+// ProfilesFileName is the name of the file holding named profiles, in the thinktank
+// directory under the XDG config directory
+const ProfilesFileName = "profiles.json"
+
+// Profile holds default settings selected with --profile. Profiles live in
+// profiles.json as an object keyed by profile name. Flags and .thinktank.json take
+// precedence: a profile only fills settings they don't set.
+type Profile struct {
+	Models           []string `json:"models,omitempty"`             // Models to run unless .thinktank.json names some
+	Synthesis        *bool    `json:"synthesis,omitempty"`          // Always (true) or never (false) synthesize results
+	Quiet            *bool    `json:"quiet,omitempty"`              // Suppress non-error output (ignored with --verbose)
+	Verbose          *bool    `json:"verbose,omitempty"`            // Verbose output and debug logging (ignored with --quiet)
+	NoProgress       *bool    `json:"no_progress,omitempty"`        // Disable progress indicators
+	JsonLogs         *bool    `json:"json_logs,omitempty"`          // Show JSON logs on stderr
+	PartialSuccessOk *bool    `json:"partial_success_ok,omitempty"` // Exit 0 when some models succeed
+	OutputFormat     string   `json:"output_format,omitempty"`      // "text" or "json"
+	Color            string   `json:"color,omitempty"`              // "auto", "always", or "never"
+	Theme            string   `json:"theme,omitempty"`              // "default" or "high-contrast"
+	Timeout          string   `json:"timeout,omitempty"`            // Go duration bounding the whole run
+	ModelTimeout     string   `json:"model_timeout,omitempty"`      // Go duration bounding each model
+	GatherTimeout    string   `json:"gather_timeout,omitempty"`     // Go duration bounding context gathering
+	MaxRetries       *int     `json:"max_retries,omitempty"`        // Retries after transient model errors
+	Concurrency      int      `json:"concurrency,omitempty"`        // Maximum concurrent requests (0 = default)
+	CacheDir         string   `json:"cache_dir,omitempty"`          // Response cache directory
+	Exclude          string   `json:"exclude,omitempty"`            // Extra file extensions to exclude (comma-separated)
+	ExcludeNames     string   `json:"exclude_names,omitempty"`      // Extra file/dir names to exclude (comma-separated)
+}
+
+// ProfilesPath returns the location of profiles.json: $XDG_CONFIG_HOME/thinktank,
+// or ~/.config/thinktank when XDG_CONFIG_HOME is unset
+func ProfilesPath(getenv func(string) string) (string, error) {
+	configHome := getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate the config directory: %w", err)
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "thinktank", ProfilesFileName), nil
+}
+
+// LoadProfile reads the named profile from the profiles file at path.
+// Unlike .thinktank.json, a missing file is an error since a profile was asked for.
+func LoadProfile(path, name string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("profile %q requested but %s does not exist", name, path)
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var profiles map[string]json.RawMessage
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	raw, ok := profiles[name]
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for profileName := range profiles {
+			names = append(names, profileName)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown profile %q in %s (available: %s)", name, path, strings.Join(names, ", "))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+
+	var profile Profile
+	if err := decoder.Decode(&profile); err != nil {
+		return nil, fmt.Errorf("invalid profile %q in %s: %w", name, path, err)
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid profile %q in %s: %w", name, path, err)
+	}
+
+	return &profile, nil
+}
+
+// Validate checks that profile values are usable
+func (p *Profile) Validate() error {
+	for i, model := range p.Models {
+		if strings.TrimSpace(model) == "" {
+			return fmt.Errorf("models[%d] is empty", i)
+		}
+	}
+	if p.OutputFormat != "" && p.OutputFormat != OutputFormatText && p.OutputFormat != OutputFormatJSON {
+		return fmt.Errorf("output_format must be %q or %q, got %q", OutputFormatText, OutputFormatJSON, p.OutputFormat)
+	}
+	switch p.Color {
+	case "", logutil.ColorAuto, logutil.ColorAlways, logutil.ColorNever:
+	default:
+		return fmt.Errorf("color must be %q, %q, or %q, got %q", logutil.ColorAuto, logutil.ColorAlways, logutil.ColorNever, p.Color)
+	}
+	switch p.Theme {
+	case "", logutil.ThemeDefault, logutil.ThemeHighContrast:
+	default:
+		return fmt.Errorf("theme must be %q or %q, got %q", logutil.ThemeDefault, logutil.ThemeHighContrast, p.Theme)
+	}
+	durations := []struct{ field, value string }{
+		{"timeout", p.Timeout},
+		{"model_timeout", p.ModelTimeout},
+		{"gather_timeout", p.GatherTimeout},
+	}
+	for _, d := range durations {
+		if d.value == "" {
+			continue
+		}
+		if parsed, err := time.ParseDuration(d.value); err != nil || parsed <= 0 {
+			return fmt.Errorf("%s must be a positive duration such as 30s or 5m, got %q", d.field, d.value)
+		}
+	}
+	if p.MaxRetries != nil && *p.MaxRetries < 0 {
+		return fmt.Errorf("max_retries must not be negative, got %d", *p.MaxRetries)
+	}
+	if p.Concurrency < 0 || p.Concurrency > MaxProjectConcurrency {
+		return fmt.Errorf("concurrency must be between 0 and %d, got %d", MaxProjectConcurrency, p.Concurrency)
+	}
+	return nil
+}
+
+// MergeInto applies the profile's values to the settings cfg did not get from a
+// flag or .thinktank.json. explicit holds those settings, keyed by their
+// profiles.json name, so a flag given its default value still wins. Exclusions
+// add to cfg's own. Models and synthesis are left to the caller, which owns
+// model selection.
+func (p *Profile) MergeInto(cfg *MinimalConfig, explicit map[string]bool) {
+	if p.Quiet != nil && !explicit["quiet"] && !(*p.Quiet && cfg.Verbose) {
+		cfg.Quiet = *p.Quiet
+	}
+	if p.Verbose != nil && !explicit["verbose"] && !(*p.Verbose && cfg.Quiet) {
+		cfg.Verbose = *p.Verbose
+		if cfg.Verbose {
+			cfg.LogLevel = logutil.DebugLevel
+		}
+	}
+	mergeBool(&cfg.NoProgress, p.NoProgress, explicit["no_progress"])
+	mergeBool(&cfg.JsonLogs, p.JsonLogs, explicit["json_logs"])
+	mergeBool(&cfg.PartialSuccessOk, p.PartialSuccessOk, explicit["partial_success_ok"])
+
+	if p.OutputFormat != "" && !explicit["output_format"] {
+		cfg.OutputFormat = p.OutputFormat
+	}
+	if p.Color != "" && !explicit["color"] {
+		cfg.ColorMode = p.Color
+	}
+	if p.Theme != "" && !explicit["theme"] {
+		cfg.Theme = p.Theme
+	}
+
+	// The gather timeout defaults to the run timeout, so it follows a profile's
+	// run timeout unless it was set on its own, and never exceeds it
+	if timeout := parseProfileDuration(p.Timeout); timeout > 0 && !explicit["timeout"] {
+		cfg.Timeout = timeout
+		if !explicit["gather_timeout"] {
+			cfg.GatherTimeout = timeout
+		}
+	}
+	if gather := parseProfileDuration(p.GatherTimeout); gather > 0 && !explicit["gather_timeout"] {
+		cfg.GatherTimeout = gather
+	}
+	cfg.GatherTimeout = min(cfg.GatherTimeout, cfg.Timeout)
+	if modelTimeout := parseProfileDuration(p.ModelTimeout); modelTimeout > 0 && !explicit["model_timeout"] {
+		cfg.ModelTimeout = modelTimeout
+	}
+
+	if p.MaxRetries != nil && !explicit["max_retries"] {
+		cfg.MaxRetries = *p.MaxRetries
+	}
+	if p.Concurrency > 0 && !explicit["concurrency"] {
+		cfg.MaxConcurrentRequests = p.Concurrency
+	}
+	if p.CacheDir != "" && !explicit["cache_dir"] {
+		cfg.CacheDir = p.CacheDir
+	}
+	if p.Exclude != "" {
+		cfg.Exclude = cfg.Exclude + "," + p.Exclude
+	}
+	if p.ExcludeNames != "" {
+		cfg.ExcludeNames = cfg.ExcludeNames + "," + p.ExcludeNames
+	}
+}
+
+// mergeBool sets *setting to the profile's value unless it is unset or the
+// setting was given explicitly
+func mergeBool(setting *bool, value *bool, explicit bool) {
+	if value != nil && !explicit {
+		*setting = *value
+	}
+}
+
+// parseProfileDuration parses a duration checked by Validate, returning 0 when unset
+func parseProfileDuration(value string) time.Duration {
+	d, _ := time.ParseDuration(value)
+	return d
+}
@@ -0,0 +1,204 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/misty-step/thinktank/internal/logutil"
+)
+
+func TestLoadProfile(t *testing.T) {
+	t.Parallel()
+
+	retries, quiet := 4, true
+	tests := []struct {
+		name        string
+		content     string // empty means no file is written
+		profile     string
+		want        *Profile
+		errContains string
+	}{
+		{
+			name:    "selects the named profile",
+			content: `{"ci":{"quiet":true,"output_format":"json","timeout":"20m","max_retries":4},"local":{"models":["gemini-3-flash"]}}`,
+			profile: "ci",
+			want:    &Profile{Quiet: &quiet, OutputFormat: "json", Timeout: "20m", MaxRetries: &retries},
+		},
+		{
+			name:        "missing file",
+			profile:     "ci",
+			errContains: `profile "ci" requested but`,
+		},
+		{
+			name:        "unknown profile lists the available ones",
+			content:     `{"local":{},"ci":{}}`,
+			profile:     "nightly",
+			errContains: "(available: ci, local)",
+		},
+		{
+			name:        "unknown field rejected",
+			content:     `{"ci":{"quite":true}}`,
+			profile:     "ci",
+			errContains: "unknown field",
+		},
+		{
+			name:        "malformed JSON rejected",
+			content:     `{"ci":`,
+			profile:     "ci",
+			errContains: "invalid",
+		},
+		{
+			name:        "bad duration rejected",
+			content:     `{"ci":{"model_timeout":"5 minutes"}}`,
+			profile:     "ci",
+			errContains: "model_timeout must be a positive duration",
+		},
+		{
+			name:        "bad output format rejected",
+			content:     `{"ci":{"output_format":"yaml"}}`,
+			profile:     "ci",
+			errContains: "output_format must be",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), ProfilesFileName)
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+					t.Fatalf("Failed to write profiles: %v", err)
+				}
+			}
+
+			got, err := LoadProfile(path, tt.profile)
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("Expected error containing %q, got %v", tt.errContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadProfile() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProfilesPath(t *testing.T) {
+	t.Parallel()
+
+	path, err := ProfilesPath(func(key string) string {
+		if key == "XDG_CONFIG_HOME" {
+			return "/xdg"
+		}
+		return ""
+	})
+	if err != nil || path != filepath.Join("/xdg", "thinktank", "profiles.json") {
+		t.Errorf("ProfilesPath() = %q, %v; want the XDG_CONFIG_HOME location", path, err)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	path, err = ProfilesPath(func(string) string { return "" })
+	if err != nil || path != filepath.Join(home, ".config", "thinktank", "profiles.json") {
+		t.Errorf("ProfilesPath() = %q, %v; want ~/.config/thinktank/profiles.json", path, err)
+	}
+}
+
+func TestProfileMergeInto(t *testing.T) {
+	t.Parallel()
+
+	defaults := func() *MinimalConfig {
+		return &MinimalConfig{
+			LogLevel:      logutil.InfoLevel,
+			Timeout:       DefaultTimeout,
+			GatherTimeout: DefaultTimeout,
+			MaxRetries:    DefaultMaxRetries,
+			Exclude:       ".exe",
+			ExcludeNames:  ".git",
+		}
+	}
+	retries, on, off := 5, true, false
+	profile := &Profile{
+		Quiet:         &on,
+		NoProgress:    &off,
+		OutputFormat:  OutputFormatJSON,
+		Color:         logutil.ColorNever,
+		Timeout:       "20m",
+		ModelTimeout:  "3m",
+		GatherTimeout: "1m",
+		MaxRetries:    &retries,
+		Concurrency:   4,
+		CacheDir:      "/cache",
+		Exclude:       ".md",
+		ExcludeNames:  "fixtures",
+	}
+
+	t.Run("fills defaults", func(t *testing.T) {
+		t.Parallel()
+		cfg := defaults()
+		cfg.NoProgress = true
+		profile.MergeInto(cfg, nil)
+
+		want := &MinimalConfig{
+			LogLevel:              logutil.InfoLevel,
+			Quiet:                 true,
+			OutputFormat:          OutputFormatJSON,
+			ColorMode:             logutil.ColorNever,
+			Timeout:               20 * time.Minute,
+			GatherTimeout:         time.Minute,
+			ModelTimeout:          3 * time.Minute,
+			MaxRetries:            5,
+			MaxConcurrentRequests: 4,
+			CacheDir:              "/cache",
+			Exclude:               ".exe,.md",
+			ExcludeNames:          ".git,fixtures",
+		}
+		if !reflect.DeepEqual(cfg, want) {
+			t.Errorf("MergeInto() =\n%+v\nwant\n%+v", cfg, want)
+		}
+	})
+
+	t.Run("explicit settings take precedence", func(t *testing.T) {
+		t.Parallel()
+		cfg := defaults()
+		cfg.Verbose = true
+		cfg.NoProgress = true
+		cfg.OutputFormat = OutputFormatText
+		cfg.GatherTimeout = 10 * time.Second
+		cfg.ModelTimeout = time.Minute
+		cfg.CacheDir = "/mine"
+		// Timeout and retries keep their default values, but were still given explicitly
+		profile.MergeInto(cfg, map[string]bool{
+			"verbose": true, "no_progress": true, "output_format": true, "timeout": true,
+			"gather_timeout": true, "model_timeout": true, "max_retries": true, "cache_dir": true,
+		})
+
+		if cfg.Quiet {
+			t.Error("a profile must not turn on quiet mode alongside --verbose")
+		}
+		if !cfg.NoProgress || cfg.OutputFormat != OutputFormatText || cfg.Timeout != DefaultTimeout || cfg.GatherTimeout != 10*time.Second ||
+			cfg.ModelTimeout != time.Minute || cfg.MaxRetries != DefaultMaxRetries || cfg.CacheDir != "/mine" {
+			t.Errorf("explicit values were overridden: %+v", cfg)
+		}
+	})
+
+	t.Run("gather timeout follows the profile run timeout", func(t *testing.T) {
+		t.Parallel()
+		cfg := defaults()
+		(&Profile{Timeout: "2m"}).MergeInto(cfg, nil)
+		if cfg.Timeout != 2*time.Minute || cfg.GatherTimeout != 2*time.Minute {
+			t.Errorf("Timeout = %v, GatherTimeout = %v; want both 2m", cfg.Timeout, cfg.GatherTimeout)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ProjectConfigFileName is the name of the project-local configuration file
+const ProjectConfigFileName = ".thinktank.json"
+
+// MaxProjectConcurrency bounds the concurrency value accepted from project config
+const MaxProjectConcurrency = 100
+
+// ProjectConfig holds project-local defaults read from .thinktank.json.
+// It is a subset of MinimalConfig; CLI flags always take precedence over these values.
+type ProjectConfig struct {
+	Models       []string `json:"models,omitempty"`        // Models to run instead of the default council
+	Synthesis    bool     `json:"synthesis,omitempty"`     // Always synthesize results
+	Exclude      string   `json:"exclude,omitempty"`       // Extra file extensions to exclude (comma-separated)
+	ExcludeNames string   `json:"exclude_names,omitempty"` // Extra file/dir names to exclude (comma-separated)
+	Concurrency  int      `json:"concurrency,omitempty"`   // Maximum concurrent requests (0 = default)
+	CacheDir     string   `json:"cache_dir,omitempty"`     // Response cache directory (empty = no caching)
+}
+
+// LoadProjectConfig reads .thinktank.json from dir.
+// Returns nil without error when the file does not exist.
+func LoadProjectConfig(dir string) (*ProjectConfig, error) {
+	path := filepath.Join(dir, ProjectConfigFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var cfg ProjectConfig
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks that project config values are usable
+func (p *ProjectConfig) Validate() error {
+	for i, model := range p.Models {
+		if strings.TrimSpace(model) == "" {
+			return fmt.Errorf("models[%d] is empty", i)
+		}
+	}
+	if p.Concurrency < 0 || p.Concurrency > MaxProjectConcurrency {
+		return fmt.Errorf("concurrency must be between 0 and %d, got %d", MaxProjectConcurrency, p.Concurrency)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadProjectConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		content     string // empty means no file is written
+		want        *ProjectConfig
+		errContains string
+	}{
+		{
+			name: "missing file returns nil",
+			want: nil,
+		},
+		{
+			name:    "all supported fields",
+			content: `{"models":["gpt-5.2","gemini-3-pro"],"synthesis":true,"exclude":".md","exclude_names":"fixtures","concurrency":3}`,
+			want: &ProjectConfig{
+				Models:       []string{"gpt-5.2", "gemini-3-pro"},
+				Synthesis:    true,
+				Exclude:      ".md",
+				ExcludeNames: "fixtures",
+				Concurrency:  3,
+			},
+		},
+		{
+			name:        "unknown field rejected",
+			content:     `{"modles":["gpt-5.2"]}`,
+			errContains: "unknown field",
+		},
+		{
+			name:        "malformed JSON rejected",
+			content:     `{"models":`,
+			errContains: "invalid",
+		},
+		{
+			name:        "empty model name rejected",
+			content:     `{"models":["gpt-5.2",""]}`,
+			errContains: "models[1] is empty",
+		},
+		{
+			name:        "negative concurrency rejected",
+			content:     `{"concurrency":-1}`,
+			errContains: "concurrency must be between",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			if tt.content != "" {
+				if err := os.WriteFile(filepath.Join(dir, ProjectConfigFileName), []byte(tt.content), 0644); err != nil {
+					t.Fatalf("Failed to write project config: %v", err)
+				}
+			}
+
+			got, err := LoadProjectConfig(dir)
+			if tt.errContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+					t.Fatalf("Expected error containing %q, got %v", tt.errContains, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadProjectConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
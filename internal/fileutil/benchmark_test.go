@@ -88,7 +88,7 @@ func BenchmarkShouldProcess(b *testing.B) {
 			perftest.RunBenchmark(b, "ShouldProcess_"+bm.name, func(b *testing.B) {
 				perftest.ReportAllocs(b)
 				for i := 0; i < b.N; i++ {
-					_ = shouldProcess(bm.path, bm.config)
+					_ = shouldProcess("", bm.path, bm.config)
 				}
 			})
 		})
@@ -14,7 +14,7 @@ import (
 // ConcurrentConfig holds configuration for concurrent file processing
 type ConcurrentConfig struct {
 	// MaxWorkers specifies the number of worker goroutines per stage.
-	// Default: runtime.GOMAXPROCS(0). Range: 1-32.
+	// Default: runtime.NumCPU(). Range: 1-32.
 	MaxWorkers int
 
 	// Context for cancellation propagation
@@ -37,12 +37,14 @@ type FileProgress struct {
 // discoverResult wraps discovered file path with any error
 type discoverResult struct {
 	path string
+	root string // Directory the path was found under; globs match relative to it
 	err  error
 }
 
 // filterResult wraps filtering decision
 type filterResult struct {
 	path      string
+	root      string
 	shouldAdd bool
 }
 
@@ -63,7 +65,7 @@ func NewDefaultConcurrentConfig(ctx context.Context) *ConcurrentConfig {
 // normalizeWorkers ensures workers is within valid range [1, 32]
 func normalizeWorkers(workers int) int {
 	if workers <= 0 {
-		workers = runtime.GOMAXPROCS(0)
+		workers = runtime.NumCPU()
 	}
 	return max(1, min(workers, 32))
 }
@@ -79,6 +81,10 @@ func normalizeWorkers(workers int) int {
 //
 // This function maintains backward compatibility - results are identical to the sequential
 // version, just faster.
+//
+// Overlapping paths (the same directory twice, or a directory and one of its
+// subdirectories) are deduplicated: each file appears once in the result, and the
+// returned count is the number of unique files.
 func GatherProjectContextConcurrent(ctx context.Context, paths []string, config *Config, concCfg *ConcurrentConfig) ([]FileMeta, int, error) {
 	if ctx == nil {
 		ctx = context.Background()
@@ -104,6 +110,7 @@ func GatherProjectContextConcurrent(ctx context.Context, paths []string, config
 	readChan := make(chan readResult, bufSize)
 
 	// Start the three-stage pipeline
+	paths = uniqueRoots(paths)
 	go discoverFiles(ctx, paths, config, workers, discoverChan, &totalDiscovered)
 	go filterFiles(ctx, discoverChan, config, workers, filterChan, &totalSkipped)
 	go readFiles(ctx, filterChan, config, workers, readChan, &totalSkipped)
@@ -120,13 +127,9 @@ func GatherProjectContextConcurrent(ctx context.Context, paths []string, config
 
 		files = append(files, result.meta)
 
+		// Counts files read, so it may include duplicates from overlapping roots
 		totalProcessed.Add(1)
 
-		// Call file collector if set
-		if config.fileCollector != nil {
-			config.fileCollector(result.meta.Path)
-		}
-
 		// Send progress update if channel is available
 		if concCfg.ProgressChan != nil {
 			discovered := totalDiscovered.Load()
@@ -158,7 +161,63 @@ func GatherProjectContextConcurrent(ctx context.Context, paths []string, config
 		return files[i].Path < files[j].Path
 	})
 
-	return files, int(totalProcessed.Load()), nil
+	// Drop files reached through more than one root, e.g. ./ and ./internal.
+	// Paths are absolute, so after sorting any duplicates are adjacent.
+	files = compactFilesByPath(files)
+
+	// Call the file collector on this goroutine, in path order, so it needs no locking
+	if config.fileCollector != nil {
+		for _, file := range files {
+			config.fileCollector(file.Path)
+		}
+	}
+
+	return files, len(files), nil
+}
+
+// uniqueRoots removes paths that resolve to the same location as an earlier path,
+// so "." and its absolute form, or a path and a symlink to it, are walked once.
+// Order is preserved; nested roots are kept and deduplicated per file instead.
+func uniqueRoots(paths []string) []string {
+	seen := make(map[string]bool, len(paths))
+	unique := make([]string, 0, len(paths))
+	for _, p := range paths {
+		key := canonicalPath(p)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, p)
+	}
+	return unique
+}
+
+// canonicalPath returns the absolute, symlink-resolved form of path, falling back
+// to the cleaned absolute path when it can't be resolved (e.g. it doesn't exist)
+func canonicalPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved
+	}
+	return abs
+}
+
+// compactFilesByPath removes entries whose path matches the previous entry.
+// files must be sorted by path.
+func compactFilesByPath(files []FileMeta) []FileMeta {
+	if len(files) < 2 {
+		return files
+	}
+	unique := files[:1]
+	for _, file := range files[1:] {
+		if file.Path != unique[len(unique)-1].Path {
+			unique = append(unique, file)
+		}
+	}
+	return unique
 }
 
 // discoverFiles walks directories concurrently using worker pool pattern
@@ -191,7 +250,7 @@ func discoverFiles(ctx context.Context, paths []string, config *Config, workers
 				} else {
 					totalDiscovered.Add(1)
 					select {
-					case results <- discoverResult{path: path}:
+					case results <- discoverResult{path: path, root: filepath.Dir(path)}:
 					case <-ctx.Done():
 						return
 					}
@@ -218,7 +277,22 @@ func discoverFiles(ctx context.Context, paths []string, config *Config, workers
 
 // walkDirectoryConcurrent walks a single directory and sends files to results channel
 func walkDirectoryConcurrent(ctx context.Context, root string, config *Config, results chan<- discoverResult, totalDiscovered *atomic.Int64) {
-	err := WalkDirectory(root, func(path string, d os.DirEntry, err error) error {
+	var visited visitedDirs
+	if config.FollowSymlinks {
+		visited = visitedDirs{}
+	}
+
+	err := walkTree(ctx, root, root, config, results, totalDiscovered, visited)
+	if err != nil && err != context.Canceled {
+		config.Logger.Printf("Error walking directory %s: %v\n", root, err)
+	}
+}
+
+// walkTree walks dir, sending files to results tagged with root, the input path
+// the walk started from. Symlinked directories are followed only when visited is
+// non-nil; it records every directory walked.
+func walkTree(ctx context.Context, root, dir string, config *Config, results chan<- discoverResult, totalDiscovered *atomic.Int64, visited visitedDirs) error {
+	return WalkDirectory(dir, func(path string, d os.DirEntry, err error) error {
 		// Check for context cancellation
 		select {
 		case <-ctx.Done():
@@ -233,38 +307,77 @@ func walkDirectoryConcurrent(ctx context.Context, root string, config *Config, r
 			return err // Return error to potentially stop walk on this branch
 		}
 
+		if visited != nil && d.Type()&os.ModeSymlink != 0 {
+			return followSymlink(ctx, root, path, config, results, totalDiscovered, visited)
+		}
+
 		// Skip directories that should be excluded
 		if d.IsDir() {
+			path = filepath.Clean(path) // A followed link's root keeps its trailing separator
 			base := d.Name()
 			// Skip .git and other excluded directories
-			if base == ".git" || isGitIgnored(path, config) {
+			reason := gitIgnoreReason(path, config)
+			if base == ".git" {
+				reason = SkipGitDirectory
+			}
+			if reason != SkipNone {
 				config.Logger.Printf("Verbose: Skipping directory: %s\n", path)
+				config.recordSkip(path, true, reason)
 				return filepath.SkipDir
 			}
 			// Check explicit excludes
 			for _, name := range config.ExcludeNames {
 				if base == name {
 					config.Logger.Printf("Verbose: Skipping directory: %s\n", path)
+					config.excludeCounts.record(ExcludeKindName, name)
+					config.recordSkip(path, true, SkipExcludedName)
 					return filepath.SkipDir
 				}
 			}
+			if visited != nil && !visited.visit(path) {
+				config.Logger.Printf("Verbose: Skipping already visited directory (symlink cycle): %s\n", path)
+				return filepath.SkipDir
+			}
 			return nil // Continue into directory
 		}
 
 		// It's a file - send to results
-		totalDiscovered.Add(1)
-		select {
-		case results <- discoverResult{path: path}:
-		case <-ctx.Done():
-			return ctx.Err()
-		}
+		return sendDiscovered(ctx, root, path, results, totalDiscovered)
+	})
+}
 
+// followSymlink resolves a symlink found while walking. Linked directories are
+// walked beneath the link's path; broken links are logged and skipped.
+func followSymlink(ctx context.Context, root, path string, config *Config, results chan<- discoverResult, totalDiscovered *atomic.Int64, visited visitedDirs) error {
+	info, err := StatPath(path)
+	if err != nil {
+		config.Logger.Printf("Warning: Skipping broken symlink %s: %v\n", path, err)
 		return nil
-	})
+	}
+	if !info.IsDir() {
+		return sendDiscovered(ctx, root, path, results, totalDiscovered)
+	}
 
-	if err != nil && err != context.Canceled {
-		config.Logger.Printf("Error walking directory %s: %v\n", root, err)
+	// A trailing separator makes the walk resolve the link rather than report it
+	err = walkTree(ctx, root, path+string(filepath.Separator), config, results, totalDiscovered, visited)
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
+	if err != nil {
+		config.Logger.Printf("Error walking directory %s: %v\n", path, err)
+	}
+	return nil // Never SkipDir: on a non-directory entry it would skip the link's siblings
+}
+
+// sendDiscovered counts a file found under root and sends it to results
+func sendDiscovered(ctx context.Context, root, path string, results chan<- discoverResult, totalDiscovered *atomic.Int64) error {
+	totalDiscovered.Add(1)
+	select {
+	case results <- discoverResult{path: path, root: root}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
 }
 
 // filterFiles filters discovered paths concurrently
@@ -289,13 +402,13 @@ func filterFiles(ctx context.Context, discovered <-chan discoverResult, config *
 					continue
 				}
 
-				shouldAdd := shouldProcess(item.path, config)
+				shouldAdd := shouldProcess(item.root, item.path, config)
 				if !shouldAdd {
 					totalSkipped.Add(1)
 				}
 
 				select {
-				case results <- filterResult{path: item.path, shouldAdd: shouldAdd}:
+				case results <- filterResult{path: item.path, root: item.root, shouldAdd: shouldAdd}:
 				case <-ctx.Done():
 					return
 				}
@@ -327,22 +440,19 @@ func readFiles(ctx context.Context, filtered <-chan filterResult, config *Config
 					continue
 				}
 
-				content, err := ReadFileContent(item.path)
-				if err != nil {
-					config.Logger.Printf("Warning: Cannot read file %s: %v\n", item.path, err)
-					totalSkipped.Add(1)
-					continue
-				}
-
-				if isBinaryFile(content) {
-					config.Logger.Printf("Verbose: Skipping binary file: %s\n", item.path)
+				content, ok := readForContext(item.path, config)
+				if !ok {
 					totalSkipped.Add(1)
 					continue
 				}
 
 				select {
 				case results <- readResult{
-					meta: FileMeta{Path: EnsureAbsolutePath(item.path), Content: string(content)},
+					meta: FileMeta{
+						Path:     EnsureAbsolutePath(item.path),
+						Content:  string(content),
+						Priority: PriorityScore(item.root, item.path, config.PriorityGlobs),
+					},
 				}:
 				case <-ctx.Done():
 					return
@@ -31,8 +31,8 @@ func TestNormalizeWorkers(t *testing.T) {
 		input    int
 		expected int
 	}{
-		{"zero defaults to GOMAXPROCS", 0, min(runtime.GOMAXPROCS(0), 32)},
-		{"negative defaults to GOMAXPROCS", -1, min(runtime.GOMAXPROCS(0), 32)},
+		{"zero defaults to NumCPU", 0, min(runtime.NumCPU(), 32)},
+		{"negative defaults to NumCPU", -1, min(runtime.NumCPU(), 32)},
 		{"1 stays 1", 1, 1},
 		{"16 stays 16", 16, 16},
 		{"32 stays 32", 32, 32},
@@ -111,6 +111,50 @@ func TestGatherProjectContextConcurrent_Directory(t *testing.T) {
 	assert.Equal(t, 4, count)
 }
 
+func TestGatherProjectContextConcurrent_OverlappingRoots(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, path := range []string{"main.go", "sub/sub.go", "sub/deep/deep.go"} {
+		fullPath := filepath.Join(tmpDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte("package x\n"), 0644))
+	}
+	subDir := filepath.Join(tmpDir, "sub")
+	want := []string{
+		filepath.Join(tmpDir, "main.go"),
+		filepath.Join(tmpDir, "sub", "deep", "deep.go"),
+		filepath.Join(tmpDir, "sub", "sub.go"),
+	}
+
+	tests := []struct {
+		name  string
+		paths []string
+	}{
+		{name: "identical roots", paths: []string{tmpDir, tmpDir}},
+		{name: "identical roots spelled differently", paths: []string{tmpDir, tmpDir + string(filepath.Separator) + "."}},
+		{name: "nested root after parent", paths: []string{tmpDir, subDir}},
+		{name: "nested root before parent", paths: []string{subDir, tmpDir}},
+		{name: "file inside a root", paths: []string{filepath.Join(subDir, "sub.go"), tmpDir}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			config := NewConfig(false, "", "", "", "", testutil.NewMockLogger())
+			concCfg := &ConcurrentConfig{MaxWorkers: 4, Context: ctx}
+
+			result, count, err := GatherProjectContextConcurrent(ctx, tt.paths, config, concCfg)
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(result))
+			for _, file := range result {
+				got = append(got, file.Path)
+			}
+			assert.Equal(t, want, got, "each file should appear once, sorted by path")
+			assert.Equal(t, len(want), count, "processed count should reflect unique files")
+		})
+	}
+}
+
 func TestGatherProjectContextConcurrent_ContextCancellation(t *testing.T) {
 	// Create temporary directory with files
 	tmpDir := t.TempDir()
@@ -296,6 +340,51 @@ func TestGatherProjectContextConcurrent_FileCollector(t *testing.T) {
 	assert.Len(t, files, 3)
 	assert.Equal(t, 3, count)
 	assert.Len(t, collectedFiles, 3)
+	for i, file := range files {
+		assert.Equal(t, file.Path, collectedFiles[i], "collector should see files in sorted order")
+	}
+}
+
+func TestGatherProjectContext_WorkersAcrossRoots(t *testing.T) {
+	// Several roots gathered with different pool sizes must produce identical, sorted results
+	var roots []string
+	for r := 0; r < 4; r++ {
+		root := t.TempDir()
+		for d := 0; d < 3; d++ {
+			dir := filepath.Join(root, fmt.Sprintf("pkg%d", d))
+			require.NoError(t, os.MkdirAll(dir, 0755))
+			for f := 0; f < 5; f++ {
+				path := filepath.Join(dir, fmt.Sprintf("file%d.go", f))
+				require.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf("package pkg%d\n", d)), 0644))
+			}
+		}
+		roots = append(roots, root)
+	}
+
+	var baseline []FileMeta
+	for _, workers := range []int{1, 4, 0} {
+		config := NewConfig(false, "", "", "", "", testutil.NewMockLogger())
+		config.Workers = workers
+		var collected []string
+		config.SetFileCollector(func(path string) {
+			collected = append(collected, path)
+		})
+
+		files, count, err := GatherProjectContext(roots, config)
+		require.NoError(t, err)
+		assert.Len(t, files, 60, "workers=%d", workers)
+		assert.Equal(t, 60, count, "workers=%d", workers)
+		assert.Len(t, collected, 60, "workers=%d", workers)
+		for i := 1; i < len(files); i++ {
+			assert.Less(t, files[i-1].Path, files[i].Path, "workers=%d: files must be sorted by path", workers)
+		}
+
+		if baseline == nil {
+			baseline = files
+		} else {
+			assert.Equal(t, baseline, files, "workers=%d should match the single-worker result", workers)
+		}
+	}
 }
 
 func TestGatherProjectContextConcurrent_IncludeFilter(t *testing.T) {
@@ -0,0 +1,79 @@
+package fileutil
+
+import (
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// contentPatternHeadBytes is how much of the start of a file ExcludeContentPatterns
+// are matched against; generators put their markers in the header.
+const contentPatternHeadBytes = 1024
+
+// GeneratedFilePatterns match the markers code generators write at the top of
+// their output, for --exclude-generated
+var GeneratedFilePatterns = []*regexp.Regexp{
+	// Go convention (https://go.dev/s/generatedcode), also used by ts-proto and buf
+	regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`),
+	// @generated, used by protobuf-ts, Relay, Thrift and many JS/TS tools
+	regexp.MustCompile(`(?m)^\s*(//|/\*+|\*|#)\s*@generated\b`),
+	// "This file was automatically generated", common in TypeScript and OpenAPI clients
+	regexp.MustCompile(`(?mi)^\s*(//|/\*+|\*|#)\s*this file (is|was|has been) (automatically |auto-)?generated\b`),
+	// .NET tooling, e.g. "// <auto-generated />"
+	regexp.MustCompile(`(?m)^\s*//\s*<auto-generated`),
+}
+
+// ContentExcludedFile is a file skipped because its head matched one of
+// Config.ExcludeContentPatterns
+type ContentExcludedFile struct {
+	Path    string
+	Pattern string
+}
+
+// contentExcludedFiles collects files skipped for their content. Reading workers
+// record concurrently, so access is serialized.
+type contentExcludedFiles struct {
+	mu    sync.Mutex
+	files []ContentExcludedFile
+}
+
+// record notes a skipped file; a nil tracker records nothing
+func (c *contentExcludedFiles) record(path, pattern string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.files = append(c.files, ContentExcludedFile{Path: EnsureAbsolutePath(path), Pattern: pattern})
+}
+
+// matchesContentPattern reports whether the head of content matches one of the
+// configured content patterns, logging and recording the file if so
+func matchesContentPattern(path string, content []byte, config *Config) bool {
+	if len(config.ExcludeContentPatterns) == 0 {
+		return false
+	}
+	head := content[:min(len(content), contentPatternHeadBytes)]
+	for _, pattern := range config.ExcludeContentPatterns {
+		if pattern.Match(head) {
+			config.Logger.Printf("Verbose: Skipping %s: content matches exclude pattern %s\n", path, pattern)
+			config.contentExcluded.record(path, pattern.String())
+			return true
+		}
+	}
+	return false
+}
+
+// ContentExcludedFiles returns the files skipped by ExcludeContentPatterns, sorted by path
+func (c *Config) ContentExcludedFiles() []ContentExcludedFile {
+	if c.contentExcluded == nil {
+		return nil
+	}
+	c.contentExcluded.mu.Lock()
+	defer c.contentExcluded.mu.Unlock()
+	files := append([]ContentExcludedFile(nil), c.contentExcluded.files...)
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files
+}
@@ -0,0 +1,103 @@
+package fileutil
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestGeneratedFilePatterns(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		generated bool
+	}{
+		{"go generate", "// Code generated by stringer -type=Kind; DO NOT EDIT.\n\npackage kind\n", true},
+		{"protoc-gen-go after license", "// Copyright 2024\n\n// Code generated by protoc-gen-go. DO NOT EDIT.\n// versions:\n", true},
+		{"protobuf-ts", "// @generated by protobuf-ts 2.9.4\n// tslint:disable\n", true},
+		{"jsdoc block", "/**\n * @generated SignedSource<<abc>>\n */\n", true},
+		{"python comment", "# @generated by thrift\nimport os\n", true},
+		{"openapi client", "/* tslint:disable */\n/**\n * This file was automatically generated by openapi-generator.\n */\n", true},
+		{"dotnet", "//------\n// <auto-generated>\n//     This code was generated by a tool.\n", true},
+		{"hand written go", "package main\n\nfunc main() {}\n", false},
+		{"marker mentioned mid-line", "package lint\n\nconst marker = \"// Code generated by x. DO NOT EDIT.\"\n", false},
+		{"missing DO NOT EDIT", "// Code generated by hand, feel free to edit\npackage main\n", false},
+		{"prose about generation", "# Docs\n\nThis file was written by hand and is never generated.\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewConfig(false, "", "", "", "", NewMockLogger())
+			config.ExcludeContentPatterns = GeneratedFilePatterns
+
+			if got := matchesContentPattern("file", []byte(tt.content), config); got != tt.generated {
+				t.Errorf("matchesContentPattern(%q) = %v, want %v", tt.content, got, tt.generated)
+			}
+		})
+	}
+}
+
+func TestGatherProjectContextExcludeContentPatterns(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTree(t, tempDir, map[string]string{
+		"main.go":          "package main\n",
+		"api/api.pb.go":    "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage api\n",
+		"web/client.ts":    "// @generated\nexport const x = 1\n",
+		"late/marker.go":   strings.Repeat("// padding\n", 200) + "// Code generated by x. DO NOT EDIT.\n",
+		"docs/overview.md": "# Overview\n",
+	})
+
+	logger := NewMockLogger()
+	config := NewConfig(false, "", "", "", "", logger)
+	config.ExcludeContentPatterns = GeneratedFilePatterns
+
+	files, count, err := GatherProjectContext([]string{tempDir}, config)
+	if err != nil {
+		t.Fatalf("GatherProjectContext returned error: %v", err)
+	}
+	// Markers past the first kilobyte don't count
+	if len(files) != 3 || count != 3 {
+		t.Errorf("gathered %d files (count %d), want 3", len(files), count)
+	}
+
+	excluded := config.ContentExcludedFiles()
+	want := []string{"api/api.pb.go", "web/client.ts"}
+	if len(excluded) != len(want) {
+		t.Fatalf("ContentExcludedFiles() = %v, want %v", excluded, want)
+	}
+	for i, name := range want {
+		path := filepath.Join(tempDir, filepath.FromSlash(name))
+		if excluded[i].Path != path {
+			t.Errorf("ContentExcludedFiles()[%d].Path = %s, want %s", i, excluded[i].Path, path)
+		}
+		if excluded[i].Pattern == "" {
+			t.Errorf("ContentExcludedFiles()[%d].Pattern is empty", i)
+		}
+		if !logger.ContainsMessage("Verbose: Skipping " + path) {
+			t.Errorf("expected a log message for %s", path)
+		}
+	}
+}
+
+func TestProcessFileExcludeContentPatterns(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTree(t, tempDir, map[string]string{
+		"keep.txt": "hello\n",
+		"skip.txt": "SKIP ME\nhello\n",
+	})
+
+	config := NewConfig(false, "", "", "", "", NewMockLogger())
+	config.ExcludeContentPatterns = []*regexp.Regexp{regexp.MustCompile(`^SKIP ME`)}
+
+	var files []FileMeta
+	processFile(tempDir, filepath.Join(tempDir, "keep.txt"), &files, config)
+	processFile(tempDir, filepath.Join(tempDir, "skip.txt"), &files, config)
+
+	if len(files) != 1 || filepath.Base(files[0].Path) != "keep.txt" {
+		t.Errorf("processFile kept %v, want only keep.txt", files)
+	}
+	if excluded := config.ContentExcludedFiles(); len(excluded) != 1 || excluded[0].Pattern != "^SKIP ME" {
+		t.Errorf("ContentExcludedFiles() = %v, want skip.txt matching ^SKIP ME", excluded)
+	}
+}
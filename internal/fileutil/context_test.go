@@ -3,6 +3,8 @@ package fileutil
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"testing"
 )
 
@@ -515,3 +517,65 @@ func TestPathNormalization(t *testing.T) {
 		}
 	}
 }
+
+func TestGatherProjectContextIncludeHidden(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTree(t, tempDir, map[string]string{
+		"main.go":                  "package main\n",
+		".env.example":             "API_KEY=\n",
+		".env":                     "API_KEY=secret\n",
+		".gitignore":               ".env\n",
+		".github/workflows/ci.yml": "on: push\n",
+		".cache/state.json":        "{}\n",
+		".git/config":              "[core]\n",
+		".git/HEAD":                "ref: refs/heads/main\n",
+	})
+
+	tests := []struct {
+		name          string
+		includeHidden bool
+		excludeNames  string
+		want          []string
+	}{
+		{
+			name: "hidden files skipped by default",
+			want: []string{"main.go"},
+		},
+		{
+			name:          "include hidden keeps dotfiles and dot-directories",
+			includeHidden: true,
+			want:          []string{".cache/state.json", ".env.example", ".github/workflows/ci.yml", ".gitignore", "main.go"},
+		},
+		{
+			name:          "include hidden still honours exclude names",
+			includeHidden: true,
+			excludeNames:  ".cache,.github",
+			want:          []string{".env.example", ".gitignore", "main.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewConfig(false, "", "", tt.excludeNames, "", NewMockLogger())
+			config.IncludeHidden = tt.includeHidden
+
+			files, _, err := GatherProjectContext([]string{tempDir}, config)
+			if err != nil {
+				t.Fatalf("GatherProjectContext returned error: %v", err)
+			}
+
+			var got []string
+			for _, file := range files {
+				rel, err := filepath.Rel(tempDir, file.Path)
+				if err != nil {
+					t.Fatalf("Rel: %v", err)
+				}
+				got = append(got, filepath.ToSlash(rel))
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("gathered %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
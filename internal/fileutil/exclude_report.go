@@ -0,0 +1,71 @@
+package fileutil
+
+import (
+	"sync"
+)
+
+// Exclude rule kinds reported in ExcludeRuleMatch
+const (
+	ExcludeKindExtension = "extension"
+	ExcludeKindName      = "name"
+)
+
+// ExcludeRuleMatch reports how many paths a single exclude rule skipped.
+// A skipped directory counts once; files beneath it are never visited.
+type ExcludeRuleMatch struct {
+	Kind    string // ExcludeKindExtension or ExcludeKindName
+	Rule    string // The configured extension or name
+	Matched int    // Number of paths skipped because of this rule
+}
+
+// excludeCounter attributes skipped paths to the exclude rule that caused them.
+// It is shared by the concurrent filtering workers, so access is serialized.
+type excludeCounter struct {
+	mu     sync.Mutex
+	counts map[ExcludeRuleMatch]int
+}
+
+// record counts one match for a rule; a nil counter records nothing
+func (c *excludeCounter) record(kind, rule string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[ExcludeRuleMatch]int)
+	}
+	c.counts[ExcludeRuleMatch{Kind: kind, Rule: rule}]++
+}
+
+// count returns the matches recorded for a rule
+func (c *excludeCounter) count(kind, rule string) int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[ExcludeRuleMatch{Kind: kind, Rule: rule}]
+}
+
+// ExcludeMatches reports every configured exclude rule with the number of paths it skipped,
+// names first and then extensions, in configuration order. Rules that matched nothing
+// are included with a zero count so unused rules are easy to spot.
+func (c *Config) ExcludeMatches() []ExcludeRuleMatch {
+	matches := make([]ExcludeRuleMatch, 0, len(c.ExcludeNames)+len(c.ExcludeExts))
+	for _, name := range c.ExcludeNames {
+		matches = append(matches, ExcludeRuleMatch{
+			Kind:    ExcludeKindName,
+			Rule:    name,
+			Matched: c.excludeCounts.count(ExcludeKindName, name),
+		})
+	}
+	for _, ext := range c.ExcludeExts {
+		matches = append(matches, ExcludeRuleMatch{
+			Kind:    ExcludeKindExtension,
+			Rule:    ext,
+			Matched: c.excludeCounts.count(ExcludeKindExtension, ext),
+		})
+	}
+	return matches
+}
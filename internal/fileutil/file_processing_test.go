@@ -101,7 +101,7 @@ func TestProcessFileErrors(t *testing.T) {
 			var files []FileMeta
 
 			// Process the file
-			processFile(tt.path, &files, config)
+			processFile("", tt.path, &files, config)
 
 			// For binary file case, verify it was skipped
 			if tt.name == "Binary file detection" {
@@ -155,7 +155,7 @@ func TestPathConversionError(t *testing.T) {
 	// Just check that we can handle the warning without a crash
 	// This test is mainly to ensure code coverage for the filepath.Abs error handling path
 	logger.ClearMessages()
-	processFile("non/existent/relative/path.txt", &files, config)
+	processFile("", "non/existent/relative/path.txt", &files, config)
 
 	// Verify we logged a warning about the file read error
 	if !logger.ContainsMessage("Cannot read file") {
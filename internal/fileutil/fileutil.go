@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strings"
 	"unicode"
@@ -18,21 +19,66 @@ import (
 type FileMeta struct {
 	Path    string
 	Content string
+
+	// Priority ranks the file for trimming: files with a lower priority are dropped
+	// first when the context must shrink. 0 unless it matches Config.PriorityGlobs.
+	Priority int
 }
 
 // Config holds file processing configuration
 type Config struct {
-	Verbose        bool
-	IncludeExts    []string
-	ExcludeExts    []string
-	ExcludeNames   []string
-	Format         string
-	Logger         logutil.LoggerInterface
-	GitAvailable   bool
-	GitChecker     *GitChecker // Cached git operations (created automatically if nil)
-	processedFiles int
-	totalFiles     int               // For verbose logging
-	fileCollector  func(path string) // Optional callback to collect processed file paths
+	Verbose         bool
+	IncludeExts     []string
+	ExcludeExts     []string
+	ExcludeNames    []string
+	Format          string
+	Logger          logutil.LoggerInterface
+	GitAvailable    bool
+	GitChecker      *GitChecker // Cached git operations (created automatically if nil)
+	processedFiles  int
+	totalFiles      int                   // For verbose logging
+	fileCollector   func(path string)     // Optional callback to collect processed file paths
+	excludeCounts   *excludeCounter       // Per-rule skip counts (nil = not tracked)
+	oversized       *oversizedFiles       // Files skipped for size (nil = not tracked)
+	contentExcluded *contentExcludedFiles // Files skipped by content pattern (nil = not tracked)
+	skipped         *skippedPaths         // Paths skipped by any filter, when RecordSkips is set
+
+	// NormalizeLineEndings converts CRLF and lone CR line endings to LF in file content.
+	// Off by default so content is passed through byte-for-byte.
+	NormalizeLineEndings bool
+
+	// IncludeGlobs limits processing to files matching at least one pattern (see MatchGlob),
+	// matched against paths relative to the input path each file was found under.
+	// Empty means all files.
+	IncludeGlobs []string
+
+	// PriorityGlobs marks files to keep when the context is trimmed (see PriorityScore).
+	// They do not filter anything.
+	PriorityGlobs []string
+
+	// Workers is the number of goroutines per gathering stage (walking, filtering,
+	// reading). 0 means runtime.NumCPU(); values are capped at 32.
+	Workers int
+
+	// MaxFileSizeBytes skips files larger than this before reading them (0 = unlimited).
+	// Skipped files are reported by OversizedFiles.
+	MaxFileSizeBytes int64
+
+	// FollowSymlinks walks into symlinked directories (off by default). Each directory
+	// is walked once, so links that form cycles are skipped; broken links are logged.
+	FollowSymlinks bool
+
+	// ExcludeContentPatterns skips files whose first kilobyte matches any pattern,
+	// such as generated-code markers. Skipped files are reported by ContentExcludedFiles.
+	ExcludeContentPatterns []*regexp.Regexp
+
+	// RecordSkips records every path a filter leaves out, with the reason, for
+	// SkippedPaths. Off by default since large trees can skip many paths.
+	RecordSkips bool
+
+	// IncludeHidden gathers dotfiles and dot-directories, which are skipped by default.
+	// Exclude lists and .gitignore still apply, and .git is always skipped.
+	IncludeHidden bool
 }
 
 // parseExtensions splits a comma-separated string and normalizes extensions (lowercase, with dot prefix)
@@ -72,50 +118,66 @@ func NewConfig(verbose bool, include, exclude, excludeNames, format string, logg
 	}
 
 	return &Config{
-		Verbose:      verbose,
-		Format:       format,
-		Logger:       logger,
-		GitAvailable: gitErr == nil,
-		GitChecker:   NewGitChecker(),
-		IncludeExts:  parseExtensions(include),
-		ExcludeExts:  parseExtensions(exclude),
-		ExcludeNames: parseNames(excludeNames),
+		Verbose:         verbose,
+		Format:          format,
+		Logger:          logger,
+		GitAvailable:    gitErr == nil,
+		GitChecker:      NewGitChecker(),
+		IncludeExts:     parseExtensions(include),
+		ExcludeExts:     parseExtensions(exclude),
+		ExcludeNames:    parseNames(excludeNames),
+		excludeCounts:   &excludeCounter{},
+		oversized:       &oversizedFiles{},
+		contentExcluded: &contentExcludedFiles{},
+		skipped:         &skippedPaths{},
 	}
 }
 
-// SetFileCollector sets a callback function that will be called for each processed file
+// SetFileCollector sets a callback function that will be called for each processed file.
+// It is called from a single goroutine in path order once gathering finishes.
 func (c *Config) SetFileCollector(collector func(path string)) {
 	c.fileCollector = collector
 }
 
-// isGitIgnored checks if a file is likely ignored by git or is hidden.
+// isGitIgnored checks if a file is likely ignored by git or is hidden
+// (unless config.IncludeHidden is set).
 func isGitIgnored(path string, config *Config) bool {
+	return gitIgnoreReason(path, config) != SkipNone
+}
+
+// gitIgnoreReason reports whether path is inside .git, ignored by git, or hidden
+// (unless config.IncludeHidden is set), returning SkipNone otherwise
+func gitIgnoreReason(path string, config *Config) SkipReason {
 	base := filepath.Base(path)
 
 	// Always ignore .git directory contents
 	if base == ".git" || strings.Contains(path, string(filepath.Separator)+".git"+string(filepath.Separator)) {
-		return true
+		return SkipGitDirectory
 	}
 
-	// Check git ignore status if git is available
-	if config.GitAvailable && config.GitChecker != nil {
-		dir := filepath.Dir(path)
-		isIgnored, err := config.GitChecker.IsIgnored(dir, base)
-		if err != nil {
-			config.Logger.Printf("Verbose: Error running git check-ignore for %s: %v. Falling back.\n", path, err)
-		} else if isIgnored {
+	// Prefer the parsed .gitignore files; only shell out to git when none apply
+	if config.GitChecker != nil {
+		isIgnored, found := config.GitChecker.MatchGitignore(path)
+		if !found && config.GitAvailable {
+			var err error
+			isIgnored, err = config.GitChecker.IsIgnored(filepath.Dir(path), base)
+			if err != nil {
+				config.Logger.Printf("Verbose: Error running git check-ignore for %s: %v. Falling back.\n", path, err)
+			}
+		}
+		if isIgnored {
 			config.Logger.Printf("Verbose: Git ignored: %s\n", path)
-			return true
+			return SkipGitignored
 		}
 	}
 
 	// Check if hidden file/directory (starts with dot)
-	if strings.HasPrefix(base, ".") && base != "." && base != ".." {
+	if !config.IncludeHidden && strings.HasPrefix(base, ".") && base != "." && base != ".." {
 		config.Logger.Printf("Verbose: Hidden file/dir ignored: %s\n", path)
-		return true
+		return SkipHidden
 	}
 
-	return false
+	return SkipNone
 }
 
 // Constants for binary file detection
@@ -139,58 +201,114 @@ func isBinaryFile(content []byte) bool {
 	return float64(nonPrintable) > float64(sampleSize)*binaryNonPrintableThreshold
 }
 
+// normalizeLineEndings converts CRLF and lone CR line endings to LF.
+func normalizeLineEndings(content []byte) []byte {
+	if bytes.IndexByte(content, '\r') == -1 {
+		return content
+	}
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	return bytes.ReplaceAll(content, []byte("\r"), []byte("\n"))
+}
+
 func isWhitespace(b byte) bool {
 	return b == '\n' || b == '\r' || b == '\t' || b == ' '
 }
 
-// shouldProcess checks all filters for a given file path.
-func shouldProcess(path string, config *Config) bool {
+// shouldProcess checks all filters for a file path found under root, recording
+// the reason when one skips it.
+func shouldProcess(root, path string, config *Config) bool {
+	reason := skipReason(root, path, config)
+	if reason != SkipNone {
+		config.recordSkip(path, false, reason)
+		return false
+	}
+	return true
+}
+
+// skipReason returns the first filter that skips path, or SkipNone if it passes them all.
+// Include globs are matched relative to root (see globPath).
+func skipReason(root, path string, config *Config) SkipReason {
 	base := filepath.Base(path)
 	ext := strings.ToLower(filepath.Ext(path))
 
 	// Check if explicitly excluded by name
 	if slices.Contains(config.ExcludeNames, base) {
 		config.Logger.Printf("Verbose: Skipping excluded name: %s\n", path)
-		return false
+		config.excludeCounts.record(ExcludeKindName, base)
+		return SkipExcludedName
 	}
 
 	// Check if gitignored or hidden (handles .git implicitly)
-	if isGitIgnored(path, config) {
-		return false
+	if reason := gitIgnoreReason(path, config); reason != SkipNone {
+		return reason
 	}
 
 	// Check include extensions (if specified)
 	if len(config.IncludeExts) > 0 && !slices.Contains(config.IncludeExts, ext) {
 		config.Logger.Printf("Verbose: Skipping non-included extension: %s (%s)\n", path, ext)
-		return false
+		return SkipExtensionNotIncluded
 	}
 
 	// Check exclude extensions
 	if slices.Contains(config.ExcludeExts, ext) {
 		config.Logger.Printf("Verbose: Skipping excluded extension: %s (%s)\n", path, ext)
-		return false
+		config.excludeCounts.record(ExcludeKindExtension, ext)
+		return SkipExcludedExtension
 	}
 
-	return true
-}
+	// Check include globs (if specified)
+	if !matchesIncludeGlobs(root, path, config.IncludeGlobs) {
+		config.Logger.Printf("Verbose: Skipping path not matched by include globs: %s\n", path)
+		return SkipIncludeGlob
+	}
 
-// processFile reads, checks, and adds a file to the FileMeta slice.
-func processFile(path string, files *[]FileMeta, config *Config) {
-	config.totalFiles++ // Increment total count when we attempt to process
+	return SkipNone
+}
 
-	// Run all checks first
-	if !shouldProcess(path, config) {
-		return // Already logged why it was skipped
+// readForContext reads a file that passed shouldProcess, applying the checks that
+// need its size or content. It returns false, recording the reason, if the file
+// is skipped.
+func readForContext(path string, config *Config) ([]byte, bool) {
+	if exceedsMaxFileSize(path, config) {
+		config.recordSkip(path, false, SkipTooLarge)
+		return nil, false
 	}
 
 	content, err := ReadFileContent(path)
 	if err != nil {
 		config.Logger.Printf("Warning: Cannot read file %s: %v\n", path, err)
-		return
+		config.recordSkip(path, false, SkipUnreadable)
+		return nil, false
 	}
 
 	if isBinaryFile(content) {
 		config.Logger.Printf("Verbose: Skipping binary file: %s\n", path)
+		config.recordSkip(path, false, SkipBinary)
+		return nil, false
+	}
+
+	if matchesContentPattern(path, content, config) {
+		config.recordSkip(path, false, SkipContentPattern)
+		return nil, false
+	}
+
+	if config.NormalizeLineEndings {
+		content = normalizeLineEndings(content)
+	}
+	return content, true
+}
+
+// processFile reads, checks, and adds a file found under root to the FileMeta slice.
+func processFile(root, path string, files *[]FileMeta, config *Config) {
+	config.totalFiles++ // Increment total count when we attempt to process
+
+	// Run all checks first
+	if !shouldProcess(root, path, config) {
+		return // Already logged why it was skipped
+	}
+
+	content, ok := readForContext(path, config)
+	if !ok {
 		return
 	}
 
@@ -206,8 +324,9 @@ func processFile(path string, files *[]FileMeta, config *Config) {
 
 	// Create a FileMeta and add it to the slice
 	*files = append(*files, FileMeta{
-		Path:    EnsureAbsolutePath(path),
-		Content: string(content),
+		Path:     EnsureAbsolutePath(path),
+		Content:  string(content),
+		Priority: PriorityScore(root, path, config.PriorityGlobs),
 	})
 }
 
@@ -228,8 +347,9 @@ func GatherProjectContextWithContext(ctx context.Context, paths []string, config
 		config.Logger.DebugContext(ctx, "Starting GatherProjectContext with correlation ID: %s", correlationID)
 	}
 
-	// Delegate to concurrent implementation with default configuration
+	// Delegate to concurrent implementation with the configured pool size
 	concCfg := NewDefaultConcurrentConfig(ctx)
+	concCfg.MaxWorkers = normalizeWorkers(config.Workers)
 	return GatherProjectContextConcurrent(ctx, paths, config, concCfg)
 }
 
@@ -420,7 +420,7 @@ func CreateFilteringOptions(config *Config) FilteringOptions {
 		IncludeExts:    config.IncludeExts,
 		ExcludeExts:    config.ExcludeExts,
 		ExcludeNames:   config.ExcludeNames,
-		IgnoreHidden:   true, // Default behavior
+		IgnoreHidden:   !config.IncludeHidden,
 		IgnoreGitFiles: true, // Default behavior
 	}
 }
@@ -453,10 +453,10 @@ func GetAbsolutePath(path string) (string, error) {
 	return filepath.Abs(path)
 }
 
-// EnsureAbsolutePath returns an absolute path, falling back to original if conversion fails.
+// EnsureAbsolutePath returns a clean absolute path, falling back to original if conversion fails.
 func EnsureAbsolutePath(path string) string {
 	if filepath.IsAbs(path) {
-		return path
+		return filepath.Clean(path)
 	}
 	if abs, err := filepath.Abs(path); err == nil {
 		return abs
@@ -251,7 +251,7 @@ func TestShouldProcess(t *testing.T) {
 			}
 
 			// Run the test
-			result := shouldProcess(tt.path, config)
+			result := shouldProcess("", tt.path, config)
 
 			// Check the result
 			if result != tt.expected {
@@ -27,8 +27,12 @@ import (
 //   - Caching CheckGitIgnore is NOT effective for single-pass file walks:
 //     each filename is unique, resulting in 0% cache hit rate. Therefore,
 //     git ignore checks are not cached.
+//
+// Ignore checks consult parsed .gitignore files first and only run git when
+// none apply, so large trees don't pay for a subprocess per file.
 type GitChecker struct {
 	repoCache sync.Map // absolute dir path -> isRepo (bool)
+	gitignore gitignoreMatcher
 }
 
 // NewGitChecker creates a new GitChecker instance.
@@ -71,9 +75,8 @@ func (gc *GitChecker) IsRepo(dir string) bool {
 // This method does NOT cache results because:
 //   - In single-pass file walks, each filename is unique (0% hit rate)
 //   - Caching would add O(files) memory overhead with no benefit
-//   - The subprocess cost is unavoidable for accurate gitignore checking
-//
-// For batch operations, consider using CheckGitIgnoreBatch instead.
+//   - Each call spawns a git subprocess, so prefer MatchGitignore and use
+//     this only when no parsed .gitignore applies
 //
 // Returns:
 //   - true if the file is ignored by git
@@ -87,6 +90,15 @@ func (gc *GitChecker) IsIgnored(dir, filename string) (bool, error) {
 	return checkGitIgnoreUncached(dir, filename)
 }
 
+// MatchGitignore reports whether path is ignored according to the parsed
+// .gitignore files between it and its repository root, without running git.
+// found is false when no ignore file applies; use IsIgnored in that case.
+//
+// This method is safe for concurrent use.
+func (gc *GitChecker) MatchGitignore(path string) (ignored, found bool) {
+	return gc.gitignore.Match(path)
+}
+
 // CacheStats returns the number of cached entries for diagnostics.
 // This is useful for testing and debugging.
 func (gc *GitChecker) CacheStats() int {
@@ -151,6 +151,9 @@ func TestIsGitIgnoredWithMockGit(t *testing.T) {
 	// Create a temporary directory to act as a git repo
 	tempDir := t.TempDir()
 
+	// Run outside any repository so no parsed .gitignore applies and git is consulted
+	t.Chdir(tempDir)
+
 	// Create a mock git executable script in the temp directory
 	mockGitPath := filepath.Join(tempDir, "git")
 	if isWindows() {
@@ -288,6 +291,9 @@ func TestGitErrorHandling(t *testing.T) {
 	// Create a temporary directory for the mock git
 	tempDir := t.TempDir()
 
+	// Run outside any repository so no parsed .gitignore applies and git is consulted
+	t.Chdir(tempDir)
+
 	// Create a mock git executable that always fails
 	mockGitPath := filepath.Join(tempDir, "git")
 	if isWindows() {
@@ -0,0 +1,228 @@
+package fileutil
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// gitignoreRule is a single parsed line of a .gitignore file
+type gitignoreRule struct {
+	pattern  string // Slash-separated pattern without the leading "!", "/" or trailing "/"
+	negate   bool   // "!pattern" re-includes a previously ignored path
+	dirOnly  bool   // "pattern/" matches directories only
+	anchored bool   // Pattern contains a slash, so it matches relative to the .gitignore's directory
+}
+
+// parseGitignore parses .gitignore content into rules, in file order.
+// Blank lines and comments are skipped; "\#" and "\!" escape a literal first character.
+func parseGitignore(content string) []gitignoreRule {
+	var rules []gitignoreRule
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		// Trailing spaces are ignored unless escaped
+		for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\\ ") {
+			line = line[:len(line)-1]
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var rule gitignoreRule
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, "\\#") || strings.HasPrefix(line, "\\!") {
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if strings.Contains(line, "/") {
+			rule.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		rule.pattern = line
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// matches reports whether the rule applies to a path given relative to the
+// directory holding the .gitignore, as slash-separated segments
+func (r gitignoreRule) matches(rel []string, isDir func() bool) bool {
+	if r.anchored {
+		if !MatchGlob(r.pattern, strings.Join(rel, "/")) {
+			return false
+		}
+	} else if ok, err := path.Match(r.pattern, rel[len(rel)-1]); err != nil || !ok {
+		return false
+	}
+	return !r.dirOnly || isDir()
+}
+
+// gitignoreLevel holds the rules from one directory's .gitignore
+type gitignoreLevel struct {
+	segments []string // Directory relative to the repository root
+	rules    []gitignoreRule
+}
+
+// gitignoreMatcher answers ignore queries from parsed .gitignore files instead
+// of running git. Parsed files and repository roots are cached per directory,
+// so the zero value is ready to use and safe for concurrent use.
+type gitignoreMatcher struct {
+	roots sync.Map // absolute dir -> repository root ("" if not in a repository)
+	rules sync.Map // absolute ignore file path -> []gitignoreRule (nil if missing)
+
+	excludesOnce sync.Once
+	excludesFile string // The user's core.excludesFile, resolved on first use
+}
+
+// Match reports whether filePath is ignored by the user's global excludes file,
+// .git/info/exclude, and the .gitignore files between it and its repository
+// root, in git's order of precedence. A path is also ignored when any directory
+// above it is. found is false when the path is outside a repository or no ignore
+// file applies, in which case callers should fall back to git.
+func (m *gitignoreMatcher) Match(filePath string) (ignored, found bool) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return false, false
+	}
+	root := m.repoRoot(filepath.Dir(absPath))
+	if root == "" {
+		return false, false
+	}
+	rel, err := filepath.Rel(root, absPath)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false, false
+	}
+	segments := strings.Split(filepath.ToSlash(rel), "/")
+
+	// Collect the ignore files from the root down to the path's directory
+	var levels []gitignoreLevel
+	if excludesFile := m.globalExcludesFile(); excludesFile != "" {
+		if rules := m.dirRules(excludesFile); len(rules) > 0 {
+			levels = append(levels, gitignoreLevel{rules: rules})
+		}
+	}
+	if rules := m.dirRules(filepath.Join(root, ".git", "info", "exclude")); len(rules) > 0 {
+		levels = append(levels, gitignoreLevel{rules: rules})
+	}
+	dir := root
+	for i := 0; i < len(segments); i++ {
+		if i > 0 {
+			dir = filepath.Join(dir, segments[i-1])
+		}
+		if rules := m.dirRules(filepath.Join(dir, ".gitignore")); len(rules) > 0 {
+			levels = append(levels, gitignoreLevel{segments: segments[:i], rules: rules})
+		}
+	}
+	if len(levels) == 0 {
+		return false, false
+	}
+
+	// An ignored parent directory hides everything beneath it
+	for depth := 1; depth <= len(segments); depth++ {
+		candidate := segments[:depth]
+		isDir := func() bool { return true }
+		if depth == len(segments) {
+			isDir = lazyIsDir(absPath)
+		}
+		if matchLevels(levels, candidate, isDir) {
+			return true, true
+		}
+	}
+	return false, true
+}
+
+// matchLevels applies every level's rules to candidate in order; the last
+// matching rule wins, so deeper .gitignore files override shallower ones
+func matchLevels(levels []gitignoreLevel, candidate []string, isDir func() bool) bool {
+	ignored := false
+	for _, level := range levels {
+		if len(level.segments) >= len(candidate) {
+			continue // The .gitignore is at or below the candidate
+		}
+		rel := candidate[len(level.segments):]
+		for _, rule := range level.rules {
+			if rule.matches(rel, isDir) {
+				ignored = !rule.negate
+			}
+		}
+	}
+	return ignored
+}
+
+// repoRoot returns the nearest directory at or above dir that contains .git, or ""
+func (m *gitignoreMatcher) repoRoot(dir string) string {
+	if cached, ok := m.roots.Load(dir); ok {
+		return cached.(string)
+	}
+	root := ""
+	if _, err := os.Lstat(filepath.Join(dir, ".git")); err == nil {
+		root = dir
+	} else if parent := filepath.Dir(dir); parent != dir {
+		root = m.repoRoot(parent)
+	}
+	m.roots.Store(dir, root)
+	return root
+}
+
+// dirRules returns the parsed rules from ignoreFile, cached under its path
+func (m *gitignoreMatcher) dirRules(ignoreFile string) []gitignoreRule {
+	if cached, ok := m.rules.Load(ignoreFile); ok {
+		return cached.([]gitignoreRule)
+	}
+	var rules []gitignoreRule
+	if content, err := os.ReadFile(ignoreFile); err == nil {
+		rules = parseGitignore(string(content))
+	}
+	m.rules.Store(ignoreFile, rules)
+	return rules
+}
+
+// globalExcludesFile returns the path of the user's global ignore file: git's
+// core.excludesFile setting, or $XDG_CONFIG_HOME/git/ignore (~/.config/git/ignore)
+// when it is unset or git cannot be run. It is resolved once per matcher.
+func (m *gitignoreMatcher) globalExcludesFile() string {
+	m.excludesOnce.Do(func() {
+		out, err := exec.Command("git", "config", "--global", "--path", "--get", "core.excludesFile").Output()
+		if configured := strings.TrimSpace(string(out)); err == nil && configured != "" {
+			m.excludesFile = configured
+			return
+		}
+		configHome := os.Getenv("XDG_CONFIG_HOME")
+		if configHome == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return
+			}
+			configHome = filepath.Join(home, ".config")
+		}
+		m.excludesFile = filepath.Join(configHome, "git", "ignore")
+	})
+	return m.excludesFile
+}
+
+// lazyIsDir returns a function that stats absPath on first use only, since
+// most rules never need to know whether a path is a directory
+func lazyIsDir(absPath string) func() bool {
+	var once sync.Once
+	var isDir bool
+	return func() bool {
+		once.Do(func() {
+			info, err := os.Stat(absPath)
+			isDir = err == nil && info.IsDir()
+		})
+		return isDir
+	}
+}
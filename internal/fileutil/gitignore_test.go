@@ -0,0 +1,191 @@
+package fileutil
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseGitignore(t *testing.T) {
+	content := "# comment\n\n*.log\n!keep.log\nbuild/\n/root.txt\ndocs/*.md\n\\#hash\n\\!bang\ntrailing   \r\n/\n"
+
+	expected := []gitignoreRule{
+		{pattern: "*.log"},
+		{pattern: "keep.log", negate: true},
+		{pattern: "build", dirOnly: true},
+		{pattern: "root.txt", anchored: true},
+		{pattern: "docs/*.md", anchored: true},
+		{pattern: "#hash"},
+		{pattern: "!bang"},
+		{pattern: "trailing"},
+	}
+
+	if got := parseGitignore(content); !reflect.DeepEqual(got, expected) {
+		t.Errorf("parseGitignore() =\n%+v\nwant\n%+v", got, expected)
+	}
+}
+
+// writeTree creates files (with parent directories) beneath root
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		fullPath := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0640); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+// matcherWithExcludesFile returns a matcher whose global excludes file is
+// excludesFile rather than the user's, so tests don't depend on their git config
+func matcherWithExcludesFile(excludesFile string) *gitignoreMatcher {
+	matcher := &gitignoreMatcher{}
+	matcher.excludesOnce.Do(func() { matcher.excludesFile = excludesFile })
+	return matcher
+}
+
+func TestGitignoreMatcher(t *testing.T) {
+	repo := t.TempDir()
+	writeTree(t, repo, map[string]string{
+		".git/HEAD":           "ref: refs/heads/main\n",
+		".git/info/exclude":   "secret.txt\n",
+		".gitignore":          "*.log\n!keep.log\nbuild/\n/root.txt\ndocs/**/*.tmp\n",
+		"app.log":             "",
+		"keep.log":            "",
+		"main.go":             "",
+		"root.txt":            "",
+		"secret.txt":          "",
+		"build/out.bin":       "",
+		"docs/a/b/notes.tmp":  "",
+		"docs/readme.md":      "",
+		"sub/.gitignore":      "!*.log\nlocal.txt\n",
+		"sub/debug.log":       "",
+		"sub/local.txt":       "",
+		"sub/root.txt":        "",
+		"sub/build":           "", // A file named like the directory-only rule
+		"sub/nested/deep.txt": "",
+	})
+
+	tests := []struct {
+		path    string
+		ignored bool
+	}{
+		{"app.log", true},
+		{"keep.log", false},               // Negated
+		{"main.go", false},                // No rule matches
+		{"root.txt", true},                // Anchored to the repository root
+		{"sub/root.txt", false},           // Anchored pattern does not match deeper
+		{"secret.txt", true},              // From .git/info/exclude
+		{"build", true},                   // Directory-only rule matches the directory
+		{"build/out.bin", true},           // Inside an ignored directory
+		{"sub/build", false},              // Directory-only rule skips files
+		{"docs/a/b/notes.tmp", true},      // "**" spans directories
+		{"docs/readme.md", false},         // Outside the glob
+		{"sub/debug.log", false},          // Deeper .gitignore re-includes
+		{"sub/local.txt", true},           // Deeper .gitignore adds rules
+		{"sub/nested/deep.txt", false},    // Nothing applies
+		{"sub/nested/../local.txt", true}, // Paths are cleaned
+	}
+
+	matcher := matcherWithExcludesFile("")
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			ignored, found := matcher.Match(filepath.Join(repo, filepath.FromSlash(tt.path)))
+			if !found {
+				t.Fatalf("Match(%q) found no ignore files", tt.path)
+			}
+			if ignored != tt.ignored {
+				t.Errorf("Match(%q) = %v, want %v", tt.path, ignored, tt.ignored)
+			}
+		})
+	}
+}
+
+func TestGitignoreMatcherNotFound(t *testing.T) {
+	outside := t.TempDir()
+	writeTree(t, outside, map[string]string{
+		".gitignore": "*.log\n", // Not in a repository, so git would not apply it
+		"app.log":    "",
+	})
+
+	repo := t.TempDir()
+	writeTree(t, repo, map[string]string{
+		".git/HEAD": "ref: refs/heads/main\n",
+		"app.log":   "",
+	})
+
+	matcher := matcherWithExcludesFile("")
+	for _, path := range []string{filepath.Join(outside, "app.log"), filepath.Join(repo, "app.log"), repo} {
+		if _, found := matcher.Match(path); found {
+			t.Errorf("Match(%q) should report no applicable ignore files", path)
+		}
+	}
+}
+
+func TestGitignoreMatcherGlobalExcludes(t *testing.T) {
+	excludesFile := filepath.Join(t.TempDir(), "ignore")
+	if err := os.WriteFile(excludesFile, []byte("*.swp\n.idea/\n*.log\n"), 0640); err != nil {
+		t.Fatalf("Failed to write excludes file: %v", err)
+	}
+
+	repo := t.TempDir()
+	writeTree(t, repo, map[string]string{
+		".git/HEAD":         "ref: refs/heads/main\n",
+		".git/info/exclude": "local.txt\n",
+		".gitignore":        "!keep.log\n",
+		"main.go.swp":       "",
+		".idea/workspace":   "",
+		"local.txt":         "",
+		"keep.log":          "",
+		"main.go":           "",
+	})
+
+	tests := []struct {
+		path    string
+		ignored bool
+	}{
+		{"main.go.swp", true},     // From the global excludes file
+		{".idea/workspace", true}, // Inside a globally excluded directory
+		{"local.txt", true},       // .git/info/exclude still applies alongside .gitignore
+		{"keep.log", false},       // .gitignore overrides the global excludes file
+		{"main.go", false},
+	}
+
+	matcher := matcherWithExcludesFile(excludesFile)
+	for _, tt := range tests {
+		ignored, found := matcher.Match(filepath.Join(repo, filepath.FromSlash(tt.path)))
+		if !found || ignored != tt.ignored {
+			t.Errorf("Match(%q) = %v (found %v), want %v", tt.path, ignored, found, tt.ignored)
+		}
+	}
+}
+
+func TestGatherProjectContextWithoutGit(t *testing.T) {
+	repo := t.TempDir()
+	writeTree(t, repo, map[string]string{
+		".git/HEAD":             "ref: refs/heads/main\n",
+		".gitignore":            "node_modules/\n*.log\n",
+		"main.go":               "package main\n",
+		"debug.log":             "log output\n",
+		"node_modules/x/dep.js": "module.exports = {}\n",
+	})
+
+	config := NewConfig(false, "", "", "", "", NewMockLogger())
+	config.GitAvailable = false // .gitignore must be honored without the git binary
+
+	files, _, err := GatherProjectContext([]string{repo}, config)
+	if err != nil {
+		t.Fatalf("GatherProjectContext returned error: %v", err)
+	}
+	if len(files) != 1 || filepath.Base(files[0].Path) != "main.go" {
+		var paths []string
+		for _, file := range files {
+			paths = append(paths, file.Path)
+		}
+		t.Errorf("gathered %v, want only main.go", paths)
+	}
+}
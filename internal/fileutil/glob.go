@@ -0,0 +1,104 @@
+package fileutil
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// MatchGlob reports whether a slash-separated path matches pattern.
+// Each pattern segment is matched with path.Match, and a "**" segment matches
+// zero or more whole segments, so "src/**/*.go" matches both "src/a.go" and
+// "src/a/b/c.go". A malformed pattern matches nothing.
+func MatchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+// ValidateGlob returns an error if any segment of pattern is malformed
+func ValidateGlob(pattern string) error {
+	for _, segment := range strings.Split(pattern, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchSegments matches path segments against pattern segments, expanding "**"
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// Collapse repeated "**" and try every possible number of skipped segments
+			for len(pattern) > 0 && pattern[0] == "**" {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := range name {
+				if matchSegments(pattern, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// globPath returns the form of a file path that globs are matched against:
+// relative to root, the input path the file was found under, with forward
+// slashes. An empty root means the working directory; a path that is not
+// beneath root is returned cleaned.
+func globPath(root, filePath string) string {
+	cleaned := filepath.Clean(filePath)
+	if root == "" {
+		root = "."
+	}
+	if abs, err := filepath.Abs(cleaned); err == nil {
+		if base, err := filepath.Abs(root); err == nil {
+			if rel, err := filepath.Rel(base, abs); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				cleaned = rel
+			}
+		}
+	}
+	return filepath.ToSlash(cleaned)
+}
+
+// matchesIncludeGlobs reports whether filePath, found under root, matches any
+// of globs. An empty list matches everything.
+func matchesIncludeGlobs(root, filePath string, globs []string) bool {
+	if len(globs) == 0 {
+		return true
+	}
+	name := globPath(root, filePath)
+	for _, glob := range globs {
+		if MatchGlob(glob, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// PriorityScore returns the trimming priority under globs of filePath, found
+// under root: len(globs)-i for the first glob i it matches, so earlier patterns
+// rank higher, or 0 when none matches
+func PriorityScore(root, filePath string, globs []string) int {
+	if len(globs) == 0 {
+		return 0
+	}
+	name := globPath(root, filePath)
+	for i, glob := range globs {
+		if MatchGlob(glob, name) {
+			return len(globs) - i
+		}
+	}
+	return 0
+}
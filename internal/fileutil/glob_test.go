@@ -0,0 +1,223 @@
+package fileutil
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		name     string
+		expected bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "src/main.go", false},
+		{"src/*.go", "src/main.go", true},
+		{"src/*.go", "src/a/main.go", false},
+		{"src/**/*.go", "src/main.go", true},
+		{"src/**/*.go", "src/a/b/main.go", true},
+		{"src/**/*.go", "lib/main.go", false},
+		{"**/*_test.go", "main_test.go", true},
+		{"**/*_test.go", "a/b/main_test.go", true},
+		{"**/*_test.go", "a/b/main.go", false},
+		{"src/**", "src/a/b/c.txt", true},
+		{"src/**/**/*.go", "src/main.go", true},
+		{"**/vendor/**/*.go", "a/vendor/x/y.go", true},
+		{"cmd/?ain.go", "cmd/main.go", true},
+		{"[a-c]*/*.go", "lib/main.go", false},
+		{"[a-c]*/*.go", "bin/main.go", true},
+		{"src/[", "src/[", false}, // Malformed patterns match nothing
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"_"+tt.name, func(t *testing.T) {
+			if got := MatchGlob(tt.pattern, tt.name); got != tt.expected {
+				t.Errorf("MatchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateGlob(t *testing.T) {
+	for _, pattern := range []string{"*.go", "src/**/*.go", "**", "a/[bc]/d"} {
+		if err := ValidateGlob(pattern); err != nil {
+			t.Errorf("ValidateGlob(%q) = %v, want nil", pattern, err)
+		}
+	}
+	for _, pattern := range []string{"src/[", "a/\\"} {
+		if err := ValidateGlob(pattern); err == nil {
+			t.Errorf("ValidateGlob(%q) = nil, want an error", pattern)
+		}
+	}
+}
+
+func TestGlobPath(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+
+	outside := filepath.Join(filepath.Dir(cwd), "other")
+
+	tests := []struct {
+		name     string
+		root     string
+		path     string
+		expected string
+	}{
+		{"relative path", "", filepath.Join("src", "main.go"), "src/main.go"},
+		{"dot prefix removed", "", "./" + filepath.Join("src", "main.go"), "src/main.go"},
+		{"absolute path beneath working dir", "", filepath.Join(cwd, "src", "main.go"), "src/main.go"},
+		{"path outside working dir", "", filepath.Join(outside, "main.go"), filepath.ToSlash(filepath.Join(outside, "main.go"))},
+		{"relative to root", "project", filepath.Join("project", "src", "main.go"), "src/main.go"},
+		{"root outside working dir", outside, filepath.Join(outside, "src", "main.go"), "src/main.go"},
+		{"relative root with absolute path", ".", filepath.Join(cwd, "src", "main.go"), "src/main.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := globPath(tt.root, tt.path); got != tt.expected {
+				t.Errorf("globPath(%q, %q) = %q, want %q", tt.root, tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPriorityScore(t *testing.T) {
+	globs := []string{"src/core/**", "src/**/*.go"}
+
+	tests := []struct {
+		path     string
+		globs    []string
+		expected int
+	}{
+		{"src/core/engine.go", globs, 2},
+		{"src/api/handler.go", globs, 1},
+		{"docs/README.md", globs, 0},
+		{"src/core/engine.go", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := PriorityScore("", tt.path, tt.globs); got != tt.expected {
+				t.Errorf("PriorityScore(%q, %v) = %d, want %d", tt.path, tt.globs, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGatherProjectContextPriorityGlobs(t *testing.T) {
+	testDir, cleanup := setupTestDir(t)
+	defer cleanup()
+
+	config := NewConfig(false, "", "", "", "", NewMockLogger())
+	config.PriorityGlobs = []string{"**/src/**/*.go"}
+
+	files, _, err := GatherProjectContext([]string{testDir}, config)
+	if err != nil {
+		t.Fatalf("GatherProjectContext returned error: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("gathered no files")
+	}
+
+	for _, file := range files {
+		rel, err := filepath.Rel(testDir, file.Path)
+		if err != nil {
+			t.Fatalf("Rel: %v", err)
+		}
+		want := 0
+		if rel = filepath.ToSlash(rel); rel == "src/lib.go" || rel == "src/utils/helper.go" {
+			want = 1
+		}
+		if file.Priority != want {
+			t.Errorf("%s has priority %d, want %d", rel, file.Priority, want)
+		}
+	}
+}
+
+func TestGatherProjectContextIncludeGlobs(t *testing.T) {
+	testDir, cleanup := setupTestDir(t)
+	defer cleanup()
+
+	tests := []struct {
+		name     string
+		include  string
+		globs    []string
+		expected []string
+	}{
+		{
+			name:     "glob selects a subtree",
+			globs:    []string{"**/src/**/*.go"},
+			expected: []string{"src/lib.go", "src/utils/helper.go"},
+		},
+		{
+			name:     "any glob may match",
+			globs:    []string{"**/*_test.go", "**/README.md"},
+			expected: []string{"README.md", "tests/lib_test.go"},
+		},
+		{
+			name:     "globs match relative to the input root",
+			globs:    []string{"src/**/*.go"},
+			expected: []string{"src/lib.go", "src/utils/helper.go"},
+		},
+		{
+			name:     "globs compose with extension filters",
+			include:  ".md",
+			globs:    []string{"**/src/**"},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewConfig(false, tt.include, "", "", "", NewMockLogger())
+			config.IncludeGlobs = tt.globs
+
+			files, _, err := GatherProjectContext([]string{testDir}, config)
+			if err != nil {
+				t.Fatalf("GatherProjectContext returned error: %v", err)
+			}
+
+			var got []string
+			for _, file := range files {
+				rel, err := filepath.Rel(testDir, file.Path)
+				if err != nil {
+					t.Fatalf("Rel: %v", err)
+				}
+				got = append(got, filepath.ToSlash(rel))
+			}
+			sort.Strings(got)
+
+			if len(got) != len(tt.expected) {
+				t.Fatalf("gathered %v, want %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("gathered %v, want %v", got, tt.expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestGatherProjectContextIncludeGlobsFileInput(t *testing.T) {
+	testDir, cleanup := setupTestDir(t)
+	defer cleanup()
+
+	config := NewConfig(false, "", "", "", "", NewMockLogger())
+	config.IncludeGlobs = []string{"lib.go"}
+
+	// A file given directly matches by its name, wherever the working directory is
+	files, _, err := GatherProjectContext([]string{filepath.Join(testDir, "src", "lib.go")}, config)
+	if err != nil {
+		t.Fatalf("GatherProjectContext returned error: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("gathered %d files, want 1", len(files))
+	}
+}
@@ -0,0 +1,112 @@
+package fileutil
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// extensionLanguages maps file extensions to the language names used to tag
+// fenced code blocks
+var extensionLanguages = map[string]string{
+	".go":         "go",
+	".py":         "python",
+	".pyi":        "python",
+	".js":         "javascript",
+	".mjs":        "javascript",
+	".cjs":        "javascript",
+	".jsx":        "jsx",
+	".ts":         "typescript",
+	".tsx":        "tsx",
+	".rs":         "rust",
+	".java":       "java",
+	".kt":         "kotlin",
+	".kts":        "kotlin",
+	".scala":      "scala",
+	".swift":      "swift",
+	".c":          "c",
+	".h":          "c",
+	".cc":         "cpp",
+	".cpp":        "cpp",
+	".cxx":        "cpp",
+	".hpp":        "cpp",
+	".cs":         "csharp",
+	".rb":         "ruby",
+	".php":        "php",
+	".lua":        "lua",
+	".pl":         "perl",
+	".r":          "r",
+	".dart":       "dart",
+	".ex":         "elixir",
+	".exs":        "elixir",
+	".erl":        "erlang",
+	".hs":         "haskell",
+	".clj":        "clojure",
+	".sh":         "bash",
+	".bash":       "bash",
+	".zsh":        "zsh",
+	".fish":       "fish",
+	".ps1":        "powershell",
+	".sql":        "sql",
+	".html":       "html",
+	".htm":        "html",
+	".css":        "css",
+	".scss":       "scss",
+	".sass":       "sass",
+	".less":       "less",
+	".vue":        "vue",
+	".svelte":     "svelte",
+	".json":       "json",
+	".yaml":       "yaml",
+	".yml":        "yaml",
+	".toml":       "toml",
+	".ini":        "ini",
+	".xml":        "xml",
+	".proto":      "protobuf",
+	".graphql":    "graphql",
+	".tf":         "hcl",
+	".hcl":        "hcl",
+	".md":         "markdown",
+	".dockerfile": "dockerfile",
+}
+
+// filenameLanguages maps conventional file names without a telling extension to
+// language names. Keys are lower case, so "Makefile" and "makefile" both match.
+var filenameLanguages = map[string]string{
+	"dockerfile":     "dockerfile",
+	"containerfile":  "dockerfile",
+	"makefile":       "makefile",
+	"gnumakefile":    "makefile",
+	"cmakelists.txt": "cmake",
+	"jenkinsfile":    "groovy",
+	"gemfile":        "ruby",
+	"rakefile":       "ruby",
+	"vagrantfile":    "ruby",
+	".bashrc":        "bash",
+	".bash_profile":  "bash",
+	".zshrc":         "zsh",
+}
+
+// LanguageForPath returns the language name for a file, for tagging fenced code
+// blocks. Conventional names such as Dockerfile, Dockerfile.dev or Makefile are
+// recognized before falling back to LanguageForExtension; unknown files return "".
+func LanguageForPath(path string) string {
+	base := strings.ToLower(filepath.Base(path))
+	if lang, ok := filenameLanguages[base]; ok {
+		return lang
+	}
+	if strings.HasPrefix(base, "dockerfile.") {
+		return "dockerfile"
+	}
+	return LanguageForExtension(filepath.Ext(base))
+}
+
+// LanguageForExtension returns the language name for a file extension such as
+// ".go" or "py", for tagging fenced code blocks. Matching is case-insensitive;
+// unknown extensions return "".
+func LanguageForExtension(ext string) string {
+	ext = strings.ToLower(ext)
+	if ext != "" && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return extensionLanguages[ext]
+}
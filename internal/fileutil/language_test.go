@@ -0,0 +1,52 @@
+package fileutil
+
+import "testing"
+
+func TestLanguageForExtension(t *testing.T) {
+	tests := []struct {
+		ext  string
+		want string
+	}{
+		{ext: ".go", want: "go"},
+		{ext: "go", want: "go"},
+		{ext: ".py", want: "python"},
+		{ext: ".PY", want: "python"},
+		{ext: ".ts", want: "typescript"},
+		{ext: ".tsx", want: "tsx"},
+		{ext: ".yml", want: "yaml"},
+		{ext: ".sh", want: "bash"},
+		{ext: ".md", want: "markdown"},
+		{ext: ".unknown", want: ""},
+		{ext: "", want: ""},
+		{ext: ".", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := LanguageForExtension(tt.ext); got != tt.want {
+			t.Errorf("LanguageForExtension(%q) = %q, want %q", tt.ext, got, tt.want)
+		}
+	}
+}
+
+func TestLanguageForPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "cmd/main.go", want: "go"},
+		{path: "build/Dockerfile", want: "dockerfile"},
+		{path: "Dockerfile.dev", want: "dockerfile"},
+		{path: "Makefile", want: "makefile"},
+		{path: "src/makefile", want: "makefile"},
+		{path: "CMakeLists.txt", want: "cmake"},
+		{path: "Gemfile", want: "ruby"},
+		{path: "notes.txt", want: ""},
+		{path: "LICENSE", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := LanguageForPath(tt.path); got != tt.want {
+			t.Errorf("LanguageForPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ package fileutil
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -510,3 +511,90 @@ func TestGatherProjectContextFiltering(t *testing.T) {
 		})
 	}
 }
+
+func TestGatherProjectContextLineEndings(t *testing.T) {
+	tempDir := t.TempDir()
+	crlfPath := filepath.Join(tempDir, "crlf.txt")
+	crlfContent := "line one\r\nline two\r\nold mac\rend\r\n"
+	if err := os.WriteFile(crlfPath, []byte(crlfContent), 0640); err != nil {
+		t.Fatalf("Failed to create CRLF fixture: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		normalize bool
+		expected  string
+	}{
+		{
+			name:      "Normalization disabled keeps content exact",
+			normalize: false,
+			expected:  crlfContent,
+		},
+		{
+			name:      "Normalization enabled converts to LF",
+			normalize: true,
+			expected:  "line one\nline two\nold mac\nend\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{
+				Logger:               NewMockLogger(),
+				NormalizeLineEndings: tt.normalize,
+			}
+
+			files, count, err := GatherProjectContext([]string{crlfPath}, config)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if count != 1 || len(files) != 1 {
+				t.Fatalf("Expected 1 file, got count=%d len=%d", count, len(files))
+			}
+			if files[0].Content != tt.expected {
+				t.Errorf("Expected content %q, got %q", tt.expected, files[0].Content)
+			}
+		})
+	}
+}
+
+func TestGatherProjectContextExcludeMatches(t *testing.T) {
+	tempDir := t.TempDir()
+	fixtures := map[string]string{
+		"main.go":              "package main",
+		"debug.log":            "log line",
+		"trace.log":            "log line",
+		"generated/output.txt": "generated",
+		"generated/more.txt":   "generated",
+	}
+	for rel, content := range fixtures {
+		path := filepath.Join(tempDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0640); err != nil {
+			t.Fatalf("Failed to create fixture: %v", err)
+		}
+	}
+
+	config := NewConfig(false, "", ".log,.exe", "generated,node_modules", "", NewMockLogger())
+	config.GitAvailable = false
+
+	_, count, err := GatherProjectContext([]string{tempDir}, config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("Expected 1 processed file, got %d", count)
+	}
+
+	expected := []ExcludeRuleMatch{
+		{Kind: ExcludeKindName, Rule: "generated", Matched: 1},
+		{Kind: ExcludeKindName, Rule: "node_modules", Matched: 0},
+		{Kind: ExcludeKindExtension, Rule: ".log", Matched: 2},
+		{Kind: ExcludeKindExtension, Rule: ".exe", Matched: 0},
+	}
+	if got := config.ExcludeMatches(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("ExcludeMatches() = %+v, want %+v", got, expected)
+	}
+}
@@ -0,0 +1,59 @@
+package fileutil
+
+import (
+	"sort"
+	"sync"
+)
+
+// OversizedFile is a file skipped because it exceeded Config.MaxFileSizeBytes
+type OversizedFile struct {
+	Path      string
+	SizeBytes int64
+}
+
+// oversizedFiles collects files skipped for size. Reading workers record
+// concurrently, so access is serialized.
+type oversizedFiles struct {
+	mu    sync.Mutex
+	files []OversizedFile
+}
+
+// record notes a skipped file; a nil tracker records nothing
+func (o *oversizedFiles) record(path string, size int64) {
+	if o == nil {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.files = append(o.files, OversizedFile{Path: EnsureAbsolutePath(path), SizeBytes: size})
+}
+
+// exceedsMaxFileSize reports whether path is larger than the configured limit,
+// logging and recording it if so. It stats the file so oversized content is never read.
+func exceedsMaxFileSize(path string, config *Config) bool {
+	if config.MaxFileSizeBytes <= 0 {
+		return false
+	}
+	info, err := StatPath(path)
+	if err != nil || info.Size() <= config.MaxFileSizeBytes {
+		return false // Unreadable files are reported when the read fails
+	}
+	config.Logger.Printf("Warning: Skipping %s: size %d bytes exceeds the %d byte limit\n",
+		path, info.Size(), config.MaxFileSizeBytes)
+	config.oversized.record(path, info.Size())
+	return true
+}
+
+// OversizedFiles returns the files skipped for exceeding MaxFileSizeBytes, sorted by path
+func (c *Config) OversizedFiles() []OversizedFile {
+	if c.oversized == nil {
+		return nil
+	}
+	c.oversized.mu.Lock()
+	defer c.oversized.mu.Unlock()
+	files := append([]OversizedFile(nil), c.oversized.files...)
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files
+}
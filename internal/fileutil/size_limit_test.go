@@ -0,0 +1,66 @@
+package fileutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGatherProjectContextMaxFileSize(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTree(t, tempDir, map[string]string{
+		"small.go":          "package main\n",
+		"big/generated.go":  strings.Repeat("x", 2048),
+		"exact.txt":         strings.Repeat("y", 1024),
+		"nested/large.json": strings.Repeat("z", 4096),
+	})
+
+	tests := []struct {
+		name          string
+		limit         int64
+		expectedFiles int
+		oversized     []string
+	}{
+		{"unlimited", 0, 4, nil},
+		{"limit skips larger files", 1024, 2, []string{"big/generated.go", "nested/large.json"}},
+		{"limit above every file", 1 << 20, 4, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := NewMockLogger()
+			config := NewConfig(false, "", "", "", "", logger)
+			config.MaxFileSizeBytes = tt.limit
+
+			files, count, err := GatherProjectContext([]string{tempDir}, config)
+			if err != nil {
+				t.Fatalf("GatherProjectContext returned error: %v", err)
+			}
+			if len(files) != tt.expectedFiles || count != tt.expectedFiles {
+				t.Errorf("gathered %d files (count %d), want %d", len(files), count, tt.expectedFiles)
+			}
+
+			oversized := config.OversizedFiles()
+			if len(oversized) != len(tt.oversized) {
+				t.Fatalf("OversizedFiles() = %v, want %v", oversized, tt.oversized)
+			}
+			for i, name := range tt.oversized {
+				want := filepath.Join(tempDir, filepath.FromSlash(name))
+				if oversized[i].Path != want {
+					t.Errorf("OversizedFiles()[%d].Path = %s, want %s", i, oversized[i].Path, want)
+				}
+				info, err := os.Stat(want)
+				if err != nil {
+					t.Fatalf("Stat: %v", err)
+				}
+				if oversized[i].SizeBytes != info.Size() {
+					t.Errorf("OversizedFiles()[%d].SizeBytes = %d, want %d", i, oversized[i].SizeBytes, info.Size())
+				}
+				if !logger.ContainsMessage("Warning: Skipping " + want) {
+					t.Errorf("expected a warning for %s", want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package fileutil
+
+import (
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// SkipReason names the filter that left a path out of the gathered context
+type SkipReason string
+
+// Reasons a path is skipped. SkipNone means the path passed every filter.
+const (
+	SkipNone                 SkipReason = ""
+	SkipExcludedName         SkipReason = "excluded name"
+	SkipExcludedExtension    SkipReason = "excluded extension"
+	SkipExtensionNotIncluded SkipReason = "extension not included"
+	SkipIncludeGlob          SkipReason = "not matched by include globs"
+	SkipGitDirectory         SkipReason = "git directory"
+	SkipGitignored           SkipReason = "gitignored"
+	SkipHidden               SkipReason = "hidden"
+	SkipTooLarge             SkipReason = "exceeds max file size"
+	SkipUnreadable           SkipReason = "unreadable"
+	SkipBinary               SkipReason = "binary"
+	SkipContentPattern       SkipReason = "content matches exclude pattern"
+	SkipTokenBudget          SkipReason = "over the context token budget"
+)
+
+// SkippedPath is a file or directory left out of the context and why.
+// A skipped directory stands for everything beneath it.
+type SkippedPath struct {
+	Path   string
+	IsDir  bool
+	Reason SkipReason
+}
+
+// DisplayPath returns the path, with a trailing separator for a directory
+func (p SkippedPath) DisplayPath() string {
+	if p.IsDir {
+		return p.Path + string(filepath.Separator)
+	}
+	return p.Path
+}
+
+// skippedPaths collects skipped paths. Walking and reading workers record
+// concurrently, so access is serialized.
+type skippedPaths struct {
+	mu    sync.Mutex
+	paths []SkippedPath
+}
+
+// record notes a skipped path; a nil tracker records nothing
+func (s *skippedPaths) record(path string, isDir bool, reason SkipReason) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.paths = append(s.paths, SkippedPath{Path: EnsureAbsolutePath(path), IsDir: isDir, Reason: reason})
+}
+
+// recordSkip notes why path was skipped when RecordSkips is set
+func (c *Config) recordSkip(path string, isDir bool, reason SkipReason) {
+	if c.RecordSkips {
+		c.skipped.record(path, isDir, reason)
+	}
+}
+
+// SkippedPaths returns every path skipped while gathering, sorted by path.
+// Paths are only recorded when RecordSkips is set.
+func (c *Config) SkippedPaths() []SkippedPath {
+	if c.skipped == nil {
+		return nil
+	}
+	c.skipped.mu.Lock()
+	defer c.skipped.mu.Unlock()
+	paths := append([]SkippedPath(nil), c.skipped.paths...)
+	sort.Slice(paths, func(i, j int) bool {
+		return paths[i].Path < paths[j].Path
+	})
+	return paths
+}
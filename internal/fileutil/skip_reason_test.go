@@ -0,0 +1,122 @@
+package fileutil
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGatherProjectContextRecordSkips(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTree(t, tempDir, map[string]string{
+		"main.go":              "package main\n",
+		"app.log":              "log line\n",
+		"notes.txt":            "notes\n",
+		"big.go":               strings.Repeat("x", 2048),
+		"image.go":             "GIF89a\x00\x00",
+		"gen.go":               "// Code generated by x. DO NOT EDIT.\n\npackage main\n",
+		".env":                 "KEY=1\n",
+		".gitignore":           "build/\n",
+		".git/HEAD":            "ref: refs/heads/main\n",
+		"build/out.go":         "package build\n",
+		"node_modules/x/a.js":  "module.exports = 1\n",
+		"vendor.lock":          "lock\n",
+		"docs/guide/intro.md":  "# Intro\n",
+		"docs/guide/.draft.md": "# Draft\n",
+	})
+
+	config := NewConfig(false, "", ".log", "node_modules,vendor.lock", "", NewMockLogger())
+	config.IncludeGlobs = []string{"**/*.go", "**/*.md"}
+	config.MaxFileSizeBytes = 1024
+	config.ExcludeContentPatterns = GeneratedFilePatterns
+	config.RecordSkips = true
+
+	files, _, err := GatherProjectContext([]string{tempDir}, config)
+	if err != nil {
+		t.Fatalf("GatherProjectContext returned error: %v", err)
+	}
+	if len(files) != 2 {
+		t.Errorf("gathered %d files, want main.go and docs/guide/intro.md", len(files))
+	}
+
+	got := make(map[string]SkipReason)
+	for _, skipped := range config.SkippedPaths() {
+		rel, err := filepath.Rel(tempDir, skipped.Path)
+		if err != nil {
+			t.Fatalf("Rel: %v", err)
+		}
+		rel = filepath.ToSlash(rel)
+		if skipped.IsDir {
+			rel += "/"
+		}
+		got[rel] = skipped.Reason
+	}
+
+	want := map[string]SkipReason{
+		"app.log":              SkipExcludedExtension,
+		"notes.txt":            SkipIncludeGlob,
+		"big.go":               SkipTooLarge,
+		"image.go":             SkipBinary,
+		"gen.go":               SkipContentPattern,
+		".env":                 SkipHidden,
+		".gitignore":           SkipHidden,
+		".git/":                SkipGitDirectory,
+		"build/":               SkipGitignored,
+		"node_modules/":        SkipExcludedName,
+		"vendor.lock":          SkipExcludedName,
+		"docs/guide/.draft.md": SkipHidden,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SkippedPaths() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestGatherProjectContextSkipsNotRecordedByDefault(t *testing.T) {
+	tempDir := t.TempDir()
+	writeTree(t, tempDir, map[string]string{"main.go": "package main\n", ".env": "KEY=1\n"})
+
+	config := NewConfig(false, "", "", "", "", NewMockLogger())
+	if _, _, err := GatherProjectContext([]string{tempDir}, config); err != nil {
+		t.Fatalf("GatherProjectContext returned error: %v", err)
+	}
+	if skipped := config.SkippedPaths(); len(skipped) != 0 {
+		t.Errorf("SkippedPaths() = %v, want none without RecordSkips", skipped)
+	}
+}
+
+func TestSkipReason(t *testing.T) {
+	config := NewConfig(false, ".go,.md", ".md", "secret.go", "", NewMockLogger())
+	config.GitChecker = nil
+	config.IncludeGlobs = []string{"src/**"}
+
+	tests := []struct {
+		path string
+		want SkipReason
+	}{
+		{filepath.Join("src", "main.go"), SkipNone},
+		{filepath.Join("src", "secret.go"), SkipExcludedName},
+		{filepath.Join("src", ".hidden.go"), SkipHidden},
+		{filepath.Join("repo", ".git", "config"), SkipGitDirectory},
+		{filepath.Join("src", "main.py"), SkipExtensionNotIncluded},
+		{filepath.Join("src", "README.md"), SkipExcludedExtension},
+		{filepath.Join("cmd", "main.go"), SkipIncludeGlob},
+	}
+
+	for _, tt := range tests {
+		if got := skipReason("", tt.path, config); got != tt.want {
+			t.Errorf("skipReason(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestSkippedPathDisplayPath(t *testing.T) {
+	dir := SkippedPath{Path: filepath.Join("repo", "build"), IsDir: true}
+	if got, want := dir.DisplayPath(), filepath.Join("repo", "build")+string(filepath.Separator); got != want {
+		t.Errorf("DisplayPath() = %q, want %q", got, want)
+	}
+	file := SkippedPath{Path: filepath.Join("repo", "a.go")}
+	if got := file.DisplayPath(); got != file.Path {
+		t.Errorf("DisplayPath() = %q, want %q", got, file.Path)
+	}
+}
@@ -0,0 +1,18 @@
+package fileutil
+
+// visitedDirs records the identity of every directory walked while following
+// symlinks, so a link back into an already walked tree cannot loop forever
+type visitedDirs map[any]bool
+
+// visit marks the directory at path as walked, reporting false if it already was
+func (v visitedDirs) visit(path string) bool {
+	key, ok := dirKey(path)
+	if !ok {
+		return true // Unidentifiable directories fail when read, so walking them cannot loop
+	}
+	if v[key] {
+		return false
+	}
+	v[key] = true
+	return true
+}
@@ -0,0 +1,18 @@
+//go:build !unix
+
+package fileutil
+
+import "path/filepath"
+
+// dirKey identifies a directory by its fully resolved path where inodes are unavailable
+func dirKey(path string) (any, bool) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return nil, false
+	}
+	abs, err := filepath.Abs(resolved)
+	if err != nil {
+		return nil, false
+	}
+	return abs, true
+}
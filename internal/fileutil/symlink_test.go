@@ -0,0 +1,119 @@
+package fileutil
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// symlinkTree builds a tree with a shared directory linked into the project,
+// a link back to the project root, and a broken link
+func symlinkTree(t *testing.T) string {
+	t.Helper()
+	base := t.TempDir()
+	writeTree(t, base, map[string]string{
+		"project/main.go":     "package main\n",
+		"project/pkg/util.go": "package pkg\n",
+		"shared/common.go":    "package shared\n",
+	})
+	project := filepath.Join(base, "project")
+	links := map[string]string{
+		filepath.Join(project, "shared"):      filepath.Join(base, "shared"),
+		filepath.Join(project, "pkg", "loop"): project,
+		filepath.Join(project, "dangling"):    filepath.Join(base, "missing"),
+	}
+	for link, target := range links {
+		if err := os.Symlink(target, link); err != nil {
+			t.Skipf("symlinks unavailable: %v", err)
+		}
+	}
+	return project
+}
+
+func TestGatherProjectContextFollowSymlinks(t *testing.T) {
+	project := symlinkTree(t)
+
+	tests := []struct {
+		name     string
+		follow   bool
+		expected []string
+	}{
+		{
+			name:     "links not followed by default",
+			follow:   false,
+			expected: []string{"main.go", "pkg/util.go"},
+		},
+		{
+			name:     "linked directories walked once",
+			follow:   true,
+			expected: []string{"main.go", "pkg/util.go", "shared/common.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := NewMockLogger()
+			config := NewConfig(false, "", "", "", "", logger)
+			config.FollowSymlinks = tt.follow
+
+			files, _, err := GatherProjectContext([]string{project}, config)
+			if err != nil {
+				t.Fatalf("GatherProjectContext returned error: %v", err)
+			}
+
+			var got []string
+			for _, file := range files {
+				rel, err := filepath.Rel(project, file.Path)
+				if err != nil {
+					t.Fatalf("Rel: %v", err)
+				}
+				got = append(got, filepath.ToSlash(rel))
+			}
+			sort.Strings(got)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("gathered %v, want %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Fatalf("gathered %v, want %v", got, tt.expected)
+				}
+			}
+
+			if tt.follow && !logger.ContainsMessage("Warning: Skipping broken symlink "+filepath.Join(project, "dangling")) {
+				t.Error("expected the broken symlink to be logged")
+			}
+		})
+	}
+}
+
+func TestGatherProjectContextSymlinkedRoot(t *testing.T) {
+	project := symlinkTree(t)
+	root := filepath.Join(project, "shared") // The root argument itself is a link
+
+	config := NewConfig(false, "", "", "", "", NewMockLogger())
+	config.FollowSymlinks = true
+
+	files, _, err := GatherProjectContext([]string{root}, config)
+	if err != nil {
+		t.Fatalf("GatherProjectContext returned error: %v", err)
+	}
+	if len(files) != 1 || files[0].Path != filepath.Join(root, "common.go") {
+		t.Errorf("gathered %v, want only %s", files, filepath.Join(root, "common.go"))
+	}
+}
+
+func TestVisitedDirs(t *testing.T) {
+	dir := t.TempDir()
+	visited := visitedDirs{}
+
+	if !visited.visit(dir) {
+		t.Error("first visit should report true")
+	}
+	if visited.visit(filepath.Join(dir, ".")) {
+		t.Error("second visit to the same directory should report false")
+	}
+	if !visited.visit(filepath.Join(dir, "missing")) {
+		t.Error("directories that cannot be identified are not treated as visited")
+	}
+}
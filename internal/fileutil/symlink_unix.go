@@ -0,0 +1,21 @@
+//go:build unix
+
+package fileutil
+
+import (
+	"os"
+	"syscall"
+)
+
+// dirKey identifies a directory by device and inode
+func dirKey(path string) (any, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil, false
+	}
+	return [2]uint64{uint64(stat.Dev), uint64(stat.Ino)}, true // Field types vary by platform
+}
@@ -12,10 +12,10 @@ import (
 	"github.com/misty-step/thinktank/internal/config"
 	"github.com/misty-step/thinktank/internal/llm"
 	"github.com/misty-step/thinktank/internal/logutil"
+	"github.com/misty-step/thinktank/internal/models"
 	"github.com/misty-step/thinktank/internal/ratelimit"
 	"github.com/misty-step/thinktank/internal/thinktank"
 	"github.com/misty-step/thinktank/internal/thinktank/interfaces"
-	"github.com/misty-step/thinktank/internal/thinktank/modelproc"
 )
 
 // TestBoundarySynthesisFlowNew tests the complete flow with synthesis model using boundary mocks
@@ -179,7 +179,7 @@ func TestBoundarySynthesisFlowNew(t *testing.T) {
 
 	// Verify that individual model output files were also created
 	for _, modelName := range modelNames {
-		sanitizedModelName := modelproc.SanitizeFilename(modelName)
+		sanitizedModelName := models.SafeFilename(modelName)
 		expectedFilePath := filepath.Join(outputDir, sanitizedModelName+".md")
 		_, modelStatErr := os.Stat(expectedFilePath)
 		if os.IsNotExist(modelStatErr) {
@@ -372,7 +372,7 @@ func TestBoundarySynthesisWithFailures(t *testing.T) {
 	// Verify that o3 and gemini-3-flash output files were created
 	successfulModels := []string{"moonshotai/kimi-k2.5", "gemini-3-flash"}
 	for _, modelName := range successfulModels {
-		sanitizedModelName := modelproc.SanitizeFilename(modelName)
+		sanitizedModelName := models.SafeFilename(modelName)
 		expectedFilePath := filepath.Join(outputDir, sanitizedModelName+".md")
 		_, modelStatErr := os.Stat(expectedFilePath)
 		if os.IsNotExist(modelStatErr) {
@@ -9,7 +9,7 @@ import (
 
 	"github.com/misty-step/thinktank/internal/llm"
 	"github.com/misty-step/thinktank/internal/logutil"
-	"github.com/misty-step/thinktank/internal/thinktank/modelproc"
+	"github.com/misty-step/thinktank/internal/models"
 )
 
 // TestBoundarySynthesisFlow tests the complete flow with synthesis model using boundary mocks
@@ -48,7 +48,7 @@ func TestBoundarySynthesisFlow(t *testing.T) {
 
 		// Verify that individual model output files were also created
 		for _, modelName := range modelNames {
-			sanitizedModelName := modelproc.SanitizeFilename(modelName)
+			sanitizedModelName := models.SafeFilename(modelName)
 			expectedFilePath := filepath.Join(outputDir, sanitizedModelName+".md")
 			expectedContent := mockOutputs[modelName]
 			VerifyFileContent(t, env, expectedFilePath, expectedContent)
@@ -117,7 +117,7 @@ func TestBoundarySynthesisWithPartialFailure(t *testing.T) {
 		// Verify that model1 and model3 output files were created, but not model2
 		successfulModels := []string{"model1", "model3"}
 		for _, modelName := range successfulModels {
-			sanitizedModelName := modelproc.SanitizeFilename(modelName)
+			sanitizedModelName := models.SafeFilename(modelName)
 			expectedFilePath := filepath.Join(outputDir, sanitizedModelName+".md")
 			expectedContent := mockOutputs[modelName]
 			VerifyFileContent(t, env, expectedFilePath, expectedContent)
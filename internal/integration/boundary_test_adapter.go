@@ -599,6 +599,11 @@ func (a *BoundaryAuditLogger) Log(ctx context.Context, entry auditlog.AuditEntry
 	return nil
 }
 
+// LogContext delegates to Log
+func (a *BoundaryAuditLogger) LogContext(ctx context.Context, entry auditlog.AuditEntry) error {
+	return a.Log(ctx, entry)
+}
+
 // LogLegacy implements the backward-compatible AuditLogger.LogLegacy method
 func (a *BoundaryAuditLogger) LogLegacy(entry auditlog.AuditEntry) error {
 	return a.Log(context.Background(), entry)
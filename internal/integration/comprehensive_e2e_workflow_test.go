@@ -9,7 +9,7 @@ import (
 
 	"github.com/misty-step/thinktank/internal/llm"
 	"github.com/misty-step/thinktank/internal/logutil"
-	"github.com/misty-step/thinktank/internal/thinktank/modelproc"
+	"github.com/misty-step/thinktank/internal/models"
 )
 
 // TestComprehensiveE2EWorkflow validates the entire application workflow from start to finish
@@ -181,7 +181,7 @@ This analysis provides a clear foundation for continued development, balancing i
 
 		// 2. Verify individual model output files were created
 		for _, modelName := range modelNames {
-			sanitizedModelName := modelproc.SanitizeFilename(modelName)
+			sanitizedModelName := models.SafeFilename(modelName)
 			expectedFilePath := filepath.Join(outputDir, sanitizedModelName+".md")
 			expectedContent := modelResponses[modelName]
 			VerifyFileContent(t, env, expectedFilePath, expectedContent)
@@ -239,7 +239,7 @@ func TestComprehensiveE2EWorkflowIndividualOutput(t *testing.T) {
 		// Verify only individual files were created (no synthesis)
 		outputDir := env.Config.OutputDir
 		for _, modelName := range modelNames {
-			sanitizedModelName := modelproc.SanitizeFilename(modelName)
+			sanitizedModelName := models.SafeFilename(modelName)
 			expectedFilePath := filepath.Join(outputDir, sanitizedModelName+".md")
 			VerifyFileContent(t, env, expectedFilePath, modelResponses[modelName])
 		}
@@ -341,7 +341,7 @@ func TestComprehensiveE2EWorkflowPartialSuccess(t *testing.T) {
 		// Verify successful models created output files
 		outputDir := env.Config.OutputDir
 		for model, expectedContent := range successfulResponses {
-			sanitizedModelName := modelproc.SanitizeFilename(model)
+			sanitizedModelName := models.SafeFilename(model)
 			expectedFilePath := filepath.Join(outputDir, sanitizedModelName+".md")
 			VerifyFileContent(t, env, expectedFilePath, expectedContent)
 		}
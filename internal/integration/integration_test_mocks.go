@@ -152,6 +152,11 @@ func (m *MockAuditLogger) Log(ctx context.Context, entry auditlog.AuditEntry) er
 	return nil
 }
 
+// LogContext delegates to Log
+func (m *MockAuditLogger) LogContext(ctx context.Context, entry auditlog.AuditEntry) error {
+	return m.Log(ctx, entry)
+}
+
 // LogLegacy implements the backward-compatible AuditLogger.LogLegacy method
 func (m *MockAuditLogger) LogLegacy(entry auditlog.AuditEntry) error {
 	if m.LogLegacyFunc != nil {
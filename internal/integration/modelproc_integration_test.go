@@ -392,6 +392,10 @@ func (m *ModelProcAuditLogger) Log(ctx context.Context, entry auditlog.AuditEntr
 	return nil
 }
 
+func (m *ModelProcAuditLogger) LogContext(ctx context.Context, entry auditlog.AuditEntry) error {
+	return m.Log(ctx, entry)
+}
+
 func (m *ModelProcAuditLogger) LogLegacy(entry auditlog.AuditEntry) error {
 	return m.Log(context.Background(), entry)
 }
@@ -16,7 +16,6 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
-	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -75,7 +74,7 @@ func TestMultiModelReliability_AllModelsBasic(t *testing.T) {
 
 	// Verify output files were created for all models
 	for _, modelName := range allModels {
-		sanitizedName := sanitizeModelName(modelName)
+		sanitizedName := models.SafeFilename(modelName)
 		outputFile := filepath.Join(env.outputDir, sanitizedName+".md")
 		if !env.fileExists(outputFile) {
 			t.Errorf("Output file for model %s was not created", modelName)
@@ -331,7 +330,7 @@ func TestMultiModelReliability_PartialFailureResilience(t *testing.T) {
 
 	// Verify output files were created for successful models only
 	for _, model := range expectedSuccessful {
-		sanitizedName := sanitizeModelName(model)
+		sanitizedName := models.SafeFilename(model)
 		outputFile := filepath.Join(env.outputDir, sanitizedName+".md")
 		if !env.fileExists(outputFile) {
 			t.Errorf("Output file for successful model %s was not created", model)
@@ -339,7 +338,7 @@ func TestMultiModelReliability_PartialFailureResilience(t *testing.T) {
 	}
 
 	for _, model := range expectedFailed {
-		sanitizedName := sanitizeModelName(model)
+		sanitizedName := models.SafeFilename(model)
 		outputFile := filepath.Join(env.outputDir, sanitizedName+".md")
 		if env.fileExists(outputFile) {
 			t.Errorf("Output file for failed model %s should not exist", model)
@@ -435,7 +434,7 @@ func TestMultiModelReliability_SynthesisWithUnifiedProvider(t *testing.T) {
 	}
 
 	// Verify synthesis output file was created
-	sanitizedSynthesisName := sanitizeModelName(synthesisModel)
+	sanitizedSynthesisName := models.SafeFilename(synthesisModel)
 	synthesisFile := filepath.Join(env.outputDir, sanitizedSynthesisName+"-synthesis.md")
 	if !env.fileExists(synthesisFile) {
 		t.Error("Synthesis output file was not created")
@@ -686,22 +685,3 @@ func createSuccessfulMockClient(modelName string, processedModels *map[string]bo
 		},
 	}
 }
-
-// sanitizeModelName sanitizes a model name for use as a filename
-// This mirrors the SanitizeFilename function in the modelproc package
-func sanitizeModelName(modelName string) string {
-	// Replace slashes and other problematic characters with hyphens
-	replacer := strings.NewReplacer(
-		"/", "-",
-		"\\", "-",
-		":", "-",
-		"*", "-",
-		"?", "-",
-		"\"", "-",
-		"'", "-",
-		"<", "-",
-		">", "-",
-		"|", "-",
-	)
-	return replacer.Replace(modelName)
-}
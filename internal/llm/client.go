@@ -7,10 +7,18 @@ import (
 
 // ProviderResult holds the response from a content generation call
 type ProviderResult struct {
-	Content      string   // The generated content
-	FinishReason string   // Why generation stopped, e.g., "stop", "length", "safety"
-	Truncated    bool     // Whether the response was truncated
-	SafetyInfo   []Safety // Optional safety information
+	Content      string      // The generated content
+	FinishReason string      // Why generation stopped, e.g., "stop", "length", "safety"
+	Truncated    bool        // Whether the response was truncated
+	SafetyInfo   []Safety    // Optional safety information
+	Usage        *TokenUsage // Token usage reported by the provider (nil = not reported)
+}
+
+// TokenUsage represents the token counts a provider billed for a request
+type TokenUsage struct {
+	PromptTokens     int // Tokens consumed by the prompt
+	CompletionTokens int // Tokens generated in the response
+	TotalTokens      int // Sum reported by the provider
 }
 
 // Safety represents content safety evaluation information
@@ -33,6 +41,15 @@ type LLMClient interface {
 	Close() error
 }
 
+// HealthChecker is an optional interface for clients that can cheaply confirm the
+// provider is reachable and accepts the API key, without generating anything.
+// Callers should type-assert and skip the check when it isn't implemented.
+type HealthChecker interface {
+	// CheckHealth returns a categorized error (e.g. CategoryAuth or CategoryNetwork)
+	// if the provider can't be used
+	CheckHealth(ctx context.Context) error
+}
+
 // MockLLMClient is a testing mock for the LLMClient interface
 type MockLLMClient struct {
 	GenerateContentFunc func(ctx context.Context, prompt string, params map[string]interface{}) (*ProviderResult, error)
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -34,6 +36,9 @@ var (
 
 	// ErrProviderNotFound indicates a provider was not found in models package
 	ErrProviderNotFound = errors.New("provider not found in models package")
+
+	// ErrStreamIncomplete indicates a streamed response ended before it was complete
+	ErrStreamIncomplete = errors.New("streamed response ended before it was complete")
 )
 
 // ErrorCategory represents different categories of errors that can occur when using LLM APIs
@@ -140,6 +145,22 @@ type LLMError struct {
 
 	// Details contains additional error details
 	Details string
+
+	// RetryAfter is how long the provider asked us to wait before retrying (0 if not given)
+	RetryAfter time.Duration
+
+	// CreditsRemaining is the account balance in dollars reported with an
+	// insufficient-credits error (nil if not given)
+	CreditsRemaining *float64
+
+	// CreditsRequired is the amount in dollars the request needed (nil if not given)
+	CreditsRequired *float64
+
+	// TokensRequested is the output token limit the request asked for, and
+	// TokensAffordable the most the balance covers, when the provider reports the
+	// shortfall in tokens rather than dollars (nil if not given)
+	TokensRequested  *int
+	TokensAffordable *int
 }
 
 // Error implements the error interface
@@ -171,6 +192,13 @@ func (e *LLMError) UserFacingError() string {
 		sb.WriteString(e.Message)
 	}
 
+	// Say how far short the account is when the provider told us
+	if credits := e.creditSummary(); credits != "" {
+		sb.WriteString(" (")
+		sb.WriteString(credits)
+		sb.WriteString(")")
+	}
+
 	// Add suggestions if available
 	if e.Suggestion != "" {
 		sb.WriteString("\n\nSuggestion: ")
@@ -200,6 +228,26 @@ func (e *LLMError) DebugInfo() string {
 		sb.WriteString(fmt.Sprintf("Request ID: %s\n", e.RequestID))
 	}
 
+	if e.RetryAfter > 0 {
+		sb.WriteString(fmt.Sprintf("Retry After: %v\n", e.RetryAfter))
+	}
+
+	if e.CreditsRequired != nil {
+		sb.WriteString(fmt.Sprintf("Credits Required: %s\n", formatDollars(*e.CreditsRequired)))
+	}
+
+	if e.CreditsRemaining != nil {
+		sb.WriteString(fmt.Sprintf("Credits Remaining: %s\n", formatDollars(*e.CreditsRemaining)))
+	}
+
+	if e.TokensRequested != nil {
+		sb.WriteString(fmt.Sprintf("Tokens Requested: %d\n", *e.TokensRequested))
+	}
+
+	if e.TokensAffordable != nil {
+		sb.WriteString(fmt.Sprintf("Tokens Affordable: %d\n", *e.TokensAffordable))
+	}
+
 	if e.Original != nil {
 		sb.WriteString(fmt.Sprintf("Original Error: %v\n", e.Original))
 	}
@@ -215,6 +263,35 @@ func (e *LLMError) DebugInfo() string {
 	return sb.String()
 }
 
+// creditSummary describes the credit shortfall, e.g. "need $0.12, have $0.03" or
+// "requested 16384 tokens, can afford 1234", or returns "" when no amount is known
+func (e *LLMError) creditSummary() string {
+	var parts []string
+	if e.CreditsRequired != nil {
+		parts = append(parts, "need "+formatDollars(*e.CreditsRequired))
+	}
+	if e.CreditsRemaining != nil {
+		parts = append(parts, "have "+formatDollars(*e.CreditsRemaining))
+	}
+	if e.TokensRequested != nil {
+		parts = append(parts, fmt.Sprintf("requested %d tokens", *e.TokensRequested))
+	}
+	if e.TokensAffordable != nil {
+		parts = append(parts, fmt.Sprintf("can afford %d", *e.TokensAffordable))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatDollars formats a dollar amount with at least two decimal places, keeping
+// the extra precision of sub-cent amounts
+func formatDollars(amount float64) string {
+	s := strconv.FormatFloat(amount, 'f', -1, 64)
+	if dot := strings.IndexByte(s, '.'); dot < 0 || len(s)-dot-1 < 2 {
+		s = strconv.FormatFloat(amount, 'f', 2, 64)
+	}
+	return "$" + s
+}
+
 // New creates a new LLMError with the specified parameters
 func New(provider string, code string, statusCode int, message string, requestID string, original error, category ErrorCategory) *LLMError {
 	return &LLMError{
@@ -258,6 +335,87 @@ func Wrap(err error, provider string, message string, category ErrorCategory) *L
 	}
 }
 
+// ParseRetryAfter parses an HTTP Retry-After header value, given either as
+// delay-seconds or as an HTTP date. Returns 0 for empty, invalid, or past values.
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// RetryAfterFromError returns the Retry-After delay carried by an LLMError in err's chain, or 0
+func RetryAfterFromError(err error) time.Duration {
+	var llmErr *LLMError
+	if errors.As(err, &llmErr) {
+		return llmErr.RetryAfter
+	}
+	return 0
+}
+
+var (
+	// creditsRequiredPattern matches amounts like "requires $0.12" or "need at least $5"
+	creditsRequiredPattern = regexp.MustCompile(`(?i)\b(?:needs?|needed|requires?|required|costs?)\s+(?:at\s+least\s+)?\$(\d+(?:\.\d+)?)`)
+
+	// creditsRemainingPattern matches amounts like "have $0.03" or "balance is $0.03"
+	creditsRemainingPattern = regexp.MustCompile(`(?i)\b(?:have|has|balance|remaining)(?:\s+(?:is|of|only))?:?\s+\$(\d+(?:\.\d+)?)`)
+
+	// affordableTokensPattern matches OpenRouter's 402 message, which gives the
+	// shortfall in tokens: "You requested up to 16384 tokens, but can only afford 1234"
+	affordableTokensPattern = regexp.MustCompile(`(?i)\brequested\s+up\s+to\s+(\d+)\s+tokens,?\s+but\s+can\s+only\s+afford\s+(\d+)`)
+)
+
+// CreditAmounts holds the figures an insufficient-credits message gives; fields
+// not found in the message are nil
+type CreditAmounts struct {
+	Remaining        *float64 // Account balance in dollars
+	Required         *float64 // Request cost in dollars
+	TokensRequested  *int     // Output token limit the request asked for
+	TokensAffordable *int     // Most output tokens the balance covers
+}
+
+// ParseCreditAmounts extracts the amounts an insufficient-credits message gives,
+// in dollars ("this request requires $0.12 but your balance is $0.03") or, as
+// OpenRouter reports them, in tokens ("You requested up to 16384 tokens, but can
+// only afford 1234").
+func ParseCreditAmounts(message string) CreditAmounts {
+	amounts := CreditAmounts{
+		Remaining: matchDollars(creditsRemainingPattern, message),
+		Required:  matchDollars(creditsRequiredPattern, message),
+	}
+	if match := affordableTokensPattern.FindStringSubmatch(message); match != nil {
+		requested, errRequested := strconv.Atoi(match[1])
+		affordable, errAffordable := strconv.Atoi(match[2])
+		if errRequested == nil && errAffordable == nil {
+			amounts.TokensRequested, amounts.TokensAffordable = &requested, &affordable
+		}
+	}
+	return amounts
+}
+
+// matchDollars returns the amount captured by the first match of pattern in s, or nil
+func matchDollars(pattern *regexp.Regexp, s string) *float64 {
+	match := pattern.FindStringSubmatch(s)
+	if match == nil {
+		return nil
+	}
+	amount, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return nil
+	}
+	return &amount
+}
+
 // WrapWithCorrelationID wraps an existing error with additional LLM-specific context and correlation ID
 func WrapWithCorrelationID(err error, provider string, message string, category ErrorCategory, correlationID string) *LLMError {
 	if err == nil {
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 // Test the String method of ErrorCategory
@@ -558,3 +560,137 @@ func TestFormatAPIError(t *testing.T) {
 		}
 	})
 }
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+	}{
+		{"delay seconds", "30", 30 * time.Second},
+		{"surrounding whitespace", " 5 ", 5 * time.Second},
+		{"HTTP date", now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{"date in the past", now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"zero", "0", 0},
+		{"negative", "-3", 0},
+		{"empty", "", 0},
+		{"garbage", "soon", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseRetryAfter(tt.value, now); got != tt.expected {
+				t.Errorf("ParseRetryAfter(%q) = %v, want %v", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRetryAfterFromError(t *testing.T) {
+	llmErr := &LLMError{Message: "rate limited", ErrorCategory: CategoryRateLimit, RetryAfter: 3 * time.Second}
+
+	if got := RetryAfterFromError(fmt.Errorf("processing failed: %w", llmErr)); got != 3*time.Second {
+		t.Errorf("RetryAfterFromError(wrapped) = %v, want 3s", got)
+	}
+	if got := RetryAfterFromError(errors.New("plain")); got != 0 {
+		t.Errorf("RetryAfterFromError(plain) = %v, want 0", got)
+	}
+	if got := RetryAfterFromError(nil); got != 0 {
+		t.Errorf("RetryAfterFromError(nil) = %v, want 0", got)
+	}
+}
+
+func TestParseCreditAmounts(t *testing.T) {
+	tests := []struct {
+		name           string
+		message        string
+		wantRemaining  string
+		wantRequired   string
+		wantRequested  string
+		wantAffordable string
+	}{
+		{name: "both amounts", message: "This request requires $0.12 but your balance is $0.03", wantRemaining: "$0.03", wantRequired: "$0.12"},
+		{name: "need and have", message: "Insufficient credits: need $5, have $0.5", wantRemaining: "$0.50", wantRequired: "$5.00"},
+		{name: "sub-cent amounts", message: "request needs at least $0.0042; remaining: $0.001", wantRemaining: "$0.001", wantRequired: "$0.0042"},
+		{name: "required only", message: "Payment required: this request costs $1.25", wantRequired: "$1.25"},
+		{name: "no amounts", message: "Insufficient credits. Add more using https://openrouter.ai/credits"},
+		{
+			name:           "openrouter token shortfall",
+			message:        "This request requires more credits, or fewer max_tokens. You requested up to 16384 tokens, but can only afford 1234. To increase, visit https://openrouter.ai/settings/credits and upgrade to a paid account",
+			wantRequested:  "16384",
+			wantAffordable: "1234",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amounts := ParseCreditAmounts(tt.message)
+			if got := dollarsOrEmpty(amounts.Remaining); got != tt.wantRemaining {
+				t.Errorf("Remaining = %q, want %q", got, tt.wantRemaining)
+			}
+			if got := dollarsOrEmpty(amounts.Required); got != tt.wantRequired {
+				t.Errorf("Required = %q, want %q", got, tt.wantRequired)
+			}
+			if got := tokensOrEmpty(amounts.TokensRequested); got != tt.wantRequested {
+				t.Errorf("TokensRequested = %q, want %q", got, tt.wantRequested)
+			}
+			if got := tokensOrEmpty(amounts.TokensAffordable); got != tt.wantAffordable {
+				t.Errorf("TokensAffordable = %q, want %q", got, tt.wantAffordable)
+			}
+		})
+	}
+}
+
+func tokensOrEmpty(tokens *int) string {
+	if tokens == nil {
+		return ""
+	}
+	return strconv.Itoa(*tokens)
+}
+
+func dollarsOrEmpty(amount *float64) string {
+	if amount == nil {
+		return ""
+	}
+	return formatDollars(*amount)
+}
+
+func TestLLMErrorCreditDetails(t *testing.T) {
+	required, remaining := 0.12, 0.03
+	llmErr := &LLMError{
+		Message:          "Insufficient credits",
+		ErrorCategory:    CategoryInsufficientCredits,
+		Suggestion:       "Add credits",
+		CreditsRequired:  &required,
+		CreditsRemaining: &remaining,
+	}
+
+	if got, want := llmErr.UserFacingError(), "Insufficient credits (need $0.12, have $0.03)\n\nSuggestion: Add credits"; got != want {
+		t.Errorf("UserFacingError() = %q, want %q", got, want)
+	}
+	debug := llmErr.DebugInfo()
+	for _, want := range []string{"Credits Required: $0.12\n", "Credits Remaining: $0.03\n"} {
+		if !strings.Contains(debug, want) {
+			t.Errorf("DebugInfo() = %q, want it to contain %q", debug, want)
+		}
+	}
+
+	llmErr.CreditsRequired = nil
+	if got, want := llmErr.UserFacingError(), "Insufficient credits (have $0.03)\n\nSuggestion: Add credits"; got != want {
+		t.Errorf("UserFacingError() = %q, want %q", got, want)
+	}
+	llmErr.CreditsRemaining = nil
+	if got, want := llmErr.UserFacingError(), "Insufficient credits\n\nSuggestion: Add credits"; got != want {
+		t.Errorf("UserFacingError() = %q, want %q", got, want)
+	}
+
+	requested, affordable := 16384, 1234
+	llmErr.TokensRequested, llmErr.TokensAffordable = &requested, &affordable
+	if got, want := llmErr.UserFacingError(), "Insufficient credits (requested 16384 tokens, can afford 1234)\n\nSuggestion: Add credits"; got != want {
+		t.Errorf("UserFacingError() = %q, want %q", got, want)
+	}
+	if debug := llmErr.DebugInfo(); !strings.Contains(debug, "Tokens Requested: 16384\nTokens Affordable: 1234\n") {
+		t.Errorf("DebugInfo() = %q, want the token amounts", debug)
+	}
+}
@@ -0,0 +1,135 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ScriptedProvider is the provider name on errors returned by a ScriptedClient
+const ScriptedProvider = "scripted"
+
+// ScriptedStep is the outcome of one GenerateContent call on a ScriptedClient
+type ScriptedStep struct {
+	Content  string        // Returned as the response content when the step has no error
+	Usage    *TokenUsage   // Reported on the response (nil = not reported)
+	Err      error         // Returned as is, taking precedence over Category
+	Category ErrorCategory // When set, an *LLMError of this category is returned
+	Latency  time.Duration // How long the call takes; cancelling the context ends it early
+}
+
+// ScriptedResponse returns a step that succeeds with content
+func ScriptedResponse(content string) ScriptedStep {
+	return ScriptedStep{Content: content}
+}
+
+// ScriptedError returns a step that fails with an *LLMError of the given category
+func ScriptedError(category ErrorCategory) ScriptedStep {
+	return ScriptedStep{Category: category}
+}
+
+// ScriptedClient is an LLMClient for tests that plays back queued steps in order,
+// one per GenerateContent call, so retry and partial-failure paths can be driven
+// deterministically. Calls after the script runs out fail with an error.
+// It is safe for concurrent use.
+type ScriptedClient struct {
+	modelName string
+
+	mu      sync.Mutex
+	steps   []ScriptedStep
+	prompts []string
+	closed  bool
+}
+
+// NewScriptedClient creates a client for modelName that plays back steps in order
+func NewScriptedClient(modelName string, steps ...ScriptedStep) *ScriptedClient {
+	return &ScriptedClient{modelName: modelName, steps: append([]ScriptedStep{}, steps...)}
+}
+
+// Enqueue appends steps to the end of the script
+func (c *ScriptedClient) Enqueue(steps ...ScriptedStep) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.steps = append(c.steps, steps...)
+}
+
+// GenerateContent takes the next step off the script, waits out its latency, and
+// returns its outcome. If ctx is done first, the step is still used up and ctx.Err()
+// is returned, as a real request would be abandoned mid-flight.
+func (c *ScriptedClient) GenerateContent(ctx context.Context, prompt string, params map[string]interface{}) (*ProviderResult, error) {
+	c.mu.Lock()
+	c.prompts = append(c.prompts, prompt)
+	if len(c.steps) == 0 {
+		calls := len(c.prompts)
+		c.mu.Unlock()
+		return nil, fmt.Errorf("scripted client for %s has no step for call %d", c.modelName, calls)
+	}
+	step := c.steps[0]
+	c.steps = c.steps[1:]
+	c.mu.Unlock()
+
+	if step.Latency > 0 {
+		timer := time.NewTimer(step.Latency)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	} else if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case step.Err != nil:
+		return nil, step.Err
+	case step.Category != CategoryUnknown:
+		return nil, New(ScriptedProvider, "", 0, fmt.Sprintf("scripted %s error", step.Category), "", nil, step.Category)
+	}
+	return &ProviderResult{Content: step.Content, FinishReason: "stop", Usage: step.Usage}, nil
+}
+
+// GetModelName returns the model name the client was created with
+func (c *ScriptedClient) GetModelName() string {
+	return c.modelName
+}
+
+// Close marks the client closed; see Closed
+func (c *ScriptedClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+// Calls returns how many times GenerateContent has been called
+func (c *ScriptedClient) Calls() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.prompts)
+}
+
+// Prompts returns the prompt of each GenerateContent call, in call order
+func (c *ScriptedClient) Prompts() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string{}, c.prompts...)
+}
+
+// Remaining returns how many scripted steps have not been used yet
+func (c *ScriptedClient) Remaining() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.steps)
+}
+
+// Closed reports whether Close has been called
+func (c *ScriptedClient) Closed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// Compile-time check that ScriptedClient satisfies LLMClient
+var _ LLMClient = (*ScriptedClient)(nil)
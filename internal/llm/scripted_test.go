@@ -0,0 +1,112 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScriptedClient_PlaysStepsInOrder(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	errCustom := errors.New("custom failure")
+	client := NewScriptedClient("scripted-model",
+		ScriptedError(CategoryRateLimit),
+		ScriptedStep{Err: errCustom},
+		ScriptedStep{Content: "done", Usage: &TokenUsage{CompletionTokens: 3}},
+	)
+
+	_, err := client.GenerateContent(ctx, "first", nil)
+	if catErr, ok := IsCategorizedError(err); !ok || catErr.Category() != CategoryRateLimit {
+		t.Errorf("call 1 error = %v, want a CategoryRateLimit error", err)
+	}
+
+	_, err = client.GenerateContent(ctx, "second", nil)
+	if !errors.Is(err, errCustom) {
+		t.Errorf("call 2 error = %v, want %v", err, errCustom)
+	}
+
+	result, err := client.GenerateContent(ctx, "third", nil)
+	if err != nil || result.Content != "done" || result.Usage == nil || result.Usage.CompletionTokens != 3 {
+		t.Errorf("call 3 = %+v, %v; want content \"done\" with usage", result, err)
+	}
+
+	_, err = client.GenerateContent(ctx, "fourth", nil)
+	if err == nil || !strings.Contains(err.Error(), "no step for call 4") {
+		t.Errorf("call past the script error = %v, want an exhausted-script error", err)
+	}
+
+	if got := client.Prompts(); strings.Join(got, ",") != "first,second,third,fourth" {
+		t.Errorf("Prompts() = %v", got)
+	}
+	if client.Calls() != 4 || client.Remaining() != 0 {
+		t.Errorf("Calls() = %d, Remaining() = %d; want 4, 0", client.Calls(), client.Remaining())
+	}
+}
+
+func TestScriptedClient_Enqueue(t *testing.T) {
+	t.Parallel()
+	client := NewScriptedClient("scripted-model")
+	client.Enqueue(ScriptedResponse("a"), ScriptedResponse("b"))
+
+	for _, want := range []string{"a", "b"} {
+		result, err := client.GenerateContent(context.Background(), "prompt", nil)
+		if err != nil || result.Content != want {
+			t.Errorf("GenerateContent() = %+v, %v; want content %q", result, err, want)
+		}
+	}
+}
+
+func TestScriptedClient_LatencyHonorsCancellation(t *testing.T) {
+	t.Parallel()
+	client := NewScriptedClient("scripted-model",
+		ScriptedStep{Content: "slow", Latency: time.Hour},
+		ScriptedStep{Content: "quick", Latency: time.Millisecond},
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.GenerateContent(ctx, "prompt", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GenerateContent() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("cancelled call took %v, want it to return at the deadline", elapsed)
+	}
+
+	result, err := client.GenerateContent(context.Background(), "prompt", nil)
+	if err != nil || result.Content != "quick" {
+		t.Errorf("GenerateContent() = %+v, %v; want the next step", result, err)
+	}
+}
+
+func TestScriptedClient_ConcurrentCalls(t *testing.T) {
+	t.Parallel()
+	const calls = 20
+	client := NewScriptedClient("scripted-model")
+	for i := 0; i < calls; i++ {
+		client.Enqueue(ScriptedResponse("ok"))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GenerateContent(context.Background(), "prompt", nil); err != nil {
+				t.Errorf("GenerateContent() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if client.Calls() != calls || client.Remaining() != 0 {
+		t.Errorf("Calls() = %d, Remaining() = %d; want %d, 0", client.Calls(), client.Remaining(), calls)
+	}
+	if err := client.Close(); err != nil || !client.Closed() {
+		t.Errorf("Close() = %v, Closed() = %v", err, client.Closed())
+	}
+}
@@ -0,0 +1,68 @@
+package llm
+
+import (
+	"context"
+	"strings"
+)
+
+// Chunk is an incremental piece of a streamed response
+type Chunk struct {
+	Content      string      // Text generated since the previous chunk
+	FinishReason string      // Why generation stopped; set on the last chunk if reported
+	Usage        *TokenUsage // Token usage; set on the last chunk if reported
+	Done         bool        // The response is complete; the stream closes after a chunk with Done set
+	Err          error       // Terminal error; the stream closes after a chunk with Err set
+}
+
+// StreamingLLMClient is an optional interface for clients that can stream a response
+// as it is generated. Callers should type-assert and fall back to GenerateContent.
+type StreamingLLMClient interface {
+	LLMClient
+
+	// GenerateContentStream sends a prompt and returns a channel of chunks that is
+	// closed when generation finishes. Errors before the stream starts are returned
+	// directly; errors mid-stream, including cancellation, arrive as a final Chunk
+	// with Err set. A complete response ends with a Chunk with Done set, so a stream
+	// that closes without one was cut short. Callers must read until the channel
+	// is closed.
+	GenerateContentStream(ctx context.Context, prompt string, params map[string]interface{}) (<-chan Chunk, error)
+}
+
+// CollectStream drains stream into a ProviderResult, passing each piece of content
+// to onChunk (if non-nil) as it arrives. Returns the first chunk error, if any, or
+// an ErrStreamIncomplete network error when the stream closes without a Done chunk.
+// A partial response is never returned.
+func CollectStream(stream <-chan Chunk, onChunk func(content string)) (*ProviderResult, error) {
+	var content strings.Builder
+	result := &ProviderResult{SafetyInfo: []Safety{}}
+	done := false
+	for chunk := range stream {
+		if chunk.Err != nil {
+			// Drain so the producer is never left blocked on a send
+			for range stream {
+			}
+			return nil, chunk.Err
+		}
+		if chunk.Content != "" {
+			content.WriteString(chunk.Content)
+			if onChunk != nil {
+				onChunk(chunk.Content)
+			}
+		}
+		if chunk.FinishReason != "" {
+			result.FinishReason = chunk.FinishReason
+		}
+		if chunk.Usage != nil {
+			result.Usage = chunk.Usage
+		}
+		if chunk.Done {
+			done = true
+		}
+	}
+	if !done {
+		return nil, Wrap(ErrStreamIncomplete, "", "stream closed without a completion marker", CategoryNetwork)
+	}
+	result.Content = content.String()
+	result.Truncated = result.FinishReason == "length"
+	return result, nil
+}
@@ -0,0 +1,95 @@
+package llm
+
+import (
+	"errors"
+	"testing"
+)
+
+// streamOf returns a closed channel holding chunks
+func streamOf(chunks ...Chunk) <-chan Chunk {
+	stream := make(chan Chunk, len(chunks))
+	for _, chunk := range chunks {
+		stream <- chunk
+	}
+	close(stream)
+	return stream
+}
+
+func TestCollectStream(t *testing.T) {
+	t.Parallel()
+
+	var seen []string
+	result, err := CollectStream(streamOf(
+		Chunk{Content: "Hello, "},
+		Chunk{Content: ""},
+		Chunk{Content: "world", FinishReason: "length", Usage: &TokenUsage{TotalTokens: 5}},
+		Chunk{Done: true},
+	), func(content string) { seen = append(seen, content) })
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Content != "Hello, world" {
+		t.Errorf("Expected content %q, got %q", "Hello, world", result.Content)
+	}
+	if result.FinishReason != "length" || !result.Truncated {
+		t.Errorf("Expected a truncated result with finish reason length, got %q (truncated %v)", result.FinishReason, result.Truncated)
+	}
+	if result.Usage == nil || result.Usage.TotalTokens != 5 {
+		t.Errorf("Expected usage from the final chunk, got %+v", result.Usage)
+	}
+	if len(seen) != 2 || seen[0] != "Hello, " || seen[1] != "world" {
+		t.Errorf("Expected onChunk for each non-empty chunk, got %q", seen)
+	}
+}
+
+func TestCollectStreamError(t *testing.T) {
+	t.Parallel()
+
+	streamErr := errors.New("connection reset")
+	result, err := CollectStream(streamOf(
+		Chunk{Content: "partial"},
+		Chunk{Err: streamErr},
+		Chunk{Content: "ignored"},
+	), nil)
+
+	if !errors.Is(err, streamErr) {
+		t.Errorf("Expected the chunk error, got %v", err)
+	}
+	if result != nil {
+		t.Errorf("Expected no result on error, got %+v", result)
+	}
+}
+
+func TestCollectStreamIncomplete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		chunks []Chunk
+	}{
+		{name: "closed without a terminal marker", chunks: []Chunk{{Content: "partial"}, {Content: " output", FinishReason: "stop"}}},
+		{name: "closed with nothing sent", chunks: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var seen []string
+			result, err := CollectStream(streamOf(tt.chunks...), func(content string) { seen = append(seen, content) })
+			if !errors.Is(err, ErrStreamIncomplete) {
+				t.Fatalf("Expected ErrStreamIncomplete, got %v", err)
+			}
+			if !IsNetwork(err) {
+				t.Errorf("Expected a network error so the request is retried, got %v", err)
+			}
+			if result != nil {
+				t.Errorf("Expected no partial result, got %+v", result)
+			}
+			if len(seen) != len(tt.chunks) {
+				t.Errorf("Expected onChunk for the content that did arrive, got %q", seen)
+			}
+		})
+	}
+}
@@ -38,9 +38,57 @@ type ColorScheme struct {
 	renderer      *lipgloss.Renderer // Custom renderer for color output
 }
 
+// Color modes accepted by --color
+const (
+	ColorAuto   = "auto"   // Color interactive terminals, honoring NO_COLOR and FORCE_COLOR
+	ColorAlways = "always" // Color even when output is piped
+	ColorNever  = "never"  // Never emit ANSI codes
+)
+
+// Themes accepted by --theme
+const (
+	ThemeDefault      = "default"       // Adaptive palette with muted grays for secondary text
+	ThemeHighContrast = "high-contrast" // Bright basic ANSI colors and no dim text
+)
+
+// ColorsEnabled decides whether output should be colored. In auto mode a set
+// NO_COLOR disables colors and a set FORCE_COLOR enables them even when output
+// is piped (NO_COLOR wins if both are set); otherwise colors follow interactivity.
+// See https://no-color.org.
+func ColorsEnabled(mode string, interactive bool, getEnvFunc func(string) string) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if getEnvFunc("NO_COLOR") != "" {
+		return false
+	}
+	if force := getEnvFunc("FORCE_COLOR"); force != "" && force != "0" && force != "false" {
+		return true
+	}
+	return interactive
+}
+
 // createStylesForRenderer creates lipgloss styles bound to a specific renderer.
 // This ensures colors work even when running in non-TTY environments (like tests).
-func createStylesForRenderer(r *lipgloss.Renderer) (modelName, success, warning, errorStyle, info, muted, sectionHeader, noStyle lipgloss.Style) {
+func createStylesForRenderer(r *lipgloss.Renderer, theme string) (modelName, success, warning, errorStyle, info, muted, sectionHeader, noStyle lipgloss.Style) {
+	noStyle = r.NewStyle()
+	if theme == ThemeHighContrast {
+		// Basic ANSI colors are remapped by most terminal accessibility palettes,
+		// and secondary text uses the full foreground instead of gray
+		modelName = r.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+		success = r.NewStyle().Bold(true).Foreground(lipgloss.Color("10"))
+		warning = r.NewStyle().Bold(true).Foreground(lipgloss.Color("11"))
+		errorStyle = r.NewStyle().Bold(true).Foreground(lipgloss.Color("9"))
+		info = r.NewStyle().Bold(true).Foreground(lipgloss.Color("14"))
+		muted = r.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "0", Dark: "15"})
+		sectionHeader = r.NewStyle().Bold(true).Underline(true).
+			Foreground(lipgloss.AdaptiveColor{Light: "0", Dark: "15"})
+		return
+	}
+
 	modelName = r.NewStyle().
 		Foreground(lipgloss.AdaptiveColor{Light: "#2563EB", Dark: "#3B82F6"})
 	success = r.NewStyle().
@@ -56,19 +104,25 @@ func createStylesForRenderer(r *lipgloss.Renderer) (modelName, success, warning,
 	sectionHeader = r.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.AdaptiveColor{Light: "#1F2937", Dark: "#FFFFFF"})
-	noStyle = r.NewStyle()
 	return
 }
 
 // NewColorScheme creates a new ColorScheme based on environment type.
 // If interactive is true, returns a scheme with adaptive colors.
 // If interactive is false, returns a scheme with no colors.
+// NO_COLOR and FORCE_COLOR override the interactive default.
 func NewColorScheme(interactive bool) *ColorScheme {
-	if !interactive {
+	return NewThemedColorScheme(ColorsEnabled(ColorAuto, interactive, os.Getenv), ThemeDefault)
+}
+
+// NewThemedColorScheme creates a ColorScheme using the named theme when enabled
+// is true, or a scheme with no colors otherwise. Unknown themes use the default.
+func NewThemedColorScheme(enabled bool, theme string) *ColorScheme {
+	if !enabled {
 		// Non-interactive mode: use ASCII profile (no colors)
 		r := lipgloss.NewRenderer(os.Stdout)
 		r.SetColorProfile(termenv.Ascii)
-		_, _, _, _, _, _, _, noStyle := createStylesForRenderer(r)
+		_, _, _, _, _, _, _, noStyle := createStylesForRenderer(r, theme)
 		return &ColorScheme{
 			ModelName:     noStyle,
 			Success:       noStyle,
@@ -93,7 +147,7 @@ func NewColorScheme(interactive bool) *ColorScheme {
 	// Only override if detection fails or returns Ascii in interactive mode
 	detectedProfile := termenv.NewOutput(os.Stdout).ColorProfile()
 	if detectedProfile == termenv.Ascii {
-		// We determined we're interactive via our own detection, so at minimum support ANSI
+		// Colors were requested (interactive or forced), so at minimum support ANSI
 		r.SetColorProfile(termenv.ANSI)
 	} else {
 		r.SetColorProfile(detectedProfile)
@@ -104,7 +158,7 @@ func NewColorScheme(interactive bool) *ColorScheme {
 	} else {
 		r.SetHasDarkBackground(false)
 	}
-	modelName, success, warning, errorStyle, info, muted, sectionHeader, noStyle := createStylesForRenderer(r)
+	modelName, success, warning, errorStyle, info, muted, sectionHeader, noStyle := createStylesForRenderer(r, theme)
 
 	return &ColorScheme{
 		ModelName:     modelName,
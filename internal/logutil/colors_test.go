@@ -247,3 +247,58 @@ func TestGetEnvForColors(t *testing.T) {
 		t.Errorf("getEnvForColors() should return empty string for empty key, got %q", result3)
 	}
 }
+
+func TestColorsEnabled(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        string
+		interactive bool
+		env         map[string]string
+		expected    bool
+	}{
+		{"auto follows interactive", "", true, nil, true},
+		{"auto piped", ColorAuto, false, nil, false},
+		{"NO_COLOR disables", ColorAuto, true, map[string]string{"NO_COLOR": "1"}, false},
+		{"FORCE_COLOR enables when piped", ColorAuto, false, map[string]string{"FORCE_COLOR": "1"}, true},
+		{"FORCE_COLOR=0 is ignored", ColorAuto, false, map[string]string{"FORCE_COLOR": "0"}, false},
+		{"NO_COLOR wins over FORCE_COLOR", ColorAuto, true, map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "1"}, false},
+		{"always overrides NO_COLOR", ColorAlways, false, map[string]string{"NO_COLOR": "1"}, true},
+		{"never overrides FORCE_COLOR", ColorNever, true, map[string]string{"FORCE_COLOR": "1"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getEnv := func(key string) string { return tt.env[key] }
+			if got := ColorsEnabled(tt.mode, tt.interactive, getEnv); got != tt.expected {
+				t.Errorf("ColorsEnabled(%q, %v) = %v, want %v", tt.mode, tt.interactive, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewColorScheme_HonorsNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	if result := NewColorScheme(true).ColorError("test"); result != "test" {
+		t.Errorf("Expected no color codes with NO_COLOR set, got %q", result)
+	}
+}
+
+func TestNewThemedColorScheme(t *testing.T) {
+	defaultScheme := NewThemedColorScheme(true, ThemeDefault)
+	highContrast := NewThemedColorScheme(true, ThemeHighContrast)
+
+	for _, scheme := range []*ColorScheme{defaultScheme, highContrast} {
+		if !containsColorCode(scheme.ColorError("test")) {
+			t.Errorf("Expected color codes, got %q", scheme.ColorError("test"))
+		}
+	}
+	if defaultScheme.ColorError("test") == highContrast.ColorError("test") {
+		t.Error("Expected the high-contrast theme to render differently from the default")
+	}
+	if !highContrast.Error.GetBold() {
+		t.Error("Expected high-contrast errors to be bold")
+	}
+	if result := NewThemedColorScheme(false, ThemeHighContrast).ColorError("test"); result != "test" {
+		t.Errorf("Expected a disabled scheme to leave text unchanged, got %q", result)
+	}
+}
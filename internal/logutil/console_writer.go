@@ -86,6 +86,11 @@ type ConsoleWriter interface {
 	// FinishStatusTracking completes status tracking and cleans up the display.
 	FinishStatusTracking()
 
+	// StreamModelOutput writes a piece of a model's response as it is generated.
+	// The first chunk freezes the in-place status display so the streamed text
+	// is never overwritten; FinishStatusTracking ends the streamed block.
+	StreamModelOutput(modelName string, content string)
+
 	// ModelQueued reports that a model has been added to the processing queue.
 	// The index parameter indicates the model's position in the processing order (1-based).
 	//
@@ -210,6 +215,14 @@ type ConsoleWriter interface {
 	// This is typically controlled by CLI flags like --no-progress.
 	SetNoProgress(noProgress bool)
 
+	// SetSilent enables or disables silent mode, which is stricter than quiet:
+	// nothing at all is written to stdout, including the summary and success
+	// messages. Errors and warnings are still shown, on stderr instead.
+	// Silent overrides quiet.
+	//
+	// This is typically controlled by the --silent CLI flag.
+	SetSilent(silent bool)
+
 	// IsInteractive returns true if the output environment supports interactive features
 	// like emojis, colors, and dynamic line updates (i.e., running in a TTY).
 	//
@@ -246,6 +259,7 @@ type consoleWriter struct {
 	isInteractive bool            // Whether running in interactive terminal
 	quiet         bool            // Whether to suppress non-essential output
 	noProgress    bool            // Whether to suppress detailed progress indicators
+	silent        bool            // Whether to keep stdout empty, sending errors and warnings to stderr
 	modelCount    int             // Total number of models to process
 	modelIndex    int             // Current model index (for progress tracking)
 	terminalWidth int             // Cached terminal width, 0 means not detected yet
@@ -258,6 +272,9 @@ type consoleWriter struct {
 	statusDisplay  *StatusDisplay      // Handles status rendering
 	usingStatus    bool                // Whether status tracking is active
 	midSectionOpen bool                // Whether the mid-section divider is open
+	streaming      bool                // Whether a model's response is being streamed
+	processing     *processingRegion   // In-place processing lines, nil when none are live
+	ordered        *orderedOutput      // Reorder buffer for model progress, nil prints lines as they arrive
 
 	// Dependency injection for testing
 	isTerminalFunc  func() bool
@@ -281,9 +298,9 @@ func NewConsoleWriter() ConsoleWriter {
 	}
 }
 
-// NewConsoleWriterWithOptions creates a ConsoleWriter with injectable dependencies
-// for testing. This allows mocking of terminal detection and other environment
-// checks to ensure reliable testing across different scenarios.
+// NewConsoleWriterWithOptions creates a ConsoleWriter with color options and
+// injectable dependencies. The dependencies allow mocking of terminal detection
+// and other environment checks to ensure reliable testing across different scenarios.
 func NewConsoleWriterWithOptions(opts ConsoleWriterOptions) ConsoleWriter {
 	isTerminalFunc := opts.IsTerminalFunc
 	if isTerminalFunc == nil {
@@ -301,13 +318,17 @@ func NewConsoleWriterWithOptions(opts ConsoleWriterOptions) ConsoleWriter {
 	}
 
 	isInteractive := DetectInteractiveEnvironment(isTerminalFunc, getEnvFunc)
-	return &consoleWriter{
+	writer := &consoleWriter{
 		isTerminalFunc:  isTerminalFunc,
 		getTermSizeFunc: getTermSizeFunc,
 		isInteractive:   isInteractive,
-		colors:          NewColorScheme(isInteractive),
+		colors:          NewThemedColorScheme(ColorsEnabled(opts.ColorMode, isInteractive, getEnvFunc), opts.Theme),
 		symbols:         NewSymbolProvider(isInteractive),
 	}
+	if opts.OrderedOutput {
+		writer.ordered = newOrderedOutput()
+	}
+	return writer
 }
 
 // defaultIsTerminal uses golang.org/x/term to detect if stdout is a terminal
@@ -330,11 +351,13 @@ func detectInteractiveEnvironment(isTerminalFunc func() bool) bool {
 func (c *consoleWriter) StartProcessing(modelCount int) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.settleProcessingLinesLocked()
+	c.flushOrderedLocked()
 
 	c.modelCount = modelCount
 	c.modelIndex = 0
 
-	if c.quiet {
+	if c.isQuietLocked() {
 		return
 	}
 
@@ -348,7 +371,7 @@ func (c *consoleWriter) ModelQueued(modelName string, index int) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.quiet || c.noProgress {
+	if c.isQuietLocked() || c.noProgress {
 		return
 	}
 
@@ -360,8 +383,9 @@ func (c *consoleWriter) ModelQueued(modelName string, index int) {
 func (c *consoleWriter) ModelStarted(modelIndex, totalModels int, modelName string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.settleProcessingLinesLocked()
 
-	if c.quiet {
+	if c.isQuietLocked() {
 		return
 	}
 
@@ -371,9 +395,9 @@ func (c *consoleWriter) ModelStarted(modelIndex, totalModels int, modelName stri
 
 	coloredModelName := c.colors.ColorModelName(modelName)
 	if c.isInteractive {
-		WriteToConsoleF("[%d/%d] %s: processing...\n", modelIndex, totalModels, coloredModelName)
+		c.writeModelLineLocked(modelIndex, "[%d/%d] %s: processing...\n", modelIndex, totalModels, coloredModelName)
 	} else {
-		WriteToConsoleF("Processing model %d/%d: %s\n", modelIndex, totalModels, coloredModelName)
+		c.writeModelLineLocked(modelIndex, "Processing model %d/%d: %s\n", modelIndex, totalModels, coloredModelName)
 	}
 }
 
@@ -381,9 +405,11 @@ func (c *consoleWriter) ModelStarted(modelIndex, totalModels int, modelName stri
 func (c *consoleWriter) ModelCompleted(modelIndex, totalModels int, modelName string, duration time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.settleProcessingLinesLocked()
+	defer c.finishModelLocked(modelIndex)
 
 	// Success messages can be suppressed in quiet mode
-	if c.quiet || c.noProgress {
+	if c.isQuietLocked() || c.noProgress {
 		return
 	}
 
@@ -392,9 +418,9 @@ func (c *consoleWriter) ModelCompleted(modelIndex, totalModels int, modelName st
 	successSymbol := c.colors.ColorSuccess(c.symbols.GetSymbols().Success)
 
 	if c.isInteractive {
-		WriteToConsoleF("[%d/%d] %s: %s completed (%s)\n", modelIndex, totalModels, coloredModelName, successSymbol, durationStr)
+		c.writeModelLineLocked(modelIndex, "[%d/%d] %s: %s completed (%s)\n", modelIndex, totalModels, coloredModelName, successSymbol, durationStr)
 	} else {
-		WriteToConsoleF("Completed model %d/%d: %s (%s)\n", modelIndex, totalModels, coloredModelName, durationStr)
+		c.writeModelLineLocked(modelIndex, "Completed model %d/%d: %s (%s)\n", modelIndex, totalModels, coloredModelName, durationStr)
 	}
 }
 
@@ -402,6 +428,8 @@ func (c *consoleWriter) ModelCompleted(modelIndex, totalModels int, modelName st
 func (c *consoleWriter) ModelFailed(modelIndex, totalModels int, modelName string, reason string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.settleProcessingLinesLocked()
+	defer c.finishModelLocked(modelIndex)
 
 	// Errors are essential - always show them even in quiet mode
 	coloredModelName := c.colors.ColorModelName(modelName)
@@ -413,9 +441,9 @@ func (c *consoleWriter) ModelFailed(modelIndex, totalModels int, modelName strin
 	coloredFirstLine := c.colors.ColorError(firstLine)
 
 	if c.isInteractive {
-		WriteToConsoleF("[%d/%d] %s: %s failed (%s)\n", modelIndex, totalModels, coloredModelName, errorSymbol, coloredFirstLine)
+		c.writeModelLineLocked(modelIndex, "[%d/%d] %s: %s failed (%s)\n", modelIndex, totalModels, coloredModelName, errorSymbol, coloredFirstLine)
 	} else {
-		WriteToConsoleF("Failed model %d/%d: %s (%s)\n", modelIndex, totalModels, coloredModelName, coloredFirstLine)
+		c.writeModelLineLocked(modelIndex, "Failed model %d/%d: %s (%s)\n", modelIndex, totalModels, coloredModelName, coloredFirstLine)
 	}
 
 	// Print additional lines (like suggestions) with proper indentation
@@ -424,7 +452,7 @@ func (c *consoleWriter) ModelFailed(modelIndex, totalModels int, modelName strin
 			if strings.TrimSpace(line) != "" {
 				// Use warning color for suggestions to make them less prominent than errors
 				coloredLine := c.colors.ColorWarning(line)
-				WriteToConsoleF("  %s\n", coloredLine)
+				c.writeModelLineLocked(modelIndex, "  %s\n", coloredLine)
 			}
 		}
 	}
@@ -434,8 +462,9 @@ func (c *consoleWriter) ModelFailed(modelIndex, totalModels int, modelName strin
 func (c *consoleWriter) ModelRateLimited(modelIndex, totalModels int, modelName string, retryAfter time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.settleProcessingLinesLocked()
 
-	if c.quiet {
+	if c.isQuietLocked() {
 		return
 	}
 
@@ -444,9 +473,9 @@ func (c *consoleWriter) ModelRateLimited(modelIndex, totalModels int, modelName
 	warningSymbol := c.colors.ColorWarning(c.symbols.GetSymbols().Warning)
 
 	if c.isInteractive {
-		WriteToConsoleF("[%d/%d] %s: %s rate limited (retry in %s)\n", modelIndex, totalModels, coloredModelName, warningSymbol, retryStr)
+		c.writeModelLineLocked(modelIndex, "[%d/%d] %s: %s rate limited (retry in %s)\n", modelIndex, totalModels, coloredModelName, warningSymbol, retryStr)
 	} else {
-		WriteToConsoleF("Rate limited for model %d/%d: %s (retry in %s)\n", modelIndex, totalModels, coloredModelName, retryStr)
+		c.writeModelLineLocked(modelIndex, "Rate limited for model %d/%d: %s (retry in %s)\n", modelIndex, totalModels, coloredModelName, retryStr)
 	}
 }
 
@@ -454,8 +483,9 @@ func (c *consoleWriter) ModelRateLimited(modelIndex, totalModels int, modelName
 func (c *consoleWriter) SynthesisStarted() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.flushOrderedLocked()
 
-	if c.quiet {
+	if c.isQuietLocked() {
 		return
 	}
 }
@@ -465,7 +495,7 @@ func (c *consoleWriter) SynthesisCompleted(outputPath string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.quiet {
+	if c.isQuietLocked() {
 		return
 	}
 	c.startMidSectionLocked()
@@ -479,8 +509,9 @@ func (c *consoleWriter) SynthesisCompleted(outputPath string) {
 func (c *consoleWriter) StatusMessage(message string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.settleProcessingLinesLocked()
 
-	if c.quiet {
+	if c.isQuietLocked() {
 		return
 	}
 
@@ -509,6 +540,29 @@ func (c *consoleWriter) SetNoProgress(noProgress bool) {
 	c.noProgress = noProgress
 }
 
+// SetSilent enables or disables silent mode
+func (c *consoleWriter) SetSilent(silent bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.silent = silent
+}
+
+// isQuietLocked reports whether non-essential output is suppressed, which
+// silent mode implies. Callers must hold c.mu.
+func (c *consoleWriter) isQuietLocked() bool {
+	return c.quiet || c.silent
+}
+
+// writeEssentialLocked writes an error or warning line to stdout, or to stderr
+// in silent mode. Callers must hold c.mu.
+func (c *consoleWriter) writeEssentialLocked(format string, args ...interface{}) {
+	if c.silent {
+		WriteToStderrF(format, args...)
+		return
+	}
+	WriteToConsoleF(format, args...)
+}
+
 // IsInteractive returns true if the output environment supports interactive features
 func (c *consoleWriter) IsInteractive() bool {
 	c.mu.Lock()
@@ -728,6 +782,7 @@ func summarizeErrorReason(details string) string {
 func (c *consoleWriter) ErrorMessage(message string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.settleProcessingLinesLocked()
 
 	// Errors are essential - always show them even in quiet mode
 	modelName, details := parseErrorDetails(message)
@@ -740,26 +795,27 @@ func (c *consoleWriter) ErrorMessage(message string) {
 	coloredReason := c.colors.ColorError(reason)
 
 	if c.isInteractive {
-		WriteToConsole("Error")
+		c.writeEssentialLocked("Error\n")
 		if modelName != "" {
 			coloredModel := c.colors.ColorModelName(modelName)
-			WriteToConsoleF("  Model: %s\n", coloredModel)
+			c.writeEssentialLocked("  Model: %s\n", coloredModel)
 		}
-		WriteToConsoleF("  Reason: %s\n", coloredReason)
+		c.writeEssentialLocked("  Reason: %s\n", coloredReason)
 		return
 	}
 
 	if modelName != "" {
-		WriteToConsoleF("ERROR: model=%s reason=%s\n", modelName, reason)
+		c.writeEssentialLocked("ERROR: model=%s reason=%s\n", modelName, reason)
 		return
 	}
-	WriteToConsoleF("ERROR: %s\n", reason)
+	c.writeEssentialLocked("ERROR: %s\n", reason)
 }
 
 // WarningMessage displays a warning message to the user with appropriate formatting
 func (c *consoleWriter) WarningMessage(message string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.settleProcessingLinesLocked()
 
 	// Warnings are essential - always show them even in quiet mode
 	formattedMessage := c.formatMessageForTerminal(message)
@@ -767,9 +823,9 @@ func (c *consoleWriter) WarningMessage(message string) {
 
 	if c.isInteractive {
 		warningSymbol := c.colors.ColorWarning(c.symbols.GetSymbols().Warning)
-		WriteToConsoleF("%s %s\n", warningSymbol, coloredMessage)
+		c.writeEssentialLocked("%s %s\n", warningSymbol, coloredMessage)
 	} else {
-		WriteToConsoleF("WARNING: %s\n", coloredMessage)
+		c.writeEssentialLocked("WARNING: %s\n", coloredMessage)
 	}
 }
 
@@ -777,8 +833,9 @@ func (c *consoleWriter) WarningMessage(message string) {
 func (c *consoleWriter) SuccessMessage(message string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.settleProcessingLinesLocked()
 
-	if c.quiet {
+	if c.isQuietLocked() {
 		return
 	}
 
@@ -797,50 +854,30 @@ func (c *consoleWriter) SuccessMessage(message string) {
 // These methods implement the modern clean CLI output format with proper
 // alignment, color schemes, and responsive layout.
 
-// ShowProcessingLine displays an initial processing status line for a model
+// ShowProcessingLine displays an initial processing status line for a model.
+// In interactive terminals the line joins a region that is updated in place.
 func (c *consoleWriter) ShowProcessingLine(modelName string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.quiet {
+	if c.isQuietLocked() {
 		return
 	}
 
-	layout := c.getLayoutLocked()
-
-	// Apply color to model name
-	coloredModelName := c.colors.ColorModelName(modelName)
-
-	// Create processing status
-	processingStatus := "processing..."
-
-	// Format with proper alignment
-	alignedOutput := layout.FormatAlignedText(coloredModelName, processingStatus)
-
-	WriteToConsole(alignedOutput)
+	c.setProcessingLineLocked(modelName, "processing...")
 }
 
-// UpdateProcessingLine updates the processing line in-place with final status
+// UpdateProcessingLine updates the processing line with final status. Interactive
+// terminals rewrite the model's line in place; other environments print a new line.
 func (c *consoleWriter) UpdateProcessingLine(modelName string, status string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.quiet {
+	if c.isQuietLocked() {
 		return
 	}
 
-	layout := c.getLayoutLocked()
-
-	// Apply color to model name
-	coloredModelName := c.colors.ColorModelName(modelName)
-
-	// Apply semantic coloring to status based on content
-	coloredStatus := c.colorizeStatus(status)
-
-	// Format with proper alignment
-	alignedOutput := layout.FormatAlignedText(coloredModelName, coloredStatus)
-
-	WriteToConsole(alignedOutput)
+	c.setProcessingLineLocked(modelName, status)
 }
 
 // colorizeStatus applies appropriate colors to status text based on content
@@ -852,8 +889,9 @@ func (c *consoleWriter) colorizeStatus(status string) string {
 func (c *consoleWriter) ShowFileOperations(message string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.settleProcessingLinesLocked()
 
-	if c.quiet {
+	if c.isQuietLocked() {
 		return
 	}
 
@@ -878,8 +916,9 @@ func (c *consoleWriter) ShowFileOperations(message string) {
 func (c *consoleWriter) ShowSummarySection(summary SummaryData) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.settleProcessingLinesLocked()
 
-	if c.quiet {
+	if c.isQuietLocked() {
 		return
 	}
 
@@ -927,12 +966,65 @@ func (c *consoleWriter) ShowSummarySection(summary SummaryData) {
 		outputLabel,
 		c.colors.ColorFilePath(pathutil.SanitizePathForDisplay(summary.OutputDirectory)))
 
+	if summary.TruncatedFiles > 0 {
+		noun := "file"
+		if summary.TruncatedFiles != 1 {
+			noun = "files"
+		}
+		truncatedLabel := fmt.Sprintf("  %-*s", labelWidth, "Truncated")
+		WriteToConsoleF("%s %s\n", truncatedLabel,
+			c.colors.ColorWarning(fmt.Sprintf("%d %s at size limit", summary.TruncatedFiles, noun)))
+	}
+
+	if summary.TokenMismatches > 0 {
+		noun := "model"
+		if summary.TokenMismatches != 1 {
+			noun = "models"
+		}
+		tokensLabel := fmt.Sprintf("  %-*s", labelWidth, "Tokens")
+		WriteToConsoleF("%s %s\n", tokensLabel,
+			c.colors.ColorWarning(fmt.Sprintf("%d %s billed differently than estimated (see logs)", summary.TokenMismatches, noun)))
+	}
+
+	if len(summary.ExcludeRules) > 0 {
+		excludedLabel := fmt.Sprintf("  %-*s", labelWidth, "Excluded")
+		WriteToConsoleF("%s %s\n", excludedLabel, formatExcludeRules(summary.ExcludeRules))
+	}
+
 	// Add contextual messaging and guidance based on scenarios
 	c.displayScenarioGuidance(summary)
 
 	WriteToConsoleF("%s\n", c.colors.ColorSeparator(separatorLine))
 }
 
+// formatExcludeRules summarizes exclude rule matches for the summary section,
+// e.g. "12 paths (node_modules: 10, .log: 2); unused: vendor"
+func formatExcludeRules(rules []ExcludeRuleCount) string {
+	total := 0
+	var matched, unused []string
+	for _, rule := range rules {
+		if rule.Matched == 0 {
+			unused = append(unused, rule.Rule)
+			continue
+		}
+		total += rule.Matched
+		matched = append(matched, fmt.Sprintf("%s: %d", rule.Rule, rule.Matched))
+	}
+
+	text := "no paths"
+	if total > 0 {
+		noun := "paths"
+		if total == 1 {
+			noun = "path"
+		}
+		text = fmt.Sprintf("%d %s (%s)", total, noun, strings.Join(matched, ", "))
+	}
+	if len(unused) > 0 {
+		text += "; unused: " + strings.Join(unused, ", ")
+	}
+	return text
+}
+
 // displayScenarioGuidance provides contextual messaging and actionable next steps
 // based on the processing results (all failed, partial success, etc.)
 func (c *consoleWriter) displayScenarioGuidance(summary SummaryData) {
@@ -1022,8 +1114,9 @@ func stripANSI(text string) string {
 func (c *consoleWriter) ShowOutputFiles(files []OutputFile) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.settleProcessingLinesLocked()
 
-	if c.quiet || len(files) == 0 {
+	if c.isQuietLocked() || len(files) == 0 {
 		return
 	}
 
@@ -1056,8 +1149,9 @@ func (c *consoleWriter) ShowOutputFiles(files []OutputFile) {
 func (c *consoleWriter) ShowFailedModels(failed []FailedModel) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.settleProcessingLinesLocked()
 
-	if c.quiet || len(failed) == 0 {
+	if c.isQuietLocked() || len(failed) == 0 {
 		return
 	}
 
@@ -1092,6 +1186,13 @@ type ConsoleWriterOptions struct {
 	GetTermSizeFunc func() (int, int, error)
 	// GetEnvFunc allows injecting custom environment variable reading for testing
 	GetEnvFunc func(string) string
+	// ColorMode is ColorAuto (the default when empty), ColorAlways, or ColorNever
+	ColorMode string
+	// Theme names the color palette, ThemeDefault when empty
+	Theme string
+	// OrderedOutput holds per-model progress lines and prints them in model
+	// index order rather than arrival order during parallel processing
+	OrderedOutput bool
 }
 
 // Status tracking methods are implemented in console_writer_status.go
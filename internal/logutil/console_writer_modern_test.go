@@ -259,6 +259,10 @@ func TestModernConsoleWriter_SummaryFormatting(t *testing.T) {
 		FailedModels:     1,
 		SynthesisStatus:  "completed",
 		OutputDirectory:  "/tmp/thinktank-output",
+		ExcludeRules: []ExcludeRuleCount{
+			{Kind: "name", Rule: "node_modules", Matched: 2},
+			{Kind: "extension", Rule: ".log", Matched: 0},
+		},
 	}
 
 	writer.ShowSummarySection(summaryData)
@@ -281,6 +285,7 @@ func TestModernConsoleWriter_SummaryFormatting(t *testing.T) {
 		"[OK] completed",     // ASCII success symbol
 		"Output",             // Output line
 		"./thinktank-output", // Sanitized to relative path
+		"Excluded   2 paths (node_modules: 2); unused: .log",
 		"1 model failed - review errors above",
 	}
 
@@ -446,6 +451,63 @@ func TestModernConsoleWriter_ProcessingLineAlignment(t *testing.T) {
 	}
 }
 
+// TestModernConsoleWriter_ProcessingLineInPlace verifies interactive processing lines
+// are redrawn in place and collapse to a summary once every model has finished
+func TestModernConsoleWriter_ProcessingLineInPlace(t *testing.T) {
+	writer := NewConsoleWriterWithOptions(ConsoleWriterOptions{
+		IsTerminalFunc:  func() bool { return true },
+		GetTermSizeFunc: func() (int, int, error) { return 80, 24, nil },
+		GetEnvFunc:      func(key string) string { return "" },
+	})
+
+	output := captureOutput(func() {
+		writer.StartProcessing(2)
+		writer.ShowProcessingLine("model-a")
+		writer.ShowProcessingLine("model-b")
+		writer.UpdateProcessingLine("model-a", "✓ 2.3s")
+		writer.UpdateProcessingLine("model-b", "✗ rate limited")
+	})
+	plain := stripANSI(output)
+
+	// Each redraw moves back over the lines already printed
+	for _, move := range []string{"\033[1A", "\033[2A"} {
+		if !strings.Contains(output, move) {
+			t.Errorf("expected cursor movement %q in output %q", move, output)
+		}
+	}
+	if !strings.HasSuffix(plain, "Processed 2 models: 1 succeeded, 1 failed\n") {
+		t.Errorf("expected the region to collapse to a summary, got %q", plain)
+	}
+	if strings.Count(output, "\033[J") != 3 {
+		t.Errorf("expected the region to be erased before each of the 3 redraws, got %q", output)
+	}
+}
+
+// TestModernConsoleWriter_ProcessingLineSettles verifies other output is never
+// overwritten by a later redraw of the processing region
+func TestModernConsoleWriter_ProcessingLineSettles(t *testing.T) {
+	writer := NewConsoleWriterWithOptions(ConsoleWriterOptions{
+		IsTerminalFunc:  func() bool { return true },
+		GetTermSizeFunc: func() (int, int, error) { return 80, 24, nil },
+		GetEnvFunc:      func(key string) string { return "" },
+	})
+
+	output := captureOutput(func() {
+		writer.ShowProcessingLine("model-a")
+		writer.ShowProcessingLine("model-b")
+		writer.StatusMessage("retrying model-a")
+		writer.UpdateProcessingLine("model-a", "✓ 2.3s")
+	})
+
+	if after := output[strings.Index(output, "retrying model-a"):]; strings.Contains(after, "\033[") && strings.Contains(after, "A\r") {
+		t.Errorf("expected no cursor movement after other output, got %q", after)
+	}
+	plain := stripANSI(output)
+	if !strings.Contains(plain, "retrying model-a\nmodel-a") || strings.Contains(plain, "Processed") {
+		t.Errorf("expected the final status on a new line without a summary, got %q", plain)
+	}
+}
+
 // TestModernConsoleWriter_EnvironmentDetection verifies proper environment adaptation
 func TestModernConsoleWriter_EnvironmentDetection(t *testing.T) {
 	envTests := []struct {
@@ -603,3 +665,39 @@ func TestShowFileOperations(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatExcludeRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []ExcludeRuleCount
+		want  string
+	}{
+		{
+			name:  "one match",
+			rules: []ExcludeRuleCount{{Kind: "extension", Rule: ".log", Matched: 1}},
+			want:  "1 path (.log: 1)",
+		},
+		{
+			name: "matched and unused",
+			rules: []ExcludeRuleCount{
+				{Kind: "name", Rule: "node_modules", Matched: 10},
+				{Kind: "name", Rule: "vendor"},
+				{Kind: "extension", Rule: ".log", Matched: 2},
+			},
+			want: "12 paths (node_modules: 10, .log: 2); unused: vendor",
+		},
+		{
+			name:  "nothing matched",
+			rules: []ExcludeRuleCount{{Kind: "name", Rule: "vendor"}, {Kind: "extension", Rule: ".tmp"}},
+			want:  "no paths; unused: vendor, .tmp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatExcludeRules(tt.rules); got != tt.want {
+				t.Errorf("formatExcludeRules() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
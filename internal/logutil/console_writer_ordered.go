@@ -0,0 +1,78 @@
+package logutil
+
+import "fmt"
+
+// orderedOutput is the reorder buffer behind ConsoleWriterOptions.OrderedOutput.
+// Lines for the lowest unfinished model index are printed as they arrive; lines
+// for later models are held until every earlier model has completed or failed,
+// so concurrent progress reads in model order instead of arrival order.
+type orderedOutput struct {
+	next    int              // Lowest model index that has not finished, 1-based
+	pending map[int][]string // Held lines keyed by model index
+	done    map[int]bool     // Models that finished while an earlier one was still running
+}
+
+func newOrderedOutput() *orderedOutput {
+	return &orderedOutput{
+		next:    1,
+		pending: make(map[int][]string),
+		done:    make(map[int]bool),
+	}
+}
+
+// writeModelLineLocked prints a formatted progress line for modelIndex, or holds
+// it in the reorder buffer when ordered output is enabled and the model is not
+// the next one due. In silent mode only failures get here, and they go straight
+// to stderr. Callers must hold c.mu.
+func (c *consoleWriter) writeModelLineLocked(modelIndex int, format string, args ...interface{}) {
+	if c.silent {
+		WriteToStderrF(format, args...)
+		return
+	}
+	if c.ordered == nil || modelIndex <= c.ordered.next {
+		WriteToConsoleF(format, args...)
+		return
+	}
+	c.ordered.pending[modelIndex] = append(c.ordered.pending[modelIndex], fmt.Sprintf(format, args...))
+}
+
+// finishModelLocked records that modelIndex produced its last line and releases
+// any held lines that are now in order. Callers must hold c.mu.
+func (c *consoleWriter) finishModelLocked(modelIndex int) {
+	if c.ordered == nil || modelIndex < c.ordered.next {
+		return
+	}
+	c.ordered.done[modelIndex] = true
+
+	for c.ordered.done[c.ordered.next] {
+		delete(c.ordered.done, c.ordered.next)
+		c.ordered.next++
+		for _, line := range c.ordered.pending[c.ordered.next] {
+			WriteToConsoleF("%s", line)
+		}
+		delete(c.ordered.pending, c.ordered.next)
+	}
+}
+
+// flushOrderedLocked prints every held line in model order and resets the
+// buffer, so nothing is lost when a model never reports a final state.
+// Callers must hold c.mu.
+func (c *consoleWriter) flushOrderedLocked() {
+	if c.ordered == nil {
+		return
+	}
+
+	for len(c.ordered.pending) > 0 {
+		lowest := 0
+		for index := range c.ordered.pending {
+			if lowest == 0 || index < lowest {
+				lowest = index
+			}
+		}
+		for _, line := range c.ordered.pending[lowest] {
+			WriteToConsoleF("%s", line)
+		}
+		delete(c.ordered.pending, lowest)
+	}
+	c.ordered = newOrderedOutput()
+}
@@ -0,0 +1,101 @@
+package logutil
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func newCIConsoleWriter(ordered bool) ConsoleWriter {
+	return NewConsoleWriterWithOptions(ConsoleWriterOptions{
+		IsTerminalFunc: func() bool { return false },
+		GetEnvFunc:     func(key string) string { return "" },
+		ColorMode:      ColorNever,
+		OrderedOutput:  ordered,
+	})
+}
+
+// reportOutOfOrder reports three models whose events arrive interleaved, with
+// the last model finishing first
+func reportOutOfOrder(cw ConsoleWriter) {
+	cw.ModelStarted(1, 3, "alpha")
+	cw.ModelStarted(2, 3, "beta")
+	cw.ModelStarted(3, 3, "gamma")
+	cw.ModelFailed(3, 3, "gamma", "boom\nTry again later")
+	cw.ModelRateLimited(2, 3, "beta", time.Second)
+	cw.ModelCompleted(1, 3, "alpha", time.Second)
+	cw.ModelCompleted(2, 3, "beta", time.Second)
+}
+
+func TestConsoleWriter_OrderedOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		ordered bool
+		want    []string
+	}{
+		{
+			name:    "direct output keeps arrival order",
+			ordered: false,
+			want: []string{
+				"Processing model 1/3: alpha",
+				"Processing model 2/3: beta",
+				"Processing model 3/3: gamma",
+				"Failed model 3/3: gamma (boom)",
+				"  Try again later",
+				"Rate limited for model 2/3: beta (retry in 1.0s)",
+				"Completed model 1/3: alpha (1.0s)",
+				"Completed model 2/3: beta (1.0s)",
+			},
+		},
+		{
+			name:    "ordered output groups lines by model index",
+			ordered: true,
+			want: []string{
+				"Processing model 1/3: alpha",
+				"Completed model 1/3: alpha (1.0s)",
+				"Processing model 2/3: beta",
+				"Rate limited for model 2/3: beta (retry in 1.0s)",
+				"Completed model 2/3: beta (1.0s)",
+				"Processing model 3/3: gamma",
+				"Failed model 3/3: gamma (boom)",
+				"  Try again later",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cw := newCIConsoleWriter(tt.ordered)
+			output := captureOutput(func() { reportOutOfOrder(cw) })
+
+			got := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("output =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestConsoleWriter_OrderedOutputFlush(t *testing.T) {
+	cw := newCIConsoleWriter(true)
+
+	// Model 1 never reports a final state, so model 2 stays held
+	held := captureOutput(func() {
+		cw.ModelStarted(1, 2, "alpha")
+		cw.ModelCompleted(2, 2, "beta", time.Second)
+	})
+	if strings.Contains(held, "beta") {
+		t.Fatalf("beta should be held until alpha finishes, got %q", held)
+	}
+
+	flushed := captureOutput(func() { cw.FinishStatusTracking() })
+	if !strings.Contains(flushed, "Completed model 2/2: beta") {
+		t.Errorf("FinishStatusTracking should flush held lines, got %q", flushed)
+	}
+
+	// The buffer starts over for the next batch
+	next := captureOutput(func() { cw.ModelStarted(1, 1, "gamma") })
+	if !strings.Contains(next, "Processing model 1/1: gamma") {
+		t.Errorf("first model of a new batch should print immediately, got %q", next)
+	}
+}
@@ -0,0 +1,130 @@
+package logutil
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Processing Line Implementation
+// This file contains the in-place processing region used by ShowProcessingLine
+// and UpdateProcessingLine in interactive terminals
+
+// processingRegion is the block of per-model processing lines at the bottom of an
+// interactive terminal. Each update redraws the block in place rather than
+// appending a line, and the block collapses to a one-line summary once every
+// model has a final status.
+type processingRegion struct {
+	models   []string          // Models in the order their lines were first shown
+	statuses map[string]string // Latest uncolored status per model
+	printed  int               // Lines currently on screen, for moving the cursor back up
+}
+
+func newProcessingRegion() *processingRegion {
+	return &processingRegion{statuses: make(map[string]string)}
+}
+
+// set records a model's status, adding a line for models not yet shown
+func (r *processingRegion) set(modelName, status string) {
+	if _, ok := r.statuses[modelName]; !ok {
+		r.models = append(r.models, modelName)
+	}
+	r.statuses[modelName] = status
+}
+
+// counts returns how many models finished, and how many of those failed
+func (r *processingRegion) counts() (finished, failed int) {
+	for _, model := range r.models {
+		status := r.statuses[model]
+		if isPendingProcessingStatus(status) {
+			continue
+		}
+		finished++
+		if isFailedProcessingStatus(status) {
+			failed++
+		}
+	}
+	return finished, failed
+}
+
+// isPendingProcessingStatus reports whether a status line still describes work
+// in progress, including rate limit waits that will be followed by a final status
+func isPendingProcessingStatus(status string) bool {
+	lower := strings.ToLower(status)
+	return strings.Contains(status, "⚠") || strings.Contains(lower, "processing") ||
+		strings.Contains(lower, "queued") || strings.Contains(lower, "starting")
+}
+
+// isFailedProcessingStatus reports whether a final status describes a failure
+func isFailedProcessingStatus(status string) bool {
+	return strings.Contains(status, "✗") || strings.Contains(status, "[X]") ||
+		strings.Contains(strings.ToLower(status), "failed")
+}
+
+// setProcessingLineLocked records a model's status and prints it. Interactive
+// terminals redraw the processing region in place; everything else gets one line
+// per update so logs read top to bottom.
+func (c *consoleWriter) setProcessingLineLocked(modelName, status string) {
+	layout := c.getLayoutLocked()
+	if !c.isInteractive {
+		WriteToConsole(layout.FormatAlignedText(c.colors.ColorModelName(modelName), c.colorizeStatus(status)))
+		return
+	}
+
+	if c.processing == nil {
+		if !isPendingProcessingStatus(status) {
+			// A final status with no live region, such as after other output
+			// settled it, is simply printed on its own line
+			WriteToConsole(layout.FormatAlignedText(c.colors.ColorModelName(modelName), c.colorizeStatus(status)))
+			return
+		}
+		c.processing = newProcessingRegion()
+	}
+	region := c.processing
+	region.set(modelName, status)
+	c.clearProcessingRegionLocked()
+
+	finished, failed := region.counts()
+	if finished == len(region.models) && finished >= c.modelCount {
+		WriteToConsole(c.formatProcessingSummaryLocked(finished, failed))
+		c.processing = nil
+		return
+	}
+
+	for _, model := range region.models {
+		WriteToConsole(layout.FormatAlignedText(c.colors.ColorModelName(model), c.colorizeStatus(region.statuses[model])))
+	}
+	region.printed = len(region.models)
+}
+
+// clearProcessingRegionLocked moves the cursor back to the top of the region and
+// erases it, so the next draw replaces the previous one
+func (c *consoleWriter) clearProcessingRegionLocked() {
+	if c.processing == nil || c.processing.printed == 0 {
+		return
+	}
+	WriteToConsoleF("\033[%dA\r\033[J", c.processing.printed)
+	c.processing.printed = 0
+}
+
+// settleProcessingLinesLocked leaves the region's lines on screen as they are and
+// stops updating them. Called before any other output so that lines written below
+// the region are never overwritten by a redraw; later updates start a new region.
+func (c *consoleWriter) settleProcessingLinesLocked() {
+	c.processing = nil
+}
+
+// formatProcessingSummaryLocked renders the line the region collapses to,
+// e.g. "✓ Processed 3 models: 3 succeeded" or "✗ Processed 3 models: 2 succeeded, 1 failed"
+func (c *consoleWriter) formatProcessingSummaryLocked(finished, failed int) string {
+	noun := "models"
+	if finished == 1 {
+		noun = "model"
+	}
+	summary := fmt.Sprintf("Processed %d %s: %d succeeded", finished, noun, finished-failed)
+	symbols := c.symbols.GetSymbols()
+	if failed > 0 {
+		summary += fmt.Sprintf(", %d failed", failed)
+		return fmt.Sprintf("%s %s", c.colors.ColorError(symbols.Error), summary)
+	}
+	return fmt.Sprintf("%s %s", c.colors.ColorSuccess(symbols.Success), summary)
+}
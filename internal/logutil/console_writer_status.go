@@ -13,8 +13,9 @@ import (
 func (c *consoleWriter) StartStatusTracking(modelNames []string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.settleProcessingLinesLocked()
 
-	if c.quiet || c.noProgress {
+	if c.isQuietLocked() || c.noProgress {
 		return
 	}
 
@@ -36,7 +37,7 @@ func (c *consoleWriter) StartStatusTracking(modelNames []string) {
 
 	// Initialize tracking components
 	c.statusTracker = NewModelStatusTracker(modelInfos)
-	c.statusDisplay = NewStatusDisplay(c.isInteractive)
+	c.statusDisplay = NewStatusDisplayWithColors(c.isInteractive, c.colors)
 	c.usingStatus = true
 	if c.isInteractive {
 		c.statusDisplay.SetOnSpinnerTick(func() {
@@ -57,12 +58,15 @@ func (c *consoleWriter) UpdateModelStatus(modelName string, status ModelStatus,
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if !c.usingStatus || c.quiet {
+	if !c.usingStatus || c.isQuietLocked() {
 		return
 	}
 
 	// Update the tracker
 	c.statusTracker.UpdateStatus(modelName, status, duration, errorMsg)
+	if c.streaming {
+		return // Rendering in place would overwrite the streamed output
+	}
 
 	// Render updated status
 	states := c.statusTracker.GetAllStates()
@@ -74,12 +78,15 @@ func (c *consoleWriter) UpdateModelRateLimited(modelName string, retryAfter time
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if !c.usingStatus || c.quiet {
+	if !c.usingStatus || c.isQuietLocked() {
 		return
 	}
 
 	// Update the tracker
 	c.statusTracker.UpdateRateLimited(modelName, retryAfter)
+	if c.streaming {
+		return // Rendering in place would overwrite the streamed output
+	}
 
 	// Render updated status
 	states := c.statusTracker.GetAllStates()
@@ -91,7 +98,7 @@ func (c *consoleWriter) RefreshStatusDisplay() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if !c.usingStatus || c.quiet {
+	if !c.usingStatus || c.isQuietLocked() || c.streaming {
 		return
 	}
 
@@ -109,6 +116,13 @@ func (c *consoleWriter) RefreshStatusDisplay() {
 func (c *consoleWriter) FinishStatusTracking() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.flushOrderedLocked()
+
+	// End the streamed block on its own line
+	if c.streaming {
+		WriteToConsole("")
+		c.streaming = false
+	}
 
 	if !c.usingStatus {
 		return
@@ -124,3 +138,25 @@ func (c *consoleWriter) FinishStatusTracking() {
 	c.statusDisplay = nil
 	c.usingStatus = false
 }
+
+// StreamModelOutput writes a piece of a model's streamed response as it arrives
+func (c *consoleWriter) StreamModelOutput(modelName string, content string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.settleProcessingLinesLocked()
+
+	if c.isQuietLocked() {
+		return
+	}
+
+	if !c.streaming {
+		c.streaming = true
+		// Leave the status lines in place and stream below them
+		if c.statusDisplay != nil {
+			c.statusDisplay.Stop()
+			c.statusDisplay.lastLineCount = 0
+		}
+		WriteToConsole("")
+	}
+	WriteToConsoleF("%s", content)
+}
@@ -1,6 +1,7 @@
 package logutil
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -85,3 +86,47 @@ func TestConsoleWriter_StatusTracking_NoProgressMode(t *testing.T) {
 		t.Errorf("Expected no output in no-progress mode, got: %q", output)
 	}
 }
+
+func TestConsoleWriter_StreamModelOutput(t *testing.T) {
+	cw := NewConsoleWriterWithOptions(ConsoleWriterOptions{
+		IsTerminalFunc: func() bool { return true },
+		GetEnvFunc:     func(key string) string { return "" },
+	})
+	captureOutput(func() {
+		cw.StartStatusTracking([]string{"model1"})
+	})
+
+	output := captureOutput(func() {
+		cw.StreamModelOutput("model1", "Hello, ")
+		cw.StreamModelOutput("model1", "world")
+		// Status updates must not redraw over the streamed text
+		cw.UpdateModelStatus("model1", StatusCompleted, time.Second, "")
+		cw.RefreshStatusDisplay()
+	})
+	if output != "\nHello, world" {
+		t.Errorf("Expected only the streamed text, got: %q", output)
+	}
+
+	output = captureOutput(func() {
+		cw.FinishStatusTracking()
+	})
+	if !strings.HasPrefix(output, "\n") {
+		t.Errorf("Expected the streamed block to end with a newline, got: %q", output)
+	}
+}
+
+func TestConsoleWriter_StreamModelOutput_QuietMode(t *testing.T) {
+	cw := NewConsoleWriterWithOptions(ConsoleWriterOptions{
+		IsTerminalFunc: func() bool { return true },
+		GetEnvFunc:     func(key string) string { return "" },
+	})
+	cw.SetQuiet(true)
+
+	output := captureOutput(func() {
+		cw.StreamModelOutput("model1", "Hello")
+		cw.FinishStatusTracking()
+	})
+	if output != "" {
+		t.Errorf("Expected no output in quiet mode, got: %q", output)
+	}
+}
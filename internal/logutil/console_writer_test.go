@@ -362,6 +362,34 @@ func TestConsoleWriter_NonInteractiveEnvironment(t *testing.T) {
 	}
 }
 
+func TestConsoleWriter_ColorMode(t *testing.T) {
+	tests := []struct {
+		name       string
+		colorMode  string
+		env        map[string]string
+		wantColors bool
+	}{
+		{"auto in a terminal", ColorAuto, nil, true},
+		{"NO_COLOR in a terminal", ColorAuto, map[string]string{"NO_COLOR": "1"}, false},
+		{"never in a terminal", ColorNever, nil, false},
+		{"always with NO_COLOR", ColorAlways, map[string]string{"NO_COLOR": "1"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cw := NewConsoleWriterWithOptions(ConsoleWriterOptions{
+				IsTerminalFunc: func() bool { return true },
+				GetEnvFunc:     func(key string) string { return tt.env[key] },
+				ColorMode:      tt.colorMode,
+			}).(*consoleWriter)
+
+			if got := containsColorCode(cw.colors.ColorError("test")); got != tt.wantColors {
+				t.Errorf("colors enabled = %v, want %v", got, tt.wantColors)
+			}
+		})
+	}
+}
+
 func TestNewConsoleWriter_DefaultBehavior(t *testing.T) {
 	cw := NewConsoleWriter()
 	if cw == nil {
@@ -676,3 +704,73 @@ func TestConsoleWriter_QuietModeWithNewMethods(t *testing.T) {
 		}
 	})
 }
+
+// captureStderr captures stderr for the duration of a function call.
+func captureStderr(f func()) string {
+	old := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	done := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+		done <- buf.String()
+	}()
+
+	f()
+
+	_ = w.Close()
+	os.Stderr = old
+	return <-done
+}
+
+func TestConsoleWriter_SilentMode(t *testing.T) {
+	cw := NewConsoleWriterWithOptions(ConsoleWriterOptions{
+		IsTerminalFunc: func() bool { return false },
+		GetEnvFunc:     func(key string) string { return "" },
+		ColorMode:      ColorNever,
+	})
+	// Silent overrides quiet, whatever quiet is set to
+	cw.SetQuiet(false)
+	cw.SetSilent(true)
+
+	var stderr string
+	stdout := captureOutput(func() {
+		stderr = captureStderr(func() {
+			cw.StartProcessing(2)
+			cw.ModelStarted(1, 2, "alpha")
+			cw.ModelCompleted(1, 2, "alpha", time.Second)
+			cw.ModelStarted(2, 2, "beta")
+			cw.ModelFailed(2, 2, "beta", "boom")
+			cw.StatusMessage("status")
+			cw.SuccessMessage("success")
+			cw.SynthesisCompleted("out.md")
+			cw.ShowOutputFiles([]OutputFile{{Name: "alpha.md", Size: 10}})
+			cw.ShowFailedModels([]FailedModel{{Name: "beta", Reason: "boom"}})
+			cw.ShowSummarySection(SummaryData{ModelsProcessed: 2, SuccessfulModels: 1, FailedModels: 1})
+			cw.WarningMessage("careful")
+			cw.ErrorMessage("broken")
+		})
+	})
+
+	if stdout != "" {
+		t.Errorf("silent mode should write nothing to stdout, got %q", stdout)
+	}
+	for _, want := range []string{"Failed model 2/2: beta (boom)", "WARNING: careful", "ERROR: broken"} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("stderr should contain %q, got %q", want, stderr)
+		}
+	}
+	for _, unwanted := range []string{"alpha", "success", "status"} {
+		if strings.Contains(stderr, unwanted) {
+			t.Errorf("stderr should only carry failures, warnings and errors, got %q", stderr)
+		}
+	}
+
+	// Turning silent off restores stdout for errors
+	cw.SetSilent(false)
+	if output := captureOutput(func() { cw.ErrorMessage("broken") }); !strings.Contains(output, "ERROR: broken") {
+		t.Errorf("ErrorMessage should go to stdout outside silent mode, got %q", output)
+	}
+}
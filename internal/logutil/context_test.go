@@ -242,3 +242,23 @@ func TestCorrelationIDWithDeadlineContext(t *testing.T) {
 		t.Errorf("Correlation ID should be accessible after context deadline. Expected %s, got %s", id, GetCorrelationID(ctxWithID))
 	}
 }
+
+func TestWithAttempt(t *testing.T) {
+	if got := GetAttempt(context.Background()); got != 0 {
+		t.Errorf("GetAttempt on empty context = %d, want 0", got)
+	}
+
+	ctx := WithAttempt(context.Background(), 2)
+	if got := GetAttempt(ctx); got != 2 {
+		t.Errorf("GetAttempt = %d, want 2", got)
+	}
+
+	// A later attempt replaces the earlier one without touching the correlation ID
+	ctx = WithAttempt(WithCorrelationID(ctx, "run-1"), 3)
+	if got := GetAttempt(ctx); got != 3 {
+		t.Errorf("GetAttempt = %d, want 3", got)
+	}
+	if got := GetCorrelationID(ctx); got != "run-1" {
+		t.Errorf("GetCorrelationID = %q, want run-1", got)
+	}
+}
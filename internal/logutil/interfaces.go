@@ -41,6 +41,7 @@ type EnvironmentAware interface {
 type QuietModeController interface {
 	SetQuiet(quiet bool)
 	SetNoProgress(noProgress bool)
+	SetSilent(silent bool)
 }
 
 var (
@@ -0,0 +1,191 @@
+package logutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// jsonSummary is the document written by --output-format json
+type jsonSummary struct {
+	ModelsProcessed  int              `json:"models_processed"`
+	SuccessfulModels int              `json:"successful_models"`
+	FailedModels     int              `json:"failed_models"`
+	Successes        []string         `json:"successes"`
+	Failures         []jsonFailure    `json:"failures"`
+	Skipped          []jsonFailure    `json:"skipped"`
+	SynthesisStatus  string           `json:"synthesis_status"`
+	OutputDirectory  string           `json:"output_directory"`
+	OutputFiles      []jsonOutputFile `json:"output_files"`
+	TruncatedFiles   int              `json:"truncated_files"`
+	TokenMismatches  int              `json:"token_mismatches"`
+	ExcludeRules     []jsonExclude    `json:"exclude_rules"`
+	DurationMs       int64            `json:"duration_ms"`
+}
+
+type jsonExclude struct {
+	Kind    string `json:"kind"`
+	Rule    string `json:"rule"`
+	Matched int    `json:"matched"`
+}
+
+type jsonFailure struct {
+	Model  string `json:"model"`
+	Reason string `json:"reason"`
+}
+
+type jsonOutputFile struct {
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+// MarshalSummaryJSON serializes a run summary as a single JSON object.
+// Lists are always present (empty rather than null) so consumers need no nil checks.
+func MarshalSummaryJSON(summary SummaryData) ([]byte, error) {
+	doc := jsonSummary{
+		ModelsProcessed:  summary.ModelsProcessed,
+		SuccessfulModels: summary.SuccessfulModels,
+		FailedModels:     summary.FailedModels,
+		Successes:        append([]string{}, summary.SuccessfulNames...),
+		Failures:         toJSONFailures(summary.Failures),
+		Skipped:          toJSONFailures(summary.Skipped),
+		SynthesisStatus:  summary.SynthesisStatus,
+		OutputDirectory:  summary.OutputDirectory,
+		OutputFiles:      make([]jsonOutputFile, 0, len(summary.OutputFiles)),
+		TruncatedFiles:   summary.TruncatedFiles,
+		TokenMismatches:  summary.TokenMismatches,
+		ExcludeRules:     make([]jsonExclude, 0, len(summary.ExcludeRules)),
+		DurationMs:       summary.Duration.Milliseconds(),
+	}
+	sort.Strings(doc.Successes)
+	for _, file := range summary.OutputFiles {
+		doc.OutputFiles = append(doc.OutputFiles, jsonOutputFile{Name: file.Name, Path: file.Path, SizeBytes: file.Size})
+	}
+	for _, rule := range summary.ExcludeRules {
+		doc.ExcludeRules = append(doc.ExcludeRules, jsonExclude{Kind: rule.Kind, Rule: rule.Rule, Matched: rule.Matched})
+	}
+	return json.Marshal(doc)
+}
+
+func toJSONFailures(models []FailedModel) []jsonFailure {
+	failures := make([]jsonFailure, 0, len(models))
+	for _, model := range models {
+		failures = append(failures, jsonFailure{Model: model.Name, Reason: model.Reason})
+	}
+	return failures
+}
+
+// jsonConsoleWriter is the ConsoleWriter used with --output-format json. It keeps
+// stdout free of progress and status text so the only thing written there is the
+// summary document; errors and warnings go to stderr as plain lines.
+type jsonConsoleWriter struct {
+	mu          sync.Mutex
+	out         io.Writer
+	errOut      io.Writer
+	outputFiles []OutputFile  // From ShowOutputFiles, used when the summary has none
+	failed      []FailedModel // From ShowFailedModels, used when the summary has none
+	silent      bool          // Skip the summary document, leaving stdout empty
+}
+
+// Ensure jsonConsoleWriter implements ConsoleWriter interface
+var _ ConsoleWriter = (*jsonConsoleWriter)(nil)
+
+// NewJSONConsoleWriter creates a ConsoleWriter that writes the run summary to out
+// as JSON and error or warning messages to errOut
+func NewJSONConsoleWriter(out, errOut io.Writer) ConsoleWriter {
+	return &jsonConsoleWriter{out: out, errOut: errOut}
+}
+
+// ShowSummarySection writes the summary as one JSON object followed by a newline
+func (c *jsonConsoleWriter) ShowSummarySection(summary SummaryData) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.silent {
+		return
+	}
+	if len(summary.OutputFiles) == 0 {
+		summary.OutputFiles = c.outputFiles
+	}
+	if len(summary.Failures) == 0 {
+		summary.Failures = c.failed
+	}
+	data, err := MarshalSummaryJSON(summary)
+	if err != nil {
+		_, _ = fmt.Fprintf(c.errOut, "ERROR: failed to encode summary: %v\n", err)
+		return
+	}
+	_, _ = fmt.Fprintf(c.out, "%s\n", data)
+}
+
+// ShowOutputFiles records the files for the JSON summary
+func (c *jsonConsoleWriter) ShowOutputFiles(files []OutputFile) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.outputFiles = append([]OutputFile(nil), files...)
+}
+
+// ShowFailedModels records the failures for the JSON summary
+func (c *jsonConsoleWriter) ShowFailedModels(failed []FailedModel) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failed = append([]FailedModel(nil), failed...)
+}
+
+// ErrorMessage writes the error to stderr so stdout stays valid JSON
+func (c *jsonConsoleWriter) ErrorMessage(message string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _ = fmt.Fprintf(c.errOut, "ERROR: %s\n", message)
+}
+
+// WarningMessage writes the warning to stderr so stdout stays valid JSON
+func (c *jsonConsoleWriter) WarningMessage(message string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _ = fmt.Fprintf(c.errOut, "WARNING: %s\n", message)
+}
+
+// Progress, status and success messages are part of the human-readable output only
+
+func (c *jsonConsoleWriter) StartProcessing(int)                                          {}
+func (c *jsonConsoleWriter) StartStatusTracking([]string)                                 {}
+func (c *jsonConsoleWriter) UpdateModelStatus(string, ModelStatus, time.Duration, string) {}
+func (c *jsonConsoleWriter) UpdateModelRateLimited(string, time.Duration)                 {}
+func (c *jsonConsoleWriter) RefreshStatusDisplay()                                        {}
+func (c *jsonConsoleWriter) FinishStatusTracking()                                        {}
+func (c *jsonConsoleWriter) StreamModelOutput(string, string)                             {}
+func (c *jsonConsoleWriter) ModelQueued(string, int)                                      {}
+func (c *jsonConsoleWriter) ModelStarted(int, int, string)                                {}
+func (c *jsonConsoleWriter) ModelCompleted(int, int, string, time.Duration)               {}
+func (c *jsonConsoleWriter) ModelFailed(int, int, string, string)                         {}
+func (c *jsonConsoleWriter) ModelRateLimited(int, int, string, time.Duration)             {}
+func (c *jsonConsoleWriter) ShowProcessingLine(string)                                    {}
+func (c *jsonConsoleWriter) UpdateProcessingLine(string, string)                          {}
+func (c *jsonConsoleWriter) ShowFileOperations(string)                                    {}
+func (c *jsonConsoleWriter) SynthesisStarted()                                            {}
+func (c *jsonConsoleWriter) SynthesisCompleted(string)                                    {}
+func (c *jsonConsoleWriter) StatusMessage(string)                                         {}
+func (c *jsonConsoleWriter) SuccessMessage(string)                                        {}
+func (c *jsonConsoleWriter) SetQuiet(bool)                                                {}
+func (c *jsonConsoleWriter) SetNoProgress(bool)                                           {}
+
+// SetSilent suppresses the summary document; errors and warnings already go to stderr
+func (c *jsonConsoleWriter) SetSilent(silent bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.silent = silent
+}
+
+// IsInteractive is always false: JSON output is for machines, never a live terminal display
+func (c *jsonConsoleWriter) IsInteractive() bool { return false }
+
+// GetTerminalWidth returns the default width since nothing is laid out for a terminal
+func (c *jsonConsoleWriter) GetTerminalWidth() int { return DefaultTerminalWidth }
+
+// FormatMessage returns the message unchanged
+func (c *jsonConsoleWriter) FormatMessage(message string) string { return message }
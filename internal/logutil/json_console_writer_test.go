@@ -0,0 +1,117 @@
+package logutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONConsoleWriterSummary(t *testing.T) {
+	var out, errOut bytes.Buffer
+	writer := NewJSONConsoleWriter(&out, &errOut)
+
+	// Progress and status output never reaches stdout in JSON mode
+	writer.StartProcessing(2)
+	writer.StatusMessage("Gathering project files...")
+	writer.UpdateModelStatus("model-a", StatusCompleted, time.Second, "")
+	writer.SuccessMessage("done")
+	writer.WarningMessage("slow provider")
+
+	writer.ShowSummarySection(SummaryData{
+		ModelsProcessed:  3,
+		SuccessfulModels: 1,
+		FailedModels:     1,
+		SynthesisStatus:  "failed",
+		OutputDirectory:  "/out/",
+		SuccessfulNames:  []string{"model-a"},
+		Failures:         []FailedModel{{Name: "model-b", Reason: "rate limited"}},
+		Skipped:          []FailedModel{{Name: "model-c", Reason: "input too large"}},
+		OutputFiles:      []OutputFile{{Name: "model-a.md", Path: "/out/model-a.md", Size: 42}},
+		Duration:         2500 * time.Millisecond,
+		ExcludeRules:     []ExcludeRuleCount{{Kind: "name", Rule: "vendor", Matched: 3}},
+	})
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("stdout is not a single JSON object: %v\n%s", err, out.String())
+	}
+	expected := map[string]interface{}{
+		"models_processed":  float64(3),
+		"successful_models": float64(1),
+		"failed_models":     float64(1),
+		"successes":         []interface{}{"model-a"},
+		"failures":          []interface{}{map[string]interface{}{"model": "model-b", "reason": "rate limited"}},
+		"skipped":           []interface{}{map[string]interface{}{"model": "model-c", "reason": "input too large"}},
+		"synthesis_status":  "failed",
+		"output_directory":  "/out/",
+		"output_files": []interface{}{
+			map[string]interface{}{"name": "model-a.md", "path": "/out/model-a.md", "size_bytes": float64(42)},
+		},
+		"truncated_files":  float64(0),
+		"token_mismatches": float64(0),
+		"exclude_rules": []interface{}{
+			map[string]interface{}{"kind": "name", "rule": "vendor", "matched": float64(3)},
+		},
+		"duration_ms": float64(2500),
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("summary JSON =\n%v\nwant\n%v", got, expected)
+	}
+	if strings.Count(out.String(), "\n") != 1 {
+		t.Errorf("expected exactly one line on stdout, got %q", out.String())
+	}
+	if errOut.String() != "WARNING: slow provider\n" {
+		t.Errorf("stderr = %q, want only the warning", errOut.String())
+	}
+}
+
+func TestMarshalSummaryJSONEmptyLists(t *testing.T) {
+	data, err := MarshalSummaryJSON(SummaryData{SynthesisStatus: "skipped"})
+	if err != nil {
+		t.Fatalf("MarshalSummaryJSON: %v", err)
+	}
+	for _, field := range []string{`"successes":[]`, `"failures":[]`, `"skipped":[]`, `"output_files":[]`, `"exclude_rules":[]`} {
+		if !strings.Contains(string(data), field) {
+			t.Errorf("expected %s in %s", field, data)
+		}
+	}
+}
+
+func TestJSONConsoleWriterUsesReportedSections(t *testing.T) {
+	var out bytes.Buffer
+	writer := NewJSONConsoleWriter(&out, &bytes.Buffer{})
+
+	writer.ShowOutputFiles([]OutputFile{{Name: "a.md", Path: "/out/a.md", Size: 7}})
+	writer.ShowFailedModels([]FailedModel{{Name: "model-b", Reason: "timeout"}})
+	writer.ShowSummarySection(SummaryData{ModelsProcessed: 2, SuccessfulModels: 1, FailedModels: 1})
+
+	var got jsonSummary
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(got.OutputFiles) != 1 || got.OutputFiles[0].SizeBytes != 7 {
+		t.Errorf("output_files = %+v, want the file from ShowOutputFiles", got.OutputFiles)
+	}
+	if len(got.Failures) != 1 || got.Failures[0].Reason != "timeout" {
+		t.Errorf("failures = %+v, want the model from ShowFailedModels", got.Failures)
+	}
+}
+
+func TestJSONConsoleWriterSilent(t *testing.T) {
+	var out, errOut bytes.Buffer
+	writer := NewJSONConsoleWriter(&out, &errOut)
+	writer.SetSilent(true)
+
+	writer.ShowSummarySection(SummaryData{ModelsProcessed: 1, SuccessfulModels: 1})
+	writer.ErrorMessage("broken")
+
+	if out.Len() != 0 {
+		t.Errorf("silent mode should skip the summary, got %q", out.String())
+	}
+	if !strings.Contains(errOut.String(), "ERROR: broken") {
+		t.Errorf("errors should still reach stderr, got %q", errOut.String())
+	}
+}
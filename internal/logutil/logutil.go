@@ -35,6 +35,9 @@ type ContextKey string
 // CorrelationIDKey is the context key for correlation ID
 const CorrelationIDKey ContextKey = "correlation_id"
 
+// AttemptKey is the context key for the attempt number of a retried request
+const AttemptKey ContextKey = "attempt"
+
 // WithCorrelationID adds a correlation ID to the context.
 // If an ID already exists in the context, it is preserved.
 // If no correlation ID is present, a new UUID is generated.
@@ -91,6 +94,25 @@ func GetCorrelationID(ctx context.Context) string {
 	return id
 }
 
+// WithAttempt records which attempt (1 for the first try) a request is on, so
+// log lines written while making it carry an "attempt" field.
+func WithAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, AttemptKey, attempt)
+}
+
+// GetAttempt retrieves the attempt number from context, or returns 0 if not present
+func GetAttempt(ctx context.Context) int {
+	if ctx == nil {
+		return 0
+	}
+
+	attempt, ok := ctx.Value(AttemptKey).(int)
+	if !ok {
+		return 0
+	}
+	return attempt
+}
+
 // LoggerInterface defines a comprehensive logging interface with context-awareness
 // that can be implemented by different logger backends (e.g., slog, zerolog, etc.)
 type LoggerInterface interface {
@@ -0,0 +1,122 @@
+package logutil
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// ProgressEvent is one line written by --progress=json
+type ProgressEvent struct {
+	Event        string `json:"event"` // model_started, model_completed, model_failed, or model_rate_limited
+	Model        string `json:"model"`
+	Index        int    `json:"index"` // 1-based position in the processing order, 0 if unknown
+	Total        int    `json:"total"`
+	Status       string `json:"status"`
+	DurationMs   int64  `json:"duration_ms"`              // Elapsed time, or the rate limit wait
+	Error        string `json:"error,omitempty"`          // Failure reason for model_failed
+	RetryAfterMs int64  `json:"retry_after_ms,omitempty"` // Wait before retrying for model_rate_limited
+}
+
+// progressEventWriter decorates a ConsoleWriter, writing a JSON line for each model
+// progress change to a separate stream. Events are written even in quiet mode,
+// since quiet only governs the human-readable output.
+type progressEventWriter struct {
+	ConsoleWriter
+
+	mu      sync.Mutex
+	out     io.Writer
+	indexes map[string]int // Model name to 1-based position, from StartStatusTracking
+	total   int
+}
+
+// NewProgressEventWriter wraps console so that model progress is also written to out
+// as one JSON event per line. All other output is left to console.
+func NewProgressEventWriter(console ConsoleWriter, out io.Writer) ConsoleWriter {
+	return &progressEventWriter{ConsoleWriter: console, out: out, indexes: make(map[string]int)}
+}
+
+// StartStatusTracking records each model's position so status updates carry an index
+func (p *progressEventWriter) StartStatusTracking(modelNames []string) {
+	p.mu.Lock()
+	p.total = len(modelNames)
+	p.indexes = make(map[string]int, len(modelNames))
+	for i, name := range modelNames {
+		p.indexes[name] = i + 1
+	}
+	p.mu.Unlock()
+	p.ConsoleWriter.StartStatusTracking(modelNames)
+}
+
+// UpdateModelStatus emits events for models starting, completing, or failing
+func (p *progressEventWriter) UpdateModelStatus(modelName string, status ModelStatus, duration time.Duration, errorMsg string) {
+	p.ConsoleWriter.UpdateModelStatus(modelName, status, duration, errorMsg)
+
+	index, total := p.position(modelName)
+	switch status {
+	case StatusProcessing:
+		p.emit(ProgressEvent{Event: "model_started", Model: modelName, Index: index, Total: total, Status: "started"})
+	case StatusCompleted:
+		p.emit(ProgressEvent{Event: "model_completed", Model: modelName, Index: index, Total: total,
+			Status: "completed", DurationMs: duration.Milliseconds()})
+	case StatusFailed:
+		p.emit(ProgressEvent{Event: "model_failed", Model: modelName, Index: index, Total: total,
+			Status: "failed", DurationMs: duration.Milliseconds(), Error: errorMsg})
+	}
+}
+
+// UpdateModelRateLimited emits a rate limit event
+func (p *progressEventWriter) UpdateModelRateLimited(modelName string, retryAfter time.Duration) {
+	p.ConsoleWriter.UpdateModelRateLimited(modelName, retryAfter)
+
+	index, total := p.position(modelName)
+	p.emit(ProgressEvent{Event: "model_rate_limited", Model: modelName, Index: index, Total: total,
+		Status: "rate_limited", DurationMs: retryAfter.Milliseconds(), RetryAfterMs: retryAfter.Milliseconds()})
+}
+
+// ModelStarted emits a start event
+func (p *progressEventWriter) ModelStarted(modelIndex, totalModels int, modelName string) {
+	p.ConsoleWriter.ModelStarted(modelIndex, totalModels, modelName)
+	p.emit(ProgressEvent{Event: "model_started", Model: modelName, Index: modelIndex, Total: totalModels, Status: "started"})
+}
+
+// ModelCompleted emits a completion event
+func (p *progressEventWriter) ModelCompleted(modelIndex, totalModels int, modelName string, duration time.Duration) {
+	p.ConsoleWriter.ModelCompleted(modelIndex, totalModels, modelName, duration)
+	p.emit(ProgressEvent{Event: "model_completed", Model: modelName, Index: modelIndex, Total: totalModels,
+		Status: "completed", DurationMs: duration.Milliseconds()})
+}
+
+// ModelFailed emits a failure event
+func (p *progressEventWriter) ModelFailed(modelIndex, totalModels int, modelName string, reason string) {
+	p.ConsoleWriter.ModelFailed(modelIndex, totalModels, modelName, reason)
+	p.emit(ProgressEvent{Event: "model_failed", Model: modelName, Index: modelIndex, Total: totalModels,
+		Status: "failed", Error: reason})
+}
+
+// ModelRateLimited emits a rate limit event
+func (p *progressEventWriter) ModelRateLimited(modelIndex, totalModels int, modelName string, retryAfter time.Duration) {
+	p.ConsoleWriter.ModelRateLimited(modelIndex, totalModels, modelName, retryAfter)
+	p.emit(ProgressEvent{Event: "model_rate_limited", Model: modelName, Index: modelIndex, Total: totalModels,
+		Status: "rate_limited", DurationMs: retryAfter.Milliseconds(), RetryAfterMs: retryAfter.Milliseconds()})
+}
+
+// position returns a model's index and the model count recorded by StartStatusTracking
+func (p *progressEventWriter) position(modelName string) (int, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.indexes[modelName], p.total
+}
+
+// emit writes one event as a JSON line. Lines are written whole under the lock so
+// events from concurrently running models never interleave.
+func (p *progressEventWriter) emit(event ProgressEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, _ = p.out.Write(append(data, '\n'))
+}
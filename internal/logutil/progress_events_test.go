@@ -0,0 +1,59 @@
+package logutil
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// recordingConsoleWriter counts the status updates forwarded to the wrapped writer
+type recordingConsoleWriter struct {
+	jsonConsoleWriter
+	updates int
+}
+
+func (r *recordingConsoleWriter) UpdateModelStatus(string, ModelStatus, time.Duration, string) {
+	r.updates++
+}
+
+func TestProgressEventWriter(t *testing.T) {
+	var events bytes.Buffer
+	inner := &recordingConsoleWriter{}
+	writer := NewProgressEventWriter(inner, &events)
+	writer.SetQuiet(true) // Quiet governs only the human-readable output
+
+	writer.StartStatusTracking([]string{"model-a", "model-b"})
+	writer.UpdateModelStatus("model-a", StatusStarting, 0, "") // Queued models emit nothing
+	writer.UpdateModelStatus("model-a", StatusProcessing, 0, "")
+	writer.UpdateModelRateLimited("model-b", 2*time.Second)
+	writer.UpdateModelStatus("model-a", StatusCompleted, 1500*time.Millisecond, "")
+	writer.UpdateModelStatus("model-b", StatusFailed, 300*time.Millisecond, "rate limited")
+	writer.ModelStarted(3, 3, "model-c")
+
+	expected := []ProgressEvent{
+		{Event: "model_started", Model: "model-a", Index: 1, Total: 2, Status: "started"},
+		{Event: "model_rate_limited", Model: "model-b", Index: 2, Total: 2, Status: "rate_limited", DurationMs: 2000, RetryAfterMs: 2000},
+		{Event: "model_completed", Model: "model-a", Index: 1, Total: 2, Status: "completed", DurationMs: 1500},
+		{Event: "model_failed", Model: "model-b", Index: 2, Total: 2, Status: "failed", DurationMs: 300, Error: "rate limited"},
+		{Event: "model_started", Model: "model-c", Index: 3, Total: 3, Status: "started"},
+	}
+
+	var got []ProgressEvent
+	scanner := bufio.NewScanner(&events)
+	for scanner.Scan() {
+		var event ProgressEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("event line is not JSON: %v: %q", err, scanner.Text())
+		}
+		got = append(got, event)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("events =\n%+v\nwant\n%+v", got, expected)
+	}
+	if inner.updates != 4 {
+		t.Errorf("wrapped writer received %d status updates, want 4", inner.updates)
+	}
+}
@@ -191,11 +191,8 @@ func (s *SlogLogger) DebugContext(ctx context.Context, msg string, args ...inter
 		message = fmt.Sprintf(msg, args...)
 	}
 
-	// Add correlation ID if present
-	correlationID := GetCorrelationID(ctx)
-	if correlationID != "" {
-		kvPairs = append(kvPairs, slog.String("correlation_id", correlationID))
-	}
+	// Add correlation ID and attempt number if present
+	kvPairs = appendContextAttrs(ctx, kvPairs)
 
 	// Log with appropriate logger based on stream separation
 	if s.streamSplit {
@@ -229,11 +226,8 @@ func (s *SlogLogger) InfoContext(ctx context.Context, msg string, args ...interf
 		message = fmt.Sprintf(msg, args...)
 	}
 
-	// Add correlation ID if present
-	correlationID := GetCorrelationID(ctx)
-	if correlationID != "" {
-		kvPairs = append(kvPairs, slog.String("correlation_id", correlationID))
-	}
+	// Add correlation ID and attempt number if present
+	kvPairs = appendContextAttrs(ctx, kvPairs)
 
 	// Log with appropriate logger based on stream separation
 	if s.streamSplit {
@@ -267,11 +261,8 @@ func (s *SlogLogger) WarnContext(ctx context.Context, msg string, args ...interf
 		message = fmt.Sprintf(msg, args...)
 	}
 
-	// Add correlation ID if present
-	correlationID := GetCorrelationID(ctx)
-	if correlationID != "" {
-		kvPairs = append(kvPairs, slog.String("correlation_id", correlationID))
-	}
+	// Add correlation ID and attempt number if present
+	kvPairs = appendContextAttrs(ctx, kvPairs)
 
 	// Log with appropriate logger based on stream separation
 	if s.streamSplit {
@@ -305,11 +296,8 @@ func (s *SlogLogger) ErrorContext(ctx context.Context, msg string, args ...inter
 		message = fmt.Sprintf(msg, args...)
 	}
 
-	// Add correlation ID if present
-	correlationID := GetCorrelationID(ctx)
-	if correlationID != "" {
-		kvPairs = append(kvPairs, slog.String("correlation_id", correlationID))
-	}
+	// Add correlation ID and attempt number if present
+	kvPairs = appendContextAttrs(ctx, kvPairs)
 
 	// Log with appropriate logger based on stream separation
 	if s.streamSplit {
@@ -343,11 +331,8 @@ func (s *SlogLogger) FatalContext(ctx context.Context, msg string, args ...inter
 		message = fmt.Sprintf(msg, args...)
 	}
 
-	// Add correlation ID if present
-	correlationID := GetCorrelationID(ctx)
-	if correlationID != "" {
-		kvPairs = append(kvPairs, slog.String("correlation_id", correlationID))
-	}
+	// Add correlation ID and attempt number if present
+	kvPairs = appendContextAttrs(ctx, kvPairs)
 
 	// Log with appropriate logger based on stream separation
 	if s.streamSplit {
@@ -358,6 +343,18 @@ func (s *SlogLogger) FatalContext(ctx context.Context, msg string, args ...inter
 	osExit(1)
 }
 
+// appendContextAttrs adds the correlation ID and attempt number carried by ctx
+// to a log call's key-value pairs
+func appendContextAttrs(ctx context.Context, kvPairs []interface{}) []interface{} {
+	if correlationID := GetCorrelationID(ctx); correlationID != "" {
+		kvPairs = append(kvPairs, slog.String("correlation_id", correlationID))
+	}
+	if attempt := GetAttempt(ctx); attempt > 0 {
+		kvPairs = append(kvPairs, slog.Int("attempt", attempt))
+	}
+	return kvPairs
+}
+
 // isAttr checks if the interface is a slog.Attr or can be used as a structured logging attribute
 func isAttr(arg interface{}) bool {
 	switch arg.(type) {
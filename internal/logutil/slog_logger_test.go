@@ -72,6 +72,31 @@ func TestSlogLogger_WithContext(t *testing.T) {
 	}
 }
 
+func TestSlogLogger_AttemptField(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewSlogLogger(&buf, slog.LevelDebug)
+
+	logger.WarnContext(context.Background(), "first try")
+	ctx := WithAttempt(WithCustomCorrelationID(context.Background(), "run-1"), 2)
+	logger.WarnContext(ctx, "retrying %s", "model-a")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d: %s", len(lines), buf.String())
+	}
+	if strings.Contains(lines[0], `"attempt"`) {
+		t.Errorf("attempt field should be absent without WithAttempt: %s", lines[0])
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatalf("invalid JSON log line: %v", err)
+	}
+	if entry["attempt"] != float64(2) || entry["correlation_id"] != "run-1" || entry["msg"] != "retrying model-a" {
+		t.Errorf("log entry = %v, want attempt 2 with correlation ID run-1", entry)
+	}
+}
+
 func TestSlogLogger_WithContextImplicit(t *testing.T) {
 	var buf bytes.Buffer
 	logger := NewSlogLogger(&buf, slog.LevelDebug)
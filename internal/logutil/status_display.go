@@ -31,6 +31,12 @@ type StatusDisplay struct {
 
 // NewStatusDisplay creates a new status display with environment detection
 func NewStatusDisplay(isInteractive bool) *StatusDisplay {
+	return NewStatusDisplayWithColors(isInteractive, NewColorScheme(isInteractive))
+}
+
+// NewStatusDisplayWithColors creates a status display that renders with the given colors,
+// so it matches the console writer's --color and --theme choices
+func NewStatusDisplayWithColors(isInteractive bool, colors *ColorScheme) *StatusDisplay {
 	width := 80 // default width
 	if isInteractive {
 		if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
@@ -41,7 +47,7 @@ func NewStatusDisplay(isInteractive bool) *StatusDisplay {
 	display := &StatusDisplay{
 		isInteractive: isInteractive,
 		terminalWidth: width,
-		colors:        NewColorScheme(isInteractive),
+		colors:        colors,
 	}
 	if isInteractive {
 		display.spinner = spinner.New()
@@ -0,0 +1,80 @@
+package logutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FormatSummaryMarkdown renders a run summary as a markdown document for --summary-file.
+// It reports what ShowSummarySection prints, plus the per-model details and output
+// files that --output-format json includes.
+func FormatSummaryMarkdown(summary SummaryData) string {
+	var b strings.Builder
+	b.WriteString("# Summary\n\n")
+
+	fmt.Fprintf(&b, "- **Models:** %d processed, %d succeeded, %d failed\n",
+		summary.ModelsProcessed, summary.SuccessfulModels, summary.FailedModels)
+	fmt.Fprintf(&b, "- **Synthesis:** %s\n", summary.SynthesisStatus)
+	if summary.OutputDirectory != "" {
+		fmt.Fprintf(&b, "- **Output:** `%s`\n", summary.OutputDirectory)
+	}
+	if summary.TruncatedFiles > 0 {
+		fmt.Fprintf(&b, "- **Truncated:** %d %s at size limit\n", summary.TruncatedFiles, plural(summary.TruncatedFiles, "file", "files"))
+	}
+	if summary.TokenMismatches > 0 {
+		fmt.Fprintf(&b, "- **Tokens:** %d %s billed differently than estimated\n", summary.TokenMismatches, plural(summary.TokenMismatches, "model", "models"))
+	}
+	if summary.Duration > 0 {
+		fmt.Fprintf(&b, "- **Duration:** %s\n", FormatDuration(summary.Duration))
+	}
+
+	if len(summary.SuccessfulNames) > 0 {
+		names := append([]string(nil), summary.SuccessfulNames...)
+		sort.Strings(names)
+		b.WriteString("\n## Successful models\n\n")
+		for _, name := range names {
+			fmt.Fprintf(&b, "- %s\n", name)
+		}
+	}
+	writeMarkdownReasons(&b, "Failed models", summary.Failures)
+	writeMarkdownReasons(&b, "Skipped models", summary.Skipped)
+
+	if len(summary.ExcludeRules) > 0 {
+		b.WriteString("\n## Exclude rules\n\n| Rule | Kind | Skipped |\n| --- | --- | --- |\n")
+		for _, rule := range summary.ExcludeRules {
+			fmt.Fprintf(&b, "| `%s` | %s | %d |\n", rule.Rule, rule.Kind, rule.Matched)
+		}
+	}
+
+	if len(summary.OutputFiles) > 0 {
+		b.WriteString("\n## Output files\n\n| File | Size |\n| --- | --- |\n")
+		for _, file := range summary.OutputFiles {
+			fmt.Fprintf(&b, "| `%s` | %s |\n", file.Path, FormatFileSize(file.Size))
+		}
+	}
+	return b.String()
+}
+
+// writeMarkdownReasons writes a section listing each model with its reason, if any
+func writeMarkdownReasons(b *strings.Builder, heading string, models []FailedModel) {
+	if len(models) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n\n", heading)
+	for _, model := range models {
+		if model.Reason == "" {
+			fmt.Fprintf(b, "- %s\n", model.Name)
+			continue
+		}
+		fmt.Fprintf(b, "- %s: %s\n", model.Name, model.Reason)
+	}
+}
+
+// plural returns singular when n is 1, otherwise pluralForm
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return singular
+	}
+	return pluralForm
+}
@@ -0,0 +1,82 @@
+package logutil
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatSummaryMarkdown(t *testing.T) {
+	got := FormatSummaryMarkdown(SummaryData{
+		ModelsProcessed:  3,
+		SuccessfulModels: 1,
+		FailedModels:     1,
+		SynthesisStatus:  "failed",
+		OutputDirectory:  "/out/",
+		TruncatedFiles:   1,
+		SuccessfulNames:  []string{"model-a"},
+		Failures:         []FailedModel{{Name: "model-b", Reason: "rate limited"}},
+		Skipped:          []FailedModel{{Name: "model-c", Reason: "input too large"}},
+		OutputFiles:      []OutputFile{{Name: "model-a.md", Path: "/out/model-a.md", Size: 42}},
+		Duration:         2500 * time.Millisecond,
+		ExcludeRules: []ExcludeRuleCount{
+			{Kind: "name", Rule: "vendor", Matched: 3},
+			{Kind: "extension", Rule: ".log"},
+		},
+	})
+
+	expected := `# Summary
+
+- **Models:** 3 processed, 1 succeeded, 1 failed
+- **Synthesis:** failed
+- **Output:** ` + "`/out/`" + `
+- **Truncated:** 1 file at size limit
+- **Duration:** 2.5s
+
+## Successful models
+
+- model-a
+
+## Failed models
+
+- model-b: rate limited
+
+## Skipped models
+
+- model-c: input too large
+
+## Exclude rules
+
+| Rule | Kind | Skipped |
+| --- | --- | --- |
+| ` + "`vendor`" + ` | name | 3 |
+| ` + "`.log`" + ` | extension | 0 |
+
+## Output files
+
+| File | Size |
+| --- | --- |
+| ` + "`/out/model-a.md`" + ` | 42B |
+`
+	if got != expected {
+		t.Errorf("FormatSummaryMarkdown() =\n%s\nwant\n%s", got, expected)
+	}
+}
+
+func TestFormatSummaryMarkdownAllSucceeded(t *testing.T) {
+	got := FormatSummaryMarkdown(SummaryData{
+		ModelsProcessed:  2,
+		SuccessfulModels: 2,
+		SynthesisStatus:  "skipped",
+		SuccessfulNames:  []string{"model-b", "model-a"},
+	})
+
+	for _, absent := range []string{"## Failed models", "## Skipped models", "## Output files", "## Exclude rules", "Truncated", "Tokens"} {
+		if strings.Contains(got, absent) {
+			t.Errorf("summary should not contain %q:\n%s", absent, got)
+		}
+	}
+	if !strings.Contains(got, "- model-a\n- model-b\n") {
+		t.Errorf("successful models should be sorted:\n%s", got)
+	}
+}
@@ -1,5 +1,7 @@
 package logutil
 
+import "time"
+
 // SummaryData contains the data needed to display the execution summary section.
 // It captures the overall results of a thinktank run including model counts,
 // synthesis status, and output location.
@@ -9,6 +11,26 @@ type SummaryData struct {
 	FailedModels     int    // Number of models that failed
 	SynthesisStatus  string // "completed", "failed", or "skipped"
 	OutputDirectory  string // Path to the directory containing outputs
+	TruncatedFiles   int    // Number of output files truncated at the size limit
+	TokenMismatches  int    // Models whose provider-reported tokens differ substantially from our counts
+
+	// Details beyond the console summary, reported in full by --output-format json
+	SuccessfulNames []string      // Models that completed successfully, sorted
+	Failures        []FailedModel // Failed models with their reasons
+	Skipped         []FailedModel // Models never attempted, with the reason they were skipped
+	OutputFiles     []OutputFile  // Files written by the run, including the synthesis file
+	Duration        time.Duration // Total run time
+
+	// ExcludeRules reports how many paths each configured exclude rule skipped
+	ExcludeRules []ExcludeRuleCount
+}
+
+// ExcludeRuleCount reports how many paths a single exclude rule skipped
+// while gathering context. Rules that matched nothing have a zero count.
+type ExcludeRuleCount struct {
+	Kind    string // "extension" or "name"
+	Rule    string // The configured extension or name
+	Matched int    // Number of paths skipped because of this rule
 }
 
 // OutputFile represents a single output file generated by thinktank,
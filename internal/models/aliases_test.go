@@ -0,0 +1,75 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestResolveAlias(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		input     string
+		canonical string
+		ok        bool
+	}{
+		{name: "gemini pro alias", input: "pro", canonical: "gemini-3-pro", ok: true},
+		{name: "gemini flash alias", input: "flash", canonical: "gemini-3-flash", ok: true},
+		{name: "alias to namespaced model", input: "kimi", canonical: "moonshotai/kimi-k2.5", ok: true},
+		{name: "canonical ID is not an alias", input: "gemini-3-pro", ok: false},
+		{name: "unknown name", input: "not-a-model", ok: false},
+		{name: "empty name", input: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canonical, ok := ResolveAlias(tt.input)
+			if ok != tt.ok || canonical != tt.canonical {
+				t.Errorf("ResolveAlias(%q) = (%q, %v), want (%q, %v)", tt.input, canonical, ok, tt.canonical, tt.ok)
+			}
+		})
+	}
+}
+
+func TestAliasesResolveThroughLookups(t *testing.T) {
+	t.Parallel()
+
+	info, err := GetModelInfo("pro")
+	if err != nil {
+		t.Fatalf("GetModelInfo(\"pro\") returned error: %v", err)
+	}
+	canonicalInfo, _ := GetModelInfo("gemini-3-pro")
+	if info.APIModelID != canonicalInfo.APIModelID {
+		t.Errorf("alias resolved to %q, want %q", info.APIModelID, canonicalInfo.APIModelID)
+	}
+
+	provider, err := GetProviderForModel("opus")
+	if err != nil || provider != "openrouter" {
+		t.Errorf("GetProviderForModel(\"opus\") = (%q, %v), want (\"openrouter\", nil)", provider, err)
+	}
+}
+
+func TestAliasesAreConsistent(t *testing.T) {
+	t.Parallel()
+	for alias, canonical := range modelAliases {
+		if _, exists := modelDefinitions[alias]; exists {
+			t.Errorf("alias %q shadows a real model ID", alias)
+		}
+		if _, exists := modelDefinitions[canonical]; !exists {
+			t.Errorf("alias %q points to unknown model %q", alias, canonical)
+		}
+	}
+}
+
+func TestListingsUseCanonicalNames(t *testing.T) {
+	t.Parallel()
+	for _, name := range ListAllModels() {
+		if _, isAlias := modelAliases[name]; isAlias {
+			t.Errorf("ListAllModels returned alias %q", name)
+		}
+	}
+	for _, name := range SelectModelsForInput(1000, []string{"openrouter"}) {
+		if _, isAlias := modelAliases[name]; isAlias {
+			t.Errorf("SelectModelsForInput returned alias %q", name)
+		}
+	}
+}
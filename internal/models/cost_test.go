@@ -0,0 +1,81 @@
+package models
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestEstimateCost(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		modelName     string
+		inputTokens   int
+		outputTokens  int
+		want          float64
+		errorContains string
+	}{
+		{
+			name:         "claude-opus-4.5 input and output",
+			modelName:    "claude-opus-4.5",
+			inputTokens:  10000,
+			outputTokens: 2000,
+			want:         10*0.005 + 2*0.025,
+		},
+		{
+			name:        "input only",
+			modelName:   "gemini-3-flash",
+			inputTokens: 1000,
+			want:        0.0005,
+		},
+		{
+			name:      "zero tokens",
+			modelName: "gpt-5.2",
+			want:      0,
+		},
+		{
+			name:          "unknown model",
+			modelName:     "no-such-model",
+			inputTokens:   1000,
+			errorContains: "unknown model: no-such-model",
+		},
+		{
+			name:          "negative tokens",
+			modelName:     "gpt-5.2",
+			inputTokens:   -1,
+			errorContains: "must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := EstimateCost(tt.modelName, tt.inputTokens, tt.outputTokens)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("EstimateCost() error = %v, want error containing %q", err, tt.errorContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EstimateCost() unexpected error: %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("EstimateCost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModelPricingDefined(t *testing.T) {
+	t.Parallel()
+	for name, info := range modelDefinitions {
+		if info.Provider == "test" {
+			continue
+		}
+		if info.InputPricePer1K <= 0 || info.OutputPricePer1K <= 0 {
+			t.Errorf("model %s is missing pricing (input %v, output %v)", name, info.InputPricePer1K, info.OutputPricePer1K)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+)
+
+// filenameReplacer maps characters that are unsafe in file names on some
+// supported platform to readable stand-ins
+var filenameReplacer = strings.NewReplacer(
+	"/", "-",
+	"\\", "-",
+	":", "-",
+	"*", "-",
+	"?", "-",
+	"\"", "-",
+	"'", "-",
+	"<", "-",
+	">", "-",
+	"|", "-",
+	" ", "_", // Spaces become underscores for readability
+)
+
+// SafeFilename returns modelName in a form that is safe as a single file name on
+// every supported platform, for building per-model output paths. Path separators,
+// as in OpenRouter IDs like deepseek/deepseek-chat-v3-0324, and other unsafe
+// characters become '-', and spaces become '_'.
+//
+// The mapping is lossy, so distinct names can share a result ("a/b" and "a:b");
+// use SafeFilenames when several models write to the same directory.
+func SafeFilename(modelName string) string {
+	// "." and ".." name directories rather than files
+	if modelName != "" && strings.Trim(modelName, ".") == "" {
+		return strings.Repeat("-", len(modelName))
+	}
+	return filenameReplacer.Replace(modelName)
+}
+
+// SafeFilenames returns a SafeFilename for each distinct name in modelNames such
+// that no two names share one. Names keep their plain SafeFilename unless another
+// name maps to the same result; then each of them that SafeFilename changed gets
+// a short hash of the original name appended, e.g. a-b-1f3c9a2e, while a name
+// that needed no changes keeps it. The result does not depend on the order of
+// modelNames.
+func SafeFilenames(modelNames []string) map[string]string {
+	groups := make(map[string][]string)
+	for _, name := range modelNames {
+		filename := SafeFilename(name)
+		if !slices.Contains(groups[filename], name) {
+			groups[filename] = append(groups[filename], name)
+		}
+	}
+
+	filenames := make(map[string]string, len(modelNames))
+	taken := make(map[string]bool, len(groups))
+	for filename := range groups {
+		taken[filename] = true
+	}
+	for filename, names := range groups {
+		for _, name := range names {
+			if len(names) == 1 || name == filename {
+				filenames[name] = filename
+				continue
+			}
+			filenames[name] = disambiguatedFilename(filename, name, taken)
+		}
+	}
+	return filenames
+}
+
+// disambiguatedFilename appends a hash of name to filename, lengthening the hash
+// in the unlikely case the result is already taken, and records the result in taken
+func disambiguatedFilename(filename, name string, taken map[string]bool) string {
+	sum := sha256.Sum256([]byte(name))
+	hash := hex.EncodeToString(sum[:])
+	for n := 8; ; n += 4 {
+		candidate := filename + "-" + hash[:min(n, len(hash))]
+		if !taken[candidate] || n >= len(hash) {
+			taken[candidate] = true
+			return candidate
+		}
+	}
+}
@@ -1,13 +1,13 @@
-package modelproc_test
+package models_test
 
 import (
 	"testing"
 
-	"github.com/misty-step/thinktank/internal/thinktank/modelproc"
+	"github.com/misty-step/thinktank/internal/models"
 )
 
-// TestSanitizeFilename tests the filename sanitization function
-func TestSanitizeFilename(t *testing.T) {
+// TestSafeFilename_Mappings tests the output file name mappings
+func TestSafeFilename_Mappings(t *testing.T) {
 	testCases := []struct {
 		name     string
 		input    string
@@ -102,19 +102,19 @@ func TestSanitizeFilename(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := modelproc.SanitizeFilename(tc.input)
+			result := models.SafeFilename(tc.input)
 			if result != tc.expected {
-				t.Errorf("SanitizeFilename(%q) = %q, expected %q", tc.input, result, tc.expected)
+				t.Errorf("SafeFilename(%q) = %q, expected %q", tc.input, result, tc.expected)
 			}
 		})
 	}
 }
 
-// TestSanitizeFilename_EdgeCases tests edge cases for filename sanitization
-func TestSanitizeFilename_EdgeCases(t *testing.T) {
+// TestSafeFilename_EdgeCases tests edge cases for filename sanitization
+func TestSafeFilename_EdgeCases(t *testing.T) {
 	// Test very long filename
 	longInput := "this-is-a-very-long-model-name-that-might-cause-issues-with-filesystem-limits-but-should-still-be-handled-correctly"
-	result := modelproc.SanitizeFilename(longInput)
+	result := models.SafeFilename(longInput)
 	if result != longInput {
 		t.Errorf("Long filename should remain unchanged if no special characters: got %q", result)
 	}
@@ -122,14 +122,14 @@ func TestSanitizeFilename_EdgeCases(t *testing.T) {
 	// Test mixed case preservation
 	mixedCase := "GPT-4/Turbo"
 	expected := "GPT-4-Turbo"
-	result = modelproc.SanitizeFilename(mixedCase)
+	result = models.SafeFilename(mixedCase)
 	if result != expected {
-		t.Errorf("SanitizeFilename(%q) = %q, expected %q", mixedCase, result, expected)
+		t.Errorf("SafeFilename(%q) = %q, expected %q", mixedCase, result, expected)
 	}
 
 	// Test numbers and dots
 	numberDots := "gpt-3.5.turbo"
-	result = modelproc.SanitizeFilename(numberDots)
+	result = models.SafeFilename(numberDots)
 	if result != numberDots {
 		t.Errorf("Numbers and dots should remain unchanged: got %q", result)
 	}
@@ -1,4 +1,4 @@
-package modelproc_test
+package models_test
 
 import (
 	"strings"
@@ -8,25 +8,25 @@ import (
 	"github.com/leanovate/gopter/gen"
 	"github.com/leanovate/gopter/prop"
 
+	"github.com/misty-step/thinktank/internal/models"
 	"github.com/misty-step/thinktank/internal/testutil"
-	"github.com/misty-step/thinktank/internal/thinktank/modelproc"
 )
 
-// TestSanitizeFilename_Properties verifies invariants of the SanitizeFilename function
+// TestSafeFilename_Properties verifies invariants of the SafeFilename function
 // using property-based testing with the Gopter library.
-func TestSanitizeFilename_Properties(t *testing.T) {
+func TestSafeFilename_Properties(t *testing.T) {
 	properties := gopter.NewProperties(nil)
 
-	properties.Property("SanitizeFilename preserves safety invariants", prop.ForAll(
+	properties.Property("SafeFilename preserves safety invariants", prop.ForAll(
 		func(input string) bool {
 			// Call the function under test
-			result := modelproc.SanitizeFilename(input)
+			result := models.SafeFilename(input)
 
 			// Property 1: Output never contains dangerous characters
 			dangerousChars := []string{"/", "\\", ":", "*", "?", "\"", "'", "<", ">", "|"}
 			for _, char := range dangerousChars {
 				if strings.Contains(result, char) {
-					t.Errorf("SanitizeFilename(%q) = %q contains dangerous character %q", input, result, char)
+					t.Errorf("SafeFilename(%q) = %q contains dangerous character %q", input, result, char)
 					return false
 				}
 			}
@@ -34,24 +34,25 @@ func TestSanitizeFilename_Properties(t *testing.T) {
 			// Property 2: Length relationship - output should not be longer than input
 			// (characters are replaced, not added)
 			if len(result) > len(input) {
-				t.Errorf("SanitizeFilename(%q) = %q output longer than input (len=%d > %d)",
+				t.Errorf("SafeFilename(%q) = %q output longer than input (len=%d > %d)",
 					input, result, len(result), len(input))
 				return false
 			}
 
 			// Property 3: Idempotency - sanitizing a sanitized filename should not change it
-			resanitized := modelproc.SanitizeFilename(result)
+			resanitized := models.SafeFilename(result)
 			if resanitized != result {
-				t.Errorf("SanitizeFilename not idempotent: SanitizeFilename(%q) = %q, but SanitizeFilename(%q) = %q",
+				t.Errorf("SafeFilename not idempotent: SafeFilename(%q) = %q, but SafeFilename(%q) = %q",
 					input, result, result, resanitized)
 				return false
 			}
 
-			// Property 4: Safe characters should remain unchanged
+			// Property 4: Safe characters should remain unchanged within a name
+			// (a name made only of dots is not, since "." and ".." are directories)
 			safeChars := "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789.-"
 			for _, char := range safeChars {
-				charStr := string(char)
-				sanitized := modelproc.SanitizeFilename(charStr)
+				charStr := "v" + string(char)
+				sanitized := models.SafeFilename(charStr)
 				if sanitized != charStr {
 					t.Errorf("Safe character %q was changed to %q", charStr, sanitized)
 					return false
@@ -66,29 +67,29 @@ func TestSanitizeFilename_Properties(t *testing.T) {
 	properties.TestingRun(t)
 }
 
-// TestSanitizeFilename_SpecificMappings tests that specific character mappings
+// TestSafeFilename_SpecificMappings tests that specific character mappings
 // are consistent using property-based testing.
-func TestSanitizeFilename_SpecificMappings(t *testing.T) {
+func TestSafeFilename_SpecificMappings(t *testing.T) {
 	properties := gopter.NewProperties(nil)
 
-	properties.Property("SanitizeFilename character mappings", prop.ForAll(
+	properties.Property("SafeFilename character mappings", prop.ForAll(
 		func(prefix, suffix string) bool {
 			// Test space mapping
 			inputWithSpace := prefix + " " + suffix
-			result := modelproc.SanitizeFilename(inputWithSpace)
+			result := models.SafeFilename(inputWithSpace)
 			expected := prefix + "_" + suffix
 			if result != expected {
-				t.Errorf("Space mapping failed: SanitizeFilename(%q) = %q, expected %q",
+				t.Errorf("Space mapping failed: SafeFilename(%q) = %q, expected %q",
 					inputWithSpace, result, expected)
 				return false
 			}
 
 			// Test slash mapping
 			inputWithSlash := prefix + "/" + suffix
-			result = modelproc.SanitizeFilename(inputWithSlash)
+			result = models.SafeFilename(inputWithSlash)
 			expected = prefix + "-" + suffix
 			if result != expected {
-				t.Errorf("Slash mapping failed: SanitizeFilename(%q) = %q, expected %q",
+				t.Errorf("Slash mapping failed: SafeFilename(%q) = %q, expected %q",
 					inputWithSlash, result, expected)
 				return false
 			}
@@ -102,15 +103,15 @@ func TestSanitizeFilename_SpecificMappings(t *testing.T) {
 	properties.TestingRun(t)
 }
 
-// TestSanitizeFilename_ModelNameProperties tests properties specific to model names
+// TestSafeFilename_ModelNameProperties tests properties specific to model names
 // using our custom generators.
-func TestSanitizeFilename_ModelNameProperties(t *testing.T) {
+func TestSafeFilename_ModelNameProperties(t *testing.T) {
 	properties := gopter.NewProperties(nil)
 
-	properties.Property("SanitizeFilename model name properties", prop.ForAll(
+	properties.Property("SafeFilename model name properties", prop.ForAll(
 		func(modelName string) bool {
 			// Sanitize the model name
-			sanitized := modelproc.SanitizeFilename(modelName)
+			sanitized := models.SafeFilename(modelName)
 
 			// Property: Sanitized model name should be non-empty for non-empty input
 			if modelName != "" && sanitized == "" {
@@ -156,11 +157,11 @@ func TestSanitizeFilename_ModelNameProperties(t *testing.T) {
 	properties.TestingRun(t)
 }
 
-// TestSanitizeFilename_EmptyAndSpecialCases tests edge cases using property-based testing.
-func TestSanitizeFilename_EmptyAndSpecialCases(t *testing.T) {
+// TestSafeFilename_EmptyAndSpecialCases tests edge cases using property-based testing.
+func TestSafeFilename_EmptyAndSpecialCases(t *testing.T) {
 	properties := gopter.NewProperties(nil)
 
-	properties.Property("SanitizeFilename special character handling", prop.ForAll(
+	properties.Property("SafeFilename special character handling", prop.ForAll(
 		func(numChars int) bool {
 			// Test with strings containing only special characters
 			specialChars := []string{"/", "\\", ":", "*", "?", "\"", "'", "<", ">", "|"}
@@ -173,7 +174,7 @@ func TestSanitizeFilename_EmptyAndSpecialCases(t *testing.T) {
 			}
 
 			inputStr := input.String()
-			result := modelproc.SanitizeFilename(inputStr)
+			result := models.SafeFilename(inputStr)
 
 			// Property: Result should contain only replacement characters
 			for _, char := range result {
@@ -198,18 +199,18 @@ func TestSanitizeFilename_EmptyAndSpecialCases(t *testing.T) {
 	properties.TestingRun(t)
 }
 
-// TestSanitizeFilename_UnicodeHandling tests how the function handles Unicode characters.
-func TestSanitizeFilename_UnicodeHandling(t *testing.T) {
+// TestSafeFilename_UnicodeHandling tests how the function handles Unicode characters.
+func TestSafeFilename_UnicodeHandling(t *testing.T) {
 	properties := gopter.NewProperties(nil)
 
-	properties.Property("SanitizeFilename Unicode handling", prop.ForAll(
+	properties.Property("SafeFilename Unicode handling", prop.ForAll(
 		func(unicode, ascii string) bool {
 			// Combine them in various ways
 			combined := unicode + ascii
-			result := modelproc.SanitizeFilename(combined)
+			result := models.SafeFilename(combined)
 
 			// Property: Unicode letters and numbers should be preserved
-			// (SanitizeFilename only replaces specific problematic characters)
+			// (SafeFilename only replaces specific problematic characters)
 			expectedLength := len(combined)
 			if len(result) != expectedLength {
 				// Only expect length change if input contained characters that get replaced
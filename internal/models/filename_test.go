@@ -0,0 +1,91 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSafeFilename(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		modelName string
+		want      string
+	}{
+		{"gpt-5.2", "gpt-5.2"},
+		{"deepseek/deepseek-chat-v3-0324", "deepseek-deepseek-chat-v3-0324"},
+		{"model:free", "model-free"},
+		{`a\b`, "a-b"},
+		{"gemini pro", "gemini_pro"},
+		{`model?*<>|"'`, "model-------"},
+		{"..", "--"},
+		{".", "-"},
+		{"v1..2", "v1..2"},
+		{"qwen_2", "qwen_2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.modelName, func(t *testing.T) {
+			t.Parallel()
+			if got := SafeFilename(tt.modelName); got != tt.want {
+				t.Errorf("SafeFilename(%q) = %q, want %q", tt.modelName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSafeFilenames(t *testing.T) {
+	t.Parallel()
+
+	t.Run("names that don't collide keep their plain form", func(t *testing.T) {
+		t.Parallel()
+		got := SafeFilenames([]string{"openai/gpt-5.2", "gemini-3-flash", "gemini-3-flash"})
+		if len(got) != 2 || got["openai/gpt-5.2"] != "openai-gpt-5.2" || got["gemini-3-flash"] != "gemini-3-flash" {
+			t.Errorf("SafeFilenames() = %v", got)
+		}
+	})
+
+	t.Run("colliding names are disambiguated", func(t *testing.T) {
+		t.Parallel()
+		names := []string{"a/b", "a-b", "a:b", "a b", "a_b"}
+		got := SafeFilenames(names)
+
+		if got["a-b"] != "a-b" || got["a_b"] != "a_b" {
+			t.Errorf("names SafeFilename leaves alone should keep their name, got %v", got)
+		}
+		seen := make(map[string]string)
+		for _, name := range names {
+			filename := got[name]
+			if other, ok := seen[filename]; ok {
+				t.Errorf("%q and %q both map to %q", other, name, filename)
+			}
+			seen[filename] = name
+			if !strings.HasPrefix(filename, SafeFilename(name)) || strings.ContainsAny(filename, `/\:`) {
+				t.Errorf("SafeFilenames()[%q] = %q, want a safe name starting with %q", name, filename, SafeFilename(name))
+			}
+		}
+
+		// The result does not depend on order
+		reversed := SafeFilenames([]string{"a_b", "a b", "a:b", "a-b", "a/b"})
+		for _, name := range names {
+			if reversed[name] != got[name] {
+				t.Errorf("SafeFilenames()[%q] = %q in reverse order, want %q", name, reversed[name], got[name])
+			}
+		}
+	})
+
+	t.Run("registered models never collide", func(t *testing.T) {
+		t.Parallel()
+		var names []string
+		for _, info := range ListModels() {
+			names = append(names, info.Name, info.APIModelID)
+		}
+		seen := make(map[string]string)
+		for name, filename := range SafeFilenames(names) {
+			if other, ok := seen[filename]; ok {
+				t.Errorf("%q and %q both map to %q", other, name, filename)
+			}
+			seen[filename] = name
+		}
+	})
+}
@@ -2,8 +2,10 @@ package models
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"sort"
+	"strings"
 )
 
 // ParameterConstraint defines validation rules for a single model parameter
@@ -24,6 +26,10 @@ type ParameterConstraint struct {
 // ModelInfo contains metadata for a single LLM model.
 // This struct replaces the complex registry system with simple hardcoded definitions.
 type ModelInfo struct {
+	// Name is the canonical model ID. It is filled in by GetModelInfo and ListModels
+	// rather than repeated in each definition.
+	Name string `json:"name,omitempty"`
+
 	// Provider identifies the LLM service provider (openai, gemini, openrouter)
 	Provider string `json:"provider"`
 
@@ -50,9 +56,24 @@ type ModelInfo struct {
 	// If nil, uses provider-specific default rate limits. If set, enforces per-model rate limit.
 	RateLimitRPM *int `json:"rate_limit_rpm,omitempty"`
 
+	// InputPricePer1K and OutputPricePer1K are the USD prices per 1,000 prompt and
+	// completion tokens, taken from OpenRouter's published pricing (zero for test models)
+	InputPricePer1K  float64 `json:"input_price_per_1k"`
+	OutputPricePer1K float64 `json:"output_price_per_1k"`
+
+	// LatencyTier is a rough hint of how quickly the model responds: 1 for fast models,
+	// 2 for typical ones, 3 for slow reasoning models, and 0 when unknown
+	LatencyTier int `json:"latency_tier,omitempty"`
+
 	// RequiresBYOK indicates if this model requires users to bring their own API key
 	// When true, users must provide their provider-specific API key (e.g., OpenAI key for o3)
 	RequiresBYOK bool `json:"requires_byok,omitempty"`
+
+	// BaseURL and APIKeyEnvVar locate a CustomProvider model's OpenAI-compatible API
+	// and name the environment variable holding its key. Both are set by RegisterModel
+	// and empty for built-in models, which take them from their provider.
+	BaseURL      string `json:"base_url,omitempty"`
+	APIKeyEnvVar string `json:"api_key_env_var,omitempty"`
 }
 
 // Helper functions for creating parameter constraints
@@ -87,10 +108,13 @@ var modelDefinitions = map[string]ModelInfo{
 	// Claude Opus 4.5 - Frontier reasoning model, best for complex software engineering
 	// https://openrouter.ai/anthropic/claude-opus-4.5
 	"claude-opus-4.5": {
-		Provider:        "openrouter",
-		APIModelID:      "anthropic/claude-opus-4.5",
-		ContextWindow:   200000,
-		MaxOutputTokens: 64000,
+		Provider:         "openrouter",
+		APIModelID:       "anthropic/claude-opus-4.5",
+		ContextWindow:    200000,
+		MaxOutputTokens:  64000,
+		InputPricePer1K:  0.005,
+		OutputPricePer1K: 0.025,
+		LatencyTier:      3,
 		DefaultParams: map[string]interface{}{
 			"temperature": 0.7,
 			"top_p":       0.95,
@@ -105,10 +129,13 @@ var modelDefinitions = map[string]ModelInfo{
 	// Claude Sonnet 4.5 - Best agentic coding model, #1 SWE-bench (77.2%)
 	// https://openrouter.ai/anthropic/claude-sonnet-4.5
 	"claude-sonnet-4.5": {
-		Provider:        "openrouter",
-		APIModelID:      "anthropic/claude-sonnet-4.5",
-		ContextWindow:   1000000,
-		MaxOutputTokens: 64000,
+		Provider:         "openrouter",
+		APIModelID:       "anthropic/claude-sonnet-4.5",
+		ContextWindow:    1000000,
+		MaxOutputTokens:  64000,
+		InputPricePer1K:  0.003,
+		OutputPricePer1K: 0.015,
+		LatencyTier:      2,
 		DefaultParams: map[string]interface{}{
 			"temperature": 0.7,
 			"top_p":       0.95,
@@ -127,10 +154,13 @@ var modelDefinitions = map[string]ModelInfo{
 	// GPT-5.2 - Latest flagship, 100% AIME 2025, lowest control flow errors
 	// https://openrouter.ai/openai/gpt-5.2
 	"gpt-5.2": {
-		Provider:        "openrouter",
-		APIModelID:      "openai/gpt-5.2",
-		ContextWindow:   400000,
-		MaxOutputTokens: 128000,
+		Provider:         "openrouter",
+		APIModelID:       "openai/gpt-5.2",
+		ContextWindow:    400000,
+		MaxOutputTokens:  128000,
+		InputPricePer1K:  0.00175,
+		OutputPricePer1K: 0.014,
+		LatencyTier:      3,
 		DefaultParams: map[string]interface{}{
 			"temperature":       0.7,
 			"top_p":             1.0,
@@ -149,10 +179,13 @@ var modelDefinitions = map[string]ModelInfo{
 	// GPT-5.2 Codex - Coding-optimized GPT-5.2 variant
 	// https://openrouter.ai/openai/gpt-5.2-codex
 	"gpt-5.2-codex": {
-		Provider:        "openrouter",
-		APIModelID:      "openai/gpt-5.2-codex",
-		ContextWindow:   400000,
-		MaxOutputTokens: 128000,
+		Provider:         "openrouter",
+		APIModelID:       "openai/gpt-5.2-codex",
+		ContextWindow:    400000,
+		MaxOutputTokens:  128000,
+		InputPricePer1K:  0.00175,
+		OutputPricePer1K: 0.014,
+		LatencyTier:      2,
 		DefaultParams: map[string]interface{}{
 			"temperature":       0.7,
 			"top_p":             1.0,
@@ -171,10 +204,13 @@ var modelDefinitions = map[string]ModelInfo{
 	// OpenAI GPT-5.2 Codex - Full OpenRouter slug for GPT-5.2 Codex
 	// https://openrouter.ai/openai/gpt-5.2-codex
 	"openai/gpt-5.2-codex": {
-		Provider:        "openrouter",
-		APIModelID:      "openai/gpt-5.2-codex",
-		ContextWindow:   400000,
-		MaxOutputTokens: 128000,
+		Provider:         "openrouter",
+		APIModelID:       "openai/gpt-5.2-codex",
+		ContextWindow:    400000,
+		MaxOutputTokens:  128000,
+		InputPricePer1K:  0.00175,
+		OutputPricePer1K: 0.014,
+		LatencyTier:      2,
 		DefaultParams: map[string]interface{}{
 			"temperature":       0.7,
 			"top_p":             1.0,
@@ -197,10 +233,13 @@ var modelDefinitions = map[string]ModelInfo{
 	// Gemini 3 Flash - Fast, cheap, 78% SWE-bench, 1M context (DEFAULT)
 	// https://openrouter.ai/google/gemini-3-flash-preview
 	"gemini-3-flash": {
-		Provider:        "openrouter",
-		APIModelID:      "google/gemini-3-flash-preview",
-		ContextWindow:   1048576,
-		MaxOutputTokens: 65535,
+		Provider:         "openrouter",
+		APIModelID:       "google/gemini-3-flash-preview",
+		ContextWindow:    1048576,
+		MaxOutputTokens:  65535,
+		InputPricePer1K:  0.0005,
+		OutputPricePer1K: 0.003,
+		LatencyTier:      1,
 		DefaultParams: map[string]interface{}{
 			"temperature": 0.7,
 			"top_p":       0.95,
@@ -217,10 +256,13 @@ var modelDefinitions = map[string]ModelInfo{
 	// Gemini 3 Pro - Strong reasoning, 1M multimodal context
 	// https://openrouter.ai/google/gemini-3-pro-preview
 	"gemini-3-pro": {
-		Provider:        "openrouter",
-		APIModelID:      "google/gemini-3-pro-preview",
-		ContextWindow:   1048576,
-		MaxOutputTokens: 65536,
+		Provider:         "openrouter",
+		APIModelID:       "google/gemini-3-pro-preview",
+		ContextWindow:    1048576,
+		MaxOutputTokens:  65536,
+		InputPricePer1K:  0.002,
+		OutputPricePer1K: 0.012,
+		LatencyTier:      3,
 		DefaultParams: map[string]interface{}{
 			"temperature": 0.7,
 			"top_p":       0.95,
@@ -241,10 +283,13 @@ var modelDefinitions = map[string]ModelInfo{
 	// Grok 4.1 Fast - #1 LMArena Elo, 2M context, 65% less hallucination
 	// https://openrouter.ai/x-ai/grok-4.1-fast
 	"grok-4.1-fast": {
-		Provider:        "openrouter",
-		APIModelID:      "x-ai/grok-4.1-fast",
-		ContextWindow:   2000000,
-		MaxOutputTokens: 30000,
+		Provider:         "openrouter",
+		APIModelID:       "x-ai/grok-4.1-fast",
+		ContextWindow:    2000000,
+		MaxOutputTokens:  30000,
+		InputPricePer1K:  0.0002,
+		OutputPricePer1K: 0.0005,
+		LatencyTier:      1,
 		DefaultParams: map[string]interface{}{
 			"temperature": 0.7,
 			"top_p":       0.95,
@@ -259,10 +304,13 @@ var modelDefinitions = map[string]ModelInfo{
 	// Grok Code Fast 1 - Specialized for coding, 190 tokens/sec
 	// https://openrouter.ai/x-ai/grok-code-fast-1
 	"grok-code-fast-1": {
-		Provider:        "openrouter",
-		APIModelID:      "x-ai/grok-code-fast-1",
-		ContextWindow:   256000,
-		MaxOutputTokens: 10000,
+		Provider:         "openrouter",
+		APIModelID:       "x-ai/grok-code-fast-1",
+		ContextWindow:    256000,
+		MaxOutputTokens:  10000,
+		InputPricePer1K:  0.0002,
+		OutputPricePer1K: 0.0015,
+		LatencyTier:      1,
 		DefaultParams: map[string]interface{}{
 			"temperature": 0.7,
 			"top_p":       0.95,
@@ -281,10 +329,13 @@ var modelDefinitions = map[string]ModelInfo{
 	// DeepSeek V3.2 - Best value reasoning model
 	// https://openrouter.ai/deepseek/deepseek-v3.2
 	"deepseek-v3.2": {
-		Provider:        "openrouter",
-		APIModelID:      "deepseek/deepseek-v3.2",
-		ContextWindow:   163840,
-		MaxOutputTokens: 65536,
+		Provider:         "openrouter",
+		APIModelID:       "deepseek/deepseek-v3.2",
+		ContextWindow:    163840,
+		MaxOutputTokens:  65536,
+		InputPricePer1K:  0.00025,
+		OutputPricePer1K: 0.00038,
+		LatencyTier:      2,
 		DefaultParams: map[string]interface{}{
 			"temperature": 0.7,
 			"top_p":       0.95,
@@ -301,10 +352,13 @@ var modelDefinitions = map[string]ModelInfo{
 	// DeepSeek V3.2 Speciale - Enhanced reasoning variant with mandatory thinking
 	// https://openrouter.ai/deepseek/deepseek-v3.2-speciale
 	"deepseek-v3.2-speciale": {
-		Provider:        "openrouter",
-		APIModelID:      "deepseek/deepseek-v3.2-speciale",
-		ContextWindow:   163840,
-		MaxOutputTokens: 65536,
+		Provider:         "openrouter",
+		APIModelID:       "deepseek/deepseek-v3.2-speciale",
+		ContextWindow:    163840,
+		MaxOutputTokens:  65536,
+		InputPricePer1K:  0.00027,
+		OutputPricePer1K: 0.00041,
+		LatencyTier:      3,
 		DefaultParams: map[string]interface{}{
 			"temperature": 0.7,
 			"top_p":       0.95,
@@ -325,10 +379,13 @@ var modelDefinitions = map[string]ModelInfo{
 	// Kimi K2.5 - 1T params, 99.1% AIME, #2 overall (after GPT-5)
 	// https://openrouter.ai/moonshotai/kimi-k2.5
 	"moonshotai/kimi-k2.5": {
-		Provider:        "openrouter",
-		APIModelID:      "moonshotai/kimi-k2.5",
-		ContextWindow:   262144,
-		MaxOutputTokens: 65535,
+		Provider:         "openrouter",
+		APIModelID:       "moonshotai/kimi-k2.5",
+		ContextWindow:    262144,
+		MaxOutputTokens:  65535,
+		InputPricePer1K:  0.0006,
+		OutputPricePer1K: 0.003,
+		LatencyTier:      2,
 		DefaultParams: map[string]interface{}{
 			"temperature": 0.7,
 			"top_p":       0.95,
@@ -347,10 +404,13 @@ var modelDefinitions = map[string]ModelInfo{
 	// MiniMax M2.1 - 10B active params, 72.5% SWE-bench multilingual, 8% cost of Claude
 	// https://openrouter.ai/minimax/minimax-m2.1
 	"minimax-m2.1": {
-		Provider:        "openrouter",
-		APIModelID:      "minimax/minimax-m2.1",
-		ContextWindow:   196608,
-		MaxOutputTokens: 131072,
+		Provider:         "openrouter",
+		APIModelID:       "minimax/minimax-m2.1",
+		ContextWindow:    196608,
+		MaxOutputTokens:  131072,
+		InputPricePer1K:  0.0003,
+		OutputPricePer1K: 0.0012,
+		LatencyTier:      2,
 		DefaultParams: map[string]interface{}{
 			"temperature": 0.7,
 			"top_p":       0.95,
@@ -369,10 +429,13 @@ var modelDefinitions = map[string]ModelInfo{
 	// GLM-4.7 - 355B/32B active, 95.7% AIME, 84.9% LiveCodeBench, MIT license
 	// https://openrouter.ai/z-ai/glm-4.7
 	"glm-4.7": {
-		Provider:        "openrouter",
-		APIModelID:      "z-ai/glm-4.7",
-		ContextWindow:   202752,
-		MaxOutputTokens: 65535,
+		Provider:         "openrouter",
+		APIModelID:       "z-ai/glm-4.7",
+		ContextWindow:    202752,
+		MaxOutputTokens:  65535,
+		InputPricePer1K:  0.0004,
+		OutputPricePer1K: 0.0015,
+		LatencyTier:      2,
 		DefaultParams: map[string]interface{}{
 			"temperature": 0.7,
 			"top_p":       0.95,
@@ -391,10 +454,13 @@ var modelDefinitions = map[string]ModelInfo{
 	// Qwen3 Coder - Qwen's coding-focused model
 	// https://openrouter.ai/qwen/qwen3-coder
 	"qwen/qwen3-coder": {
-		Provider:        "openrouter",
-		APIModelID:      "qwen/qwen3-coder",
-		ContextWindow:   262144,
-		MaxOutputTokens: 65536,
+		Provider:         "openrouter",
+		APIModelID:       "qwen/qwen3-coder",
+		ContextWindow:    262144,
+		MaxOutputTokens:  65536,
+		InputPricePer1K:  0.00022,
+		OutputPricePer1K: 0.00095,
+		LatencyTier:      1,
 		DefaultParams: map[string]interface{}{
 			"temperature": 0.7,
 			"top_p":       0.95,
@@ -413,10 +479,13 @@ var modelDefinitions = map[string]ModelInfo{
 	// Devstral 2 - 123B dense transformer, specialized for agentic coding
 	// https://openrouter.ai/mistralai/devstral-2512
 	"devstral-2": {
-		Provider:        "openrouter",
-		APIModelID:      "mistralai/devstral-2512",
-		ContextWindow:   262144,
-		MaxOutputTokens: 65536,
+		Provider:         "openrouter",
+		APIModelID:       "mistralai/devstral-2512",
+		ContextWindow:    262144,
+		MaxOutputTokens:  65536,
+		InputPricePer1K:  0.0004,
+		OutputPricePer1K: 0.002,
+		LatencyTier:      2,
 		DefaultParams: map[string]interface{}{
 			"temperature": 0.7,
 			"top_p":       0.95,
@@ -436,10 +505,13 @@ var modelDefinitions = map[string]ModelInfo{
 
 	// Llama 4 Maverick - 1M context open-source
 	"llama-4-maverick": {
-		Provider:        "openrouter",
-		APIModelID:      "meta-llama/llama-4-maverick",
-		ContextWindow:   1048576,
-		MaxOutputTokens: 100000,
+		Provider:         "openrouter",
+		APIModelID:       "meta-llama/llama-4-maverick",
+		ContextWindow:    1048576,
+		MaxOutputTokens:  100000,
+		InputPricePer1K:  0.00015,
+		OutputPricePer1K: 0.0006,
+		LatencyTier:      1,
 		DefaultParams: map[string]interface{}{
 			"temperature": 0.7,
 			"top_p":       0.9,
@@ -524,16 +596,79 @@ var modelDefinitions = map[string]ModelInfo{
 	},
 }
 
-// GetModelInfo returns model metadata for the given model name.
+// modelAliases maps short, stable names to canonical model IDs so users don't
+// have to track version suffixes. Aliases never shadow a real model ID:
+// lookups always try the canonical name first.
+var modelAliases = map[string]string{
+	"opus":     "claude-opus-4.5",
+	"sonnet":   "claude-sonnet-4.5",
+	"gpt":      "gpt-5.2",
+	"codex":    "gpt-5.2-codex",
+	"flash":    "gemini-3-flash",
+	"pro":      "gemini-3-pro",
+	"grok":     "grok-4.1-fast",
+	"deepseek": "deepseek-v3.2",
+	"kimi":     "moonshotai/kimi-k2.5",
+	"minimax":  "minimax-m2.1",
+	"glm":      "glm-4.7",
+	"qwen":     "qwen/qwen3-coder",
+	"devstral": "devstral-2",
+	"llama":    "llama-4-maverick",
+}
+
+// ResolveAlias returns the canonical model ID for an alias.
+// Returns false if name is not an alias, including when it is already a canonical ID.
+func ResolveAlias(name string) (string, bool) {
+	if _, exists := modelDefinitions[name]; exists {
+		return "", false
+	}
+	canonical, ok := modelAliases[name]
+	return canonical, ok
+}
+
+// GetModelInfo returns model metadata for the given model name or alias.
 // Returns an error if the model is not supported.
 func GetModelInfo(name string) (ModelInfo, error) {
+	if canonical, ok := ResolveAlias(name); ok {
+		name = canonical
+	}
 	if info, exists := modelDefinitions[name]; exists {
+		info.Name = name
 		return info, nil
 	}
 	return ModelInfo{}, fmt.Errorf("unknown model: %s", name)
 }
 
-// GetProviderForModel returns the provider name for the given model.
+// ListModels returns metadata for every supported model, sorted by provider then name.
+func ListModels() []ModelInfo {
+	infos := make([]ModelInfo, 0, len(modelDefinitions))
+	for name, info := range modelDefinitions {
+		info.Name = name
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Provider != infos[j].Provider {
+			return infos[i].Provider < infos[j].Provider
+		}
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+// EstimateCost returns the estimated USD cost of a request to the given model
+// using its hardcoded per-1K-token pricing. Returns an error if the model is not supported.
+func EstimateCost(modelName string, inputTokens, outputTokens int) (float64, error) {
+	info, err := GetModelInfo(modelName)
+	if err != nil {
+		return 0, fmt.Errorf("cannot estimate cost: %w", err)
+	}
+	if inputTokens < 0 || outputTokens < 0 {
+		return 0, fmt.Errorf("cannot estimate cost: token counts must not be negative")
+	}
+	return float64(inputTokens)/1000*info.InputPricePer1K + float64(outputTokens)/1000*info.OutputPricePer1K, nil
+}
+
+// GetProviderForModel returns the provider name for the given model or alias.
 // Returns an error if the model is not supported.
 func GetProviderForModel(name string) (string, error) {
 	info, err := GetModelInfo(name)
@@ -601,6 +736,7 @@ func GetCoreCouncilModels() []string {
 // EstimateTokensFromText provides a rough estimation of token count from text.
 // Uses a conservative approximation where 1 token ≈ 1.33 characters.
 // This estimation works reasonably well across different tokenizers.
+// Use CountTokensEstimate when the target model is known.
 func EstimateTokensFromText(text string) int {
 	if text == "" {
 		return 0
@@ -629,6 +765,42 @@ func EstimateTokensFromStats(charCount int, instructionsText string) int {
 	return contentTokens + instructionTokens + formatOverhead
 }
 
+// charsPerToken approximates how many characters one token covers for each model
+// family, keyed by the vendor prefix of the API model ID. Code tokenizes more densely
+// than prose, so the ratios lean low to avoid underestimating.
+var charsPerToken = map[string]float64{
+	"openai":    4.0, // o200k BPE
+	"google":    3.5, // Gemini SentencePiece
+	"anthropic": 3.5,
+}
+
+// defaultCharsPerToken is used for model families without a known ratio
+const defaultCharsPerToken = 3.0
+
+// CountTokensEstimate estimates how many tokens text uses with the given model,
+// using a characters-per-token ratio for the model's family. Unlike
+// EstimateTokensFromText it adds no overhead. Returns an error for unknown models.
+func CountTokensEstimate(model, text string) (int, error) {
+	info, err := GetModelInfo(model)
+	if err != nil {
+		return 0, err
+	}
+	ratio, ok := charsPerToken[modelFamily(info)]
+	if !ok {
+		ratio = defaultCharsPerToken
+	}
+	return int(math.Ceil(float64(len(text)) / ratio)), nil
+}
+
+// modelFamily returns the vendor of a model: the API model ID prefix for
+// OpenRouter-style IDs ("openai/gpt-5.2"), otherwise the provider
+func modelFamily(info ModelInfo) string {
+	if vendor, _, found := strings.Cut(info.APIModelID, "/"); found {
+		return vendor
+	}
+	return info.Provider
+}
+
 // GetModelsWithMinContextWindow returns models that have at least the specified context window.
 // Results are sorted by context window size in descending order (largest first).
 func GetModelsWithMinContextWindow(minTokens int) []string {
@@ -764,9 +936,9 @@ func GetAPIKeyEnvVar(provider string) string {
 	}
 }
 
-// GetProviderDefaultRateLimit returns the default rate limit (requests per minute) for a given provider.
+// GetProviderRateLimit returns the default rate limit (requests per minute) for a given provider.
 // These defaults are based on typical provider capabilities and can be overridden via CLI flags.
-func GetProviderDefaultRateLimit(provider string) int {
+func GetProviderRateLimit(provider string) int {
 	switch provider {
 	case "openrouter":
 		return 20 // OpenRouter varies by model, conservative default
@@ -777,6 +949,13 @@ func GetProviderDefaultRateLimit(provider string) int {
 	}
 }
 
+// GetProviderDefaultRateLimit returns the default rate limit for a given provider.
+//
+// Deprecated: use GetProviderRateLimit.
+func GetProviderDefaultRateLimit(provider string) int {
+	return GetProviderRateLimit(provider)
+}
+
 // GetModelRateLimit returns the effective rate limit for a specific model.
 // Priority: model-specific override > provider default
 func GetModelRateLimit(modelName string) (int, error) {
@@ -791,13 +970,13 @@ func GetModelRateLimit(modelName string) (int, error) {
 	}
 
 	// Otherwise, use provider default
-	return GetProviderDefaultRateLimit(modelInfo.Provider), nil
+	return GetProviderRateLimit(modelInfo.Provider), nil
 }
 
-// IsModelSupported returns true if the given model name is supported.
+// IsModelSupported returns true if the given model name or alias is supported.
 func IsModelSupported(name string) bool {
-	_, exists := modelDefinitions[name]
-	return exists
+	_, err := GetModelInfo(name)
+	return err == nil
 }
 
 // ValidateParameter validates a parameter value against the constraints defined for the given model.
@@ -814,7 +993,11 @@ func ValidateParameter(modelName, paramName string, value interface{}) error {
 		return nil
 	}
 
-	// Validate based on parameter type
+	return validateConstraint(paramName, value, constraint)
+}
+
+// validateConstraint checks value against a constraint of any type
+func validateConstraint(paramName string, value interface{}, constraint ParameterConstraint) error {
 	switch constraint.Type {
 	case "float":
 		return validateFloatParameter(paramName, value, constraint)
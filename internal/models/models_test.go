@@ -335,6 +335,11 @@ func TestIsModelSupported(t *testing.T) {
 			modelName: "llama-4-maverick",
 			expected:  true,
 		},
+		{
+			name:      "model alias",
+			modelName: "flash",
+			expected:  true,
+		},
 		{
 			name:      "invalid model",
 			modelName: "invalid-model",
@@ -1042,3 +1047,43 @@ func TestGetAvailableProvidersWithHelpfulMessages(t *testing.T) {
 		}
 	})
 }
+
+func TestListModels(t *testing.T) {
+	t.Parallel()
+	infos := ListModels()
+
+	if len(infos) != len(ListAllModels()) {
+		t.Fatalf("ListModels returned %d models, want %d", len(infos), len(ListAllModels()))
+	}
+
+	for i, info := range infos {
+		if info.Name == "" {
+			t.Errorf("model %d has no name", i)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := infos[i-1]
+		if prev.Provider > info.Provider || (prev.Provider == info.Provider && prev.Name >= info.Name) {
+			t.Errorf("models not sorted by provider then name: %s/%s before %s/%s",
+				prev.Provider, prev.Name, info.Provider, info.Name)
+		}
+	}
+
+	info, err := GetModelInfo("flash")
+	if err != nil || info.Name != "gemini-3-flash" {
+		t.Errorf("GetModelInfo(\"flash\").Name = %q (err %v), want canonical name", info.Name, err)
+	}
+}
+
+func TestGetProviderRateLimit(t *testing.T) {
+	t.Parallel()
+	for _, provider := range []string{"openrouter", "test", "unknown"} {
+		if got, want := GetProviderRateLimit(provider), GetProviderDefaultRateLimit(provider); got != want {
+			t.Errorf("GetProviderRateLimit(%q) = %d, deprecated alias returns %d", provider, got, want)
+		}
+	}
+	if got := GetProviderRateLimit("openrouter"); got != 20 {
+		t.Errorf("GetProviderRateLimit(\"openrouter\") = %d, want 20", got)
+	}
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidModelParams is wrapped by ValidateModelParams errors
+var ErrInvalidModelParams = errors.New("invalid model parameters")
+
+// samplingParamRanges bounds the sampling parameters for every model. A model's own
+// ParameterConstraints may narrow them, e.g. temperature 0-1 for Claude models.
+var samplingParamRanges = map[string]ParameterConstraint{
+	"temperature": floatConstraint(0.0, 2.0),
+	"top_p":       floatConstraint(0.0, 1.0),
+}
+
+// ValidateModelParams checks parameters for a call to model before it is made:
+// temperature must be within 0-2, top_p within 0-1, and max_tokens or
+// max_output_tokens between 1 and the model's MaxOutputTokens. Parameters with
+// ParameterConstraints must also satisfy them. Every invalid parameter is
+// reported, in name order; the error wraps ErrInvalidModelParams.
+func ValidateModelParams(model string, params map[string]any) error {
+	info, err := GetModelInfo(model)
+	if err != nil {
+		return fmt.Errorf("model '%s' not supported: %w", model, err)
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, name := range names {
+		if err := validateModelParam(info, name, params[name]); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w for model %s: %s", ErrInvalidModelParams, model, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validateModelParam checks one parameter against the common ranges and the model's constraints
+func validateModelParam(info ModelInfo, name string, value any) error {
+	switch name {
+	case "temperature", "top_p":
+		if err := validateFloatParameter(name, value, samplingParamRanges[name]); err != nil {
+			return err
+		}
+	case "max_tokens", "max_output_tokens":
+		limit := intConstraint(1, float64(info.MaxOutputTokens))
+		if info.MaxOutputTokens <= 0 {
+			limit.MaxValue = nil
+		}
+		if err := validateIntParameter(name, value, limit); err != nil {
+			return err
+		}
+	}
+
+	if constraint, ok := info.ParameterConstraints[name]; ok {
+		return validateConstraint(name, value, constraint)
+	}
+	return nil
+}
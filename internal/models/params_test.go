@@ -0,0 +1,108 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateModelParams(t *testing.T) {
+	t.Parallel()
+
+	type paramCase struct {
+		name        string
+		params      map[string]any
+		errContains []string
+	}
+
+	tests := []struct {
+		provider string
+		model    string
+		cases    []paramCase
+	}{
+		{
+			provider: "openrouter",
+			model:    "gpt-5.2",
+			cases: []paramCase{
+				{name: "no overrides"},
+				{name: "defaults", params: map[string]any{"temperature": 0.7, "top_p": 0.95}},
+				{name: "temperature bounds", params: map[string]any{"temperature": 2.0, "top_p": 0}},
+				{name: "max output tokens at the limit", params: map[string]any{"max_output_tokens": 128000}},
+				{name: "max tokens as a JSON number", params: map[string]any{"max_tokens": float64(4096)}},
+				{name: "unconstrained parameter", params: map[string]any{"seed": 42}},
+				{name: "temperature too high", params: map[string]any{"temperature": 2.5}, errContains: []string{"'temperature' value 2.50 must be <= 2.00"}},
+				{name: "temperature negative", params: map[string]any{"temperature": -0.1}, errContains: []string{"'temperature' value -0.10 must be >= 0.00"}},
+				{name: "top_p too high", params: map[string]any{"top_p": 1.5}, errContains: []string{"'top_p' value 1.50 must be <= 1.00"}},
+				{name: "top_p not a number", params: map[string]any{"top_p": "high"}, errContains: []string{"'top_p' must be a numeric value"}},
+				{name: "max output tokens over the limit", params: map[string]any{"max_output_tokens": 128001}, errContains: []string{"'max_output_tokens' value 128001 must be <= 128000"}},
+				{name: "max tokens zero", params: map[string]any{"max_tokens": 0}, errContains: []string{"'max_tokens' value 0 must be >= 1"}},
+				{name: "max tokens fractional", params: map[string]any{"max_tokens": 10.5}, errContains: []string{"'max_tokens' must be an integer"}},
+				{
+					name:        "every problem reported in name order",
+					params:      map[string]any{"top_p": 2, "temperature": 3},
+					errContains: []string{"for model gpt-5.2: parameter 'temperature' value 3.00 must be <= 2.00; parameter 'top_p' value 2.00 must be <= 1.00"},
+				},
+			},
+		},
+		{
+			provider: "openrouter",
+			model:    "claude-opus-4.5",
+			cases: []paramCase{
+				{name: "within the model's narrower range", params: map[string]any{"temperature": 1.0, "max_tokens": 64000}},
+				{name: "model constraint narrows temperature", params: map[string]any{"temperature": 1.5}, errContains: []string{"'temperature' value 1.50 must be <= 1.00"}},
+				{name: "max tokens over the model limit", params: map[string]any{"max_tokens": 64001}, errContains: []string{"'max_tokens' value 64001 must be <= 64000"}},
+			},
+		},
+		{
+			provider: "test",
+			model:    "model1",
+			cases: []paramCase{
+				{name: "defaults", params: map[string]any{"temperature": 0.7, "max_tokens": 5000}},
+				{name: "max output tokens over the limit", params: map[string]any{"max_output_tokens": 5001}, errContains: []string{"must be <= 5000"}},
+				{name: "temperature too high", params: map[string]any{"temperature": 2.01}, errContains: []string{"'temperature' value 2.01 must be <= 2.00"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider+"/"+tt.model, func(t *testing.T) {
+			t.Parallel()
+			for _, pc := range tt.cases {
+				err := ValidateModelParams(tt.model, pc.params)
+				if len(pc.errContains) == 0 {
+					if err != nil {
+						t.Errorf("%s: ValidateModelParams() = %v, want nil", pc.name, err)
+					}
+					continue
+				}
+				if !errors.Is(err, ErrInvalidModelParams) {
+					t.Errorf("%s: ValidateModelParams() = %v, want ErrInvalidModelParams", pc.name, err)
+					continue
+				}
+				for _, want := range pc.errContains {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("%s: error %q does not contain %q", pc.name, err, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestValidateModelParamsUnknownModel(t *testing.T) {
+	t.Parallel()
+	err := ValidateModelParams("no-such-model", map[string]any{"temperature": 0.5})
+	if err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("ValidateModelParams() = %v, want a not supported error", err)
+	}
+}
+
+// Every model's defaults must pass, since they are validated before each call
+func TestValidateModelParamsDefaults(t *testing.T) {
+	t.Parallel()
+	for _, info := range ListModels() {
+		if err := ValidateModelParams(info.Name, info.DefaultParams); err != nil {
+			t.Errorf("defaults for %s are invalid: %v", info.Name, err)
+		}
+	}
+}
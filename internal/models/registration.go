@@ -0,0 +1,113 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// CustomProvider serves models added with RegisterModel from an OpenAI-compatible
+// API, using the base URL and API key environment variable given at registration
+const CustomProvider = "custom"
+
+// ErrInvalidModelRegistration is wrapped by RegisterModel errors
+var ErrInvalidModelRegistration = errors.New("invalid model registration")
+
+// registeredModels holds the IDs added with RegisterModel, which UnregisterModel may remove
+var registeredModels = map[string]bool{}
+
+// RegisterModel adds a model to the catalog so it can be selected like a built-in
+// one. info.Name is the model ID; Provider defaults to CustomProvider, which
+// requires BaseURL (http or https) and APIKeyEnvVar, and "openrouter" is also
+// accepted. APIModelID defaults to Name, and ContextWindow and MaxOutputTokens
+// must be positive. An ID that is already a model or alias is rejected, as is one
+// containing '#', which --repeat uses to number the runs of a model.
+//
+// RegisterModel and UnregisterModel are meant to be called at program start, before
+// thinktank runs; they must not be called concurrently with model lookups.
+func RegisterModel(info ModelInfo) error {
+	name := strings.TrimSpace(info.Name)
+	if name == "" {
+		return fmt.Errorf("%w: model name is required", ErrInvalidModelRegistration)
+	}
+	if strings.Contains(name, "#") {
+		return fmt.Errorf("%w: model name %s must not contain '#'", ErrInvalidModelRegistration, name)
+	}
+	if _, exists := modelDefinitions[name]; exists {
+		return fmt.Errorf("%w: model %s is already defined", ErrInvalidModelRegistration, name)
+	}
+	if _, exists := modelAliases[name]; exists {
+		return fmt.Errorf("%w: model %s is already an alias", ErrInvalidModelRegistration, name)
+	}
+
+	if info.Provider == "" {
+		info.Provider = CustomProvider
+	}
+	switch info.Provider {
+	case CustomProvider:
+		if err := validateCustomEndpoint(name, info.BaseURL, info.APIKeyEnvVar); err != nil {
+			return err
+		}
+	case "openrouter":
+	default:
+		return fmt.Errorf("%w: model %s has unsupported provider %q (supported: %s, openrouter)",
+			ErrInvalidModelRegistration, name, info.Provider, CustomProvider)
+	}
+
+	if info.ContextWindow <= 0 || info.MaxOutputTokens <= 0 {
+		return fmt.Errorf("%w: model %s needs a positive context window and max output tokens",
+			ErrInvalidModelRegistration, name)
+	}
+	if info.APIModelID == "" {
+		info.APIModelID = name
+	}
+
+	// Names are filled in on lookup, like the built-in definitions
+	info.Name = ""
+	modelDefinitions[name] = info
+	registeredModels[name] = true
+	return nil
+}
+
+// UnregisterModel removes a model added with RegisterModel, reporting whether it
+// was registered. Built-in models can't be removed.
+func UnregisterModel(name string) bool {
+	if !registeredModels[name] {
+		return false
+	}
+	delete(registeredModels, name)
+	delete(modelDefinitions, name)
+	return true
+}
+
+// validateCustomEndpoint checks the base URL and key variable of a CustomProvider model
+func validateCustomEndpoint(name, baseURL, apiKeyEnvVar string) error {
+	if baseURL == "" {
+		return fmt.Errorf("%w: model %s needs a base URL for provider %s", ErrInvalidModelRegistration, name, CustomProvider)
+	}
+	parsed, err := url.Parse(baseURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: model %s has invalid base URL %q: must be an http or https URL",
+			ErrInvalidModelRegistration, name, baseURL)
+	}
+	if strings.TrimSpace(apiKeyEnvVar) == "" {
+		return fmt.Errorf("%w: model %s needs an API key environment variable for provider %s",
+			ErrInvalidModelRegistration, name, CustomProvider)
+	}
+	return nil
+}
+
+// GetAPIKeyEnvVarForModel returns the environment variable holding the API key for
+// a model or alias: its own APIKeyEnvVar when registered with one, otherwise its
+// provider's. Returns an empty string for unknown models and keyless providers.
+func GetAPIKeyEnvVarForModel(name string) string {
+	info, err := GetModelInfo(name)
+	if err != nil {
+		return ""
+	}
+	if info.APIKeyEnvVar != "" {
+		return info.APIKeyEnvVar
+	}
+	return GetAPIKeyEnvVar(info.Provider)
+}
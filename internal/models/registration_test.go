@@ -0,0 +1,114 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// customModel returns a valid registration for a custom provider model
+func customModel(name string) ModelInfo {
+	return ModelInfo{
+		Name:            name,
+		BaseURL:         "https://llm.internal.example/v1",
+		APIKeyEnvVar:    "INTERNAL_LLM_KEY",
+		ContextWindow:   32000,
+		MaxOutputTokens: 4000,
+	}
+}
+
+func TestRegisterModel(t *testing.T) {
+	t.Cleanup(func() { UnregisterModel("internal-llm") })
+
+	if err := RegisterModel(customModel("internal-llm")); err != nil {
+		t.Fatalf("RegisterModel() = %v, want nil", err)
+	}
+
+	info, err := GetModelInfo("internal-llm")
+	if err != nil {
+		t.Fatalf("GetModelInfo() = %v, want the registered model", err)
+	}
+	if info.Name != "internal-llm" || info.Provider != CustomProvider || info.APIModelID != "internal-llm" {
+		t.Errorf("GetModelInfo() = %+v, want name, custom provider, and API model ID filled in", info)
+	}
+	if got := GetAPIKeyEnvVarForModel("internal-llm"); got != "INTERNAL_LLM_KEY" {
+		t.Errorf("GetAPIKeyEnvVarForModel() = %q, want the registered variable", got)
+	}
+	if got := ListModelsForProvider(CustomProvider); len(got) != 1 || got[0] != "internal-llm" {
+		t.Errorf("ListModelsForProvider(custom) = %v, want [internal-llm]", got)
+	}
+
+	err = RegisterModel(customModel("internal-llm"))
+	if !errors.Is(err, ErrInvalidModelRegistration) || !strings.Contains(err.Error(), "already defined") {
+		t.Errorf("registering a duplicate = %v, want an already defined error", err)
+	}
+
+	if !UnregisterModel("internal-llm") {
+		t.Error("UnregisterModel() = false, want true for a registered model")
+	}
+	if IsModelSupported("internal-llm") {
+		t.Error("model still supported after UnregisterModel")
+	}
+}
+
+func TestRegisterModelValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*ModelInfo)
+		errContains string
+	}{
+		{name: "missing name", modify: func(m *ModelInfo) { m.Name = " " }, errContains: "model name is required"},
+		{name: "repeat separator in name", modify: func(m *ModelInfo) { m.Name = "internal-llm#2" }, errContains: "must not contain '#'"},
+		{name: "built-in model", modify: func(m *ModelInfo) { m.Name = "gpt-5.2" }, errContains: "already defined"},
+		{name: "alias", modify: func(m *ModelInfo) { m.Name = "opus" }, errContains: "already an alias"},
+		{name: "unsupported provider", modify: func(m *ModelInfo) { m.Provider = "openai" }, errContains: `unsupported provider "openai"`},
+		{name: "missing base URL", modify: func(m *ModelInfo) { m.BaseURL = "" }, errContains: "needs a base URL"},
+		{name: "base URL without scheme", modify: func(m *ModelInfo) { m.BaseURL = "llm.internal.example/v1" }, errContains: "invalid base URL"},
+		{name: "missing key variable", modify: func(m *ModelInfo) { m.APIKeyEnvVar = "" }, errContains: "needs an API key environment variable"},
+		{name: "missing context window", modify: func(m *ModelInfo) { m.ContextWindow = 0 }, errContains: "positive context window"},
+		{name: "missing max output tokens", modify: func(m *ModelInfo) { m.MaxOutputTokens = -1 }, errContains: "positive context window"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := customModel("invalid-llm")
+			tt.modify(&info)
+			t.Cleanup(func() { UnregisterModel(info.Name) })
+
+			err := RegisterModel(info)
+			if !errors.Is(err, ErrInvalidModelRegistration) {
+				t.Fatalf("RegisterModel() = %v, want ErrInvalidModelRegistration", err)
+			}
+			if !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("RegisterModel() error %q should contain %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestRegisterModelOpenRouter(t *testing.T) {
+	t.Cleanup(func() { UnregisterModel("mistral-small") })
+
+	err := RegisterModel(ModelInfo{
+		Name:            "mistral-small",
+		Provider:        "openrouter",
+		APIModelID:      "mistralai/mistral-small",
+		ContextWindow:   128000,
+		MaxOutputTokens: 8000,
+	})
+	if err != nil {
+		t.Fatalf("RegisterModel() = %v, want an OpenRouter model to need no base URL", err)
+	}
+	if got := GetAPIKeyEnvVarForModel("mistral-small"); got != "OPENROUTER_API_KEY" {
+		t.Errorf("GetAPIKeyEnvVarForModel() = %q, want the provider's variable", got)
+	}
+}
+
+func TestUnregisterModelKeepsBuiltInModels(t *testing.T) {
+	if UnregisterModel("gpt-5.2") {
+		t.Error("UnregisterModel() = true for a built-in model")
+	}
+	if !IsModelSupported("gpt-5.2") {
+		t.Error("built-in model removed by UnregisterModel")
+	}
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SelectionStrategy orders candidate models when several can handle the input
+type SelectionStrategy string
+
+const (
+	// SelectDefault keeps candidates in the order they were given
+	SelectDefault SelectionStrategy = ""
+	// SelectCheapest puts the lowest combined input and output price first
+	SelectCheapest SelectionStrategy = "cheapest"
+	// SelectLargest puts the largest context window first
+	SelectLargest SelectionStrategy = "largest"
+	// SelectFastest puts the lowest LatencyTier first; models without a hint go last
+	SelectFastest SelectionStrategy = "fastest"
+)
+
+// SelectionStrategies lists the strategies accepted by ParseSelectionStrategy
+var SelectionStrategies = []SelectionStrategy{SelectCheapest, SelectLargest, SelectFastest}
+
+// ParseSelectionStrategy converts a --select value into a SelectionStrategy.
+// An empty string selects SelectDefault.
+func ParseSelectionStrategy(value string) (SelectionStrategy, error) {
+	strategy := SelectionStrategy(strings.ToLower(strings.TrimSpace(value)))
+	if strategy == SelectDefault {
+		return SelectDefault, nil
+	}
+	for _, known := range SelectionStrategies {
+		if strategy == known {
+			return strategy, nil
+		}
+	}
+	names := make([]string, len(SelectionStrategies))
+	for i, known := range SelectionStrategies {
+		names[i] = string(known)
+	}
+	return "", fmt.Errorf("unknown selection strategy %q (use %s)", value, strings.Join(names, ", "))
+}
+
+// RankModels returns a copy of modelNames ordered by strategy. The sort is stable,
+// so models that compare equal keep their relative order, and unknown models
+// always sort after known ones. SelectDefault returns the names unchanged.
+func RankModels(modelNames []string, strategy SelectionStrategy) []string {
+	ranked := append([]string{}, modelNames...)
+	if strategy == SelectDefault {
+		return ranked
+	}
+
+	infos := make(map[string]ModelInfo, len(ranked))
+	for _, name := range ranked {
+		if info, err := GetModelInfo(name); err == nil {
+			infos[name] = info
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, aKnown := infos[ranked[i]]
+		b, bKnown := infos[ranked[j]]
+		if aKnown != bKnown {
+			return aKnown
+		}
+		switch strategy {
+		case SelectCheapest:
+			return a.InputPricePer1K+a.OutputPricePer1K < b.InputPricePer1K+b.OutputPricePer1K
+		case SelectLargest:
+			return a.ContextWindow > b.ContextWindow
+		case SelectFastest:
+			return latencyRank(a) < latencyRank(b)
+		}
+		return false
+	})
+	return ranked
+}
+
+// latencyRank maps LatencyTier to a sort key that puts unknown (0) tiers last
+func latencyRank(info ModelInfo) int {
+	if info.LatencyTier <= 0 {
+		return int(^uint(0) >> 1)
+	}
+	return info.LatencyTier
+}
+
+// SelectModelsForInputWithStrategy is SelectModelsForInput with the candidates
+// ordered by strategy. SelectDefault matches SelectModelsForInput exactly.
+func SelectModelsForInputWithStrategy(estimatedTokens int, availableProviders []string, strategy SelectionStrategy) []string {
+	return RankModels(SelectModelsForInput(estimatedTokens, availableProviders), strategy)
+}
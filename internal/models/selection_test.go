@@ -0,0 +1,101 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSelectionStrategy(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		value   string
+		want    SelectionStrategy
+		wantErr bool
+	}{
+		{value: "", want: SelectDefault},
+		{value: "cheapest", want: SelectCheapest},
+		{value: "LARGEST", want: SelectLargest},
+		{value: " fastest ", want: SelectFastest},
+		{value: "smartest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseSelectionStrategy(tt.value)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "cheapest, largest, fastest") {
+					t.Errorf("ParseSelectionStrategy(%q) error = %v, want list of strategies", tt.value, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseSelectionStrategy(%q) = %q, %v; want %q", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankModels(t *testing.T) {
+	t.Parallel()
+	input := []string{"claude-opus-4.5", "no-such-model", "deepseek-v3.2", "gemini-3-flash", "gpt-5.2"}
+	tests := []struct {
+		name     string
+		strategy SelectionStrategy
+		want     []string
+	}{
+		{
+			name:     "default keeps order",
+			strategy: SelectDefault,
+			want:     input,
+		},
+		{
+			name:     "cheapest",
+			strategy: SelectCheapest,
+			want:     []string{"deepseek-v3.2", "gemini-3-flash", "gpt-5.2", "claude-opus-4.5", "no-such-model"},
+		},
+		{
+			name:     "largest",
+			strategy: SelectLargest,
+			want:     []string{"gemini-3-flash", "gpt-5.2", "claude-opus-4.5", "deepseek-v3.2", "no-such-model"},
+		},
+		{
+			name:     "fastest keeps ties in input order",
+			strategy: SelectFastest,
+			want:     []string{"gemini-3-flash", "deepseek-v3.2", "claude-opus-4.5", "gpt-5.2", "no-such-model"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := RankModels(input, tt.strategy)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RankModels(%q) = %v, want %v", tt.strategy, got, tt.want)
+			}
+		})
+	}
+
+	if input[0] != "claude-opus-4.5" {
+		t.Errorf("RankModels modified its input: %v", input)
+	}
+}
+
+func TestSelectModelsForInputWithStrategy(t *testing.T) {
+	t.Parallel()
+	providers := []string{"openrouter"}
+
+	if got, want := SelectModelsForInputWithStrategy(1000, providers, SelectDefault), SelectModelsForInput(1000, providers); !reflect.DeepEqual(got, want) {
+		t.Errorf("default strategy = %v, want SelectModelsForInput order %v", got, want)
+	}
+
+	cheapest := SelectModelsForInputWithStrategy(1000, providers, SelectCheapest)
+	for i := 1; i < len(cheapest); i++ {
+		prev, _ := GetModelInfo(cheapest[i-1])
+		cur, _ := GetModelInfo(cheapest[i])
+		if prev.InputPricePer1K+prev.OutputPricePer1K > cur.InputPricePer1K+cur.OutputPricePer1K {
+			t.Errorf("cheapest order has %s before cheaper %s", cheapest[i-1], cheapest[i])
+		}
+	}
+}
@@ -252,3 +252,41 @@ func BenchmarkEstimateTokensFromStats(b *testing.B) {
 		})
 	}
 }
+
+func TestCountTokensEstimate(t *testing.T) {
+	t.Parallel()
+	code := strings.Repeat("func main() {}\n", 100) // 1500 characters
+
+	tests := []struct {
+		name     string
+		model    string
+		text     string
+		expected int
+	}{
+		{"openai family uses 4 chars per token", "gpt-5.2", code, 375},
+		{"gemini family uses 3.5 chars per token", "gemini-3-pro", code, 429},
+		{"anthropic family uses 3.5 chars per token", "claude-opus-4.5", code, 429},
+		{"unknown family uses the default ratio", "deepseek-v3.2", code, 500},
+		{"provider used when the API ID has no vendor", "model1", code, 500},
+		{"alias resolves to its model", "openai/gpt-5.2-codex", code, 375},
+		{"empty text", "gpt-5.2", "", 0},
+		{"partial tokens round up", "gpt-5.2", "abcde", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := CountTokensEstimate(tt.model, tt.text)
+			if err != nil {
+				t.Fatalf("CountTokensEstimate(%q) returned error: %v", tt.model, err)
+			}
+			if got != tt.expected {
+				t.Errorf("CountTokensEstimate(%q) = %d, want %d", tt.model, got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := CountTokensEstimate("no-such-model", code); err == nil {
+		t.Error("expected an error for an unknown model")
+	}
+}
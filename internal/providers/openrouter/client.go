@@ -2,6 +2,7 @@
 package openrouter
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -16,7 +17,7 @@ import (
 	"github.com/misty-step/thinktank/internal/logutil"
 )
 
-// openrouterClient implements the llm.LLMClient interface for OpenRouter
+// openrouterClient implements the llm.StreamingLLMClient interface for OpenRouter
 type openrouterClient struct {
 	apiKey      string
 	modelID     string
@@ -24,6 +25,9 @@ type openrouterClient struct {
 	httpClient  *http.Client
 	logger      logutil.LoggerInterface
 
+	// anyModelIDFormat skips the warning for model IDs not shaped like OpenRouter's
+	anyModelIDFormat bool
+
 	// Optional request parameters
 	temperature      *float32
 	topP             *float32
@@ -42,6 +46,14 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 	}
 }
 
+// WithAnyModelIDFormat accepts model IDs of any shape without a warning, for
+// registered models served by other OpenAI-compatible APIs
+func WithAnyModelIDFormat() ClientOption {
+	return func(c *openrouterClient) {
+		c.anyModelIDFormat = true
+	}
+}
+
 // NewClient creates a new OpenRouter client that implements the llm.LLMClient interface
 func NewClient(apiKey string, modelID string, apiEndpoint string, logger logutil.LoggerInterface, opts ...ClientOption) (*openrouterClient, error) {
 	// Validate required parameters
@@ -53,13 +65,6 @@ func NewClient(apiKey string, modelID string, apiEndpoint string, logger logutil
 		return nil, fmt.Errorf("model ID cannot be empty")
 	}
 
-	// Verify the modelID has the expected format (provider/model or provider/organization/model)
-	if !strings.Contains(modelID, "/") {
-		if logger != nil {
-			logger.Warn("OpenRouter model ID '%s' does not have expected format 'provider/model' or 'provider/organization/model'", modelID)
-		}
-	}
-
 	// Set default API endpoint if not provided
 	if apiEndpoint == "" {
 		apiEndpoint = "https://openrouter.ai/api/v1"
@@ -90,6 +95,13 @@ func NewClient(apiKey string, modelID string, apiEndpoint string, logger logutil
 		opt(client)
 	}
 
+	// Verify the modelID has the expected format (provider/model or provider/organization/model)
+	if !client.anyModelIDFormat && !strings.Contains(modelID, "/") {
+		if logger != nil {
+			logger.Warn("OpenRouter model ID '%s' does not have expected format 'provider/model' or 'provider/organization/model'", modelID)
+		}
+	}
+
 	return client, nil
 }
 
@@ -136,8 +148,206 @@ type ChatCompletionResponse struct {
 	Usage   ChatCompletionUsage    `json:"usage"`
 }
 
+// ChatCompletionStreamChoice represents a choice in one streamed chat completion chunk
+type ChatCompletionStreamChoice struct {
+	Index        int                   `json:"index"`
+	Delta        ChatCompletionMessage `json:"delta"`
+	FinishReason *string               `json:"finish_reason"`
+}
+
+// ChatCompletionStreamChunk represents one server-sent event of a streamed chat completion
+type ChatCompletionStreamChunk struct {
+	Choices []ChatCompletionStreamChoice `json:"choices"`
+	Usage   *ChatCompletionUsage         `json:"usage,omitempty"`
+	Error   *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
 // GenerateContent sends a prompt to the LLM and returns the generated content
 func (c *openrouterClient) GenerateContent(ctx context.Context, prompt string, params map[string]interface{}) (*llm.ProviderResult, error) {
+	resp, err := c.sendRequest(ctx, prompt, params, false)
+	if err != nil {
+		return nil, err
+	}
+	defer c.closeBody(resp)
+
+	// Read the response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, CreateAPIError(
+			llm.CategoryNetwork,
+			"Failed to read response from OpenRouter API",
+			err,
+			fmt.Sprintf("Response read error: %v", err),
+		)
+	}
+
+	// Parse the response
+	var completionResponse ChatCompletionResponse
+	if err := json.Unmarshal(body, &completionResponse); err != nil {
+		return nil, CreateAPIError(
+			llm.CategoryServer,
+			"Failed to parse response from OpenRouter API",
+			err,
+			fmt.Sprintf("JSON unmarshal error: %v, Body: %s", err, truncateString(string(body), 200)),
+		)
+	}
+
+	// Validate response structure
+	if len(completionResponse.Choices) == 0 {
+		return nil, CreateAPIError(
+			llm.CategoryServer,
+			"OpenRouter API returned an empty response",
+			fmt.Errorf("no completion choices in response"),
+			fmt.Sprintf("Response contained zero choices: %s", truncateString(string(body), 200)),
+		)
+	}
+
+	// Extract the content and other fields
+	content := completionResponse.Choices[0].Message.Content
+	finishReason := completionResponse.Choices[0].FinishReason
+
+	// Pass through the provider's own token accounting when present
+	var usage *llm.TokenUsage
+	if completionResponse.Usage.TotalTokens > 0 || completionResponse.Usage.PromptTokens > 0 {
+		usage = &llm.TokenUsage{
+			PromptTokens:     completionResponse.Usage.PromptTokens,
+			CompletionTokens: completionResponse.Usage.CompletionTokens,
+			TotalTokens:      completionResponse.Usage.TotalTokens,
+		}
+	}
+
+	// Build and return the result
+	return &llm.ProviderResult{
+		Content:      content,
+		FinishReason: finishReason,
+		Truncated:    finishReason == "length",
+		// OpenRouter doesn't provide safety info in the same format as Gemini,
+		// so we leave SafetyInfo empty for now
+		SafetyInfo: []llm.Safety{},
+		Usage:      usage,
+	}, nil
+}
+
+// GenerateContentStream sends a prompt to the LLM and streams the generated content as it arrives
+func (c *openrouterClient) GenerateContentStream(ctx context.Context, prompt string, params map[string]interface{}) (<-chan llm.Chunk, error) {
+	resp, err := c.sendRequest(ctx, prompt, params, true)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := make(chan llm.Chunk)
+	go func() {
+		defer close(stream)
+		defer c.closeBody(resp)
+		c.readStream(ctx, resp.Body, stream)
+	}()
+	return stream, nil
+}
+
+// readStream decodes server-sent events from body onto stream until the [DONE] marker,
+// which it reports as a Done chunk. Every other way the stream can end, including ctx
+// being canceled and the body ending without [DONE], is reported as an Err chunk.
+// The final chunk is always delivered, relying on the reader to drain the stream.
+func (c *openrouterClient) readStream(ctx context.Context, body io.Reader, stream chan<- llm.Chunk) {
+	send := func(chunk llm.Chunk) bool {
+		select {
+		case stream <- chunk:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	cancelled := func() llm.Chunk {
+		return llm.Chunk{Err: CreateAPIError(
+			llm.CategoryCancelled,
+			"Streamed response from OpenRouter API was cancelled",
+			ctx.Err(),
+			"The request was cancelled or timed out before the response was complete",
+		)}
+	}
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		// Events are "data: <json>" lines; blank lines and ": keep-alive" comments are skipped
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			stream <- llm.Chunk{Done: true}
+			return
+		}
+
+		var event ChatCompletionStreamChunk
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			stream <- llm.Chunk{Err: CreateAPIError(
+				llm.CategoryServer,
+				"Failed to parse streamed response from OpenRouter API",
+				err,
+				fmt.Sprintf("JSON unmarshal error: %v, Data: %s", err, truncateString(data, 200)),
+			)}
+			return
+		}
+		if event.Error != nil {
+			stream <- llm.Chunk{Err: CreateAPIError(
+				llm.CategoryServer,
+				"OpenRouter API failed mid-stream",
+				errors.New(event.Error.Message),
+				fmt.Sprintf("Stream error: %s", event.Error.Message),
+			)}
+			return
+		}
+
+		var chunk llm.Chunk
+		if len(event.Choices) > 0 {
+			chunk.Content = event.Choices[0].Delta.Content
+			if event.Choices[0].FinishReason != nil {
+				chunk.FinishReason = *event.Choices[0].FinishReason
+			}
+		}
+		if event.Usage != nil {
+			chunk.Usage = &llm.TokenUsage{
+				PromptTokens:     event.Usage.PromptTokens,
+				CompletionTokens: event.Usage.CompletionTokens,
+				TotalTokens:      event.Usage.TotalTokens,
+			}
+		}
+		if !send(chunk) {
+			stream <- cancelled()
+			return
+		}
+	}
+
+	// The body ended without [DONE]: cancelled, a read error, or a dropped connection
+	if ctx.Err() != nil {
+		stream <- cancelled()
+		return
+	}
+	if err := scanner.Err(); err != nil {
+		stream <- llm.Chunk{Err: CreateAPIError(
+			llm.CategoryNetwork,
+			"Failed to read streamed response from OpenRouter API",
+			err,
+			fmt.Sprintf("Stream read error: %v", err),
+		)}
+		return
+	}
+	stream <- llm.Chunk{Err: CreateAPIError(
+		llm.CategoryNetwork,
+		"Streamed response from OpenRouter API ended early",
+		llm.ErrStreamIncomplete,
+		"The connection closed before the [DONE] marker",
+	)}
+}
+
+// sendRequest validates the prompt and parameters and posts a chat completion request.
+// On a 200 response it returns the open response for the caller to read and close;
+// any other status is returned as a categorized API error.
+func (c *openrouterClient) sendRequest(ctx context.Context, prompt string, params map[string]interface{}, stream bool) (*http.Response, error) {
 	// Validate prompt
 	if prompt == "" {
 		return nil, CreateAPIError(
@@ -297,7 +507,7 @@ func (c *openrouterClient) GenerateContent(ctx context.Context, prompt string, p
 		PresencePenalty:  presencePenalty,
 		MaxTokens:        maxTokens,
 		ReasoningEffort:  reasoningEffort,
-		Stream:           false, // Non-streaming implementation for initial version
+		Stream:           stream,
 	}
 
 	// Note: BYOK models are handled by OpenRouter automatically
@@ -359,11 +569,12 @@ func (c *openrouterClient) GenerateContent(ctx context.Context, prompt string, p
 			fmt.Sprintf("HTTP error: %v", err),
 		)
 	}
-	defer func() {
-		if closeErr := resp.Body.Close(); closeErr != nil && c.logger != nil {
-			c.logger.Warn("Failed to close response body: %v", closeErr)
-		}
-	}()
+
+	// Hand back the open body on success; the caller reads and closes it
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	defer c.closeBody(resp)
 
 	// Read the response body
 	body, err := io.ReadAll(resp.Body)
@@ -377,87 +588,58 @@ func (c *openrouterClient) GenerateContent(ctx context.Context, prompt string, p
 	}
 
 	// Handle non-200 status codes
-	if resp.StatusCode != http.StatusOK {
-		// Create a categorized API error using the FormatAPIErrorFromResponse function
-		apiErr := FormatAPIErrorFromResponse(
-			fmt.Errorf("OpenRouter API returned non-200 status code: %d", resp.StatusCode),
-			resp.StatusCode,
-			body,
-		)
-
-		// Try to parse the response for any additional information
-		var usageInfo *ChatCompletionUsage
-
-		// Attempt to extract usage information and possibly finish reason if available
-		var errorResponse map[string]interface{}
-		if err := json.Unmarshal(body, &errorResponse); err == nil {
-			// Check if there's usage information
-			if usage, ok := errorResponse["usage"].(map[string]interface{}); ok {
-				usageInfo = &ChatCompletionUsage{}
-				if promptTokens, ok := usage["prompt_tokens"].(float64); ok {
-					usageInfo.PromptTokens = int(promptTokens)
-				}
-				if completionTokens, ok := usage["completion_tokens"].(float64); ok {
-					usageInfo.CompletionTokens = int(completionTokens)
-				}
-				if totalTokens, ok := usage["total_tokens"].(float64); ok {
-					usageInfo.TotalTokens = int(totalTokens)
-				}
+	// Create a categorized API error using the FormatAPIErrorFromResponse function
+	apiErr := FormatAPIErrorFromResponse(
+		fmt.Errorf("OpenRouter API returned non-200 status code: %d", resp.StatusCode),
+		resp.StatusCode,
+		body,
+	)
+	apiErr.RetryAfter = llm.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+
+	// Try to parse the response for any additional information
+	var usageInfo *ChatCompletionUsage
+
+	// Attempt to extract usage information and possibly finish reason if available
+	var errorResponse map[string]interface{}
+	if err := json.Unmarshal(body, &errorResponse); err == nil {
+		// Check if there's usage information
+		if usage, ok := errorResponse["usage"].(map[string]interface{}); ok {
+			usageInfo = &ChatCompletionUsage{}
+			if promptTokens, ok := usage["prompt_tokens"].(float64); ok {
+				usageInfo.PromptTokens = int(promptTokens)
 			}
-
-			// Check if there's a finish reason and add to error details if found
-			if choices, ok := errorResponse["choices"].([]interface{}); ok && len(choices) > 0 {
-				if choice, ok := choices[0].(map[string]interface{}); ok {
-					if reason, ok := choice["finish_reason"].(string); ok && apiErr != nil {
-						apiErr.Details += fmt.Sprintf(" (Finish reason: %s)", reason)
-					}
-				}
+			if completionTokens, ok := usage["completion_tokens"].(float64); ok {
+				usageInfo.CompletionTokens = int(completionTokens)
+			}
+			if totalTokens, ok := usage["total_tokens"].(float64); ok {
+				usageInfo.TotalTokens = int(totalTokens)
 			}
 		}
 
-		// If we have token usage information, include it in debug details
-		if usageInfo != nil && apiErr != nil {
-			apiErr.Details += fmt.Sprintf(" (Token usage: %d prompt, %d completion, %d total)",
-				usageInfo.PromptTokens, usageInfo.CompletionTokens, usageInfo.TotalTokens)
+		// Check if there's a finish reason and add to error details if found
+		if choices, ok := errorResponse["choices"].([]interface{}); ok && len(choices) > 0 {
+			if choice, ok := choices[0].(map[string]interface{}); ok {
+				if reason, ok := choice["finish_reason"].(string); ok && apiErr != nil {
+					apiErr.Details += fmt.Sprintf(" (Finish reason: %s)", reason)
+				}
+			}
 		}
-
-		return nil, apiErr
 	}
 
-	// Parse the response
-	var completionResponse ChatCompletionResponse
-	if err := json.Unmarshal(body, &completionResponse); err != nil {
-		return nil, CreateAPIError(
-			llm.CategoryServer,
-			"Failed to parse response from OpenRouter API",
-			err,
-			fmt.Sprintf("JSON unmarshal error: %v, Body: %s", err, truncateString(string(body), 200)),
-		)
-	}
-
-	// Validate response structure
-	if len(completionResponse.Choices) == 0 {
-		return nil, CreateAPIError(
-			llm.CategoryServer,
-			"OpenRouter API returned an empty response",
-			fmt.Errorf("no completion choices in response"),
-			fmt.Sprintf("Response contained zero choices: %s", truncateString(string(body), 200)),
-		)
+	// If we have token usage information, include it in debug details
+	if usageInfo != nil && apiErr != nil {
+		apiErr.Details += fmt.Sprintf(" (Token usage: %d prompt, %d completion, %d total)",
+			usageInfo.PromptTokens, usageInfo.CompletionTokens, usageInfo.TotalTokens)
 	}
 
-	// Extract the content and other fields
-	content := completionResponse.Choices[0].Message.Content
-	finishReason := completionResponse.Choices[0].FinishReason
+	return nil, apiErr
+}
 
-	// Build and return the result
-	return &llm.ProviderResult{
-		Content:      content,
-		FinishReason: finishReason,
-		Truncated:    finishReason == "length",
-		// OpenRouter doesn't provide safety info in the same format as Gemini,
-		// so we leave SafetyInfo empty for now
-		SafetyInfo: []llm.Safety{},
-	}, nil
+// closeBody closes a response body, logging any failure
+func (c *openrouterClient) closeBody(resp *http.Response) {
+	if closeErr := resp.Body.Close(); closeErr != nil && c.logger != nil {
+		c.logger.Warn("Failed to close response body: %v", closeErr)
+	}
 }
 
 // GetModelName returns the name of the model being used
@@ -654,3 +836,6 @@ func (c *openrouterClient) validateParameters(params map[string]interface{}) err
 
 	return nil
 }
+
+// Compile-time check that the client supports streaming
+var _ llm.StreamingLLMClient = (*openrouterClient)(nil)
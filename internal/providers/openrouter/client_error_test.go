@@ -177,6 +177,32 @@ func TestClientHTTPErrors(t *testing.T) {
 	}
 }
 
+// TestClientRateLimitRetryAfter verifies that a 429's Retry-After header is carried on the error
+func TestClientRateLimitRetryAfter(t *testing.T) {
+	logger := logutil.NewLogger(logutil.DebugLevel, nil, "[test] ")
+	client, err := NewClient("test-api-key", "anthropic/claude-3-opus", "", logger)
+	require.NoError(t, err)
+
+	client.httpClient = &http.Client{
+		Transport: &ErrorMockRoundTripper{
+			requestHandler: func(req *http.Request) (*http.Response, error) {
+				header := make(http.Header)
+				header.Set("Retry-After", "7")
+				return &http.Response{
+					StatusCode: http.StatusTooManyRequests,
+					Body:       io.NopCloser(bytes.NewBufferString(`{"error":{"message":"Rate limit exceeded","type":"rate_limit"}}`)),
+					Header:     header,
+				}, nil
+			},
+		},
+	}
+
+	_, err = client.GenerateContent(context.Background(), "test prompt", nil)
+	require.Error(t, err)
+	assert.True(t, llm.IsRateLimit(err))
+	assert.Equal(t, 7*time.Second, llm.RetryAfterFromError(err))
+}
+
 // TestContextCancellation tests handling of context cancellation
 func TestContextCancellation(t *testing.T) {
 	tests := []struct {
@@ -744,3 +770,147 @@ func TestFinishReasons(t *testing.T) {
 		})
 	}
 }
+
+// streamingRoundTripper answers every request with a server-sent event body
+func streamingRoundTripper(t *testing.T, body string) *ErrorMockRoundTripper {
+	return &ErrorMockRoundTripper{
+		requestHandler: func(req *http.Request) (*http.Response, error) {
+			var request ChatCompletionRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&request))
+			assert.True(t, request.Stream, "streaming requests should set stream: true")
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(body)),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+}
+
+func TestGenerateContentStream(t *testing.T) {
+	logger := logutil.NewLogger(logutil.DebugLevel, nil, "[test] ")
+	client, err := NewClient("test-api-key", "anthropic/claude-3-opus", "", logger)
+	require.NoError(t, err)
+	client.httpClient = &http.Client{Transport: streamingRoundTripper(t, ": OPENROUTER PROCESSING\n\n"+
+		`data: {"choices":[{"delta":{"content":"Hello"}}]}`+"\n\n"+
+		`data: {"choices":[{"delta":{"content":", world"},"finish_reason":"stop"}],"usage":{"prompt_tokens":3,"completion_tokens":2,"total_tokens":5}}`+"\n\n"+
+		"data: [DONE]\n\n")}
+
+	stream, err := client.GenerateContentStream(context.Background(), "test prompt", nil)
+	require.NoError(t, err)
+
+	var chunks []string
+	result, err := llm.CollectStream(stream, func(content string) { chunks = append(chunks, content) })
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Hello", ", world"}, chunks)
+	assert.Equal(t, "Hello, world", result.Content)
+	assert.Equal(t, "stop", result.FinishReason)
+	require.NotNil(t, result.Usage)
+	assert.Equal(t, 5, result.Usage.TotalTokens)
+}
+
+func TestGenerateContentStreamErrors(t *testing.T) {
+	logger := logutil.NewLogger(logutil.DebugLevel, nil, "[test] ")
+
+	t.Run("error before streaming", func(t *testing.T) {
+		client, err := NewClient("test-api-key", "anthropic/claude-3-opus", "", logger)
+		require.NoError(t, err)
+		client.httpClient = &http.Client{Transport: &ErrorMockRoundTripper{
+			statusCode:   http.StatusTooManyRequests,
+			responseBody: []byte(`{"error":{"message":"Rate limit exceeded","type":"rate_limit"}}`),
+		}}
+
+		_, err = client.GenerateContentStream(context.Background(), "test prompt", nil)
+		require.Error(t, err)
+		assert.True(t, llm.IsRateLimit(err))
+	})
+
+	t.Run("error mid-stream", func(t *testing.T) {
+		client, err := NewClient("test-api-key", "anthropic/claude-3-opus", "", logger)
+		require.NoError(t, err)
+		client.httpClient = &http.Client{Transport: streamingRoundTripper(t,
+			`data: {"choices":[{"delta":{"content":"partial"}}]}`+"\n\n"+
+				`data: {"error":{"message":"provider disconnected"}}`+"\n\n")}
+
+		stream, err := client.GenerateContentStream(context.Background(), "test prompt", nil)
+		require.NoError(t, err)
+		_, err = llm.CollectStream(stream, nil)
+		require.Error(t, err)
+		assert.True(t, llm.IsServer(err))
+		assert.Contains(t, err.Error(), "mid-stream")
+	})
+
+	t.Run("body ends without [DONE]", func(t *testing.T) {
+		client, err := NewClient("test-api-key", "anthropic/claude-3-opus", "", logger)
+		require.NoError(t, err)
+		client.httpClient = &http.Client{Transport: streamingRoundTripper(t,
+			`data: {"choices":[{"delta":{"content":"partial"},"finish_reason":"stop"}]}`+"\n\n")}
+
+		stream, err := client.GenerateContentStream(context.Background(), "test prompt", nil)
+		require.NoError(t, err)
+		result, err := llm.CollectStream(stream, nil)
+		require.Error(t, err)
+		assert.Nil(t, result, "a cut-short stream must not return partial content")
+		assert.True(t, llm.IsNetwork(err))
+		assert.ErrorIs(t, err, llm.ErrStreamIncomplete)
+	})
+
+	t.Run("cancelled mid-stream", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		client, err := NewClient("test-api-key", "anthropic/claude-3-opus", "", logger)
+		require.NoError(t, err)
+		client.httpClient = &http.Client{Transport: &ErrorMockRoundTripper{
+			requestHandler: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(&stalledReader{
+						ctx:  req.Context(),
+						data: `data: {"choices":[{"delta":{"content":"partial"}}]}` + "\n\n",
+					}),
+					Header: make(http.Header),
+				}, nil
+			},
+		}}
+
+		stream, err := client.GenerateContentStream(ctx, "test prompt", nil)
+		require.NoError(t, err)
+		result, err := llm.CollectStream(stream, func(string) { cancel() })
+		require.Error(t, err)
+		assert.Nil(t, result, "a cancelled stream must not return partial content")
+		catErr, ok := llm.IsCategorizedError(err)
+		require.True(t, ok)
+		assert.Equal(t, llm.CategoryCancelled, catErr.Category())
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("malformed event", func(t *testing.T) {
+		client, err := NewClient("test-api-key", "anthropic/claude-3-opus", "", logger)
+		require.NoError(t, err)
+		client.httpClient = &http.Client{Transport: streamingRoundTripper(t, "data: {not json\n\n")}
+
+		stream, err := client.GenerateContentStream(context.Background(), "test prompt", nil)
+		require.NoError(t, err)
+		_, err = llm.CollectStream(stream, nil)
+		require.Error(t, err)
+		assert.True(t, llm.IsServer(err))
+	})
+}
+
+// stalledReader returns data and then blocks until ctx is done, like a response
+// body whose connection stalls mid-stream
+type stalledReader struct {
+	ctx  context.Context
+	data string
+}
+
+func (r *stalledReader) Read(p []byte) (int, error) {
+	if r.data != "" {
+		n := copy(p, r.data)
+		r.data = r.data[n:]
+		return n, nil
+	}
+	<-r.ctx.Done()
+	return 0, r.ctx.Err()
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/misty-step/thinktank/internal/logutil"
+	"github.com/misty-step/thinktank/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -115,6 +116,33 @@ func TestNewClientConnectionPooling(t *testing.T) {
 	assert.Equal(t, 90*time.Second, transport.IdleConnTimeout)
 }
 
+func TestNewClientModelIDFormatWarning(t *testing.T) {
+	tests := []struct {
+		name     string
+		modelID  string
+		opts     []ClientOption
+		wantWarn bool
+	}{
+		{name: "provider/model", modelID: "openai/gpt-5.2"},
+		{name: "bare model ID", modelID: "gpt-5.2", wantWarn: true},
+		{name: "registered model", modelID: "llm-1", opts: []ClientOption{WithAnyModelIDFormat()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := testutil.NewMockLogger()
+			_, err := NewClient("sk-or-test-api-key", tt.modelID, "", logger, tt.opts...)
+			require.NoError(t, err)
+			if tt.wantWarn {
+				require.Len(t, logger.GetWarnMessages(), 1)
+				assert.Contains(t, logger.GetWarnMessages()[0], "does not have expected format")
+			} else {
+				assert.Empty(t, logger.GetWarnMessages())
+			}
+		})
+	}
+}
+
 // TestClientMethodsThroughProvider tests the client's methods
 func TestClientMethodsThroughProvider(t *testing.T) {
 	// Create provider
@@ -380,3 +408,18 @@ func TestConcurrentGenerateContent(t *testing.T) {
 	// Wait for all goroutines to complete
 	wg.Wait()
 }
+
+// TestGenerateContentReportsUsage verifies provider token usage is passed through on the result
+func TestGenerateContentReportsUsage(t *testing.T) {
+	logger := logutil.NewLogger(logutil.InfoLevel, nil, "[test] ")
+	client, err := NewClient("sk-or-test-api-key", "anthropic/claude-3-opus", "", logger)
+	require.NoError(t, err)
+	client.httpClient = &http.Client{Transport: &MockRoundTripper{ResponsesByParams: map[string][]byte{}}}
+
+	result, err := client.GenerateContent(context.Background(), "Hello", nil)
+	require.NoError(t, err)
+	require.NotNil(t, result.Usage)
+	assert.Equal(t, 10, result.Usage.PromptTokens)
+	assert.Equal(t, 20, result.Usage.CompletionTokens)
+	assert.Equal(t, 30, result.Usage.TotalTokens)
+}
@@ -0,0 +1,46 @@
+package openrouter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/misty-step/thinktank/internal/llm"
+	"github.com/misty-step/thinktank/internal/logutil"
+	"github.com/misty-step/thinktank/internal/providers"
+)
+
+// CompatibleProvider implements the Provider interface for any API that speaks
+// OpenRouter's OpenAI-compatible chat completions protocol, such as a self-hosted
+// model server. Unlike OpenRouterProvider it has no default endpoint and accepts
+// keys in any format.
+type CompatibleProvider struct {
+	logger logutil.LoggerInterface
+}
+
+// NewCompatibleProvider creates a new instance of CompatibleProvider.
+func NewCompatibleProvider(logger logutil.LoggerInterface) providers.Provider {
+	if logger == nil {
+		logger = logutil.NewLogger(logutil.InfoLevel, nil, "[compatible-provider] ")
+	}
+	return &CompatibleProvider{logger: logger}
+}
+
+// CreateClient implements the Provider interface. Both apiKey and apiEndpoint are required.
+func (p *CompatibleProvider) CreateClient(
+	ctx context.Context,
+	apiKey string,
+	modelID string,
+	apiEndpoint string,
+) (llm.LLMClient, error) {
+	if apiEndpoint == "" {
+		return nil, fmt.Errorf("an API endpoint is required for model %s", modelID)
+	}
+	p.logger.Debug("Creating OpenAI-compatible client for model %s using %s", modelID, GetBaseURLLogInfo(apiEndpoint))
+
+	// Registered models name their own API's model IDs, which needn't look like OpenRouter's
+	client, err := NewClient(apiKey, modelID, apiEndpoint, p.logger, WithAnyModelIDFormat())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OpenAI-compatible client: %w", err)
+	}
+	return client, nil
+}
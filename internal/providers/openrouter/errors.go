@@ -166,6 +166,12 @@ func FormatAPIErrorFromResponse(err error, statusCode int, responseBody []byte)
 		llmError.Suggestion = "Check that your OpenRouter API key is valid, has not expired, and starts with 'sk-or'. Ensure OPENROUTER_API_KEY environment variable is set correctly and not confused with other provider keys."
 	case llm.CategoryInsufficientCredits:
 		llmError.Suggestion = "Check your OpenRouter account balance and add credits if needed. Visit https://openrouter.ai/account for account details."
+		credits := llm.ParseCreditAmounts(errorMessage)
+		llmError.CreditsRemaining, llmError.CreditsRequired = credits.Remaining, credits.Required
+		llmError.TokensRequested, llmError.TokensAffordable = credits.TokensRequested, credits.TokensAffordable
+		if credits.TokensAffordable != nil {
+			llmError.Suggestion += " Alternatively, lower the model's max_tokens below the affordable amount with --model-param."
+		}
 	case llm.CategoryNotFound:
 		llmError.Suggestion = "Verify that the model name is correct and uses the format 'provider/model' or 'provider/organization/model'."
 	case llm.CategoryServer:
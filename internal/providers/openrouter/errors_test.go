@@ -288,6 +288,40 @@ func TestFormatAPIErrorFromResponse(t *testing.T) {
 	}
 }
 
+func TestFormatAPIErrorFromResponseCredits(t *testing.T) {
+	t.Parallel()
+	body := []byte(`{"error": {"code": 402, "message": "This request requires $0.12 but your balance is $0.03"}}`)
+
+	result := FormatAPIErrorFromResponse(errors.New("payment required"), 402, body)
+
+	require.NotNil(t, result)
+	assert.Equal(t, llm.CategoryInsufficientCredits, result.Category())
+	require.NotNil(t, result.CreditsRequired)
+	require.NotNil(t, result.CreditsRemaining)
+	assert.Equal(t, 0.12, *result.CreditsRequired)
+	assert.Equal(t, 0.03, *result.CreditsRemaining)
+	assert.Contains(t, result.UserFacingError(), "(need $0.12, have $0.03)")
+
+	// Without amounts in the message the fields stay unset
+	result = FormatAPIErrorFromResponse(errors.New("payment required"), 402,
+		[]byte(`{"error": {"code": 402, "message": "Insufficient credits"}}`))
+	assert.Nil(t, result.CreditsRequired)
+	assert.Nil(t, result.CreditsRemaining)
+	assert.Nil(t, result.TokensAffordable)
+
+	// OpenRouter's own 402 message gives the shortfall in tokens
+	result = FormatAPIErrorFromResponse(errors.New("payment required"), 402,
+		[]byte(`{"error": {"code": 402, "message": "This request requires more credits, or fewer max_tokens. You requested up to 16384 tokens, but can only afford 1234. To increase, visit https://openrouter.ai/settings/credits and upgrade to a paid account"}}`))
+	assert.Equal(t, llm.CategoryInsufficientCredits, result.Category())
+	require.NotNil(t, result.TokensRequested)
+	require.NotNil(t, result.TokensAffordable)
+	assert.Equal(t, 16384, *result.TokensRequested)
+	assert.Equal(t, 1234, *result.TokensAffordable)
+	assert.Nil(t, result.CreditsRequired)
+	assert.Contains(t, result.UserFacingError(), "(requested 16384 tokens, can afford 1234)")
+	assert.Contains(t, result.Suggestion, "max_tokens")
+}
+
 func TestFormatAPIError(t *testing.T) {
 	t.Parallel() // Pure CPU-bound API error formatting test
 	tests := []struct {
@@ -0,0 +1,70 @@
+package openrouter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/misty-step/thinktank/internal/llm"
+)
+
+// CheckHealth confirms OpenRouter is reachable and accepts the API key by requesting
+// the key's metadata from /key, which costs no credits and generates nothing
+func (c *openrouterClient) CheckHealth(ctx context.Context) error {
+	apiURL := fmt.Sprintf("%s/key", c.apiEndpoint)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return CreateAPIError(
+			llm.CategoryNetwork,
+			"Failed to create HTTP request to OpenRouter API",
+			err,
+			fmt.Sprintf("Request creation error: %v", err),
+		)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	req.Header.Set("HTTP-Referer", "https://github.com/misty-step/thinktank")
+	req.Header.Set("X-Title", "thinktank")
+
+	if c.logger != nil {
+		c.logger.Debug("Checking OpenRouter API health: %s", sanitizeURLBasic(apiURL))
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		category := llm.CategoryNetwork
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			category = llm.CategoryCancelled
+		}
+		return CreateAPIError(
+			category,
+			"Failed to connect to OpenRouter API",
+			err,
+			fmt.Sprintf("HTTP error: %v", err),
+		)
+	}
+	defer c.closeBody(resp)
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return CreateAPIError(
+			llm.CategoryNetwork,
+			"Failed to read response from OpenRouter API",
+			err,
+			fmt.Sprintf("Response read error: %v", err),
+		)
+	}
+	return FormatAPIErrorFromResponse(
+		fmt.Errorf("OpenRouter API returned non-200 status code: %d", resp.StatusCode),
+		resp.StatusCode,
+		body,
+	)
+}
+
+// Compile-time check that the client supports preflight health checks
+var _ llm.HealthChecker = (*openrouterClient)(nil)
@@ -0,0 +1,64 @@
+package openrouter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/misty-step/thinktank/internal/llm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckHealth(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantCategory llm.ErrorCategory // CategoryUnknown means no error
+	}{
+		{name: "key accepted", status: http.StatusOK, body: `{"data":{"label":"sk-or-v1-abc"}}`},
+		{name: "key rejected", status: http.StatusUnauthorized, body: `{"error":{"message":"No auth credentials found"}}`, wantCategory: llm.CategoryAuth},
+		{name: "provider down", status: http.StatusServiceUnavailable, body: `{"error":{"message":"unavailable"}}`, wantCategory: llm.CategoryServer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "GET", r.Method)
+				assert.Equal(t, "/key", r.URL.Path)
+				assert.Equal(t, "Bearer sk-or-test-key", r.Header.Get("Authorization"))
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client, err := NewClient("sk-or-test-key", "openai/gpt-5.2", server.URL, nil)
+			require.NoError(t, err)
+
+			err = client.CheckHealth(context.Background())
+			if tt.wantCategory == llm.CategoryUnknown {
+				assert.NoError(t, err)
+				return
+			}
+			catErr, ok := llm.IsCategorizedError(err)
+			require.True(t, ok, "expected a categorized error, got %v", err)
+			assert.Equal(t, tt.wantCategory, catErr.Category())
+		})
+	}
+}
+
+func TestCheckHealth_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	endpoint := server.URL
+	server.Close()
+
+	client, err := NewClient("sk-or-test-key", "openai/gpt-5.2", endpoint, nil)
+	require.NoError(t, err)
+
+	err = client.CheckHealth(context.Background())
+	catErr, ok := llm.IsCategorizedError(err)
+	require.True(t, ok, "expected a categorized error, got %v", err)
+	assert.Equal(t, llm.CategoryNetwork, catErr.Category())
+}
@@ -4,7 +4,10 @@ package ratelimit
 import (
 	"context"
 	"errors"
+	"math"
+	"math/rand"
 	"sync"
+	"time"
 
 	"golang.org/x/time/rate"
 )
@@ -69,6 +72,7 @@ type TokenBucket struct {
 	ratePerMin int
 	limit      rate.Limit
 	burst      int
+	maxJitter  time.Duration // Upper bound on the random delay added after each grant (0 = none)
 }
 
 // NewTokenBucket creates a new token bucket rate limiter
@@ -94,6 +98,17 @@ func NewTokenBucket(ratePerMin, maxBurst int) *TokenBucket {
 	}
 }
 
+// NewTokenBucketWithJitter creates a token bucket that waits a random delay of up to
+// maxJitter after granting each token, so callers unblocked at the same instant
+// don't send a synchronized burst. If ratePerMin is <= 0, returns nil (no limiting).
+func NewTokenBucketWithJitter(ratePerMin, maxBurst int, maxJitter time.Duration) *TokenBucket {
+	tb := NewTokenBucket(ratePerMin, maxBurst)
+	if tb != nil && maxJitter > 0 {
+		tb.maxJitter = maxJitter
+	}
+	return tb
+}
+
 // getLimiter returns the rate limiter for a specific model, creating it if needed
 func (tb *TokenBucket) getLimiter(modelName string) *rate.Limiter {
 	// For no limit case
@@ -124,6 +139,24 @@ func (tb *TokenBucket) getLimiter(modelName string) *rate.Limiter {
 	return limiter
 }
 
+// scaleLimit multiplies the limit for key by factor, clamped to [minPerMin, maxPerMin]
+// requests per minute, and returns the new limit in requests per minute.
+// A maxPerMin <= 0 caps the limit at the bucket's configured rate.
+func (tb *TokenBucket) scaleLimit(key string, factor float64, minPerMin, maxPerMin int, now time.Time) float64 {
+	if tb == nil {
+		return 0 // No limiting, nothing to adjust
+	}
+	if maxPerMin <= 0 {
+		maxPerMin = tb.ratePerMin
+	}
+
+	limiter := tb.getLimiter(key)
+	perMin := float64(limiter.Limit()) * 60 * factor
+	perMin = math.Min(math.Max(perMin, float64(minPerMin)), float64(maxPerMin))
+	limiter.SetLimitAt(now, rate.Limit(perMin/60))
+	return perMin
+}
+
 // Acquire waits to acquire a token, returns error if context canceled
 func (tb *TokenBucket) Acquire(ctx context.Context, modelName string) error {
 	if tb == nil {
@@ -131,13 +164,34 @@ func (tb *TokenBucket) Acquire(ctx context.Context, modelName string) error {
 	}
 
 	limiter := tb.getLimiter(modelName)
-	if limiter.Allow() {
-		// Fast path - if we can get a token without waiting
+	if !limiter.Allow() {
+		// Slow path - wait for a token to become available
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	return tb.waitJitter(ctx)
+}
+
+// waitJitter sleeps for a random duration in [0, maxJitter), returning early if ctx is canceled
+func (tb *TokenBucket) waitJitter(ctx context.Context) error {
+	if tb.maxJitter <= 0 {
+		return nil
+	}
+	delay := time.Duration(rand.Int63n(int64(tb.maxJitter)))
+	if delay <= 0 {
 		return nil
 	}
 
-	// Slow path - wait for a token to become available
-	return limiter.Wait(ctx)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // RateLimiter combines semaphore and token bucket limiters
@@ -154,6 +208,47 @@ func (tb *TokenBucket) Acquire(ctx context.Context, modelName string) error {
 type RateLimiter struct {
 	semaphore   *Semaphore
 	tokenBucket *TokenBucket
+
+	// Independent per-provider limits, shared by all of a provider's models.
+	// Providers without an entry fall back to the per-model tokenBucket.
+	providerBuckets map[string]*TokenBucket
+	providerMutex   sync.RWMutex
+
+	// Models that may not acquire until the given time, e.g. after a Retry-After
+	pausedUntil map[string]time.Time
+	pauseMutex  sync.RWMutex
+
+	// Optional controller that tunes limits from reported results (nil = static limits)
+	adaptive       *AdaptiveConfig
+	modelProviders map[string]string // Provider bucket each model acquired through
+	adaptiveMutex  sync.Mutex
+}
+
+// AdaptiveConfig configures an AIMD-style controller that cuts a limit sharply when
+// requests are rate limited and recovers it slowly as requests succeed.
+type AdaptiveConfig struct {
+	MinRatePerMin  int              // Floor for the adjusted rate (0 = 1 request per minute)
+	MaxRatePerMin  int              // Ceiling for the adjusted rate (0 = the bucket's configured rate)
+	DecreaseFactor float64          // Multiplier after a rate limited result, in (0, 1) (0 = 0.5)
+	IncreaseFactor float64          // Multiplier after any other result, above 1 (0 = 1.05)
+	Clock          func() time.Time // Time source for limit changes (nil = time.Now)
+}
+
+// withDefaults fills in unset or out-of-range fields
+func (c AdaptiveConfig) withDefaults() AdaptiveConfig {
+	if c.MinRatePerMin <= 0 {
+		c.MinRatePerMin = 1
+	}
+	if c.DecreaseFactor <= 0 || c.DecreaseFactor >= 1 {
+		c.DecreaseFactor = 0.5
+	}
+	if c.IncreaseFactor <= 1 {
+		c.IncreaseFactor = 1.05
+	}
+	if c.Clock == nil {
+		c.Clock = time.Now
+	}
+	return c
 }
 
 // NewRateLimiter creates a new combined rate limiter
@@ -165,8 +260,48 @@ func NewRateLimiter(maxConcurrent, ratePerMin int) *RateLimiter {
 	}
 }
 
+// NewAdaptiveRateLimiter creates a combined rate limiter whose token bucket limits
+// adapt to the results reported through RecordResult, within the bounds of adaptive.
+func NewAdaptiveRateLimiter(maxConcurrent, ratePerMin int, adaptive AdaptiveConfig) *RateLimiter {
+	rl := NewRateLimiter(maxConcurrent, ratePerMin)
+	adaptive = adaptive.withDefaults()
+	rl.adaptive = &adaptive
+	return rl
+}
+
+// RecordResult reports the outcome of a request for modelName. On an adaptive limiter,
+// a rate limited result lowers the limit the model acquired through (its provider's
+// bucket if it has one) and any other result slowly raises it again.
+// Returns the new limit in requests per minute, or 0 if no limit was adjusted.
+func (rl *RateLimiter) RecordResult(modelName string, rateLimited bool) float64 {
+	if rl.adaptive == nil {
+		return 0 // Static limits
+	}
+
+	factor := rl.adaptive.IncreaseFactor
+	if rateLimited {
+		factor = rl.adaptive.DecreaseFactor
+	}
+
+	rl.adaptiveMutex.Lock()
+	defer rl.adaptiveMutex.Unlock()
+
+	bucket, key := rl.tokenBucket, modelName
+	if provider, ok := rl.modelProviders[modelName]; ok {
+		rl.providerMutex.RLock()
+		bucket, key = rl.providerBuckets[provider], provider
+		rl.providerMutex.RUnlock()
+	}
+	return bucket.scaleLimit(key, factor, rl.adaptive.MinRatePerMin, rl.adaptive.MaxRatePerMin, rl.adaptive.Clock())
+}
+
 // Acquire waits to acquire both semaphore and rate limit permissions
 func (rl *RateLimiter) Acquire(ctx context.Context, modelName string) error {
+	// A paused model waits out the pause without holding a semaphore ticket
+	if err := rl.waitForPause(ctx, modelName); err != nil {
+		return err
+	}
+
 	// First try to acquire the semaphore
 	if err := rl.semaphore.Acquire(ctx); err != nil {
 		return err
@@ -181,6 +316,87 @@ func (rl *RateLimiter) Acquire(ctx context.Context, modelName string) error {
 	return nil
 }
 
+// SetProviderLimit gives provider its own token bucket, shared by all of its models,
+// so that one provider's limit never throttles another's. A ratePerMin <= 0 leaves
+// the provider unlimited.
+func (rl *RateLimiter) SetProviderLimit(provider string, ratePerMin int) {
+	rl.providerMutex.Lock()
+	defer rl.providerMutex.Unlock()
+	if rl.providerBuckets == nil {
+		rl.providerBuckets = make(map[string]*TokenBucket)
+	}
+	rl.providerBuckets[provider] = NewTokenBucket(ratePerMin, 1)
+}
+
+// AcquireForProvider waits to acquire the semaphore and the provider's rate limit.
+// Falls back to the per-model limit when no limit was set for provider.
+func (rl *RateLimiter) AcquireForProvider(ctx context.Context, provider, modelName string) error {
+	rl.providerMutex.RLock()
+	bucket, ok := rl.providerBuckets[provider]
+	rl.providerMutex.RUnlock()
+	if !ok {
+		return rl.Acquire(ctx, modelName)
+	}
+	if rl.adaptive != nil {
+		rl.adaptiveMutex.Lock()
+		if rl.modelProviders == nil {
+			rl.modelProviders = make(map[string]string)
+		}
+		rl.modelProviders[modelName] = provider
+		rl.adaptiveMutex.Unlock()
+	}
+
+	if err := rl.waitForPause(ctx, modelName); err != nil {
+		return err
+	}
+	if err := rl.semaphore.Acquire(ctx); err != nil {
+		return err
+	}
+	if err := bucket.Acquire(ctx, provider); err != nil {
+		rl.semaphore.Release()
+		return err
+	}
+	return nil
+}
+
+// PauseModel blocks Acquire calls for modelName until d has elapsed, typically
+// because the provider returned a Retry-After. Overlapping pauses extend to the later deadline.
+func (rl *RateLimiter) PauseModel(modelName string, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	until := time.Now().Add(d)
+
+	rl.pauseMutex.Lock()
+	defer rl.pauseMutex.Unlock()
+	if rl.pausedUntil == nil {
+		rl.pausedUntil = make(map[string]time.Time)
+	}
+	if until.After(rl.pausedUntil[modelName]) {
+		rl.pausedUntil[modelName] = until
+	}
+}
+
+// waitForPause blocks until any pause on modelName has elapsed
+func (rl *RateLimiter) waitForPause(ctx context.Context, modelName string) error {
+	rl.pauseMutex.RLock()
+	until := rl.pausedUntil[modelName]
+	rl.pauseMutex.RUnlock()
+
+	wait := time.Until(until)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ErrContextCanceled
+	}
+}
+
 // Release releases the semaphore (token bucket doesn't need explicit release)
 func (rl *RateLimiter) Release() {
 	rl.semaphore.Release()
@@ -209,6 +209,28 @@ func TestTokenBucket(t *testing.T) {
 		err = tb.Acquire(ctx, "test-model")
 		assert.Error(t, err, "Acquire with canceled context should fail")
 	})
+
+	t.Run("Jitter", func(t *testing.T) {
+		// Zero jitter behaves exactly like NewTokenBucket
+		assert.Nil(t, NewTokenBucketWithJitter(0, 1, time.Second), "Zero rate should still disable limiting")
+		assert.Equal(t, time.Duration(0), NewTokenBucketWithJitter(60, 1, 0).maxJitter)
+
+		// Every grant waits less than maxJitter
+		tb := NewTokenBucketWithJitter(6000, 10, 20*time.Millisecond)
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			assert.NoError(t, tb.Acquire(context.Background(), "test-model"))
+		}
+		assert.Less(t, time.Since(start), 5*20*time.Millisecond+50*time.Millisecond)
+
+		// The jitter sleep is abandoned when the context is canceled
+		slow := NewTokenBucketWithJitter(6000, 10, time.Hour)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		start = time.Now()
+		assert.ErrorIs(t, slow.Acquire(ctx, "test-model"), context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), time.Second)
+	})
 }
 
 // TestRateLimiterCoverage provides comprehensive coverage testing for uncovered code paths
@@ -398,6 +420,117 @@ func TestRateLimiter(t *testing.T) {
 		// Release should not cause any issues
 		limiter.Release()
 	})
+
+	t.Run("Per-Provider Buckets", func(t *testing.T) {
+		limiter := NewRateLimiter(10, 6000)
+		limiter.SetProviderLimit("slow", 60) // Burst of 1, then one per second
+		limiter.SetProviderLimit("fast", 6000)
+
+		assert.NoError(t, limiter.AcquireForProvider(context.Background(), "slow", "slow-a"))
+
+		// Models of the same provider share its bucket
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.Error(t, limiter.AcquireForProvider(ctx, "slow", "slow-b"),
+			"second model of a throttled provider should wait")
+
+		// Other providers are limited independently
+		assert.NoError(t, limiter.AcquireForProvider(context.Background(), "fast", "fast-a"))
+
+		// Providers without a limit fall back to per-model buckets
+		assert.NoError(t, limiter.AcquireForProvider(context.Background(), "other", "other-a"))
+
+		limiter.Release()
+		limiter.Release()
+		limiter.Release()
+	})
+
+	t.Run("Paused Model Waits", func(t *testing.T) {
+		limiter := NewRateLimiter(1, 0)
+		limiter.PauseModel("paused", 80*time.Millisecond)
+
+		// A pause shorter than the deadline elapses before the acquire succeeds
+		start := time.Now()
+		assert.NoError(t, limiter.Acquire(context.Background(), "paused"))
+		assert.GreaterOrEqual(t, time.Since(start), 70*time.Millisecond)
+		limiter.Release()
+
+		// Waiting out a pause holds no semaphore ticket, so other models proceed
+		limiter.PauseModel("paused", time.Minute)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+		assert.Error(t, limiter.AcquireForProvider(ctx, "any", "paused"))
+		assert.NoError(t, limiter.Acquire(context.Background(), "other"))
+		limiter.Release()
+
+		// Shorter pauses never cut an existing one short
+		limiter.PauseModel("paused", time.Millisecond)
+		ctx2, cancel2 := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel2()
+		assert.ErrorIs(t, limiter.Acquire(ctx2, "paused"), ErrContextCanceled)
+	})
+
+	t.Run("Per-Provider Failure Releases Semaphore", func(t *testing.T) {
+		limiter := NewRateLimiter(1, 0)
+		limiter.SetProviderLimit("slow", 60)
+
+		assert.NoError(t, limiter.AcquireForProvider(context.Background(), "slow", "model"))
+		limiter.Release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.Error(t, limiter.AcquireForProvider(ctx, "slow", "model"))
+
+		// The semaphore ticket taken before the rate limit wait must have been returned
+		assert.NoError(t, limiter.Acquire(context.Background(), "unlimited"))
+		limiter.Release()
+	})
+
+	t.Run("Adaptive Limits", func(t *testing.T) {
+		fixed := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		limiter := NewAdaptiveRateLimiter(0, 600, AdaptiveConfig{
+			MinRatePerMin:  100,
+			DecreaseFactor: 0.5,
+			IncreaseFactor: 1.5,
+			Clock:          func() time.Time { return fixed },
+		})
+
+		// Rate limited results halve the rate down to the floor
+		assert.InDelta(t, 300, limiter.RecordResult("model", true), 0.001)
+		assert.InDelta(t, 150, limiter.RecordResult("model", true), 0.001)
+		assert.InDelta(t, 100, limiter.RecordResult("model", true), 0.001)
+
+		// Successes recover the rate up to the configured ceiling
+		assert.InDelta(t, 150, limiter.RecordResult("model", false), 0.001)
+		assert.InDelta(t, 225, limiter.RecordResult("model", false), 0.001)
+		for i := 0; i < 10; i++ {
+			limiter.RecordResult("model", false)
+		}
+		assert.InDelta(t, 600, limiter.RecordResult("model", false), 0.001)
+
+		// Limits of other models are untouched
+		assert.Equal(t, rate.Limit(10), limiter.tokenBucket.getLimiter("other").Limit())
+	})
+
+	t.Run("Adaptive Provider Limits", func(t *testing.T) {
+		limiter := NewAdaptiveRateLimiter(0, 0, AdaptiveConfig{})
+		limiter.SetProviderLimit("provider", 120)
+		assert.NoError(t, limiter.AcquireForProvider(context.Background(), "provider", "model-a"))
+
+		// Results adjust the shared provider bucket with the default factors
+		assert.InDelta(t, 60, limiter.RecordResult("model-a", true), 0.001)
+		assert.InDelta(t, 63, limiter.RecordResult("model-a", false), 0.001)
+		assert.InDelta(t, 63.0/60, float64(limiter.providerBuckets["provider"].getLimiter("provider").Limit()), 0.001)
+
+		// Unlimited models have nothing to adjust
+		assert.Zero(t, limiter.RecordResult("unlimited", true))
+	})
+
+	t.Run("Static Limits Ignore Results", func(t *testing.T) {
+		limiter := NewRateLimiter(0, 600)
+		assert.Zero(t, limiter.RecordResult("model", true))
+		assert.Equal(t, rate.Limit(10), limiter.tokenBucket.getLimiter("model").Limit())
+	})
 }
 
 // TestRateLimiterDeterministic provides mathematically precise testing of rate limiter behavior
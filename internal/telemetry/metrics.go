@@ -0,0 +1,69 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// SetupMetrics registers an SDK meter provider as the global provider. Metrics are
+// exported according to OTEL_METRICS_EXPORTER: "otlp" (the default) sends them to
+// an OpenTelemetry collector over the protocol otlpProtocol picks, "console" writes
+// them as JSON to w, and "none" registers nothing, leaving measurements as no-ops.
+// Either exporter runs every OTEL_METRIC_EXPORT_INTERVAL and at shutdown.
+//
+// The returned shutdown function exports the final values and must be called before exit.
+func SetupMetrics(ctx context.Context, getenv func(string) string, w io.Writer) (func(context.Context) error, error) {
+	var reader sdkmetric.Reader
+	switch exporter := strings.TrimSpace(getenv("OTEL_METRICS_EXPORTER")); exporter {
+	case "", "otlp":
+		otlpExporter, err := newOTLPMetricExporter(ctx, getenv)
+		if err != nil {
+			return nil, err
+		}
+		reader = sdkmetric.NewPeriodicReader(otlpExporter)
+	case "console":
+		consoleExporter, err := stdoutmetric.New(stdoutmetric.WithWriter(w))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create console metric exporter: %w", err)
+		}
+		reader = sdkmetric.NewPeriodicReader(consoleExporter)
+	case "none":
+		return func(context.Context) error { return nil }, nil
+	default:
+		return nil, fmt.Errorf("unsupported OTEL_METRICS_EXPORTER %q (supported: otlp, console, none)", exporter)
+	}
+
+	res, err := newResource(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build metric resource: %w", err)
+	}
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithResource(res), sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// newOTLPMetricExporter creates the OTLP metric exporter for the configured protocol
+func newOTLPMetricExporter(ctx context.Context, getenv func(string) string) (sdkmetric.Exporter, error) {
+	protocol, err := otlpProtocol(getenv, "OTEL_EXPORTER_OTLP_METRICS_PROTOCOL")
+	if err != nil {
+		return nil, err
+	}
+	var exporter sdkmetric.Exporter
+	if protocol == otlpProtocolGRPC {
+		exporter, err = otlpmetricgrpc.New(ctx)
+	} else {
+		exporter, err = otlpmetrichttp.New(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+	}
+	return exporter, nil
+}
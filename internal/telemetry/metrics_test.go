@@ -0,0 +1,71 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetupMetrics(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        map[string]string
+		wantOutput bool
+		wantErr    string
+	}{
+		{name: "console", env: map[string]string{"OTEL_METRICS_EXPORTER": "console"}, wantOutput: true},
+		{name: "none", env: map[string]string{"OTEL_METRICS_EXPORTER": "none"}},
+		{name: "unsupported", env: map[string]string{"OTEL_METRICS_EXPORTER": "prometheus"}, wantErr: `unsupported OTEL_METRICS_EXPORTER "prometheus"`},
+		{name: "unsupported protocol", env: map[string]string{"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL": "http/json"}, wantErr: `unsupported OTEL_EXPORTER_OTLP_METRICS_PROTOCOL "http/json"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := otel.GetMeterProvider()
+			t.Cleanup(func() { otel.SetMeterProvider(previous) })
+
+			var buf bytes.Buffer
+			shutdown, err := SetupMetrics(context.Background(), withEnv(tt.env), &buf)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			counter, err := otel.Meter("test").Int64Counter("thinktank.models")
+			require.NoError(t, err)
+			counter.Add(context.Background(), 1)
+			require.NoError(t, shutdown(context.Background()))
+
+			if tt.wantOutput {
+				assert.Contains(t, buf.String(), `"Name":"thinktank.models"`)
+			} else {
+				assert.Empty(t, buf.String())
+			}
+		})
+	}
+}
+
+func TestSetupMetricsOTLP(t *testing.T) {
+	previous := otel.GetMeterProvider()
+	t.Cleanup(func() { otel.SetMeterProvider(previous) })
+	requests := otlpCollector(t, "/v1/metrics")
+
+	// OTLP over HTTP is the default, and nothing is written to the console
+	var buf bytes.Buffer
+	shutdown, err := SetupMetrics(context.Background(), withEnv(map[string]string{}), &buf)
+	require.NoError(t, err)
+
+	counter, err := otel.Meter("test").Int64Counter("thinktank.models")
+	require.NoError(t, err)
+	counter.Add(context.Background(), 1)
+	require.NoError(t, shutdown(context.Background()))
+
+	assert.Equal(t, int32(1), requests.Load())
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,117 @@
+// Package telemetry sets up optional OpenTelemetry tracing and metrics for a thinktank run.
+//
+// Instrumented code creates spans and records measurements through the global
+// OpenTelemetry API, which is a no-op until SetupTracing or SetupMetrics registers
+// a provider.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+)
+
+// defaultServiceName identifies thinktank's spans unless OTEL_SERVICE_NAME says otherwise
+const defaultServiceName = "thinktank"
+
+// SetupTracing registers an SDK tracer provider as the global provider. Spans are
+// exported according to OTEL_TRACES_EXPORTER: "otlp" (the default) sends them to
+// an OpenTelemetry collector over the protocol otlpProtocol picks, "console"
+// writes them as JSON to w, and "none" records them without exporting. The SDK and
+// the OTLP exporters read the other standard variables themselves, such as
+// OTEL_SERVICE_NAME, OTEL_RESOURCE_ATTRIBUTES, OTEL_TRACES_SAMPLER, OTEL_BSP_*, and
+// OTEL_EXPORTER_OTLP_ENDPOINT.
+//
+// The returned shutdown function flushes pending spans and must be called before exit.
+func SetupTracing(ctx context.Context, getenv func(string) string, w io.Writer) (func(context.Context) error, error) {
+	res, err := newResource(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+	options := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+
+	switch exporter := strings.TrimSpace(getenv("OTEL_TRACES_EXPORTER")); exporter {
+	case "", "otlp":
+		otlpExporter, err := newOTLPTraceExporter(ctx, getenv)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(otlpExporter))
+	case "console":
+		consoleExporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create console trace exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(consoleExporter))
+	case "none":
+	default:
+		return nil, fmt.Errorf("unsupported OTEL_TRACES_EXPORTER %q (supported: otlp, console, none)", exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// newOTLPTraceExporter creates the OTLP span exporter for the configured protocol
+func newOTLPTraceExporter(ctx context.Context, getenv func(string) string) (sdktrace.SpanExporter, error) {
+	protocol, err := otlpProtocol(getenv, "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+	if err != nil {
+		return nil, err
+	}
+	var exporter sdktrace.SpanExporter
+	if protocol == otlpProtocolGRPC {
+		exporter, err = otlptracegrpc.New(ctx)
+	} else {
+		exporter, err = otlptracehttp.New(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+	return exporter, nil
+}
+
+// OTLP transport protocols, as named by OTEL_EXPORTER_OTLP_PROTOCOL
+const (
+	otlpProtocolGRPC = "grpc"
+	otlpProtocolHTTP = "http/protobuf"
+)
+
+// otlpProtocol returns the OTLP protocol for a signal: its own variable (such as
+// OTEL_EXPORTER_OTLP_TRACES_PROTOCOL) wins over OTEL_EXPORTER_OTLP_PROTOCOL, and
+// http/protobuf is the default, as the OpenTelemetry specification recommends
+func otlpProtocol(getenv func(string) string, signalVar string) (string, error) {
+	name := signalVar
+	protocol := strings.TrimSpace(getenv(signalVar))
+	if protocol == "" {
+		name = "OTEL_EXPORTER_OTLP_PROTOCOL"
+		protocol = strings.TrimSpace(getenv(name))
+	}
+	switch protocol {
+	case "", otlpProtocolHTTP:
+		return otlpProtocolHTTP, nil
+	case otlpProtocolGRPC:
+		return otlpProtocolGRPC, nil
+	default:
+		return "", fmt.Errorf("unsupported %s %q (supported: %s, %s)", name, protocol, otlpProtocolGRPC, otlpProtocolHTTP)
+	}
+}
+
+// newResource describes this process for exported telemetry. Later options win,
+// so OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the default name.
+func newResource(ctx context.Context) (*resource.Resource, error) {
+	return resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(defaultServiceName)),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+}
@@ -0,0 +1,131 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+// withEnv returns a getenv function backed by vars
+func withEnv(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func TestSetupTracing(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        map[string]string
+		wantOutput bool
+		wantErr    string
+	}{
+		{name: "console", env: map[string]string{"OTEL_TRACES_EXPORTER": "console"}, wantOutput: true},
+		{name: "none", env: map[string]string{"OTEL_TRACES_EXPORTER": "none"}},
+		{name: "unsupported", env: map[string]string{"OTEL_TRACES_EXPORTER": "zipkin"}, wantErr: `unsupported OTEL_TRACES_EXPORTER "zipkin"`},
+		{name: "unsupported protocol", env: map[string]string{"OTEL_EXPORTER_OTLP_PROTOCOL": "http/json"}, wantErr: `unsupported OTEL_EXPORTER_OTLP_PROTOCOL "http/json"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := otel.GetTracerProvider()
+			t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+			var buf bytes.Buffer
+			shutdown, err := SetupTracing(context.Background(), withEnv(tt.env), &buf)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			_, span := otel.Tracer("test").Start(context.Background(), "thinktank.run")
+			span.End()
+			require.NoError(t, shutdown(context.Background()))
+
+			if tt.wantOutput {
+				assert.Contains(t, buf.String(), `"Name":"thinktank.run"`)
+				assert.Contains(t, buf.String(), defaultServiceName)
+			} else {
+				assert.Empty(t, buf.String())
+			}
+		})
+	}
+}
+
+// otlpCollector starts an HTTP server that counts OTLP export requests to path and
+// points the OTLP exporters at it
+func otlpCollector(t *testing.T, path string) *atomic.Int32 {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == path {
+			requests.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", server.URL)
+	return &requests
+}
+
+func TestSetupTracingOTLP(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	requests := otlpCollector(t, "/v1/traces")
+
+	// OTLP over HTTP is the default, and nothing is written to the console
+	var buf bytes.Buffer
+	shutdown, err := SetupTracing(context.Background(), withEnv(map[string]string{}), &buf)
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "thinktank.run")
+	span.End()
+	require.NoError(t, shutdown(context.Background()))
+
+	assert.Equal(t, int32(1), requests.Load())
+	assert.Empty(t, buf.String())
+}
+
+func TestOTLPProtocol(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    string
+		wantErr string
+	}{
+		{name: "http by default", env: map[string]string{}, want: "http/protobuf"},
+		{name: "grpc", env: map[string]string{"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc"}, want: "grpc"},
+		{name: "signal variable wins", env: map[string]string{"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc", "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": "http/protobuf"}, want: "http/protobuf"},
+		{name: "unsupported", env: map[string]string{"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": "http/json"}, wantErr: `unsupported OTEL_EXPORTER_OTLP_TRACES_PROTOCOL "http/json"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := otlpProtocol(withEnv(tt.env), "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetupTracingOTLPGRPC(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	// The gRPC connection is made lazily, so setup succeeds without a collector
+	shutdown, err := SetupTracing(context.Background(), withEnv(map[string]string{"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc"}), &bytes.Buffer{})
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+}
@@ -379,6 +379,11 @@ func (m *MockLogger) Log(ctx context.Context, entry auditlog.AuditEntry) error {
 	return nil
 }
 
+// LogContext delegates to Log
+func (m *MockLogger) LogContext(ctx context.Context, entry auditlog.AuditEntry) error {
+	return m.Log(ctx, entry)
+}
+
 // LogLegacy implements the backward-compatible AuditLogger.LogLegacy method
 func (m *MockLogger) LogLegacy(entry auditlog.AuditEntry) error {
 	return m.Log(context.Background(), entry)
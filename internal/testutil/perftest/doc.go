@@ -38,8 +38,16 @@
 //	        cache.Store("key", "value")
 //	        cache.Delete("key")
 //	    })
+//
+//	    // Cap the average allocation per call (memory multiplier applies in CI)
+//	    perftest.AssertMaxAllocBytes(t, "CacheLookup", 1000, 256, func() {
+//	        cache.Load("key")
+//	    })
 //	}
 //
+// AssertMaxAllocBytes counts allocations from every goroutine, so avoid it in
+// tests that call t.Parallel.
+//
 // ## 3. CI-Aware Benchmarks
 //
 //	func BenchmarkOperation(b *testing.B) {
@@ -74,6 +82,21 @@
 //	    })
 //	}
 //
+// ## 5. Regression Gates
+//
+//	func TestParseBudget(t *testing.T) {
+//	    m := perftest.MeasureThroughput(t, "Parse", parseLargeFile)
+//
+//	    // Fail if more than 15% slower than the stored baseline (CI-adjusted);
+//	    // the first run records the baseline instead
+//	    perftest.AssertNoRegression(t, "Parse", perftest.Measurement{
+//	        Duration:       m.Duration,
+//	        BytesPerSecond: m.BytesPerSecond,
+//	    }, "testdata/perf-baselines.json", 15)
+//	}
+//
+// Delete an entry from the baseline file to re-record it after an intended change.
+//
 // # Environment Detection
 //
 // The framework detects the following environments:
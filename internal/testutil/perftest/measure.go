@@ -131,6 +131,39 @@ func AssertConstantMemory(t *testing.T, name string, iterations int, fn func())
 	}
 }
 
+// AssertMaxAllocBytes runs fn iterations times and fails if it allocates more than
+// maxBytesPerOp bytes per call on average, adjusted by the environment memory multiplier
+func AssertMaxAllocBytes(t testing.TB, name string, iterations int, maxBytesPerOp uint64, fn func()) {
+	t.Helper()
+
+	if iterations <= 0 {
+		t.Fatalf("%s: iterations must be positive, got %d", name, iterations)
+		return
+	}
+
+	cfg := NewConfig()
+
+	// Warm up so one-time initialization isn't counted
+	fn()
+
+	runtime.GC()
+	before := captureMemoryStats()
+	for i := 0; i < iterations; i++ {
+		fn()
+	}
+	after := captureMemoryStats()
+
+	bytesPerOp := (after.TotalAllocBytes - before.TotalAllocBytes) / uint64(iterations)
+	allocsPerOp := (after.NumAllocs - before.NumAllocs) / uint64(iterations)
+	allowed := uint64(cfg.AdjustMemory(int64(maxBytesPerOp)))
+
+	t.Logf("%s: %d B/op, %d allocs/op over %d iterations", name, bytesPerOp, allocsPerOp, iterations)
+	if bytesPerOp > allowed {
+		t.Errorf("%s allocated %d B/op (%d allocs/op), above the maximum %d B/op (%d B/op adjusted for %s environment)",
+			name, bytesPerOp, allocsPerOp, maxBytesPerOp, allowed, cfg.Environment.RunnerType)
+	}
+}
+
 // WithTimeout runs a test function with an environment-adjusted timeout
 func WithTimeout(t *testing.T, baseTimeout time.Duration, fn func()) {
 	t.Helper()
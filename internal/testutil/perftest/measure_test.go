@@ -0,0 +1,47 @@
+package perftest
+
+import (
+	"strings"
+	"testing"
+)
+
+// allocSink keeps test allocations from being optimized away
+var allocSink []byte
+
+func TestAssertMaxAllocBytes(t *testing.T) {
+	t.Run("within budget", func(t *testing.T) {
+		rec := &recordingTB{TB: t}
+		sum := 0
+		AssertMaxAllocBytes(rec, "NoAlloc", 100, 1024, func() {
+			for i := 0; i < 10; i++ {
+				sum += i
+			}
+		})
+		if len(rec.errors) != 0 {
+			t.Errorf("errors = %q, want none", rec.errors)
+		}
+	})
+
+	t.Run("over budget reports measurements", func(t *testing.T) {
+		rec := &recordingTB{TB: t}
+		AssertMaxAllocBytes(rec, "Alloc4K", 100, 64, func() {
+			allocSink = make([]byte, 4096)
+		})
+		if len(rec.errors) != 1 {
+			t.Fatalf("errors = %q, want one failure", rec.errors)
+		}
+		for _, want := range []string{"Alloc4K allocated", "B/op", "allocs/op", "maximum 64 B/op"} {
+			if !strings.Contains(rec.errors[0], want) {
+				t.Errorf("error %q does not contain %q", rec.errors[0], want)
+			}
+		}
+	})
+
+	t.Run("invalid iterations", func(t *testing.T) {
+		rec := &recordingTB{TB: t}
+		AssertMaxAllocBytes(rec, "Invalid", 0, 64, func() {})
+		if !rec.fatal {
+			t.Error("expected a fatal error for zero iterations")
+		}
+	})
+}
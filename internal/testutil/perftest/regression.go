@@ -0,0 +1,126 @@
+package perftest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Measurement is a performance result that can be stored as a baseline.
+// Zero fields were not measured and are not compared.
+type Measurement struct {
+	Duration       time.Duration `json:"duration_ns,omitempty"`
+	BytesPerSecond float64       `json:"bytes_per_second,omitempty"`
+	AllocBytes     uint64        `json:"alloc_bytes,omitempty"`
+}
+
+// baselineMu serializes baseline file updates from parallel tests
+var baselineMu sync.Mutex
+
+// AssertNoRegression fails the test when current is worse than the baseline
+// stored under name in baselineFile by more than maxRegressionPct percent. The
+// allowance is widened by the environment multipliers, as other assertions are.
+// A missing baseline is recorded from current and the test passes, so the first
+// run bootstraps the file.
+func AssertNoRegression(t testing.TB, name string, current Measurement, baselineFile string, maxRegressionPct float64) {
+	t.Helper()
+
+	baselineMu.Lock()
+	defer baselineMu.Unlock()
+
+	baselines, err := loadBaselines(baselineFile)
+	if err != nil {
+		t.Fatalf("Failed to load performance baselines: %v", err)
+		return
+	}
+
+	baseline, ok := baselines[name]
+	if !ok {
+		baselines[name] = current
+		if err := saveBaselines(baselineFile, baselines); err != nil {
+			t.Fatalf("Failed to record performance baseline for %s: %v", name, err)
+			return
+		}
+		t.Logf("No baseline for %s; recorded current measurement in %s", name, baselineFile)
+		return
+	}
+
+	cfg := NewConfig()
+	if problems := regressions(current, baseline, maxRegressionPct, cfg); len(problems) > 0 {
+		t.Errorf("Performance regression in %s (allowed %.1f%%, adjusted for %s environment):\n  %s",
+			name, maxRegressionPct, cfg.Environment.RunnerType, strings.Join(problems, "\n  "))
+	}
+}
+
+// regressions describes each measured field of current that is worse than
+// baseline by more than maxPct percent after the environment multipliers
+func regressions(current, baseline Measurement, maxPct float64, cfg *Config) []string {
+	var problems []string
+	allowance := 1 + maxPct/100
+
+	if current.Duration > 0 && baseline.Duration > 0 {
+		limit := time.Duration(float64(baseline.Duration) * allowance * cfg.TimeoutMultiplier)
+		if current.Duration > limit {
+			problems = append(problems, fmt.Sprintf("duration: %v, baseline %v (%+.1f%%), limit %v",
+				current.Duration, baseline.Duration, percentChange(float64(current.Duration), float64(baseline.Duration)), limit))
+		}
+	}
+
+	if current.BytesPerSecond > 0 && baseline.BytesPerSecond > 0 {
+		limit := baseline.BytesPerSecond / allowance * cfg.ThroughputMultiplier
+		if current.BytesPerSecond < limit {
+			problems = append(problems, fmt.Sprintf("throughput: %.2f KB/s, baseline %.2f KB/s (%+.1f%%), limit %.2f KB/s",
+				current.BytesPerSecond/1024, baseline.BytesPerSecond/1024, percentChange(current.BytesPerSecond, baseline.BytesPerSecond), limit/1024))
+		}
+	}
+
+	if current.AllocBytes > 0 && baseline.AllocBytes > 0 {
+		limit := uint64(float64(baseline.AllocBytes) * allowance * cfg.MemoryMultiplier)
+		if current.AllocBytes > limit {
+			problems = append(problems, fmt.Sprintf("allocated: %s, baseline %s (%+.1f%%), limit %s",
+				formatBytes(current.AllocBytes), formatBytes(baseline.AllocBytes), percentChange(float64(current.AllocBytes), float64(baseline.AllocBytes)), formatBytes(limit)))
+		}
+	}
+
+	return problems
+}
+
+// percentChange returns how far current is from baseline, in percent
+func percentChange(current, baseline float64) float64 {
+	return (current - baseline) / baseline * 100
+}
+
+// loadBaselines reads the name-to-measurement map in file; a missing file is empty
+func loadBaselines(file string) (map[string]Measurement, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]Measurement{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	baselines := map[string]Measurement{}
+	if err := json.Unmarshal(data, &baselines); err != nil {
+		return nil, fmt.Errorf("invalid baseline file %s: %w", file, err)
+	}
+	return baselines, nil
+}
+
+// saveBaselines writes baselines to file as indented JSON, creating its directory
+func saveBaselines(file string, baselines map[string]Measurement) error {
+	data, err := json.MarshalIndent(baselines, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(data, '\n'), 0644)
+}
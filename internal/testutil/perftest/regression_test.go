@@ -0,0 +1,136 @@
+package perftest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordingTB captures failures so assertion helpers can be tested without failing
+type recordingTB struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Logf(format string, args ...interface{}) {}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingTB) Fatalf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+	r.fatal = true
+}
+
+func TestRegressions(t *testing.T) {
+	t.Parallel()
+	local := &Config{ThroughputMultiplier: 1, TimeoutMultiplier: 1, MemoryMultiplier: 1}
+	ci := &Config{ThroughputMultiplier: 0.5, TimeoutMultiplier: 2, MemoryMultiplier: 2}
+	baseline := Measurement{Duration: 100 * time.Millisecond, BytesPerSecond: 1000, AllocBytes: 1000}
+
+	tests := []struct {
+		name    string
+		current Measurement
+		cfg     *Config
+		want    []string
+	}{
+		{
+			name:    "within allowance",
+			current: Measurement{Duration: 110 * time.Millisecond, BytesPerSecond: 910, AllocBytes: 1100},
+			cfg:     local,
+		},
+		{
+			name:    "slower",
+			current: Measurement{Duration: 111 * time.Millisecond},
+			cfg:     local,
+			want:    []string{"duration: 111ms, baseline 100ms (+11.0%), limit 110ms"},
+		},
+		{
+			name:    "lower throughput",
+			current: Measurement{BytesPerSecond: 900},
+			cfg:     local,
+			want:    []string{"throughput:"},
+		},
+		{
+			name:    "more allocation",
+			current: Measurement{AllocBytes: 1200},
+			cfg:     local,
+			want:    []string{"allocated: 1.17 KB, baseline 1000 B (+20.0%)"},
+		},
+		{
+			name:    "environment multipliers widen the allowance",
+			current: Measurement{Duration: 200 * time.Millisecond, BytesPerSecond: 500, AllocBytes: 2000},
+			cfg:     ci,
+		},
+		{
+			name:    "unmeasured fields are ignored",
+			current: Measurement{},
+			cfg:     local,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := regressions(tt.current, baseline, 10, tt.cfg)
+			if len(got) != len(tt.want) {
+				t.Fatalf("regressions() = %q, want %d problems", got, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !strings.HasPrefix(got[i], want) {
+					t.Errorf("regressions()[%d] = %q, want prefix %q", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestAssertNoRegression(t *testing.T) {
+	t.Parallel()
+	baselineFile := filepath.Join(t.TempDir(), "perf", "baselines.json")
+
+	// The first run records the baseline and passes
+	first := &recordingTB{TB: t}
+	AssertNoRegression(first, "Parse", Measurement{Duration: time.Second}, baselineFile, 10)
+	if len(first.errors) != 0 {
+		t.Fatalf("bootstrap run failed: %v", first.errors)
+	}
+	if _, err := os.Stat(baselineFile); err != nil {
+		t.Fatalf("baseline file was not written: %v", err)
+	}
+
+	// A second measurement is recorded alongside the first
+	AssertNoRegression(&recordingTB{TB: t}, "Render", Measurement{AllocBytes: 1024}, baselineFile, 10)
+	baselines, err := loadBaselines(baselineFile)
+	if err != nil || len(baselines) != 2 {
+		t.Fatalf("baselines = %v, %v; want both measurements", baselines, err)
+	}
+
+	// A regression far beyond any environment multiplier fails with the details
+	regressed := &recordingTB{TB: t}
+	AssertNoRegression(regressed, "Parse", Measurement{Duration: time.Minute}, baselineFile, 10)
+	if len(regressed.errors) != 1 || !strings.Contains(regressed.errors[0], "Performance regression in Parse") ||
+		!strings.Contains(regressed.errors[0], "duration: 1m0s, baseline 1s") {
+		t.Errorf("errors = %q, want a Parse duration regression", regressed.errors)
+	}
+	if baselines, _ := loadBaselines(baselineFile); baselines["Parse"].Duration != time.Second {
+		t.Error("a failing run must not overwrite the baseline")
+	}
+
+	// A corrupt baseline file is a fatal error
+	if err := os.WriteFile(baselineFile, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	corrupt := &recordingTB{TB: t}
+	AssertNoRegression(corrupt, "Parse", Measurement{Duration: time.Second}, baselineFile, 10)
+	if !corrupt.fatal {
+		t.Errorf("errors = %q, want a fatal error for an invalid baseline file", corrupt.errors)
+	}
+}
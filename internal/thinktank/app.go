@@ -16,6 +16,7 @@ import (
 	"github.com/misty-step/thinktank/internal/config"
 	"github.com/misty-step/thinktank/internal/llm"
 	"github.com/misty-step/thinktank/internal/logutil"
+	"github.com/misty-step/thinktank/internal/models"
 	"github.com/misty-step/thinktank/internal/ratelimit"
 	"github.com/misty-step/thinktank/internal/thinktank/interfaces"
 	"github.com/misty-step/thinktank/internal/thinktank/orchestrator"
@@ -169,13 +170,28 @@ func generateOutput(
 	// Create context gatherer with LLMClient and ConsoleWriter
 	// Note: TokenManager was completely removed as part of tasks T032A through T032D
 	contextGatherer := NewContextGatherer(logger, consoleWriter, cliConfig.DryRun, referenceClientLLM, auditLogger)
-	fileWriter := NewFileWriter(logger, auditLogger, cliConfig.DirPermissions, cliConfig.FilePermissions)
+	fileWriter := NewFileWriterWithOptions(logger, auditLogger, FileWriterOptions{
+		DirPermissions:  cliConfig.DirPermissions,
+		FilePermissions: cliConfig.FilePermissions,
+		MaxFileSize:     cliConfig.MaxOutputFileSize,
+		NoOverwrite:     cliConfig.NoOverwrite,
+	})
 
 	// Create rate limiter from configuration
 	rateLimiter := ratelimit.NewRateLimiter(
 		cliConfig.MaxConcurrentRequests,
 		cliConfig.RateLimitRequestsPerMinute,
 	)
+	// Limit each provider independently where a limit is configured
+	for _, modelName := range cliConfig.ModelNames {
+		provider, err := models.GetProviderForModel(modelName)
+		if err != nil {
+			continue
+		}
+		if rpm := cliConfig.GetProviderRateLimit(provider); rpm > 0 {
+			rateLimiter.SetProviderLimit(provider, rpm)
+		}
+	}
 
 	// Create adapters for the interfaces
 	apiServiceAdapter := &APIServiceAdapter{APIService: apiService}
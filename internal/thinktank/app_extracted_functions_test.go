@@ -555,6 +555,7 @@ func (m *MockConsoleWriter) UpdateModelStatus(modelName string, status logutil.M
 func (m *MockConsoleWriter) UpdateModelRateLimited(modelName string, retryAfter time.Duration) {}
 func (m *MockConsoleWriter) RefreshStatusDisplay()                                             {}
 func (m *MockConsoleWriter) FinishStatusTracking()                                             {}
+func (m *MockConsoleWriter) StreamModelOutput(modelName string, content string)                {}
 func (m *MockConsoleWriter) ModelQueued(modelName string, index int)                           {}
 func (m *MockConsoleWriter) ModelStarted(modelIndex, totalModels int, modelName string)        {}
 func (m *MockConsoleWriter) ModelCompleted(modelIndex, totalModels int, modelName string, duration time.Duration) {
@@ -580,6 +581,7 @@ func (m *MockConsoleWriter) StatusMessage(message string)         {}
 // Control Methods
 func (m *MockConsoleWriter) SetQuiet(quiet bool)                 {}
 func (m *MockConsoleWriter) SetNoProgress(noProgress bool)       {}
+func (m *MockConsoleWriter) SetSilent(silent bool)               {}
 func (m *MockConsoleWriter) IsInteractive() bool                 { return false }
 func (m *MockConsoleWriter) GetTerminalWidth() int               { return 80 }
 func (m *MockConsoleWriter) FormatMessage(message string) string { return message }
@@ -3,6 +3,7 @@ package thinktank
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -59,6 +60,9 @@ func (cg *contextGatherer) GatherContext(ctx context.Context, config interfaces.
 	} else {
 		cg.logger.InfoContext(ctx, "Gathering project context from %d paths...", len(config.Paths))
 		cg.logger.DebugContext(ctx, "Include filters: %v", config.Include)
+		if len(config.IncludeGlobs) > 0 {
+			cg.logger.DebugContext(ctx, "Include globs: %v", config.IncludeGlobs)
+		}
 		cg.logger.DebugContext(ctx, "Exclude filters: %v", config.Exclude)
 		cg.logger.DebugContext(ctx, "Exclude names: %v", config.ExcludeNames)
 		cg.logger.DebugContext(ctx, "Paths being processed: %v", config.Paths)
@@ -66,6 +70,15 @@ func (cg *contextGatherer) GatherContext(ctx context.Context, config interfaces.
 
 	// Setup file processing configuration
 	fileConfig := fileutil.NewConfig(config.Verbose, config.Include, config.Exclude, config.ExcludeNames, config.Format, cg.logger)
+	fileConfig.NormalizeLineEndings = config.NormalizeLineEndings
+	fileConfig.IncludeGlobs = config.IncludeGlobs
+	fileConfig.PriorityGlobs = config.PriorityGlobs
+	fileConfig.Workers = config.Workers
+	fileConfig.MaxFileSizeBytes = config.MaxFileSizeBytes
+	fileConfig.FollowSymlinks = config.FollowSymlinks
+	fileConfig.ExcludeContentPatterns = config.ExcludeContentPatterns
+	fileConfig.IncludeHidden = config.IncludeHidden
+	fileConfig.RecordSkips = config.ExplainExcludes
 
 	// Initialize ContextStats
 	stats := &interfaces.ContextStats{
@@ -82,7 +95,7 @@ func (cg *contextGatherer) GatherContext(ctx context.Context, config interfaces.
 
 	// Gather project context
 	cg.consoleWriter.StatusMessage("Scanning files...")
-	contextFiles, processedFilesCount, err := fileutil.GatherProjectContext(config.Paths, fileConfig)
+	contextFiles, processedFilesCount, err := gatherWithTimeout(ctx, config.Paths, fileConfig, config.Timeout)
 
 	// Calculate duration in milliseconds
 	gatherDurationMs := time.Since(gatherStartTime).Milliseconds()
@@ -107,6 +120,23 @@ func (cg *contextGatherer) GatherContext(ctx context.Context, config interfaces.
 
 	// Set the processed files count in stats
 	stats.ProcessedFilesCount = processedFilesCount
+	stats.ExcludeMatches = fileConfig.ExcludeMatches()
+	for _, match := range stats.ExcludeMatches {
+		if match.Matched > 0 {
+			cg.logger.DebugContext(ctx, "Exclude %s %q skipped %d paths", match.Kind, match.Rule, match.Matched)
+		}
+	}
+	stats.OversizedFiles = fileConfig.OversizedFiles()
+	cg.auditOversizedFiles(ctx, stats.OversizedFiles, config.MaxFileSizeBytes)
+	stats.ContentExcludedFiles = fileConfig.ContentExcludedFiles()
+	cg.auditContentExcludedFiles(ctx, stats.ContentExcludedFiles)
+	stats.SkippedPaths = fileConfig.SkippedPaths()
+
+	if config.MaxContextTokens > 0 {
+		contextFiles = cg.applyTokenBudget(ctx, contextFiles, stats, config)
+		processedFilesCount = len(contextFiles)
+		stats.ProcessedFilesCount = processedFilesCount
+	}
 
 	// Log warning if no files were processed
 	if processedFilesCount == 0 {
@@ -151,10 +181,13 @@ func (cg *contextGatherer) GatherContext(ctx context.Context, config interfaces.
 
 	// Log successful completion to audit log
 	outputs := map[string]interface{}{
-		"processed_files_count": stats.ProcessedFilesCount,
-		"char_count":            stats.CharCount,
-		"line_count":            stats.LineCount,
-		"files_count":           len(contextFiles),
+		"processed_files_count":  stats.ProcessedFilesCount,
+		"char_count":             stats.CharCount,
+		"line_count":             stats.LineCount,
+		"files_count":            len(contextFiles),
+		"exclude_matches":        matchedExcludeRules(stats.ExcludeMatches),
+		"oversized_files_count":  len(stats.OversizedFiles),
+		"content_excluded_count": len(stats.ContentExcludedFiles),
 	}
 	if logErr := cg.auditLogger.LogOp(ctx, "GatherContext", "Success", inputs, outputs, nil); logErr != nil {
 		cg.logger.ErrorContext(ctx, "Failed to write audit log: %v", logErr)
@@ -163,6 +196,42 @@ func (cg *contextGatherer) GatherContext(ctx context.Context, config interfaces.
 	return contextFiles, stats, nil
 }
 
+// gatherWithTimeout runs file gathering bounded by its own timeout (0 = bounded only by ctx).
+// Gathering runs in a goroutine so that traversal stuck in slow I/O cannot outlive the deadline.
+func gatherWithTimeout(ctx context.Context, paths []string, fileConfig *fileutil.Config, timeout time.Duration) ([]fileutil.FileMeta, int, error) {
+	gatherCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		gatherCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type gatherResult struct {
+		files []fileutil.FileMeta
+		count int
+		err   error
+	}
+	done := make(chan gatherResult, 1)
+	go func() {
+		files, count, err := fileutil.GatherProjectContextWithContext(gatherCtx, paths, fileConfig)
+		done <- gatherResult{files: files, count: count, err: err}
+	}()
+
+	select {
+	case result := <-done:
+		if result.err == nil && ctx.Err() == nil && errors.Is(gatherCtx.Err(), context.DeadlineExceeded) {
+			// The pipeline stops quietly on cancellation; don't mistake partial results for success
+			return nil, 0, fmt.Errorf("%w after %v", ErrContextGatheringTimeout, timeout)
+		}
+		return result.files, result.count, result.err
+	case <-gatherCtx.Done():
+		if ctx.Err() != nil {
+			return nil, 0, ctx.Err()
+		}
+		return nil, 0, fmt.Errorf("%w after %v", ErrContextGatheringTimeout, timeout)
+	}
+}
+
 // DisplayDryRunInfo shows detailed information for dry run mode
 func (cg *contextGatherer) DisplayDryRunInfo(ctx context.Context, stats *interfaces.ContextStats) error {
 	// Log detailed information to structured logs for debugging
@@ -189,6 +258,12 @@ func (cg *contextGatherer) DisplayDryRunInfo(ctx context.Context, stats *interfa
 		}
 	}
 
+	displayExcludeMatches(cg.consoleWriter, stats.ExcludeMatches)
+	displayOversizedFiles(cg.consoleWriter, stats.OversizedFiles)
+	displayContentExcludedFiles(cg.consoleWriter, stats.ContentExcludedFiles)
+	displayTokenBudgetDropped(cg.consoleWriter, stats.TokenBudgetDropped)
+	displaySkippedPaths(cg.consoleWriter, stats.SkippedPaths)
+
 	// Display context statistics
 	cg.consoleWriter.StatusMessage("")
 	cg.consoleWriter.StatusMessage("Context statistics:")
@@ -207,3 +282,119 @@ func (cg *contextGatherer) DisplayDryRunInfo(ctx context.Context, stats *interfa
 
 	return nil
 }
+
+// matchedExcludeRules maps each exclude rule that skipped at least one path to its count
+func matchedExcludeRules(matches []fileutil.ExcludeRuleMatch) map[string]int {
+	matched := make(map[string]int)
+	for _, match := range matches {
+		if match.Matched > 0 {
+			matched[match.Rule] += match.Matched
+		}
+	}
+	return matched
+}
+
+// auditOversizedFiles records an audit entry for each file skipped for exceeding the size limit
+func (cg *contextGatherer) auditOversizedFiles(ctx context.Context, files []fileutil.OversizedFile, limit int64) {
+	for _, file := range files {
+		inputs := map[string]interface{}{
+			"path":                file.Path,
+			"size_bytes":          file.SizeBytes,
+			"max_file_size_bytes": limit,
+			"reason":              "max_file_size",
+		}
+		if logErr := cg.auditLogger.LogOp(ctx, "SkipFile", "Skipped", inputs, nil, nil); logErr != nil {
+			cg.logger.ErrorContext(ctx, "Failed to write audit log: %v", logErr)
+		}
+	}
+}
+
+// displayOversizedFiles lists files excluded for exceeding the maximum file size
+func displayOversizedFiles(consoleWriter logutil.ConsoleWriter, files []fileutil.OversizedFile) {
+	if len(files) == 0 {
+		return
+	}
+
+	consoleWriter.StatusMessage("")
+	consoleWriter.StatusMessage(fmt.Sprintf("Excluded by size (%d):", len(files)))
+	for _, file := range files {
+		consoleWriter.StatusMessage(fmt.Sprintf("  %s (%s)", file.Path, logutil.FormatFileSize(file.SizeBytes)))
+	}
+}
+
+// auditContentExcludedFiles records an audit entry for each file skipped by a content pattern
+func (cg *contextGatherer) auditContentExcludedFiles(ctx context.Context, files []fileutil.ContentExcludedFile) {
+	for _, file := range files {
+		inputs := map[string]interface{}{
+			"path":    file.Path,
+			"pattern": file.Pattern,
+			"reason":  "content_pattern",
+		}
+		if logErr := cg.auditLogger.LogOp(ctx, "SkipFile", "Skipped", inputs, nil, nil); logErr != nil {
+			cg.logger.ErrorContext(ctx, "Failed to write audit log: %v", logErr)
+		}
+	}
+}
+
+// displayContentExcludedFiles lists files excluded because their content matched an exclude pattern
+func displayContentExcludedFiles(consoleWriter logutil.ConsoleWriter, files []fileutil.ContentExcludedFile) {
+	if len(files) == 0 {
+		return
+	}
+
+	consoleWriter.StatusMessage("")
+	consoleWriter.StatusMessage(fmt.Sprintf("Excluded by content (%d):", len(files)))
+	for _, file := range files {
+		consoleWriter.StatusMessage(fmt.Sprintf("  %s (matches %s)", file.Path, file.Pattern))
+	}
+}
+
+// displayTokenBudgetDropped lists files dropped for exceeding the context token budget
+func displayTokenBudgetDropped(consoleWriter logutil.ConsoleWriter, paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+
+	consoleWriter.StatusMessage("")
+	consoleWriter.StatusMessage(fmt.Sprintf("Dropped by token budget (%d):", len(paths)))
+	for _, path := range paths {
+		consoleWriter.StatusMessage("  " + path)
+	}
+}
+
+// displaySkippedPaths lists every path a filter left out with the reason, for --explain-excludes
+func displaySkippedPaths(consoleWriter logutil.ConsoleWriter, paths []fileutil.SkippedPath) {
+	if len(paths) == 0 {
+		return
+	}
+
+	consoleWriter.StatusMessage("")
+	consoleWriter.StatusMessage(fmt.Sprintf("Excluded paths (%d):", len(paths)))
+	for _, path := range paths {
+		consoleWriter.StatusMessage(fmt.Sprintf("  %s: %s", path.DisplayPath(), path.Reason))
+	}
+}
+
+// displayExcludeMatches shows which exclude rules skipped paths and which matched nothing
+func displayExcludeMatches(consoleWriter logutil.ConsoleWriter, matches []fileutil.ExcludeRuleMatch) {
+	if len(matches) == 0 {
+		return
+	}
+
+	var unused []string
+	consoleWriter.StatusMessage("")
+	consoleWriter.StatusMessage("Exclude rules:")
+	for _, match := range matches {
+		if match.Matched == 0 {
+			unused = append(unused, match.Rule)
+			continue
+		}
+		consoleWriter.StatusMessage(fmt.Sprintf("  %s (%s): %d skipped", match.Rule, match.Kind, match.Matched))
+	}
+	if len(unused) == len(matches) {
+		consoleWriter.StatusMessage("  No exclude rules matched any paths.")
+	}
+	if len(unused) > 0 {
+		consoleWriter.StatusMessage(fmt.Sprintf("  Matched nothing (%d): %s", len(unused), strings.Join(unused, ", ")))
+	}
+}
@@ -3,11 +3,13 @@ package thinktank
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/misty-step/thinktank/internal/fileutil"
 	"github.com/misty-step/thinktank/internal/llm"
 	"github.com/misty-step/thinktank/internal/logutil"
 	"github.com/misty-step/thinktank/internal/testutil"
@@ -41,6 +43,7 @@ func (m *mockConsoleWriter) StatusMessage(message string) {
 }
 func (m *mockConsoleWriter) SetQuiet(quiet bool)                 {}
 func (m *mockConsoleWriter) SetNoProgress(noProgress bool)       {}
+func (m *mockConsoleWriter) SetSilent(silent bool)               {}
 func (m *mockConsoleWriter) IsInteractive() bool                 { return false }
 func (m *mockConsoleWriter) GetTerminalWidth() int               { return 80 }
 func (m *mockConsoleWriter) FormatMessage(message string) string { return message }
@@ -59,6 +62,7 @@ func (m *mockConsoleWriter) UpdateModelStatus(modelName string, status logutil.M
 func (m *mockConsoleWriter) UpdateModelRateLimited(modelName string, retryAfter time.Duration) {}
 func (m *mockConsoleWriter) RefreshStatusDisplay()                                             {}
 func (m *mockConsoleWriter) FinishStatusTracking()                                             {}
+func (m *mockConsoleWriter) StreamModelOutput(modelName string, content string)                {}
 
 func TestGatherContext(t *testing.T) {
 	tests := []struct {
@@ -307,6 +311,81 @@ func TestDisplayDryRunInfo(t *testing.T) {
 				"To generate content, run without the --dry-run flag",
 			},
 		},
+		{
+			name: "display info with exclude matches",
+			stats: &interfaces.ContextStats{
+				ProcessedFilesCount: 1,
+				CharCount:           10,
+				LineCount:           1,
+				ProcessedFiles:      []string{"main.go"},
+				ExcludeMatches: []fileutil.ExcludeRuleMatch{
+					{Kind: fileutil.ExcludeKindName, Rule: "node_modules", Matched: 1},
+					{Kind: fileutil.ExcludeKindName, Rule: "vendor", Matched: 0},
+					{Kind: fileutil.ExcludeKindExtension, Rule: ".log", Matched: 4},
+					{Kind: fileutil.ExcludeKindExtension, Rule: ".exe", Matched: 0},
+				},
+			},
+			expectedLogMessages: []string{
+				"Exclude rules:",
+				"node_modules (name): 1 skipped",
+				".log (extension): 4 skipped",
+				"Matched nothing (2): vendor, .exe",
+				"Context statistics:",
+			},
+		},
+		{
+			name: "display info with oversized files",
+			stats: &interfaces.ContextStats{
+				ProcessedFilesCount: 1,
+				CharCount:           10,
+				LineCount:           1,
+				ProcessedFiles:      []string{"main.go"},
+				OversizedFiles: []fileutil.OversizedFile{
+					{Path: "/repo/dist/bundle.js", SizeBytes: 3 * 1024 * 1024},
+				},
+			},
+			expectedLogMessages: []string{
+				"Excluded by size (1):",
+				"/repo/dist/bundle.js (3.0M)",
+				"Context statistics:",
+			},
+		},
+		{
+			name: "display info with content excluded files",
+			stats: &interfaces.ContextStats{
+				ProcessedFilesCount: 1,
+				CharCount:           10,
+				LineCount:           1,
+				ProcessedFiles:      []string{"main.go"},
+				ContentExcludedFiles: []fileutil.ContentExcludedFile{
+					{Path: "/repo/api/api.pb.go", Pattern: "@generated"},
+				},
+			},
+			expectedLogMessages: []string{
+				"Excluded by content (1):",
+				"/repo/api/api.pb.go (matches @generated)",
+				"Context statistics:",
+			},
+		},
+		{
+			name: "display info with explained excludes",
+			stats: &interfaces.ContextStats{
+				ProcessedFilesCount: 1,
+				CharCount:           10,
+				LineCount:           1,
+				ProcessedFiles:      []string{"main.go"},
+				SkippedPaths: []fileutil.SkippedPath{
+					{Path: "/repo/app.log", Reason: fileutil.SkipExcludedExtension},
+					{Path: "/repo/image.png", Reason: fileutil.SkipBinary},
+				},
+			},
+			expectedLogMessages: []string{
+				"Excluded paths (2):",
+				"/repo/app.log: excluded extension",
+				"/repo/image.png: binary",
+				"Context statistics:",
+			},
+		},
 		{
 			name: "display info with no files",
 			stats: &interfaces.ContextStats{
@@ -503,6 +582,44 @@ func TestGatherContext_AuditLogging(t *testing.T) {
 	})
 }
 
+func TestGatherContext_MaxFileSize(t *testing.T) {
+	mockLogger := testutil.NewMockLogger()
+	tempDir := testutil.SetupTempDir(t, "max-size-test-")
+	testutil.CreateTestFile(t, tempDir, "small.go", []byte("package main"))
+	bigFile := testutil.CreateTestFile(t, tempDir, "generated.go", []byte(strings.Repeat("x", 4096)))
+
+	gatherer := NewContextGatherer(mockLogger, &mockConsoleWriter{}, false, &llm.MockLLMClient{}, mockLogger)
+	files, stats, err := gatherer.GatherContext(context.Background(), interfaces.GatherConfig{
+		Paths:            []string{tempDir},
+		Format:           "{path}\n{content}",
+		LogLevel:         logutil.InfoLevel,
+		MaxFileSizeBytes: 1024,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(files) != 1 {
+		t.Errorf("Expected 1 file, got %d", len(files))
+	}
+	if len(stats.OversizedFiles) != 1 || stats.OversizedFiles[0].Path != bigFile {
+		t.Fatalf("OversizedFiles = %v, want only %s", stats.OversizedFiles, bigFile)
+	}
+
+	var skipCalls int
+	for _, call := range mockLogger.GetLogOpCalls() {
+		if call.Operation != "SkipFile" {
+			continue
+		}
+		skipCalls++
+		if call.Status != "Skipped" || call.Inputs["path"] != bigFile || call.Inputs["reason"] != "max_file_size" {
+			t.Errorf("unexpected SkipFile audit entry: %+v", call)
+		}
+	}
+	if skipCalls != 1 {
+		t.Errorf("Expected 1 SkipFile audit entry, got %d", skipCalls)
+	}
+}
+
 func TestNewContextGatherer(t *testing.T) {
 	mockLogger := testutil.NewMockLogger()
 	mockConsoleWriter := &mockConsoleWriter{}
@@ -517,3 +634,53 @@ func TestNewContextGatherer(t *testing.T) {
 	// Verify the gatherer implements the interface (compile-time check)
 	_ = (interfaces.ContextGatherer)(gatherer)
 }
+
+func TestGatherContext_Timeout(t *testing.T) {
+	tempDir := testutil.SetupTempDir(t, "gather-timeout-test-")
+	testutil.CreateTestFile(t, tempDir, "main.go", []byte("package main"))
+
+	newConfig := func(timeout time.Duration) interfaces.GatherConfig {
+		return interfaces.GatherConfig{
+			Paths:    []string{tempDir},
+			Format:   "{path}\n{content}",
+			LogLevel: logutil.InfoLevel,
+			Timeout:  timeout,
+		}
+	}
+
+	t.Run("expired gather timeout fails clearly", func(t *testing.T) {
+		mockLogger := testutil.NewMockLogger()
+		gatherer := NewContextGatherer(mockLogger, &mockConsoleWriter{}, false, &llm.MockLLMClient{}, mockLogger)
+
+		_, _, err := gatherer.GatherContext(context.Background(), newConfig(time.Nanosecond))
+		if !errors.Is(err, ErrContextGatheringTimeout) {
+			t.Fatalf("Expected ErrContextGatheringTimeout, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "context gathering timed out") {
+			t.Errorf("Expected clear timeout message, got %q", err.Error())
+		}
+	})
+
+	t.Run("generous gather timeout succeeds", func(t *testing.T) {
+		mockLogger := testutil.NewMockLogger()
+		gatherer := NewContextGatherer(mockLogger, &mockConsoleWriter{}, false, &llm.MockLLMClient{}, mockLogger)
+
+		files, _, err := gatherer.GatherContext(context.Background(), newConfig(time.Minute))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(files) != 1 {
+			t.Errorf("Expected 1 file, got %d", len(files))
+		}
+	})
+
+	t.Run("cancelled parent is not reported as gather timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, _, err := gatherWithTimeout(ctx, []string{tempDir}, fileutil.NewConfig(false, "", "", "", "", testutil.NewMockLogger()), time.Minute)
+		if errors.Is(err, ErrContextGatheringTimeout) {
+			t.Errorf("Expected parent cancellation, got gather timeout: %v", err)
+		}
+	})
+}
@@ -26,9 +26,16 @@ var (
 	// ErrInvalidOutputDir is returned when the output directory is invalid.
 	ErrInvalidOutputDir = errors.New("invalid output directory")
 
+	// ErrOutputFileExists is returned when a FileWriter that protects existing
+	// files is asked to overwrite one it didn't write.
+	ErrOutputFileExists = errors.New("output file already exists")
+
 	// ErrContextGatheringFailed is returned when context gathering fails.
 	ErrContextGatheringFailed = errors.New("context gathering failed")
 
+	// ErrContextGatheringTimeout is returned when context gathering exceeds its own timeout.
+	ErrContextGatheringTimeout = errors.New("context gathering timed out")
+
 	// ErrPartialSuccess is returned when some models succeed but others fail.
 	// This error is used to determine exit code behavior when the --partial-success-ok flag is enabled.
 	ErrPartialSuccess = errors.New("partial success: some models succeeded, others failed")
@@ -10,7 +10,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/misty-step/thinktank/internal/auditlog"
 	"github.com/misty-step/thinktank/internal/fileutil"
@@ -24,17 +27,56 @@ type fileWriter struct {
 	auditLogger     auditlog.AuditLogger
 	dirPermissions  os.FileMode
 	filePermissions os.FileMode
+	maxFileSize     int64 // Bytes of content kept per file (0 = unlimited)
+	noOverwrite     bool  // Refuse to replace files this writer didn't write
+
+	truncatedMu    sync.Mutex
+	truncatedFiles map[string]struct{}
+
+	writtenMu    sync.Mutex
+	writtenFiles map[string]struct{}
+}
+
+// FileWriterOptions configures NewFileWriterWithOptions
+type FileWriterOptions struct {
+	DirPermissions  os.FileMode
+	FilePermissions os.FileMode
+
+	// MaxFileSize truncates content beyond this many bytes (0 = unlimited)
+	MaxFileSize int64
+
+	// NoOverwrite refuses to replace a file that existed before this writer
+	// wrote it. A file the writer saved itself may still be rewritten, as the
+	// same output is saved more than once during a run.
+	NoOverwrite bool
 }
 
 // NewFileWriter creates a new FileWriter instance with the specified dependencies.
 // It injects the required logger and audit logger to ensure proper output
 // handling and audit trail generation during file operations.
 func NewFileWriter(logger logutil.LoggerInterface, auditLogger auditlog.AuditLogger, dirPermissions, filePermissions os.FileMode) interfaces.FileWriter {
+	return NewFileWriterWithMaxSize(logger, auditLogger, dirPermissions, filePermissions, 0)
+}
+
+// NewFileWriterWithMaxSize creates a FileWriter that truncates content beyond maxFileSize bytes,
+// appending a notice so readers know the output is incomplete. A maxFileSize of 0 means unlimited.
+func NewFileWriterWithMaxSize(logger logutil.LoggerInterface, auditLogger auditlog.AuditLogger, dirPermissions, filePermissions os.FileMode, maxFileSize int64) interfaces.FileWriter {
+	return NewFileWriterWithOptions(logger, auditLogger, FileWriterOptions{
+		DirPermissions:  dirPermissions,
+		FilePermissions: filePermissions,
+		MaxFileSize:     maxFileSize,
+	})
+}
+
+// NewFileWriterWithOptions creates a FileWriter configured by options
+func NewFileWriterWithOptions(logger logutil.LoggerInterface, auditLogger auditlog.AuditLogger, options FileWriterOptions) interfaces.FileWriter {
 	return &fileWriter{
 		logger:          logger,
 		auditLogger:     auditLogger,
-		dirPermissions:  dirPermissions,
-		filePermissions: filePermissions,
+		dirPermissions:  options.DirPermissions,
+		filePermissions: options.FilePermissions,
+		maxFileSize:     options.MaxFileSize,
+		noOverwrite:     options.NoOverwrite,
 	}
 }
 
@@ -69,6 +111,16 @@ func (fw *fileWriter) SaveToFile(ctx context.Context, content, outputFile string
 		return pathErr
 	}
 
+	// Leave earlier results alone unless this writer produced them
+	if fw.noOverwrite && !fw.wrote(outputPath) {
+		if _, err := os.Lstat(outputPath); err == nil {
+			existsErr := fmt.Errorf("%w: %s", ErrOutputFileExists, outputPath)
+			fw.logger.Error("Refusing to overwrite %s", outputPath)
+			fw.logFailure(ctx, saveStartTime, inputs, existsErr)
+			return existsErr
+		}
+	}
+
 	// Ensure the output directory exists
 	outputDir := filepath.Dir(outputPath)
 	if err := os.MkdirAll(outputDir, fw.dirPermissions); err != nil {
@@ -77,6 +129,15 @@ func (fw *fileWriter) SaveToFile(ctx context.Context, content, outputFile string
 		return fmt.Errorf("error creating output directory %s: %w", outputDir, err)
 	}
 
+	// Cap runaway outputs before they reach the disk
+	if truncated, ok := truncateContent(content, fw.maxFileSize); ok {
+		fw.logger.Warn("Output for %s exceeds %d bytes (%d bytes); truncating", outputPath, fw.maxFileSize, len(content))
+		inputs["truncated"] = true
+		inputs["original_length"] = len(content)
+		content = truncated
+		fw.recordTruncation(outputPath)
+	}
+
 	// Write to file
 	fw.logger.Info("Writing to file %s...", outputPath)
 	if err := os.WriteFile(outputPath, []byte(content), fw.filePermissions); err != nil {
@@ -85,12 +146,71 @@ func (fw *fileWriter) SaveToFile(ctx context.Context, content, outputFile string
 		return fmt.Errorf("error writing to file %s: %w", outputPath, err)
 	}
 
+	fw.recordWrite(outputPath)
+
 	// Log successful save
 	fw.logSuccess(ctx, saveStartTime, inputs, len(content))
 	fw.logger.Info("Successfully saved to %s", outputPath)
 	return nil
 }
 
+// TruncatedFiles returns the sorted paths of files whose content was truncated
+func (fw *fileWriter) TruncatedFiles() []string {
+	fw.truncatedMu.Lock()
+	defer fw.truncatedMu.Unlock()
+
+	paths := make([]string, 0, len(fw.truncatedFiles))
+	for path := range fw.truncatedFiles {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// recordTruncation remembers a truncated path; the same file may be written more than once
+func (fw *fileWriter) recordTruncation(path string) {
+	fw.truncatedMu.Lock()
+	defer fw.truncatedMu.Unlock()
+
+	if fw.truncatedFiles == nil {
+		fw.truncatedFiles = make(map[string]struct{})
+	}
+	fw.truncatedFiles[path] = struct{}{}
+}
+
+// recordWrite remembers a path this writer saved, so it may save it again
+func (fw *fileWriter) recordWrite(path string) {
+	fw.writtenMu.Lock()
+	defer fw.writtenMu.Unlock()
+
+	if fw.writtenFiles == nil {
+		fw.writtenFiles = make(map[string]struct{})
+	}
+	fw.writtenFiles[path] = struct{}{}
+}
+
+// wrote reports whether this writer has saved path
+func (fw *fileWriter) wrote(path string) bool {
+	fw.writtenMu.Lock()
+	defer fw.writtenMu.Unlock()
+
+	_, ok := fw.writtenFiles[path]
+	return ok
+}
+
+// truncateContent cuts content to at most maxBytes (on a UTF-8 boundary) and appends a notice.
+// Returns false when no truncation is needed or maxBytes is 0.
+func truncateContent(content string, maxBytes int64) (string, bool) {
+	if maxBytes <= 0 || int64(len(content)) <= maxBytes {
+		return content, false
+	}
+	cut := int(maxBytes)
+	for cut > 0 && !utf8.RuneStart(content[cut]) {
+		cut--
+	}
+	return content[:cut] + fmt.Sprintf("\n\n[output truncated at %d bytes]\n", maxBytes), true
+}
+
 // resolveAbsolutePath converts a path to absolute, returning the path unchanged if already absolute.
 func (fw *fileWriter) resolveAbsolutePath(path string) (string, error) {
 	if filepath.IsAbs(path) {
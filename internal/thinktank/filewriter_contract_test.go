@@ -228,6 +228,10 @@ func (m *mockAuditLogger) Log(ctx context.Context, entry auditlog.AuditEntry) er
 	return nil
 }
 
+func (m *mockAuditLogger) LogContext(ctx context.Context, entry auditlog.AuditEntry) error {
+	return m.Log(ctx, entry)
+}
+
 func (m *mockAuditLogger) LogLegacy(entry auditlog.AuditEntry) error {
 	return m.Log(context.Background(), entry)
 }
@@ -3,7 +3,9 @@ package thinktank_test
 
 import (
 	"context"
+	"errors"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/misty-step/thinktank/internal/logutil"
 	"github.com/misty-step/thinktank/internal/testutil"
 	"github.com/misty-step/thinktank/internal/thinktank"
+	"github.com/misty-step/thinktank/internal/thinktank/interfaces"
 )
 
 // mockAuditLogger for testing FileWriter
@@ -23,6 +26,10 @@ func (m *mockAuditLogger) Log(ctx context.Context, entry auditlog.AuditEntry) er
 	return nil
 }
 
+func (m *mockAuditLogger) LogContext(ctx context.Context, entry auditlog.AuditEntry) error {
+	return m.Log(ctx, entry)
+}
+
 func (m *mockAuditLogger) LogLegacy(entry auditlog.AuditEntry) error {
 	return m.Log(context.Background(), entry)
 }
@@ -176,3 +183,114 @@ func TestSaveToFile(t *testing.T) {
 		})
 	}
 }
+
+// TestSaveToFile_MaxFileSize tests truncation of content beyond the size limit
+func TestSaveToFile_MaxFileSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxFileSize   int64
+		content       string
+		expected      string
+		wantTruncated bool
+	}{
+		{
+			name:        "unlimited keeps content",
+			maxFileSize: 0,
+			content:     "0123456789",
+			expected:    "0123456789",
+		},
+		{
+			name:        "content within limit",
+			maxFileSize: 10,
+			content:     "0123456789",
+			expected:    "0123456789",
+		},
+		{
+			name:          "content beyond limit",
+			maxFileSize:   4,
+			content:       "0123456789",
+			expected:      "0123\n\n[output truncated at 4 bytes]\n",
+			wantTruncated: true,
+		},
+		{
+			name:          "cut lands inside a multi-byte rune",
+			maxFileSize:   2,
+			content:       "aé and more",
+			expected:      "a\n\n[output truncated at 2 bytes]\n",
+			wantTruncated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := logutil.NewLogger(logutil.InfoLevel, os.Stderr, "[test] ")
+			auditLogger := &mockAuditLogger{}
+			fileWriter := thinktank.NewFileWriterWithMaxSize(logger, auditLogger, 0750, 0640, tt.maxFileSize)
+
+			outputPath := filepath.Join(t.TempDir(), "model.md")
+			if err := fileWriter.SaveToFile(context.Background(), tt.content, outputPath); err != nil {
+				t.Fatalf("SaveToFile() error = %v", err)
+			}
+
+			data, err := os.ReadFile(outputPath)
+			if err != nil {
+				t.Fatalf("Failed to read output: %v", err)
+			}
+			if string(data) != tt.expected {
+				t.Errorf("file content = %q, want %q", string(data), tt.expected)
+			}
+
+			reporter, ok := fileWriter.(interfaces.TruncationReporter)
+			if !ok {
+				t.Fatal("FileWriter does not implement TruncationReporter")
+			}
+			truncated := reporter.TruncatedFiles()
+			if tt.wantTruncated && (len(truncated) != 1 || truncated[0] != outputPath) {
+				t.Errorf("TruncatedFiles() = %v, want [%s]", truncated, outputPath)
+			}
+			if !tt.wantTruncated && len(truncated) != 0 {
+				t.Errorf("TruncatedFiles() = %v, want none", truncated)
+			}
+
+			success := auditLogger.entries[len(auditLogger.entries)-1]
+			if got := success.Inputs["truncated"] == true; got != tt.wantTruncated {
+				t.Errorf("audit truncated = %v, want %v", got, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestSaveToFile_NoOverwrite(t *testing.T) {
+	logger := logutil.NewLogger(logutil.InfoLevel, os.Stderr, "[test] ")
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "previous.md")
+	if err := os.WriteFile(existing, []byte("earlier result"), 0640); err != nil {
+		t.Fatalf("Failed to create existing file: %v", err)
+	}
+
+	fileWriter := thinktank.NewFileWriterWithOptions(logger, &mockAuditLogger{}, thinktank.FileWriterOptions{
+		DirPermissions:  0750,
+		FilePermissions: 0640,
+		NoOverwrite:     true,
+	})
+	ctx := context.Background()
+
+	err := fileWriter.SaveToFile(ctx, "new result", existing)
+	if !errors.Is(err, thinktank.ErrOutputFileExists) {
+		t.Fatalf("SaveToFile() over an existing file error = %v, want ErrOutputFileExists", err)
+	}
+	if data, _ := os.ReadFile(existing); string(data) != "earlier result" {
+		t.Errorf("existing file content = %q, want it untouched", string(data))
+	}
+
+	// A file the writer saved itself can be saved again
+	fresh := filepath.Join(dir, "model.md")
+	for _, content := range []string{"first", "second"} {
+		if err := fileWriter.SaveToFile(ctx, content, fresh); err != nil {
+			t.Fatalf("SaveToFile(%q) error = %v", content, err)
+		}
+	}
+	if data, _ := os.ReadFile(fresh); string(data) != "second" {
+		t.Errorf("rewritten file content = %q, want %q", string(data), "second")
+	}
+}
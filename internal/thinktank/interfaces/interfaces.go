@@ -4,12 +4,15 @@ package interfaces
 
 import (
 	"context"
+	"regexp"
+	"time"
 
 	"github.com/misty-step/thinktank/internal/auditlog"
 	"github.com/misty-step/thinktank/internal/fileutil"
 	"github.com/misty-step/thinktank/internal/llm"
 	"github.com/misty-step/thinktank/internal/logutil"
 	"github.com/misty-step/thinktank/internal/models"
+	"github.com/misty-step/thinktank/internal/thinktank/prompt"
 )
 
 // APIService defines the interface for API-related operations
@@ -98,6 +101,13 @@ type APIService interface {
 	GetErrorDetails(err error) string
 }
 
+// ProviderPreflighter is implemented by APIServices that can check providers before a run
+type ProviderPreflighter interface {
+	// Preflight checks that each provider used by modelNames accepts its API key and is
+	// reachable, returning a categorized error naming every provider that failed
+	Preflight(ctx context.Context, modelNames []string) error
+}
+
 // LLMClientFactory creates provider-agnostic LLM clients.
 type LLMClientFactory interface {
 	InitLLMClient(ctx context.Context, apiKey, modelName, apiEndpoint string) (llm.LLMClient, error)
@@ -125,6 +135,21 @@ type ContextStats struct {
 	CharCount           int
 	LineCount           int
 	ProcessedFiles      []string
+
+	// ExcludeMatches reports how many paths each configured exclude rule skipped
+	ExcludeMatches []fileutil.ExcludeRuleMatch
+
+	// OversizedFiles lists files skipped for exceeding the maximum file size
+	OversizedFiles []fileutil.OversizedFile
+
+	// ContentExcludedFiles lists files skipped because their content matched an exclude pattern
+	ContentExcludedFiles []fileutil.ContentExcludedFile
+
+	// SkippedPaths lists every path a filter left out and why (only with GatherConfig.ExplainExcludes)
+	SkippedPaths []fileutil.SkippedPath
+
+	// TokenBudgetDropped lists files dropped once the context token budget was reached
+	TokenBudgetDropped []string
 }
 
 // GatherConfig holds parameters needed for gathering context
@@ -136,6 +161,45 @@ type GatherConfig struct {
 	Format       string
 	Verbose      bool
 	LogLevel     logutil.LogLevel
+
+	// NormalizeLineEndings converts CRLF/CR line endings to LF in file content
+	NormalizeLineEndings bool
+
+	// IncludeGlobs limits context to files matching at least one glob (empty = all files)
+	IncludeGlobs []string
+
+	// PriorityGlobs marks files that trimming drops last, earlier globs ranking higher
+	PriorityGlobs []string
+
+	// Workers is the number of goroutines per gathering stage (0 = runtime.NumCPU())
+	Workers int
+
+	// MaxFileSizeBytes skips files larger than this many bytes (0 = unlimited)
+	MaxFileSizeBytes int64
+
+	// FollowSymlinks walks into symlinked directories (cycles are skipped)
+	FollowSymlinks bool
+
+	// ExcludeContentPatterns skips files whose head matches any pattern (e.g. generated-code markers)
+	ExcludeContentPatterns []*regexp.Regexp
+
+	// IncludeHidden gathers dotfiles and dot-directories (.git is always skipped)
+	IncludeHidden bool
+
+	// ExplainExcludes records each skipped path and its reason in ContextStats.SkippedPaths
+	ExplainExcludes bool
+
+	// MaxContextTokens stops adding files, in gather order, once the estimated tokens
+	// of Instructions plus the files so far would exceed it (0 = unlimited).
+	// Tokens are estimated for TokenModel, or generically when it is unknown, with
+	// each file laid out as Prompt will build it.
+	MaxContextTokens int
+	Instructions     string
+	TokenModel       string
+	Prompt           prompt.PromptBuilder
+
+	// Timeout bounds file gathering separately from the overall run (0 = no separate bound)
+	Timeout time.Duration
 }
 
 // ContextGatherer defines the interface for gathering project context
@@ -153,6 +217,12 @@ type FileWriter interface {
 	SaveToFile(ctx context.Context, content, outputFile string) error
 }
 
+// TruncationReporter is implemented by FileWriters that cap output file size
+type TruncationReporter interface {
+	// TruncatedFiles returns the paths of files whose content was truncated
+	TruncatedFiles() []string
+}
+
 // AuditLogger defines the interface for writing audit logs
 type AuditLogger interface {
 	// Log writes an audit entry to the log
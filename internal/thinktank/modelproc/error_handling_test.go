@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/misty-step/thinktank/internal/config"
 	"github.com/misty-step/thinktank/internal/llm"
 	"github.com/misty-step/thinktank/internal/logutil"
+	"github.com/misty-step/thinktank/internal/models"
 	"github.com/misty-step/thinktank/internal/thinktank/modelproc"
 )
 
@@ -138,6 +140,40 @@ func TestModelProcessor_Process_GenerationError(t *testing.T) {
 	}
 }
 
+func TestModelProcessor_Process_GenerationErrorKeepsRetryAfter(t *testing.T) {
+	providerErr := &llm.LLMError{
+		Message:       "rate limited",
+		ErrorCategory: llm.CategoryRateLimit,
+		RetryAfter:    12 * time.Second,
+	}
+	mockAPI := &mockAPIService{
+		initLLMClientFunc: func(ctx context.Context, apiKey, modelName, apiEndpoint string) (llm.LLMClient, error) {
+			return &mockLLMClient{
+				generateContentFunc: func(ctx context.Context, prompt string, params map[string]interface{}) (*llm.ProviderResult, error) {
+					return nil, providerErr
+				},
+			}, nil
+		},
+	}
+
+	cfg := config.NewDefaultCliConfig()
+	cfg.APIKey = "test-api-key"
+	cfg.OutputDir = "/tmp/test-output"
+	processor := modelproc.NewProcessor(mockAPI, &mockFileWriter{}, &mockAuditLogger{}, newNoOpLogger(), cfg)
+
+	_, err := processor.Process(context.Background(), "test-model", "Test prompt")
+
+	if !errors.Is(err, modelproc.ErrModelProcessingFailed) {
+		t.Fatalf("Expected ErrModelProcessingFailed, got '%v'", err)
+	}
+	if got := llm.RetryAfterFromError(err); got != 12*time.Second {
+		t.Errorf("RetryAfter = %v, want 12s", got)
+	}
+	if !llm.IsRateLimit(err) {
+		t.Errorf("Expected the rate limit category to be preserved, got '%v'", err)
+	}
+}
+
 func TestModelProcessor_Process_SaveError(t *testing.T) {
 	// Setup mocks
 	expectedErr := errors.New("save error")
@@ -323,3 +359,28 @@ func TestProcess_NilClientDeference(t *testing.T) {
 		t.Errorf("Expected empty output on error, got: %s", output)
 	}
 }
+
+func TestModelProcessor_Process_InvalidModelParams(t *testing.T) {
+	clientInitialized := false
+	mockAPI := &mockAPIService{
+		getModelParametersFunc: func(ctx context.Context, modelName string) (map[string]interface{}, error) {
+			return nil, models.ValidateModelParams(modelName, map[string]interface{}{"temperature": 5.0})
+		},
+		initLLMClientFunc: func(ctx context.Context, apiKey, modelName, apiEndpoint string) (llm.LLMClient, error) {
+			clientInitialized = true
+			return &mockLLMClient{}, nil
+		},
+	}
+
+	cfg := config.NewDefaultCliConfig()
+	cfg.OutputDir = t.TempDir()
+	processor := modelproc.NewProcessor(mockAPI, &mockFileWriter{}, &mockAuditLogger{}, newNoOpLogger(), cfg)
+
+	_, err := processor.Process(context.Background(), "gpt-5.2", "Test prompt")
+	if !errors.Is(err, models.ErrInvalidModelParams) {
+		t.Fatalf("Process() error = %v, want ErrInvalidModelParams", err)
+	}
+	if clientInitialized {
+		t.Error("the client was initialized despite invalid parameters")
+	}
+}
@@ -137,6 +137,21 @@ func (m *mockLLMClient) Close() error {
 	return nil
 }
 
+// mockStreamingLLMClient streams its chunks when a stream handler is set
+type mockStreamingLLMClient struct {
+	mockLLMClient
+	chunks []llm.Chunk
+}
+
+func (m *mockStreamingLLMClient) GenerateContentStream(ctx context.Context, prompt string, params map[string]interface{}) (<-chan llm.Chunk, error) {
+	stream := make(chan llm.Chunk, len(m.chunks))
+	for _, chunk := range m.chunks {
+		stream <- chunk
+	}
+	close(stream)
+	return stream, nil
+}
+
 type mockAuditLogger struct {
 	logFunc         func(ctx context.Context, entry auditlog.AuditEntry) error
 	logLegacyFunc   func(entry auditlog.AuditEntry) error
@@ -154,6 +169,10 @@ func (m *mockAuditLogger) Log(ctx context.Context, entry auditlog.AuditEntry) er
 	return nil
 }
 
+func (m *mockAuditLogger) LogContext(ctx context.Context, entry auditlog.AuditEntry) error {
+	return m.Log(ctx, entry)
+}
+
 func (m *mockAuditLogger) LogLegacy(entry auditlog.AuditEntry) error {
 	if m.logLegacyFunc != nil {
 		return m.logLegacyFunc(entry)
@@ -3,8 +3,11 @@ package modelproc_test
 import (
 	"context"
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/misty-step/thinktank/internal/cache"
 	"github.com/misty-step/thinktank/internal/config"
 	"github.com/misty-step/thinktank/internal/llm"
 	"github.com/misty-step/thinktank/internal/thinktank/modelproc"
@@ -435,3 +438,479 @@ func TestProcess_ModelNameSanitization(t *testing.T) {
 		})
 	}
 }
+
+// TestProcess_OutputPath tests that a path set with SetOutputPath replaces <model>.md
+func TestProcess_OutputPath(t *testing.T) {
+	mockAPI := &mockAPIService{
+		initLLMClientFunc: func(ctx context.Context, apiKey, modelName, apiEndpoint string) (llm.LLMClient, error) {
+			return &mockLLMClient{
+				generateContentFunc: func(ctx context.Context, prompt string, params map[string]interface{}) (*llm.ProviderResult, error) {
+					return &llm.ProviderResult{Content: "Test content"}, nil
+				},
+			}, nil
+		},
+		processLLMResponseFunc: func(result *llm.ProviderResult) (string, error) {
+			return result.Content, nil
+		},
+	}
+
+	var savedPath string
+	mockWriter := &mockFileWriter{
+		saveToFileFunc: func(ctx context.Context, content, outputFile string) error {
+			savedPath = outputFile
+			return nil
+		},
+	}
+
+	cfg := config.NewDefaultCliConfig()
+	cfg.APIKey = "test-api-key"
+	cfg.OutputDir = "/tmp/test-output"
+
+	processor := modelproc.NewProcessor(mockAPI, mockWriter, &mockAuditLogger{}, newNoOpLogger(), cfg)
+	processor.SetOutputPath(func(modelName string) string {
+		return "/tmp/test-output/20250619-143022-" + modelName + ".txt"
+	})
+
+	if _, err := processor.Process(context.Background(), "gpt-4", "Test prompt"); err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+	if want := "/tmp/test-output/20250619-143022-gpt-4.txt"; savedPath != want {
+		t.Errorf("Expected output saved to %s, got: %s", want, savedPath)
+	}
+}
+
+// TestProcess_SaveHandler tests that a handler set with SetSaveHandler is called
+// with the output path once the file is saved, and not when saving fails
+func TestProcess_SaveHandler(t *testing.T) {
+	mockAPI := &mockAPIService{
+		initLLMClientFunc: func(ctx context.Context, apiKey, modelName, apiEndpoint string) (llm.LLMClient, error) {
+			return &mockLLMClient{
+				generateContentFunc: func(ctx context.Context, prompt string, params map[string]interface{}) (*llm.ProviderResult, error) {
+					return &llm.ProviderResult{Content: "Test content"}, nil
+				},
+			}, nil
+		},
+		processLLMResponseFunc: func(result *llm.ProviderResult) (string, error) {
+			return result.Content, nil
+		},
+	}
+
+	for _, saveErr := range []error{nil, errors.New("disk full")} {
+		var saved, handled []string
+		mockWriter := &mockFileWriter{
+			saveToFileFunc: func(ctx context.Context, content, outputFile string) error {
+				saved = append(saved, outputFile)
+				return saveErr
+			},
+		}
+
+		cfg := config.NewDefaultCliConfig()
+		cfg.APIKey = "test-api-key"
+		cfg.OutputDir = "/tmp/test-output"
+
+		processor := modelproc.NewProcessor(mockAPI, mockWriter, &mockAuditLogger{}, newNoOpLogger(), cfg)
+		processor.SetSaveHandler(func(ctx context.Context, path string) {
+			if len(saved) != 1 {
+				t.Errorf("Save handler called before the file was saved")
+			}
+			handled = append(handled, path)
+		})
+
+		_, err := processor.Process(context.Background(), "gpt-4", "Test prompt")
+		if saveErr != nil {
+			if err == nil {
+				t.Fatalf("Expected an error when saving fails")
+			}
+			if len(handled) != 0 {
+				t.Errorf("Expected no save handler call when saving fails, got %v", handled)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Expected success, got error: %v", err)
+		}
+		if want := []string{"/tmp/test-output/gpt-4.md"}; !reflect.DeepEqual(handled, want) {
+			t.Errorf("Expected save handler called with %v, got: %v", want, handled)
+		}
+	}
+}
+
+// TestProcess_Streaming tests that streaming clients feed the stream handler and
+// that other clients fall back to a single buffered response
+func TestProcess_Streaming(t *testing.T) {
+	tests := []struct {
+		name           string
+		client         llm.LLMClient
+		handler        bool
+		expectedChunks []string
+	}{
+		{
+			name: "streaming client with handler",
+			client: &mockStreamingLLMClient{
+				chunks: []llm.Chunk{{Content: "Streamed "}, {Content: "content", FinishReason: "stop"}, {Done: true}},
+			},
+			handler:        true,
+			expectedChunks: []string{"Streamed ", "content"},
+		},
+		{
+			name: "streaming client without handler",
+			client: &mockStreamingLLMClient{
+				mockLLMClient: mockLLMClient{
+					generateContentFunc: func(ctx context.Context, prompt string, params map[string]interface{}) (*llm.ProviderResult, error) {
+						return &llm.ProviderResult{Content: "Streamed content"}, nil
+					},
+				},
+			},
+		},
+		{
+			name: "non-streaming client with handler",
+			client: &mockLLMClient{
+				generateContentFunc: func(ctx context.Context, prompt string, params map[string]interface{}) (*llm.ProviderResult, error) {
+					return &llm.ProviderResult{Content: "Streamed content"}, nil
+				},
+			},
+			handler: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := &mockAPIService{
+				initLLMClientFunc: func(ctx context.Context, apiKey, modelName, apiEndpoint string) (llm.LLMClient, error) {
+					return tt.client, nil
+				},
+				processLLMResponseFunc: func(result *llm.ProviderResult) (string, error) {
+					return result.Content, nil
+				},
+			}
+
+			cfg := config.NewDefaultCliConfig()
+			cfg.APIKey = "test-api-key"
+			cfg.OutputDir = "/tmp/test-output"
+			processor := modelproc.NewProcessor(mockAPI, &mockFileWriter{}, &mockAuditLogger{}, newNoOpLogger(), cfg)
+
+			var chunks []string
+			if tt.handler {
+				processor.SetStreamHandler(func(content string) { chunks = append(chunks, content) })
+			}
+
+			output, err := processor.Process(context.Background(), "test-model", "Test prompt")
+			if err != nil {
+				t.Fatalf("Expected success, got error: %v", err)
+			}
+			if output != "Streamed content" {
+				t.Errorf("Expected output 'Streamed content', got: %s", output)
+			}
+			if len(chunks) != len(tt.expectedChunks) {
+				t.Fatalf("Expected chunks %q, got %q", tt.expectedChunks, chunks)
+			}
+			for i := range chunks {
+				if chunks[i] != tt.expectedChunks[i] {
+					t.Errorf("Chunk %d = %q, want %q", i, chunks[i], tt.expectedChunks[i])
+				}
+			}
+		})
+	}
+}
+
+// TestProcess_StreamCutShort tests that a stream that ends early fails the model
+// without saving or caching the partial output
+func TestProcess_StreamCutShort(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []llm.Chunk
+	}{
+		{
+			name:   "error chunk mid-stream",
+			chunks: []llm.Chunk{{Content: "Partial "}, {Err: llm.Wrap(context.DeadlineExceeded, "test", "cancelled", llm.CategoryCancelled)}},
+		},
+		{
+			name:   "closed without a terminal marker",
+			chunks: []llm.Chunk{{Content: "Partial "}, {Content: "output", FinishReason: "stop"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseCache, err := cache.New(t.TempDir())
+			if err != nil {
+				t.Fatalf("Failed to create cache: %v", err)
+			}
+			client := &mockStreamingLLMClient{chunks: tt.chunks}
+			mockAPI := &mockAPIService{
+				initLLMClientFunc: func(ctx context.Context, apiKey, modelName, apiEndpoint string) (llm.LLMClient, error) {
+					return client, nil
+				},
+				processLLMResponseFunc: func(result *llm.ProviderResult) (string, error) {
+					return result.Content, nil
+				},
+			}
+			var saved []string
+			fileWriter := &mockFileWriter{
+				saveToFileFunc: func(ctx context.Context, content, outputFile string) error {
+					saved = append(saved, content)
+					return nil
+				},
+			}
+
+			cfg := config.NewDefaultCliConfig()
+			cfg.APIKey = "test-api-key"
+			cfg.OutputDir = t.TempDir()
+			process := func() (string, error) {
+				processor := modelproc.NewProcessor(mockAPI, fileWriter, &mockAuditLogger{}, newNoOpLogger(), cfg)
+				processor.SetCache(responseCache)
+				processor.SetStreamHandler(func(string) {})
+				return processor.Process(context.Background(), "test-model", "Test prompt")
+			}
+
+			if output, err := process(); err == nil {
+				t.Fatalf("Expected the cut-short stream to fail, got output %q", output)
+			}
+			if len(saved) != 0 {
+				t.Errorf("Expected no output saved, got %q", saved)
+			}
+
+			// Nothing was cached, so the next request generates a fresh response
+			client.chunks = []llm.Chunk{{Content: "Complete output", FinishReason: "stop"}, {Done: true}}
+			if output, err := process(); err != nil || output != "Complete output" {
+				t.Errorf("Expected a fresh complete response, got %q, %v", output, err)
+			}
+		})
+	}
+}
+
+// TestProcess_ResponseCache tests that successful responses are reused for identical
+// requests and that failed generations are never cached
+func TestProcess_ResponseCache(t *testing.T) {
+	responseCache, err := cache.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create cache: %v", err)
+	}
+
+	generateCalls := 0
+	generateErr := errors.New("generation failed")
+	var failNext bool
+	mockAPI := &mockAPIService{
+		initLLMClientFunc: func(ctx context.Context, apiKey, modelName, apiEndpoint string) (llm.LLMClient, error) {
+			return &mockLLMClient{
+				generateContentFunc: func(ctx context.Context, prompt string, params map[string]interface{}) (*llm.ProviderResult, error) {
+					generateCalls++
+					if failNext {
+						return nil, generateErr
+					}
+					return &llm.ProviderResult{Content: "Generated content"}, nil
+				},
+			}, nil
+		},
+		processLLMResponseFunc: func(result *llm.ProviderResult) (string, error) {
+			return result.Content, nil
+		},
+	}
+
+	var operations []string
+	mockAudit := &mockAuditLogger{
+		logOpFunc: func(ctx context.Context, operation, status string, inputs map[string]interface{}, outputs map[string]interface{}, err error) error {
+			operations = append(operations, operation+":"+status)
+			return nil
+		},
+	}
+
+	cfg := config.NewDefaultCliConfig()
+	cfg.APIKey = "test-api-key"
+	cfg.OutputDir = t.TempDir()
+	process := func(prompt string) (string, error) {
+		processor := modelproc.NewProcessor(mockAPI, &mockFileWriter{}, mockAudit, newNoOpLogger(), cfg)
+		processor.SetCache(responseCache)
+		return processor.Process(context.Background(), "test-model", prompt)
+	}
+
+	// A failed generation is not cached, so the next identical request calls the API
+	failNext = true
+	if _, err := process("Prompt"); !errors.Is(err, modelproc.ErrModelProcessingFailed) {
+		t.Fatalf("Expected generation failure, got: %v", err)
+	}
+	failNext = false
+	if output, err := process("Prompt"); err != nil || output != "Generated content" {
+		t.Fatalf("Expected generated content, got %q, %v", output, err)
+	}
+	if generateCalls != 2 {
+		t.Fatalf("Expected 2 API calls, got %d", generateCalls)
+	}
+
+	// The successful response is reused without calling the API
+	operations = nil
+	if output, err := process("Prompt"); err != nil || output != "Generated content" {
+		t.Fatalf("Expected cached content, got %q, %v", output, err)
+	}
+	if generateCalls != 2 {
+		t.Errorf("Expected the cached response to be reused, got %d API calls", generateCalls)
+	}
+	if len(operations) == 0 || operations[0] != "CacheHit:Success" {
+		t.Errorf("Expected a CacheHit audit entry first, got %v", operations)
+	}
+	for _, op := range operations {
+		if op == "GenerateContent:InProgress" {
+			t.Errorf("Expected no GenerateContent audit entries on a cache hit, got %v", operations)
+		}
+	}
+
+	// A different prompt misses
+	if _, err := process("Other prompt"); err != nil {
+		t.Fatalf("Expected success, got: %v", err)
+	}
+	if generateCalls != 3 {
+		t.Errorf("Expected a different prompt to call the API, got %d API calls", generateCalls)
+	}
+}
+
+func TestProcess_AuditTextCapture(t *testing.T) {
+	prompt := strings.Repeat("p", 50)
+	response := strings.Repeat("r", 40)
+	mockAPI := &mockAPIService{
+		initLLMClientFunc: func(ctx context.Context, apiKey, modelName, apiEndpoint string) (llm.LLMClient, error) {
+			return &mockLLMClient{
+				generateContentFunc: func(ctx context.Context, prompt string, params map[string]interface{}) (*llm.ProviderResult, error) {
+					return &llm.ProviderResult{Content: response}, nil
+				},
+			}, nil
+		},
+		processLLMResponseFunc: func(result *llm.ProviderResult) (string, error) {
+			return result.Content, nil
+		},
+	}
+
+	tests := []struct {
+		name         string
+		verbose      bool
+		preview      int
+		wantPrompt   interface{}
+		wantResponse interface{}
+	}{
+		{name: "lengths only", wantPrompt: nil, wantResponse: nil},
+		{name: "preview", preview: 10, wantPrompt: prompt[:10], wantResponse: response[:10]},
+		{name: "verbose", verbose: true, preview: 10, wantPrompt: prompt, wantResponse: response},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var generateInputs, generateOutputs map[string]interface{}
+			mockAudit := &mockAuditLogger{
+				logOpFunc: func(ctx context.Context, operation, status string, inputs map[string]interface{}, outputs map[string]interface{}, err error) error {
+					if operation == "GenerateContent" && status == "Success" {
+						generateInputs, generateOutputs = inputs, outputs
+					}
+					return nil
+				},
+			}
+
+			cfg := config.NewDefaultCliConfig()
+			cfg.APIKey = "test-api-key"
+			cfg.OutputDir = t.TempDir()
+			cfg.AuditVerbose = tt.verbose
+			cfg.AuditPreviewLength = tt.preview
+			processor := modelproc.NewProcessor(mockAPI, &mockFileWriter{}, mockAudit, newNoOpLogger(), cfg)
+			if _, err := processor.Process(context.Background(), "test-model", prompt); err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+
+			if generateInputs["prompt_length"] != len(prompt) || generateOutputs["response_length"] != len(response) {
+				t.Errorf("lengths = %v/%v, want %d/%d", generateInputs["prompt_length"], generateOutputs["response_length"], len(prompt), len(response))
+			}
+			if generateInputs["prompt"] != tt.wantPrompt {
+				t.Errorf("prompt = %v, want %v", generateInputs["prompt"], tt.wantPrompt)
+			}
+			if generateOutputs["response"] != tt.wantResponse {
+				t.Errorf("response = %v, want %v", generateOutputs["response"], tt.wantResponse)
+			}
+		})
+	}
+}
+
+func TestProcess_AuditTokenUsage(t *testing.T) {
+	prompt := strings.Repeat("a", 400)
+	response := strings.Repeat("b", 80)
+
+	tests := []struct {
+		name       string
+		modelName  string
+		usage      *llm.TokenUsage
+		wantSource interface{}
+		wantInput  interface{}
+		wantOutput interface{}
+		wantTotal  interface{}
+	}{
+		{
+			name:       "provider reported usage",
+			modelName:  "gpt-5.2",
+			usage:      &llm.TokenUsage{PromptTokens: 120, CompletionTokens: 30, TotalTokens: 150},
+			wantSource: "provider",
+			wantInput:  120,
+			wantOutput: 30,
+			wantTotal:  150,
+		},
+		{
+			name:       "provider total missing",
+			modelName:  "gpt-5.2",
+			usage:      &llm.TokenUsage{PromptTokens: 120, CompletionTokens: 30},
+			wantSource: "provider",
+			wantInput:  120,
+			wantOutput: 30,
+			wantTotal:  150,
+		},
+		{
+			name:       "falls back to estimate",
+			modelName:  "gpt-5.2",
+			wantSource: "estimate",
+			wantInput:  100, // 400 chars at 4 chars per token for OpenAI models
+			wantOutput: 20,
+			wantTotal:  120,
+		},
+		{
+			name:      "unknown model without usage",
+			modelName: "no-such-model",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI := &mockAPIService{
+				initLLMClientFunc: func(ctx context.Context, apiKey, modelName, apiEndpoint string) (llm.LLMClient, error) {
+					return &mockLLMClient{
+						generateContentFunc: func(ctx context.Context, prompt string, params map[string]interface{}) (*llm.ProviderResult, error) {
+							return &llm.ProviderResult{Content: response, Usage: tt.usage}, nil
+						},
+					}, nil
+				},
+				processLLMResponseFunc: func(result *llm.ProviderResult) (string, error) {
+					return result.Content, nil
+				},
+			}
+			var generateOutputs map[string]interface{}
+			mockAudit := &mockAuditLogger{
+				logOpFunc: func(ctx context.Context, operation, status string, inputs map[string]interface{}, outputs map[string]interface{}, err error) error {
+					if operation == "GenerateContent" && status == "Success" {
+						generateOutputs = outputs
+					}
+					return nil
+				},
+			}
+
+			cfg := config.NewDefaultCliConfig()
+			cfg.APIKey = "test-api-key"
+			cfg.OutputDir = t.TempDir()
+			processor := modelproc.NewProcessor(mockAPI, &mockFileWriter{}, mockAudit, newNoOpLogger(), cfg)
+			if _, err := processor.Process(context.Background(), tt.modelName, prompt); err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+
+			got := []interface{}{generateOutputs["token_source"], generateOutputs["input_tokens"], generateOutputs["output_tokens"], generateOutputs["total_tokens"]}
+			want := []interface{}{tt.wantSource, tt.wantInput, tt.wantOutput, tt.wantTotal}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("token_source, input, output, total = %v, want %v", got, want)
+					break
+				}
+			}
+		})
+	}
+}
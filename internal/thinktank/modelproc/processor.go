@@ -5,12 +5,13 @@ package modelproc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/misty-step/thinktank/internal/auditlog"
+	"github.com/misty-step/thinktank/internal/cache"
 	"github.com/misty-step/thinktank/internal/config"
 	"github.com/misty-step/thinktank/internal/llm"
 	"github.com/misty-step/thinktank/internal/logutil"
@@ -54,6 +55,21 @@ type ModelProcessor struct {
 	auditLogger auditlog.AuditLogger
 	logger      logutil.LoggerInterface
 	config      *config.CliConfig
+
+	// usage holds the provider-reported token usage from the last successful generation
+	usage *llm.TokenUsage
+
+	// onChunk receives content as it is generated when the client supports streaming (nil = buffer)
+	onChunk func(content string)
+
+	// cache stores successful responses for reuse by identical requests (nil = disabled)
+	cache *cache.Cache
+
+	// outputPath returns where a model's output is saved (nil = <OutputDir>/<model>.md)
+	outputPath func(modelName string) string
+
+	// onSave is called with the path of each output file once it is saved (nil = none)
+	onSave func(ctx context.Context, path string)
 }
 
 // NewProcessor creates a new ModelProcessor with all required dependencies.
@@ -75,6 +91,43 @@ func NewProcessor(
 	}
 }
 
+// SetStreamHandler passes generated content to onChunk as it arrives when the model's
+// client implements llm.StreamingLLMClient. Other clients return the full response at once.
+func (p *ModelProcessor) SetStreamHandler(onChunk func(content string)) {
+	p.onChunk = onChunk
+}
+
+// SetCache reuses responses stored in c for identical requests and stores new successful ones
+func (p *ModelProcessor) SetCache(c *cache.Cache) {
+	p.cache = c
+}
+
+// SetOutputPath saves each model's output to the path outputPath returns for it,
+// so the file matches the one the orchestrator writes under a custom name template
+func (p *ModelProcessor) SetOutputPath(outputPath func(modelName string) string) {
+	p.outputPath = outputPath
+}
+
+// SetSaveHandler calls onSave with the path of the model's output file as soon as
+// it is saved, before Process returns
+func (p *ModelProcessor) SetSaveHandler(onSave func(ctx context.Context, path string)) {
+	p.onSave = onSave
+}
+
+// generate requests content from llmClient, streaming it when a handler is set and supported
+func (p *ModelProcessor) generate(ctx context.Context, llmClient llm.LLMClient, prompt string, params map[string]interface{}) (*llm.ProviderResult, error) {
+	streamer, ok := llmClient.(llm.StreamingLLMClient)
+	if p.onChunk == nil || !ok {
+		return llmClient.GenerateContent(ctx, prompt, params)
+	}
+
+	stream, err := streamer.GenerateContentStream(ctx, prompt, params)
+	if err != nil {
+		return nil, err
+	}
+	return llm.CollectStream(stream, p.onChunk)
+}
+
 // Process handles the entire model processing workflow for a single model.
 // It implements the logic from the previous processModel/processModelConcurrently functions,
 // including initialization, token checking, generation, response processing, and output saving.
@@ -87,13 +140,116 @@ func NewProcessor(
 func (p *ModelProcessor) Process(ctx context.Context, modelName string, stitchedPrompt string) (string, error) {
 	p.logger.InfoContext(ctx, "Processing model: %s", modelName)
 
+	// Get model parameters from the APIService
+	params, err := p.apiService.GetModelParameters(ctx, modelName)
+	if errors.Is(err, models.ErrInvalidModelParams) {
+		return "", err
+	}
+	if err != nil {
+		p.logger.DebugContext(ctx, "Failed to get model parameters for %s: %v. Using defaults.", modelName, err)
+		// Continue with empty parameters if there's an error
+		params = make(map[string]interface{})
+	}
+
+	// Log parameters being used (at debug level)
+	if len(params) > 0 {
+		p.logger.DebugContext(ctx, "Using model parameters for %s:", modelName)
+		for k, v := range params {
+			p.logger.DebugContext(ctx, "  %s: %v", k, v)
+		}
+	}
+
+	// Reuse a stored response for identical inputs instead of calling the API
+	result, cacheKey := p.cachedResult(ctx, modelName, stitchedPrompt, params)
+	cacheHit := result != nil
+	if !cacheHit {
+		if result, err = p.generateContent(ctx, modelName, stitchedPrompt, params); err != nil {
+			return "", err
+		}
+	}
+
+	// 4. Process API response
+	generatedOutput, err := p.apiService.ProcessLLMResponse(result)
+	if err != nil {
+		// Get detailed error information
+		errorDetails := p.apiService.GetErrorDetails(err)
+
+		// Provide specific error messages based on error type
+		if p.apiService.IsEmptyResponseError(err) {
+			p.logger.ErrorContext(ctx, "Received empty or invalid response from API for model %s", modelName)
+			p.logger.ErrorContext(ctx, "Error details: %s", errorDetails)
+			return "", llm.Wrap(ErrEmptyModelResponse, "", fmt.Sprintf("failed to process API response for model %s due to empty content: %v", modelName, err), llm.CategoryInvalidRequest)
+		} else if p.apiService.IsSafetyBlockedError(err) {
+			p.logger.ErrorContext(ctx, "Content was blocked by safety filters for model %s", modelName)
+			p.logger.ErrorContext(ctx, "Error details: %s", errorDetails)
+			return "", llm.Wrap(ErrContentFiltered, "", fmt.Sprintf("failed to process API response for model %s due to safety restrictions: %v", modelName, err), llm.CategoryContentFiltered)
+		} else if catErr, isCat := llm.IsCategorizedError(err); isCat {
+			// Use the new error categorization for more specific messages
+			switch catErr.Category() {
+			case llm.CategoryContentFiltered:
+				p.logger.ErrorContext(ctx, "Content was filtered by safety settings for model %s", modelName)
+				p.logger.ErrorContext(ctx, "Error details: %s", errorDetails)
+				return "", llm.Wrap(ErrContentFiltered, "", fmt.Sprintf("failed to process API response for model %s due to content filtering: %v", modelName, err), llm.CategoryContentFiltered)
+			case llm.CategoryRateLimit:
+				p.logger.ErrorContext(ctx, "Rate limit exceeded while processing response for model %s", modelName)
+				p.logger.ErrorContext(ctx, "Error details: %s", errorDetails)
+				return "", llm.Wrap(ErrModelRateLimited, "", fmt.Sprintf("failed to process API response for model %s due to rate limiting: %v", modelName, err), llm.CategoryRateLimit)
+			case llm.CategoryInputLimit:
+				p.logger.ErrorContext(ctx, "Input limit exceeded during response processing for model %s", modelName)
+				p.logger.ErrorContext(ctx, "Error details: %s", errorDetails)
+				return "", llm.Wrap(ErrModelTokenLimitExceeded, "", fmt.Sprintf("failed to process API response for model %s due to input limits: %v", modelName, err), llm.CategoryInputLimit)
+			default:
+				// Other categorized errors
+				p.logger.ErrorContext(ctx, "Error processing response for model %s (%s category)", modelName, catErr.Category())
+				p.logger.ErrorContext(ctx, "Error details: %s", errorDetails)
+				return "", llm.Wrap(ErrInvalidModelResponse, "", fmt.Sprintf("failed to process API response for model %s (%s error): %v", modelName, catErr.Category(), err), catErr.Category())
+			}
+		} else {
+			// Generic API error handling
+			p.logger.ErrorContext(ctx, "Error processing API response for model %s", modelName)
+			return "", llm.Wrap(ErrInvalidModelResponse, "", fmt.Sprintf("failed to process API response for model %s: %v", modelName, err), llm.CategoryInvalidRequest)
+		}
+	}
+	// Only successful generations are cached, so a failure is retried on the next run
+	if cacheKey != "" && !cacheHit {
+		if err := p.cache.Put(cacheKey, result.Content); err != nil {
+			p.logger.WarnContext(ctx, "Failed to cache response for model %s: %v", modelName, err)
+		}
+	}
+
+	contentLength := len(generatedOutput)
+	p.logger.InfoContext(ctx, "Output generated successfully with model %s (content length: %d characters)",
+		modelName, contentLength)
+
+	// 5. Save the output to its own file, unless every output goes to the one
+	// --combined-output file the orchestrator writes once all models finish
+	if p.config.CombinedOutput == "" {
+		outputFilePath := filepath.Join(p.config.OutputDir, models.SafeFilename(modelName)+".md")
+		if p.outputPath != nil {
+			outputFilePath = p.outputPath(modelName)
+		}
+		if err := p.saveOutputToFile(ctx, outputFilePath, generatedOutput); err != nil {
+			return "", llm.Wrap(ErrOutputWriteFailed, "", fmt.Sprintf("failed to save output for model %s: %v", modelName, err), llm.CategoryInvalidRequest)
+		}
+		if p.onSave != nil {
+			p.onSave(ctx, outputFilePath)
+		}
+	}
+
+	p.logger.InfoContext(ctx, "Successfully processed model: %s", modelName)
+	return generatedOutput, nil
+}
+
+// generateContent initializes the model's client and generates a response,
+// recording the request in the audit log
+func (p *ModelProcessor) generateContent(ctx context.Context, modelName string, stitchedPrompt string, params map[string]interface{}) (*llm.ProviderResult, error) {
 	// 1. Initialize model-specific LLM client
 	llmClient, err := p.apiService.InitLLMClient(ctx, p.config.APIKey, modelName, p.config.APIEndpoint)
 	if err != nil {
 		// Use the APIService interface for consistent error detail extraction
 		errorDetails := p.apiService.GetErrorDetails(err)
 		p.logger.ErrorContext(ctx, "Error creating LLM client for model %s: %s", modelName, errorDetails)
-		return "", llm.Wrap(ErrModelInitializationFailed, "", fmt.Sprintf("failed to initialize API client for model %s: %v", modelName, err), llm.CategoryInvalidRequest)
+		return nil, llm.Wrap(ErrModelInitializationFailed, "", fmt.Sprintf("failed to initialize API client for model %s: %v", modelName, err), llm.CategoryInvalidRequest)
 	}
 
 	// BUGFIX: Ensure llmClient is not nil before attempting to close it
@@ -115,31 +271,15 @@ func (p *ModelProcessor) Process(ctx context.Context, modelName string, stitched
 	// Log the start of content generation
 	generateStartTime := time.Now()
 	inputs := map[string]interface{}{
-		"model_name":    modelName,
-		"prompt_length": len(stitchedPrompt),
+		"model_name": modelName,
 	}
+	p.auditTextCapture().Record(inputs, "prompt", stitchedPrompt)
 	if logErr := p.auditLogger.LogOp(ctx, "GenerateContent", "InProgress", inputs, nil, nil); logErr != nil {
 		p.logger.ErrorContext(ctx, "Failed to write audit log: %v", logErr)
 	}
 
-	// Get model parameters from the APIService
-	params, err := p.apiService.GetModelParameters(ctx, modelName)
-	if err != nil {
-		p.logger.DebugContext(ctx, "Failed to get model parameters for %s: %v. Using defaults.", modelName, err)
-		// Continue with empty parameters if there's an error
-		params = make(map[string]interface{})
-	}
-
-	// Log parameters being used (at debug level)
-	if len(params) > 0 {
-		p.logger.DebugContext(ctx, "Using model parameters for %s:", modelName)
-		for k, v := range params {
-			p.logger.DebugContext(ctx, "  %s: %v", k, v)
-		}
-	}
-
 	// Generate content with parameters
-	result, err := llmClient.GenerateContent(ctx, stitchedPrompt, params)
+	result, err := p.generate(ctx, llmClient, stitchedPrompt, params)
 
 	// Calculate duration in milliseconds
 	generateDurationMs := time.Since(generateStartTime).Milliseconds()
@@ -159,7 +299,15 @@ func (p *ModelProcessor) Process(ctx context.Context, modelName string, stitched
 			p.logger.ErrorContext(ctx, "Failed to write audit log: %v", logErr)
 		}
 
-		return "", llm.Wrap(ErrModelProcessingFailed, "", fmt.Sprintf("output generation failed for model %s: %v", modelName, err), llm.CategoryInvalidRequest)
+		// Keep the provider's error category so callers can still tell rate limits apart
+		category := llm.CategoryInvalidRequest
+		if catErr, ok := llm.IsCategorizedError(err); ok {
+			category = catErr.Category()
+		}
+		procErr := llm.Wrap(ErrModelProcessingFailed, "", fmt.Sprintf("output generation failed for model %s: %v", modelName, err), category)
+		// Keep the provider's Retry-After so the orchestrator can pause this model
+		procErr.RetryAfter = llm.RetryAfterFromError(err)
+		return nil, procErr
 	}
 
 	// Log successful content generation
@@ -168,89 +316,95 @@ func (p *ModelProcessor) Process(ctx context.Context, modelName string, stitched
 		"finish_reason":      result.FinishReason,
 		"has_safety_ratings": len(result.SafetyInfo) > 0,
 	}
+	p.usage = result.Usage
+	if result.Usage != nil {
+		outputs["provider_prompt_tokens"] = result.Usage.PromptTokens
+		outputs["provider_completion_tokens"] = result.Usage.CompletionTokens
+		outputs["provider_total_tokens"] = result.Usage.TotalTokens
+	}
+	recordTokenUsage(outputs, modelName, result.Usage, stitchedPrompt, result.Content)
+	p.auditTextCapture().Record(outputs, "response", result.Content)
 	if logErr := p.auditLogger.LogOp(ctx, "GenerateContent", "Success", inputs, outputs, nil); logErr != nil {
 		p.logger.ErrorContext(ctx, "Failed to write audit log: %v", logErr)
 	}
+	return result, nil
+}
 
-	// 4. Process API response
-	generatedOutput, err := p.apiService.ProcessLLMResponse(result)
-	if err != nil {
-		// Get detailed error information
-		errorDetails := p.apiService.GetErrorDetails(err)
+// Token usage sources recorded as token_source in GenerateContent audit entries
+const (
+	tokenSourceProvider = "provider" // Counts the provider reported and billed
+	tokenSourceEstimate = "estimate" // Estimated from the text; the provider reported none
+)
 
-		// Provide specific error messages based on error type
-		if p.apiService.IsEmptyResponseError(err) {
-			p.logger.ErrorContext(ctx, "Received empty or invalid response from API for model %s", modelName)
-			p.logger.ErrorContext(ctx, "Error details: %s", errorDetails)
-			return "", llm.Wrap(ErrEmptyModelResponse, "", fmt.Sprintf("failed to process API response for model %s due to empty content: %v", modelName, err), llm.CategoryInvalidRequest)
-		} else if p.apiService.IsSafetyBlockedError(err) {
-			p.logger.ErrorContext(ctx, "Content was blocked by safety filters for model %s", modelName)
-			p.logger.ErrorContext(ctx, "Error details: %s", errorDetails)
-			return "", llm.Wrap(ErrContentFiltered, "", fmt.Sprintf("failed to process API response for model %s due to safety restrictions: %v", modelName, err), llm.CategoryContentFiltered)
-		} else if catErr, isCat := llm.IsCategorizedError(err); isCat {
-			// Use the new error categorization for more specific messages
-			switch catErr.Category() {
-			case llm.CategoryContentFiltered:
-				p.logger.ErrorContext(ctx, "Content was filtered by safety settings for model %s", modelName)
-				p.logger.ErrorContext(ctx, "Error details: %s", errorDetails)
-				return "", llm.Wrap(ErrContentFiltered, "", fmt.Sprintf("failed to process API response for model %s due to content filtering: %v", modelName, err), llm.CategoryContentFiltered)
-			case llm.CategoryRateLimit:
-				p.logger.ErrorContext(ctx, "Rate limit exceeded while processing response for model %s", modelName)
-				p.logger.ErrorContext(ctx, "Error details: %s", errorDetails)
-				return "", llm.Wrap(ErrModelRateLimited, "", fmt.Sprintf("failed to process API response for model %s due to rate limiting: %v", modelName, err), llm.CategoryRateLimit)
-			case llm.CategoryInputLimit:
-				p.logger.ErrorContext(ctx, "Input limit exceeded during response processing for model %s", modelName)
-				p.logger.ErrorContext(ctx, "Error details: %s", errorDetails)
-				return "", llm.Wrap(ErrModelTokenLimitExceeded, "", fmt.Sprintf("failed to process API response for model %s due to input limits: %v", modelName, err), llm.CategoryInputLimit)
-			default:
-				// Other categorized errors
-				p.logger.ErrorContext(ctx, "Error processing response for model %s (%s category)", modelName, catErr.Category())
-				p.logger.ErrorContext(ctx, "Error details: %s", errorDetails)
-				return "", llm.Wrap(ErrInvalidModelResponse, "", fmt.Sprintf("failed to process API response for model %s (%s error): %v", modelName, catErr.Category(), err), catErr.Category())
-			}
-		} else {
-			// Generic API error handling
-			p.logger.ErrorContext(ctx, "Error processing API response for model %s", modelName)
-			return "", llm.Wrap(ErrInvalidModelResponse, "", fmt.Sprintf("failed to process API response for model %s: %v", modelName, err), llm.CategoryInvalidRequest)
+// recordTokenUsage adds input_tokens, output_tokens, and total_tokens to outputs,
+// taken from the provider's usage when it reported any and estimated from the
+// prompt and response otherwise. Unknown models without usage get no counts.
+func recordTokenUsage(outputs map[string]interface{}, modelName string, usage *llm.TokenUsage, prompt, content string) {
+	if usage != nil {
+		total := usage.TotalTokens
+		if total == 0 {
+			total = usage.PromptTokens + usage.CompletionTokens
 		}
+		outputs["input_tokens"] = usage.PromptTokens
+		outputs["output_tokens"] = usage.CompletionTokens
+		outputs["total_tokens"] = total
+		outputs["token_source"] = tokenSourceProvider
+		return
 	}
-	contentLength := len(generatedOutput)
-	p.logger.InfoContext(ctx, "Output generated successfully with model %s (content length: %d characters)",
-		modelName, contentLength)
 
-	// 5. Sanitize model name for use in filename
-	sanitizedModelName := SanitizeFilename(modelName)
+	inputTokens, err := models.CountTokensEstimate(modelName, prompt)
+	if err != nil {
+		return
+	}
+	outputTokens, _ := models.CountTokensEstimate(modelName, content)
+	outputs["input_tokens"] = inputTokens
+	outputs["output_tokens"] = outputTokens
+	outputs["total_tokens"] = inputTokens + outputTokens
+	outputs["token_source"] = tokenSourceEstimate
+}
 
-	// 6. Construct output file path
-	outputFilePath := filepath.Join(p.config.OutputDir, sanitizedModelName+".md")
+// auditTextCapture is how much prompt and response text audit entries keep
+func (p *ModelProcessor) auditTextCapture() auditlog.TextCapture {
+	if p.config == nil {
+		return auditlog.TextCapture{}
+	}
+	return auditlog.TextCapture{Full: p.config.AuditVerbose, PreviewLength: p.config.AuditPreviewLength}
+}
 
-	// 7. Save the output to file
-	if err := p.saveOutputToFile(ctx, outputFilePath, generatedOutput); err != nil {
-		return "", llm.Wrap(ErrOutputWriteFailed, "", fmt.Sprintf("failed to save output for model %s: %v", modelName, err), llm.CategoryInvalidRequest)
+// cachedResult returns the cached response for this request and its cache key, or a nil
+// result on a miss. The key is empty when caching is disabled.
+func (p *ModelProcessor) cachedResult(ctx context.Context, modelName string, stitchedPrompt string, params map[string]interface{}) (*llm.ProviderResult, string) {
+	if p.cache == nil {
+		return nil, ""
 	}
 
-	p.logger.InfoContext(ctx, "Successfully processed model: %s", modelName)
-	return generatedOutput, nil
+	key := cache.Key(modelName, stitchedPrompt, params)
+	content, ok := p.cache.Get(key)
+	if !ok {
+		p.logger.DebugContext(ctx, "No cached response for model %s", modelName)
+		return nil, key
+	}
+
+	p.logger.InfoContext(ctx, "Using cached response for model %s", modelName)
+	inputs := map[string]interface{}{
+		"model_name":    modelName,
+		"prompt_length": len(stitchedPrompt),
+		"cache_key":     key,
+	}
+	outputs := map[string]interface{}{
+		"cache_hit":      true,
+		"content_length": len(content),
+	}
+	if logErr := p.auditLogger.LogOp(ctx, "CacheHit", "Success", inputs, outputs, nil); logErr != nil {
+		p.logger.ErrorContext(ctx, "Failed to write audit log: %v", logErr)
+	}
+	return &llm.ProviderResult{Content: content, SafetyInfo: []llm.Safety{}}, key
 }
 
-// SanitizeFilename replaces characters that are not valid in filenames
-// with safe alternatives to ensure filenames are valid across different operating systems.
-func SanitizeFilename(filename string) string {
-	// Replace slashes and other problematic characters with hyphens
-	replacer := strings.NewReplacer(
-		"/", "-",
-		"\\", "-",
-		":", "-",
-		"*", "-",
-		"?", "-",
-		"\"", "-",
-		"'", "-", // Also replace single quotes
-		"<", "-",
-		">", "-",
-		"|", "-",
-		" ", "_", // Replace spaces with underscores for better readability
-	)
-	return replacer.Replace(filename)
+// Usage returns the token usage the provider reported for the last successful
+// generation, or nil if the provider didn't report any
+func (p *ModelProcessor) Usage() *llm.TokenUsage {
+	return p.usage
 }
 
 // saveOutputToFile is a helper method that saves the generated output to a file
@@ -0,0 +1,58 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// startCheckpoints emits a progress checkpoint every CheckpointInterval until
+// the returned stop function is called. The done counter is read on each tick.
+// Returns a no-op stop function when checkpoints are disabled.
+func (o *Orchestrator) startCheckpoints(ctx context.Context, total int, done *atomic.Int64) func() {
+	interval := o.config.CheckpointInterval
+	if interval <= 0 {
+		return func() {}
+	}
+
+	start := time.Now()
+	ticker := time.NewTicker(interval)
+	stopCh := make(chan struct{})
+	finished := make(chan struct{})
+
+	go func() {
+		defer close(finished)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				message := formatCheckpoint(int(done.Load()), total, time.Since(start))
+				o.logger.InfoContext(ctx, "%s", message)
+				// StatusMessage is already suppressed in quiet mode
+				o.consoleWriter.StatusMessage(message)
+			case <-stopCh:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(stopCh)
+		<-finished
+	}
+}
+
+// formatCheckpoint renders a progress checkpoint line.
+// The remaining time is extrapolated from the average time per finished model.
+func formatCheckpoint(done, total int, elapsed time.Duration) string {
+	remaining := "unknown"
+	if done > 0 && done <= total {
+		perModel := elapsed / time.Duration(done)
+		remaining = (perModel * time.Duration(total-done)).Round(time.Second).String()
+	}
+	return fmt.Sprintf("Checkpoint: %d/%d models done, elapsed %s, estimated remaining %s",
+		done, total, elapsed.Round(time.Second), remaining)
+}
@@ -0,0 +1,91 @@
+package orchestrator
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/misty-step/thinktank/internal/config"
+	"github.com/misty-step/thinktank/internal/testutil"
+)
+
+func TestFormatCheckpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		done    int
+		total   int
+		elapsed time.Duration
+		want    string
+	}{
+		{
+			name:    "nothing done yet",
+			done:    0,
+			total:   3,
+			elapsed: 10 * time.Second,
+			want:    "Checkpoint: 0/3 models done, elapsed 10s, estimated remaining unknown",
+		},
+		{
+			name:    "partially done",
+			done:    1,
+			total:   3,
+			elapsed: 30 * time.Second,
+			want:    "Checkpoint: 1/3 models done, elapsed 30s, estimated remaining 1m0s",
+		},
+		{
+			name:    "all done",
+			done:    4,
+			total:   4,
+			elapsed: 90 * time.Second,
+			want:    "Checkpoint: 4/4 models done, elapsed 1m30s, estimated remaining 0s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatCheckpoint(tt.done, tt.total, tt.elapsed); got != tt.want {
+				t.Errorf("formatCheckpoint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartCheckpoints(t *testing.T) {
+	t.Run("disabled when interval is zero", func(t *testing.T) {
+		logger := testutil.NewMockLogger()
+		o := &Orchestrator{logger: logger, consoleWriter: &MockConsoleWriter{}, config: &config.CliConfig{}}
+
+		var done atomic.Int64
+		stop := o.startCheckpoints(context.Background(), 2, &done)
+		stop()
+
+		if logger.ContainsMessage("Checkpoint:") {
+			t.Error("expected no checkpoint messages when disabled")
+		}
+	})
+
+	t.Run("emits checkpoints until stopped", func(t *testing.T) {
+		logger := testutil.NewMockLogger()
+		o := &Orchestrator{
+			logger:        logger,
+			consoleWriter: &MockConsoleWriter{},
+			config:        &config.CliConfig{CheckpointInterval: 5 * time.Millisecond},
+		}
+
+		var done atomic.Int64
+		done.Store(1)
+		stop := o.startCheckpoints(context.Background(), 2, &done)
+		time.Sleep(30 * time.Millisecond)
+		stop()
+
+		if !logger.ContainsMessage("Checkpoint: 1/2 models done") {
+			t.Errorf("expected checkpoint message, got %v", logger.GetInfoMessages())
+		}
+
+		count := len(logger.GetInfoMessages())
+		time.Sleep(20 * time.Millisecond)
+		if got := len(logger.GetInfoMessages()); got != count {
+			t.Errorf("checkpoints continued after stop: %d messages, then %d", count, got)
+		}
+	})
+}
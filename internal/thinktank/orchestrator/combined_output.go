@@ -0,0 +1,80 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ValidateCombinedOutput checks that a --combined-output value names a file
+// that won't collide with the run manifest
+func ValidateCombinedOutput(path string) error {
+	if strings.TrimSpace(path) == "" {
+		return fmt.Errorf("combined output file must not be empty")
+	}
+	if strings.HasSuffix(path, "/") || strings.HasSuffix(path, string(filepath.Separator)) {
+		return fmt.Errorf("combined output %q must name a file, not a directory", path)
+	}
+	if !filepath.IsAbs(path) && filepath.Clean(path) == ManifestFileName {
+		return fmt.Errorf("combined output %q would overwrite the run manifest", path)
+	}
+	return nil
+}
+
+// combinedOutputPath resolves --combined-output: relative paths are placed in
+// the output directory, next to the manifest; absolute paths are used as given
+func combinedOutputPath(outputDir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(outputDir, path)
+}
+
+// combineOutputs concatenates the successful outputs under a "## model" heading
+// each, in configuration order, returning the content and the models included.
+// This is plain concatenation; synthesis is what asks an LLM to merge outputs.
+func combineOutputs(modelNames []string, modelOutputs map[string]string) (string, []string) {
+	var sections []string
+	var included []string
+	for _, modelName := range modelNames {
+		content, ok := modelOutputs[modelName]
+		if !ok {
+			continue
+		}
+		sections = append(sections, "## "+modelName+"\n\n"+strings.TrimSpace(content)+"\n")
+		included = append(included, modelName)
+	}
+	return strings.Join(sections, "\n"), included
+}
+
+// runCombinedOutputFlow writes every successful model output into the single
+// --combined-output file instead of one file per model. With --embed-instructions
+// the instructions head the file once rather than repeating in every section.
+func (o *Orchestrator) runCombinedOutputFlow(ctx context.Context, instructions string, modelOutputs map[string]string) (string, []string, error) {
+	contextLogger := o.logger.WithContext(ctx)
+
+	content, included := combineOutputs(o.runUnits(), modelOutputs)
+	if len(included) == 0 {
+		contextLogger.WarnContext(ctx, "No model outputs available to combine")
+		return "", nil, nil
+	}
+	if o.config.EmbedInstructions {
+		content = embedInstructions(instructions, content)
+	}
+
+	outputPath := combinedOutputPath(o.config.OutputDir, o.config.CombinedOutput)
+	o.consoleWriter.ShowFileOperations("Saving combined output...")
+	if err := o.fileWriter.SaveToFile(ctx, content, outputPath); err != nil {
+		contextLogger.ErrorContext(ctx, "Failed to save combined output to %s: %v", outputPath, err)
+		return "", nil, WrapOrchestratorError(
+			ErrOutputFileSaveFailed,
+			fmt.Sprintf("failed to save combined output to %s: %v", outputPath, err),
+		)
+	}
+
+	contextLogger.InfoContext(ctx, "Saved %d model outputs to combined file %s", len(included), outputPath)
+	o.postProcessFile(ctx, outputPath)
+	o.consoleWriter.ShowFileOperations(fmt.Sprintf("● Combined output saved to: %s", outputPath))
+	return outputPath, included, nil
+}
@@ -0,0 +1,160 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/misty-step/thinktank/internal/config"
+	"github.com/misty-step/thinktank/internal/metrics"
+	"github.com/misty-step/thinktank/internal/ratelimit"
+	"github.com/misty-step/thinktank/internal/testutil"
+)
+
+func TestValidateCombinedOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path    string
+		wantErr string
+	}{
+		{path: "all.md"},
+		{path: "reports/all.md"},
+		{path: "/tmp/all.md"},
+		{path: " ", wantErr: "must not be empty"},
+		{path: "reports/", wantErr: "must name a file"},
+		{path: "./manifest.json", wantErr: "overwrite the run manifest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateCombinedOutput(tt.path)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateCombinedOutput(%q) = %v, want nil", tt.path, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateCombinedOutput(%q) = %v, want error containing %q", tt.path, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCombineOutputs(t *testing.T) {
+	t.Parallel()
+
+	content, included := combineOutputs(
+		[]string{"model-b", "model-failed", "model-a"},
+		map[string]string{"model-a": "Alpha\n", "model-b": "\nBeta"},
+	)
+
+	want := "## model-b\n\nBeta\n\n## model-a\n\nAlpha\n"
+	if content != want {
+		t.Errorf("content = %q, want %q", content, want)
+	}
+	if !reflect.DeepEqual(included, []string{"model-b", "model-a"}) {
+		t.Errorf("included = %v, want models in configuration order", included)
+	}
+}
+
+func TestHandleOutputFlow_CombinedOutput(t *testing.T) {
+	ctx := context.Background()
+	o, _, _, _ := newStatusTestOrchestrator()
+	fileWriter := &MockFileWriter{}
+	outputWriter := &TestOutputWriter{}
+	o.fileWriter = fileWriter
+	o.outputWriter = outputWriter
+	o.config.OutputDir = "/out"
+	o.config.ModelNames = []string{"model1", "model2"}
+	o.config.CombinedOutput = "all.md"
+	o.config.EmbedInstructions = true
+
+	outputInfo, err := o.handleOutputFlow(ctx, "Review it", map[string]string{"model1": "One", "model2": "Two"})
+	if err != nil {
+		t.Fatalf("handleOutputFlow() error = %v", err)
+	}
+
+	combinedPath := filepath.Join("/out", "all.md")
+	want := "## Instructions\n\n> Review it\n\n---\n\n## model1\n\nOne\n\n## model2\n\nTwo\n"
+	if got := fileWriter.savedFiles[combinedPath]; got != want {
+		t.Errorf("combined file = %q, want %q", got, want)
+	}
+	if len(fileWriter.savedFiles) != 1 || outputWriter.capturedIndividualOutputs != nil {
+		t.Errorf("individual outputs should not be saved; files = %v", fileWriter.savedFiles)
+	}
+	if outputInfo.CombinedFilePath != combinedPath || len(outputInfo.IndividualFilePaths) != 0 {
+		t.Errorf("outputInfo = %+v, want only the combined file", outputInfo)
+	}
+
+	manifest := o.buildManifest(map[string]string{"model1": "One", "model2": "Two"}, outputInfo)
+	wantCombined := &ManifestCombined{File: "all.md", Models: []string{"model1", "model2"}}
+	if !reflect.DeepEqual(manifest.Combined, wantCombined) {
+		t.Errorf("manifest.Combined = %+v, want %+v", manifest.Combined, wantCombined)
+	}
+	if manifest.Models[0].File != "all.md" {
+		t.Errorf("manifest entry = %+v, want it to point at the combined file", manifest.Models[0])
+	}
+
+	summary := o.generateResultsSummary(map[string]string{"model1": "One", "model2": "Two"}, outputInfo, nil)
+	if !reflect.DeepEqual(summary.OutputPaths, []string{combinedPath}) {
+		t.Errorf("summary.OutputPaths = %v, want the combined file", summary.OutputPaths)
+	}
+}
+
+func TestProcessModelsWithCombinedOutputWritesOnlyTheCombinedFile(t *testing.T) {
+	ctx := context.Background()
+	fileWriter := &MockFileWriter{}
+	outputDir := t.TempDir()
+	o := &Orchestrator{
+		apiService:       &MockAPIService{},
+		fileWriter:       fileWriter,
+		outputWriter:     &TestOutputWriter{},
+		auditLogger:      NewMockAuditLogger(),
+		rateLimiter:      ratelimit.NewRateLimiter(1, 0),
+		logger:           testutil.NewMockLogger(),
+		consoleWriter:    &MockConsoleWriter{},
+		metricsCollector: metrics.NewNoopCollector(),
+		config: &config.CliConfig{
+			ModelNames:     []string{"model1", "model2"},
+			OutputDir:      outputDir,
+			CombinedOutput: "all.md",
+		},
+	}
+
+	outputs, errs := o.processModels(ctx, "prompt")
+	if len(errs) != 0 || len(outputs) != 2 {
+		t.Fatalf("processModels() = %v, %v", outputs, errs)
+	}
+	if _, err := o.handleOutputFlow(ctx, "prompt", outputs); err != nil {
+		t.Fatalf("handleOutputFlow() error = %v", err)
+	}
+
+	var saved []string
+	for path := range fileWriter.savedFiles {
+		saved = append(saved, path)
+	}
+	if want := []string{filepath.Join(outputDir, "all.md")}; !reflect.DeepEqual(saved, want) {
+		t.Errorf("saved files = %v, want only %v", saved, want)
+	}
+}
+
+func TestHandleOutputFlow_CombinedOutputSaveFailure(t *testing.T) {
+	o, _, _, _ := newStatusTestOrchestrator()
+	o.fileWriter = &MockFileWriter{saveError: errors.New("disk full")}
+	o.config.ModelNames = []string{"model1"}
+	o.config.CombinedOutput = "/abs/all.md"
+
+	outputInfo, err := o.handleOutputFlow(context.Background(), "", map[string]string{"model1": "One"})
+	if !errors.Is(err, ErrOutputFileSaveFailed) {
+		t.Errorf("handleOutputFlow() error = %v, want ErrOutputFileSaveFailed", err)
+	}
+	if outputInfo.CombinedFilePath != "" {
+		t.Errorf("CombinedFilePath = %q, want empty after a failed save", outputInfo.CombinedFilePath)
+	}
+}
@@ -3,8 +3,12 @@ package orchestrator
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
+
+	"github.com/misty-step/thinktank/internal/config"
+	"github.com/misty-step/thinktank/internal/logutil"
 )
 
 // ModelCompatibilityInfo holds detailed information about a model's compatibility
@@ -35,6 +39,12 @@ type CompatibilityAnalysis struct {
 
 // displayCompatibilityCard shows a clean, modern compatibility analysis
 func (o *Orchestrator) displayCompatibilityCard(analysis CompatibilityAnalysis) {
+	if o.config.OutputFormat == config.OutputFormatJSON {
+		return // The card would corrupt the JSON summary on stdout
+	}
+	if o.config.Silent {
+		return // --silent keeps stdout empty
+	}
 	fmt.Println()
 
 	// Main status line - clean and prominent
@@ -62,6 +72,8 @@ func (o *Orchestrator) displayCompatibilityCard(analysis CompatibilityAnalysis)
 	// Verbose mode: show all models
 	if o.config.Verbose && len(analysis.AllModels) > 1 {
 		fmt.Println()
+		interactive := o.consoleWriter != nil && o.consoleWriter.IsInteractive()
+		colorize := logutil.ColorsEnabled(o.config.ColorMode, interactive, os.Getenv)
 		for _, model := range analysis.AllModels {
 			status := "✓"
 			color := "\033[32m" // green
@@ -69,14 +81,17 @@ func (o *Orchestrator) displayCompatibilityCard(analysis CompatibilityAnalysis)
 				status = "✗"
 				color = "\033[31m" // red
 			}
+			if colorize {
+				status = color + status + "\033[0m"
+			}
 
 			if model.ContextWindow > 0 {
-				fmt.Printf("   %s%s\033[0m %-20s %.1f%% (%s/%s)\n",
-					color, status, model.ModelName, model.Utilization,
+				fmt.Printf("   %s %-20s %.1f%% (%s/%s)\n",
+					status, model.ModelName, model.Utilization,
 					formatWithCommas(model.TokenCount), formatWithCommas(model.ContextWindow))
 			} else {
-				fmt.Printf("   %s%s\033[0m %-20s %s\n",
-					color, status, model.ModelName, model.FailureReason)
+				fmt.Printf("   %s %-20s %s\n",
+					status, model.ModelName, model.FailureReason)
 			}
 		}
 	}
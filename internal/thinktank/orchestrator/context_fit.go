@@ -0,0 +1,140 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/misty-step/thinktank/internal/fileutil"
+	"github.com/misty-step/thinktank/internal/llm"
+	"github.com/misty-step/thinktank/internal/models"
+	"github.com/misty-step/thinktank/internal/thinktank/interfaces"
+)
+
+// inputLimitError reports that a model was skipped because its prompt is too large
+func inputLimitError(modelName string, tokens int, threshold float64, contextWindow int) error {
+	return llm.New("orchestrator", "", 0,
+		fmt.Sprintf("input of %s tokens exceeds %.0f%% of the %s token context window for model %s",
+			formatWithCommas(tokens), threshold, formatWithCommas(contextWindow), modelName),
+		"", nil, llm.CategoryInputLimit)
+}
+
+// promptForModel returns the model's trimmed prompt when --auto-trim produced one,
+// otherwise the shared prompt
+func (o *Orchestrator) promptForModel(modelName, stitchedPrompt string) string {
+	if trimmed, ok := o.trimmedPrompts[modelName]; ok {
+		return trimmed
+	}
+	return stitchedPrompt
+}
+
+// trimContextToFit builds a smaller prompt for each model whose window the full prompt
+// would overflow, dropping the lowest-priority files until it fits. Files matching a
+// --priority-glob, then files the user named explicitly, are dropped last. Each dropped file is recorded in the audit log. Models
+// that cannot fit even with every file dropped get no trimmed prompt and are skipped later.
+func (o *Orchestrator) trimContextToFit(ctx context.Context, instructions string, contextFiles []fileutil.FileMeta, stitchedPrompt string) map[string]string {
+	trimmed := make(map[string]string)
+	dropOrder := o.trimOrder(contextFiles)
+	threshold := 100.0 - float64(o.config.TokenSafetyMargin)
+
+	for _, modelName := range o.config.ModelNames {
+		modelDef, err := models.GetModelInfo(modelName)
+		if err != nil {
+			continue // The compatibility check reports unknown models
+		}
+		limit := int(float64(modelDef.ContextWindow) * threshold / 100)
+
+		tokens, err := o.countPromptTokens(ctx, stitchedPrompt, modelName)
+		if err != nil || tokens <= limit {
+			continue
+		}
+
+		// Drop files until a proportional estimate fits, then recount the rebuilt prompt;
+		// recounting once per batch keeps accurate tokenization off the per-file path
+		droppedPaths := make(map[string]bool)
+		var dropped []fileutil.FileMeta
+		current := stitchedPrompt
+		for tokens > limit && len(dropped) < len(dropOrder) {
+			estimate := tokens
+			for estimate > limit && len(dropped) < len(dropOrder) {
+				file := dropOrder[len(dropped)]
+				dropped = append(dropped, file)
+				droppedPaths[file.Path] = true
+				estimate -= int(float64(tokens) * float64(len(file.Content)) / float64(len(current)))
+			}
+			current = o.promptBuilder().Build(instructions, withoutFiles(contextFiles, droppedPaths))
+			if tokens, err = o.countPromptTokens(ctx, current, modelName); err != nil {
+				break
+			}
+		}
+		if err != nil || tokens > limit {
+			o.logger.WarnContext(ctx, "Cannot trim the context enough to fit model %s", modelName)
+			continue // Even the instructions alone do not fit
+		}
+		trimmed[modelName] = current
+
+		for _, file := range dropped {
+			o.logAuditEvent(ctx, "TrimContext", "Success", map[string]interface{}{
+				"model_name": modelName,
+				"path":       file.Path,
+				"size_bytes": len(file.Content),
+				"reason":     "auto_trim",
+			}, nil, nil)
+		}
+		o.logger.InfoContext(ctx, "Trimmed %d files from the context for model %s (%s tokens, limit %s)",
+			len(dropped), modelName, formatWithCommas(tokens), formatWithCommas(limit))
+		o.consoleWriter.StatusMessage(fmt.Sprintf("Trimmed %d files from the context to fit %s", len(dropped), modelName))
+	}
+	return trimmed
+}
+
+// countPromptTokens counts a prompt's tokens for a model the same way the compatibility check does
+func (o *Orchestrator) countPromptTokens(ctx context.Context, promptText, modelName string) (int, error) {
+	result, err := o.tokenCountingService.CountTokensForModel(ctx, interfaces.TokenCountingRequest{
+		Instructions:        promptText,
+		SafetyMarginPercent: o.config.TokenSafetyMargin,
+	}, modelName)
+	if err != nil {
+		return 0, err
+	}
+	return result.TotalTokens, nil
+}
+
+// trimOrder returns files in the order --auto-trim drops them: lowest priority first,
+// then files reached by walking a directory before files named on the command line,
+// largest first within each group
+func (o *Orchestrator) trimOrder(files []fileutil.FileMeta) []fileutil.FileMeta {
+	named := make(map[string]bool)
+	for _, path := range o.config.Paths {
+		named[fileutil.EnsureAbsolutePath(filepath.Clean(path))] = true
+	}
+
+	order := make([]fileutil.FileMeta, len(files))
+	copy(order, files)
+	sort.SliceStable(order, func(i, j int) bool {
+		if order[i].Priority != order[j].Priority {
+			return order[i].Priority < order[j].Priority
+		}
+		iNamed, jNamed := named[order[i].Path], named[order[j].Path]
+		if iNamed != jNamed {
+			return !iNamed
+		}
+		if len(order[i].Content) != len(order[j].Content) {
+			return len(order[i].Content) > len(order[j].Content)
+		}
+		return order[i].Path < order[j].Path
+	})
+	return order
+}
+
+// withoutFiles returns files minus those whose paths are in drop, leaving the input unchanged
+func withoutFiles(files []fileutil.FileMeta, drop map[string]bool) []fileutil.FileMeta {
+	result := make([]fileutil.FileMeta, 0, len(files))
+	for _, file := range files {
+		if !drop[file.Path] {
+			result = append(result, file)
+		}
+	}
+	return result
+}
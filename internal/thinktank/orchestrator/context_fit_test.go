@@ -0,0 +1,176 @@
+package orchestrator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/misty-step/thinktank/internal/fileutil"
+	"github.com/misty-step/thinktank/internal/llm"
+	"github.com/misty-step/thinktank/internal/thinktank/interfaces"
+	"github.com/misty-step/thinktank/internal/thinktank/prompt"
+)
+
+// lengthTokenCounter counts one token per byte of the prompt, so trimming is predictable
+type lengthTokenCounter struct{}
+
+func (lengthTokenCounter) CountTokens(ctx context.Context, req interfaces.TokenCountingRequest) (interfaces.TokenCountingResult, error) {
+	return interfaces.TokenCountingResult{TotalTokens: len(req.Instructions)}, nil
+}
+
+func (c lengthTokenCounter) CountTokensForModel(ctx context.Context, req interfaces.TokenCountingRequest, modelName string) (interfaces.ModelTokenCountingResult, error) {
+	result, err := c.CountTokens(ctx, req)
+	return interfaces.ModelTokenCountingResult{TokenCountingResult: result, ModelName: modelName, TokenizerUsed: "estimation"}, err
+}
+
+func (lengthTokenCounter) GetCompatibleModels(ctx context.Context, req interfaces.TokenCountingRequest, availableProviders []string) ([]interfaces.ModelCompatibility, error) {
+	return nil, nil
+}
+
+func TestTrimOrder(t *testing.T) {
+	o, _, _, _ := newStatusTestOrchestrator()
+	o.config.Paths = []string{"/proj/named.go"}
+
+	files := []fileutil.FileMeta{
+		{Path: "/proj/named.go", Content: strings.Repeat("n", 900)},
+		{Path: "/proj/src/b.go", Content: strings.Repeat("b", 100)},
+		{Path: "/proj/src/big.go", Content: strings.Repeat("x", 500)},
+		{Path: "/proj/src/a.go", Content: strings.Repeat("a", 100)},
+	}
+
+	var got []string
+	for _, file := range o.trimOrder(files) {
+		got = append(got, file.Path)
+	}
+	expected := []string{"/proj/src/big.go", "/proj/src/a.go", "/proj/src/b.go", "/proj/named.go"}
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("trimOrder() = %v, want %v", got, expected)
+	}
+	if files[0].Path != "/proj/named.go" {
+		t.Error("trimOrder() must not reorder its input")
+	}
+}
+
+func TestTrimOrderPriority(t *testing.T) {
+	o, _, _, _ := newStatusTestOrchestrator()
+	o.config.Paths = []string{"/proj/named.go"}
+
+	files := []fileutil.FileMeta{
+		{Path: "/proj/named.go", Content: strings.Repeat("n", 900)},
+		{Path: "/proj/core/big.go", Content: strings.Repeat("x", 500), Priority: 2},
+		{Path: "/proj/api/small.go", Content: strings.Repeat("s", 100), Priority: 1},
+		{Path: "/proj/src/a.go", Content: strings.Repeat("a", 100)},
+	}
+
+	var got []string
+	for _, file := range o.trimOrder(files) {
+		got = append(got, file.Path)
+	}
+	expected := []string{"/proj/src/a.go", "/proj/named.go", "/proj/api/small.go", "/proj/core/big.go"}
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("trimOrder() = %v, want %v", got, expected)
+	}
+}
+
+func TestTrimContextToFit(t *testing.T) {
+	files := []fileutil.FileMeta{
+		{Path: "/proj/named.go", Content: strings.Repeat("n", 600)},
+		{Path: "/proj/src/big.go", Content: strings.Repeat("x", 1000)},
+		{Path: "/proj/src/small.go", Content: strings.Repeat("s", 300)},
+	}
+
+	tests := []struct {
+		name         string
+		instructions string
+		files        []fileutil.FileMeta
+		expectTrim   bool
+		dropped      []string
+	}{
+		{
+			name:         "drops the largest unnamed file first",
+			instructions: strings.Repeat("i", 100),
+			files:        files,
+			expectTrim:   true,
+			dropped:      []string{"/proj/src/big.go"},
+		},
+		{
+			name:         "named files are dropped last",
+			instructions: strings.Repeat("i", 100),
+			files: append([]fileutil.FileMeta{
+				{Path: "/proj/named.go", Content: strings.Repeat("n", 1700)},
+			}, files[1:]...),
+			expectTrim: true,
+			dropped:    []string{"/proj/src/big.go", "/proj/src/small.go", "/proj/named.go"},
+		},
+		{
+			name:         "prompt that cannot fit is left untrimmed",
+			instructions: strings.Repeat("i", 2000),
+			files:        files,
+			expectTrim:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, auditLogger, _, _ := newStatusTestOrchestrator()
+			o.tokenCountingService = lengthTokenCounter{}
+			o.config.Paths = []string{"/proj/named.go"}
+			// model1 (10,000 tokens) fits everything; synthesis-model (2,000 tokens) does not
+			o.config.ModelNames = []string{"model1", "synthesis-model"}
+			o.config.TokenSafetyMargin = 10
+
+			stitched := prompt.StitchPrompt(tt.instructions, tt.files)
+			trimmed := o.trimContextToFit(context.Background(), tt.instructions, tt.files, stitched)
+
+			if _, ok := trimmed["model1"]; ok {
+				t.Error("a model whose window fits the prompt should not be trimmed")
+			}
+			trimmedPrompt, ok := trimmed["synthesis-model"]
+			if ok != tt.expectTrim {
+				t.Fatalf("trimmed prompt present = %v, want %v", ok, tt.expectTrim)
+			}
+			if ok && len(trimmedPrompt) > 1800 {
+				t.Errorf("trimmed prompt is %d tokens, want at most 1800", len(trimmedPrompt))
+			}
+
+			var dropped []string
+			for _, call := range auditLogger.LogCalls {
+				if call.Operation != "TrimContext" {
+					continue
+				}
+				if call.Inputs["model_name"] != "synthesis-model" || call.Inputs["reason"] != "auto_trim" {
+					t.Errorf("unexpected audit inputs: %v", call.Inputs)
+				}
+				dropped = append(dropped, call.Inputs["path"].(string))
+				if strings.Contains(trimmedPrompt, call.Inputs["path"].(string)) {
+					t.Errorf("dropped file %v is still in the prompt", call.Inputs["path"])
+				}
+			}
+			if strings.Join(dropped, ",") != strings.Join(tt.dropped, ",") {
+				t.Errorf("dropped %v, want %v", dropped, tt.dropped)
+			}
+		})
+	}
+}
+
+func TestOversizedModelSkippedWithInputLimitError(t *testing.T) {
+	o, auditLogger, _, logger := newStatusTestOrchestrator()
+	o.tokenCountingService = lengthTokenCounter{}
+	o.config.ModelNames = []string{"synthesis-model"}
+	o.config.TokenSafetyMargin = 10
+
+	_, _, err := o.processModelsWithErrorHandling(context.Background(), strings.Repeat("x", 1900), logger)
+	if !llm.IsInputLimit(err) {
+		t.Fatalf("expected an input limit error, got %v", err)
+	}
+
+	var reason string
+	for _, call := range auditLogger.LogCalls {
+		if call.Inputs["to"] == "skipped" {
+			reason, _ = call.Inputs["reason"].(string)
+		}
+	}
+	if !strings.Contains(reason, "exceeds 90% of the 2,000 token context window") {
+		t.Errorf("skip reason = %q, want the context window overflow", reason)
+	}
+}
@@ -6,6 +6,8 @@ package orchestrator
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 
 	"github.com/misty-step/thinktank/internal/llm"
 )
@@ -34,10 +36,116 @@ var (
 	// ErrOutputFileSaveFailed is returned when there's an error saving output to a file.
 	ErrOutputFileSaveFailed = errors.New("failed to save output to file")
 
+	// ErrOutputFilesExist is returned before any model runs when the run would
+	// overwrite files already in a protected output directory.
+	ErrOutputFilesExist = errors.New("output files already exist")
+
 	// ErrModelProcessingCancelled is returned when model processing is cancelled by context.
 	ErrModelProcessingCancelled = errors.New("model processing cancelled")
+
+	// ErrRateLimitWaitBudgetExceeded is returned when models have spent the whole
+	// rate limit wait budget queued behind rate limiters.
+	ErrRateLimitWaitBudgetExceeded = errors.New("rate limit wait budget exceeded")
+
+	// ErrFailFastAborted is returned when --fail-fast cancels the remaining
+	// models after the first model failure.
+	ErrFailFastAborted = errors.New("run aborted early by --fail-fast")
 )
 
+// ModelFailure describes why one model failed, for callers that report
+// failures per model rather than as a single message
+type ModelFailure struct {
+	Model    string
+	Category llm.ErrorCategory
+	Message  string
+}
+
+// modelError ties a model's processing error to the model's name. Its message
+// is the underlying error's, so aggregated messages read as before.
+type modelError struct {
+	model    string
+	err      error
+	failFast bool // Whether this failure cancelled the remaining models under --fail-fast
+}
+
+func (e *modelError) Error() string { return e.err.Error() }
+func (e *modelError) Unwrap() error { return e.err }
+
+// modelFailuresError carries the per-model failures behind an aggregated
+// processing error. It unwraps only to the aggregate, so the individual
+// model errors don't leak into errors.Is or errors.As on the result.
+type modelFailuresError struct {
+	error
+	failures []ModelFailure
+}
+
+func (e *modelFailuresError) Unwrap() error { return e.error }
+
+// ModelFailures returns the per-model failures recorded in err, sorted by
+// model name, or nil when err is not a model processing failure
+func ModelFailures(err error) []ModelFailure {
+	var failuresErr *modelFailuresError
+	if !errors.As(err, &failuresErr) {
+		return nil
+	}
+	return append([]ModelFailure(nil), failuresErr.failures...)
+}
+
+// modelFailures lists the failure behind each model error in errs. Errors that
+// don't name their model (see modelError) are left out.
+func modelFailures(errs []error) []ModelFailure {
+	var failures []ModelFailure
+	for _, err := range errs {
+		var modelErr *modelError
+		if !errors.As(err, &modelErr) {
+			continue
+		}
+		failures = append(failures, ModelFailure{
+			Model:    modelErr.model,
+			Category: CategorizeOrchestratorError(modelErr.err),
+			Message:  modelErr.err.Error(),
+		})
+	}
+	return failures
+}
+
+// sortModelErrors orders errs by model name so aggregated messages don't
+// depend on which concurrently processed model finished first. Errors that
+// don't name their model keep their relative order after the named ones.
+func sortModelErrors(errs []error) {
+	modelName := func(err error) (string, bool) {
+		var modelErr *modelError
+		if !errors.As(err, &modelErr) {
+			return "", false
+		}
+		return modelErr.model, true
+	}
+	sort.SliceStable(errs, func(i, j int) bool {
+		a, aNamed := modelName(errs[i])
+		b, bNamed := modelName(errs[j])
+		if aNamed != bNamed {
+			return aNamed
+		}
+		return a < b
+	})
+}
+
+// failFastError returns the aggregated error for a run that --fail-fast cut
+// short, naming the model whose failure stopped it, or nil when no model did
+func failFastError(errs []error) error {
+	for _, err := range errs {
+		var modelErr *modelError
+		if errors.As(err, &modelErr) && modelErr.failFast {
+			return &modelFailuresError{
+				error: fmt.Errorf("%w after model %s failed: %s",
+					ErrFailFastAborted, modelErr.model, aggregateErrorMessages(errs)),
+				failures: modelFailures(errs),
+			}
+		}
+	}
+	return nil
+}
+
 // CategorizeOrchestratorError maps orchestrator errors to standard LLM error categories.
 // This function is used to provide consistent error categorization across the application.
 func CategorizeOrchestratorError(err error) llm.ErrorCategory {
@@ -46,10 +154,14 @@ func CategorizeOrchestratorError(err error) llm.ErrorCategory {
 		return llm.CategoryInvalidRequest
 	case errors.Is(err, ErrNoValidModels):
 		return llm.CategoryInvalidRequest
+	case errors.Is(err, ErrOutputFilesExist):
+		return llm.CategoryInvalidRequest
 	case errors.Is(err, ErrPartialProcessingFailure):
 		// This is a partial failure, so we treat it as a server error
 		// since some models may have failed due to server issues
 		return llm.CategoryServer
+	case errors.Is(err, ErrRateLimitWaitBudgetExceeded):
+		return llm.CategoryRateLimit
 	case errors.Is(err, ErrAllProcessingFailed):
 		// This could be due to various reasons, default to server error
 		return llm.CategoryServer
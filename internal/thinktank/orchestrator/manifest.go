@@ -0,0 +1,116 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// ManifestFileName is the name of the run manifest written to the output directory
+const ManifestFileName = "manifest.json"
+
+// Manifest is a machine-readable index of the files a run produced. Unlike the
+// audit log it records only the final outcome, one entry per configured model.
+type Manifest struct {
+	Models    []ManifestEntry   `json:"models"`
+	Combined  *ManifestCombined `json:"combined,omitempty"`  // Present when outputs were written to one combined file
+	Synthesis *ManifestEntry    `json:"synthesis,omitempty"` // Present when a synthesis model was configured
+}
+
+// ManifestCombined describes the --combined-output file holding every successful model's output
+type ManifestCombined struct {
+	File      string   `json:"file"`
+	SizeBytes int64    `json:"size_bytes,omitempty"`
+	Models    []string `json:"models"` // Models with a section in the file, in order
+}
+
+// ManifestEntry describes one model's result and the file it was saved to
+type ManifestEntry struct {
+	Model        string `json:"model"`
+	Status       string `json:"status"`                  // "completed", "failed", or "skipped"
+	File         string `json:"file,omitempty"`          // Output file name, relative to the manifest
+	SizeBytes    int64  `json:"size_bytes,omitempty"`    // Size of the output file on disk
+	OutputTokens *int   `json:"output_tokens,omitempty"` // Completion tokens, when the provider reported them
+	Error        string `json:"error,omitempty"`         // Why the model failed or was skipped
+}
+
+// buildManifest assembles the manifest from the run's outputs, listing models in
+// configuration order so that failed and skipped models are included too
+func (o *Orchestrator) buildManifest(modelOutputs map[string]string, outputInfo *OutputInfo) *Manifest {
+	units := o.runUnits()
+	manifest := &Manifest{Models: make([]ManifestEntry, 0, len(units))}
+
+	for _, modelName := range units {
+		entry := ManifestEntry{Model: modelName, Status: ModelFailed.String()}
+		if _, ok := modelOutputs[modelName]; ok {
+			entry.Status = ModelCompleted.String()
+		} else if status, ok := o.modelStatus(modelName); ok && status == ModelSkipped {
+			entry.Status = ModelSkipped.String()
+		}
+		if entry.Status != ModelCompleted.String() {
+			entry.Error = o.modelReason(modelName)
+		}
+		if path, ok := outputInfo.IndividualFilePaths[modelName]; ok {
+			entry.File, entry.SizeBytes = manifestFile(o.config.OutputDir, path)
+		} else if outputInfo.CombinedFilePath != "" && entry.Status == ModelCompleted.String() {
+			// The model's output is a section of the combined file
+			entry.File, _ = manifestFile(o.config.OutputDir, outputInfo.CombinedFilePath)
+		}
+		if accounting, ok := o.tokenAccounting[modelName]; ok && accounting.ProviderReportedTokens {
+			tokens := accounting.ProviderOutputTokens
+			entry.OutputTokens = &tokens
+		}
+		manifest.Models = append(manifest.Models, entry)
+	}
+
+	if outputInfo.CombinedFilePath != "" {
+		combined := &ManifestCombined{Models: append([]string{}, outputInfo.CombinedModels...)}
+		combined.File, combined.SizeBytes = manifestFile(o.config.OutputDir, outputInfo.CombinedFilePath)
+		manifest.Combined = combined
+	}
+
+	if o.config.SynthesisModel != "" {
+		synthesis := &ManifestEntry{Model: o.config.SynthesisModel, Status: ModelFailed.String()}
+		if outputInfo.SynthesisFilePath != "" {
+			synthesis.Status = ModelCompleted.String()
+			synthesis.File, synthesis.SizeBytes = manifestFile(o.config.OutputDir, outputInfo.SynthesisFilePath)
+		}
+		manifest.Synthesis = synthesis
+	}
+
+	return manifest
+}
+
+// manifestFile returns an output file's path relative to the output directory,
+// using forward slashes, and its size on disk (0 if it can't be read)
+func manifestFile(outputDir, path string) (string, int64) {
+	var size int64
+	if info, err := os.Stat(path); err == nil {
+		size = info.Size()
+	}
+	name, err := filepath.Rel(outputDir, path)
+	if err != nil {
+		name = filepath.Base(path)
+	}
+	return filepath.ToSlash(name), size
+}
+
+// writeManifest saves manifest.json to the output directory. It runs whether or not
+// every model succeeded, so a failure to write it is logged rather than returned.
+func (o *Orchestrator) writeManifest(ctx context.Context, modelOutputs map[string]string, outputInfo *OutputInfo) {
+	contextLogger := o.logger.WithContext(ctx)
+
+	data, err := json.MarshalIndent(o.buildManifest(modelOutputs, outputInfo), "", "  ")
+	if err != nil {
+		contextLogger.WarnContext(ctx, "Failed to encode run manifest: %v", err)
+		return
+	}
+
+	manifestPath := filepath.Join(o.config.OutputDir, ManifestFileName)
+	if err := o.fileWriter.SaveToFile(ctx, string(data)+"\n", manifestPath); err != nil {
+		contextLogger.WarnContext(ctx, "Failed to write run manifest to %s: %v", manifestPath, err)
+		return
+	}
+	contextLogger.DebugContext(ctx, "Wrote run manifest to %s", manifestPath)
+}
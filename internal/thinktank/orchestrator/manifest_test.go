@@ -0,0 +1,69 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/misty-step/thinktank/internal/llm"
+)
+
+func TestWriteManifest(t *testing.T) {
+	ctx := context.Background()
+	outputDir := t.TempDir()
+	okPath := filepath.Join(outputDir, "model-ok.md")
+	if err := os.WriteFile(okPath, []byte("analysis"), 0644); err != nil {
+		t.Fatalf("Failed to write output: %v", err)
+	}
+
+	o, _, _, _ := newStatusTestOrchestrator()
+	fileWriter := &MockFileWriter{}
+	o.fileWriter = fileWriter
+	o.config.OutputDir = outputDir
+	o.config.ModelNames = []string{"model-ok", "model-failed", "model-skipped"}
+	o.config.SynthesisModel = "model-synth"
+	o.transitionModel(ctx, "model-skipped", ModelSkipped, 0, errors.New("input too large"))
+	o.recordProviderUsage("model-ok", &llm.TokenUsage{PromptTokens: 100, CompletionTokens: 42})
+
+	outputInfo := NewOutputInfo()
+	outputInfo.IndividualFilePaths["model-ok"] = okPath
+	o.writeManifest(ctx, map[string]string{"model-ok": "analysis"}, outputInfo)
+
+	content, ok := fileWriter.savedFiles[filepath.Join(outputDir, ManifestFileName)]
+	if !ok {
+		t.Fatalf("manifest.json was not written; saved files: %v", fileWriter.savedFiles)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal([]byte(content), &manifest); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v\n%s", err, content)
+	}
+
+	tokens := 42
+	want := Manifest{
+		Models: []ManifestEntry{
+			{Model: "model-ok", Status: "completed", File: "model-ok.md", SizeBytes: 8, OutputTokens: &tokens},
+			{Model: "model-failed", Status: "failed"},
+			{Model: "model-skipped", Status: "skipped", Error: "input too large"},
+		},
+		Synthesis: &ManifestEntry{Model: "model-synth", Status: "failed"},
+	}
+	if !reflect.DeepEqual(manifest, want) {
+		t.Errorf("manifest =\n%+v\nwant\n%+v", manifest, want)
+	}
+}
+
+func TestWriteManifest_SaveFailureIsNotFatal(t *testing.T) {
+	o, _, _, logger := newStatusTestOrchestrator()
+	o.fileWriter = &MockFileWriter{saveError: errors.New("disk full")}
+	o.config.ModelNames = []string{"model-ok"}
+
+	o.writeManifest(context.Background(), map[string]string{"model-ok": "analysis"}, NewOutputInfo())
+
+	if !logger.ContainsMessage("Failed to write run manifest") {
+		t.Errorf("expected a warning about the manifest, got %v", logger.GetMessages())
+	}
+}
@@ -77,7 +77,8 @@ type LogCall struct {
 	Inputs        map[string]interface{}
 	Outputs       map[string]interface{}
 	Error         error
-	CorrelationID string // Track correlation ID from context
+	CorrelationID string               // Track correlation ID from context
+	Entry         *auditlog.AuditEntry // The complete entry, for calls to Log (nil for LogOp)
 }
 
 // MockAuditLogger provides a mock implementation for testing
@@ -95,8 +96,12 @@ func NewMockAuditLogger() *MockAuditLogger {
 	}
 }
 
-// Log is a mock implementation with context
+// Log is a mock implementation with context. Entries are recorded in LogCalls
+// alongside LogOp calls, with the full entry kept for typed fields.
 func (m *MockAuditLogger) Log(ctx context.Context, entry auditlog.AuditEntry) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	// Extract correlation ID for testing
 	correlationID := logutil.GetCorrelationID(ctx)
 
@@ -110,9 +115,28 @@ func (m *MockAuditLogger) Log(ctx context.Context, entry auditlog.AuditEntry) er
 		}
 	}
 
+	var err error
+	if entry.Error != nil {
+		err = errors.New(entry.Error.Message)
+	}
+	m.LogCalls = append(m.LogCalls, LogCall{
+		Operation:     entry.Operation,
+		Status:        entry.Status,
+		Inputs:        entry.Inputs,
+		Outputs:       entry.Outputs,
+		Error:         err,
+		CorrelationID: correlationID,
+		Entry:         &entry,
+	})
+
 	return m.LogError
 }
 
+// LogContext delegates to Log
+func (m *MockAuditLogger) LogContext(ctx context.Context, entry auditlog.AuditEntry) error {
+	return m.Log(ctx, entry)
+}
+
 // LogLegacy implements the backward-compatible AuditLogger.LogLegacy method
 func (m *MockAuditLogger) LogLegacy(entry auditlog.AuditEntry) error {
 	return m.Log(context.Background(), entry)
@@ -270,10 +294,14 @@ type TestOutputWriter struct {
 	saveIndividualError error
 	saveSynthesisPath   string
 	saveSynthesisError  error
+
+	capturedIndividualOutputs map[string]string
+	capturedSynthesisContent  string
 }
 
 // SaveIndividualOutputs is a mock implementation
 func (t *TestOutputWriter) SaveIndividualOutputs(ctx context.Context, modelOutputs map[string]string, outputDir string) (int, map[string]string, error) {
+	t.capturedIndividualOutputs = modelOutputs
 	if t.saveIndividualError != nil {
 		return 0, nil, t.saveIndividualError
 	}
@@ -282,6 +310,7 @@ func (t *TestOutputWriter) SaveIndividualOutputs(ctx context.Context, modelOutpu
 
 // SaveSynthesisOutput is a mock implementation
 func (t *TestOutputWriter) SaveSynthesisOutput(ctx context.Context, content string, modelName string, outputDir string) (string, error) {
+	t.capturedSynthesisContent = content
 	if t.saveSynthesisError != nil {
 		return "", t.saveSynthesisError
 	}
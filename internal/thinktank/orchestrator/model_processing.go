@@ -3,15 +3,18 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/misty-step/thinktank/internal/llm"
 	"github.com/misty-step/thinktank/internal/logutil"
 	"github.com/misty-step/thinktank/internal/models"
+	"github.com/misty-step/thinktank/internal/ratelimit"
 	"github.com/misty-step/thinktank/internal/thinktank/interfaces"
 	"github.com/misty-step/thinktank/internal/thinktank/modelproc"
 )
@@ -23,6 +26,7 @@ import (
 func (o *Orchestrator) processModelsWithErrorHandling(ctx context.Context, stitchedPrompt string, contextLogger logutil.LoggerInterface) (map[string]string, error, error) {
 	// Start model processing
 	contextLogger.InfoContext(ctx, "Beginning model processing")
+	o.resetModelStatuses()
 
 	// Calculate token metrics for processing summary
 	tokenReq := interfaces.TokenCountingRequest{
@@ -66,13 +70,21 @@ func (o *Orchestrator) processModelsWithErrorHandling(ctx context.Context, stitc
 		sort.Strings(sortedModelNamesForCompat)
 
 		// Collect compatibility data for all models
+		skipErrors := make(map[string]error)
 		for i, modelName := range sortedModelNamesForCompat {
 			modelInfo := ModelCompatibilityInfo{
 				ModelName: modelName,
 			}
 
-			modelTokenResult, modelErr := o.tokenCountingService.CountTokensForModel(ctx, tokenReq, modelName)
+			// Models with a trimmed prompt are checked against what they will actually receive
+			modelReq := tokenReq
+			modelReq.Instructions = o.promptForModel(modelName, stitchedPrompt)
+			modelTokenResult, modelErr := o.tokenCountingService.CountTokensForModel(ctx, modelReq, modelName)
 			if modelErr == nil {
+				for _, unit := range o.expandRepeats([]string{modelName}) {
+					o.recordTokenEstimate(unit, tokenResult.TotalTokens, modelTokenResult.TotalTokens, modelTokenResult.TokenizerUsed)
+				}
+
 				// Get model info for context window
 				modelDef, infoErr := models.GetModelInfo(modelName)
 				if infoErr == nil {
@@ -92,6 +104,7 @@ func (o *Orchestrator) processModelsWithErrorHandling(ctx context.Context, stitc
 						modelInfo.IsCompatible = false
 						modelInfo.FailureReason = "input too large"
 						skippedModels = append(skippedModels, modelName)
+						skipErrors[modelName] = inputLimitError(modelName, modelTokenResult.TotalTokens, safetyMarginPercent, modelDef.ContextWindow)
 
 						contextLogger.InfoContext(ctx, "Skipping model %s (%d/%d) - input too large for context window: %d tokens > %.1f%% of %d tokens",
 							modelName, i+1, len(sortedModelNamesForCompat), modelTokenResult.TotalTokens, safetyMarginPercent, modelDef.ContextWindow)
@@ -152,6 +165,18 @@ func (o *Orchestrator) processModelsWithErrorHandling(ctx context.Context, stitc
 		// Display the compatibility card
 		o.displayCompatibilityCard(analysis)
 
+		for _, model := range allModelInfo {
+			if !model.IsCompatible {
+				reason, ok := skipErrors[model.ModelName]
+				if !ok {
+					reason = errors.New(model.FailureReason)
+				}
+				for _, unit := range o.expandRepeats([]string{model.ModelName}) {
+					o.transitionModel(ctx, unit, ModelSkipped, 0, reason)
+				}
+			}
+		}
+
 		// Log summary
 		if len(skippedModels) > 0 {
 			contextLogger.InfoContext(ctx, "Skipped %d incompatible models: %v", len(skippedModels), skippedModels)
@@ -159,7 +184,9 @@ func (o *Orchestrator) processModelsWithErrorHandling(ctx context.Context, stitc
 
 		// Check if we have any compatible models
 		if len(compatibleModels) == 0 {
-			err := fmt.Errorf("no models are compatible with input size of %s tokens", formatWithCommas(tokenResult.TotalTokens))
+			err := llm.New("orchestrator", "", 0,
+				fmt.Sprintf("no models are compatible with input size of %s tokens", formatWithCommas(tokenResult.TotalTokens)),
+				"", nil, llm.CategoryInputLimit)
 			contextLogger.ErrorContext(ctx, err.Error())
 			o.consoleWriter.StatusMessage("❌ No models are compatible with the input size")
 			return nil, nil, err
@@ -169,8 +196,8 @@ func (o *Orchestrator) processModelsWithErrorHandling(ctx context.Context, stitc
 		contextLogger.InfoContext(ctx, "Processing %d compatible models: %v", len(compatibleModels), compatibleModels)
 
 		// Store counts for later use in error handling and user feedback
-		totalModelsRequested := len(o.config.ModelNames)
-		skippedModelsCount := len(skippedModels)
+		totalModelsRequested := len(o.runUnits())
+		skippedModelsCount := len(o.expandRepeats(skippedModels))
 
 		// Temporarily update config to only process compatible models
 		originalModelNames := o.config.ModelNames
@@ -188,6 +215,23 @@ func (o *Orchestrator) processModelsWithErrorHandling(ctx context.Context, stitc
 
 	o.logRateLimitingConfiguration(ctx)
 	modelOutputs, modelErrors := o.processModels(ctx, stitchedPrompt)
+	o.reconcileTokenUsage(ctx, contextLogger)
+
+	// An exhausted rate limit wait budget aborts the whole run
+	for _, err := range modelErrors {
+		if errors.Is(err, ErrRateLimitWaitBudgetExceeded) {
+			contextLogger.ErrorContext(ctx, "Aborting: %v", err)
+			o.consoleWriter.StatusMessage(fmt.Sprintf("Rate limit wait budget of %v exhausted - aborting", o.config.RateLimitWaitBudget))
+			return nil, nil, err
+		}
+	}
+
+	// Under --fail-fast the first model failure aborts the whole run
+	if abortErr := failFastError(modelErrors); abortErr != nil {
+		contextLogger.ErrorContext(ctx, abortErr.Error())
+		o.consoleWriter.StatusMessage("A model failed - remaining models cancelled (--fail-fast)")
+		return nil, nil, abortErr
+	}
 
 	// Handle model processing errors
 	var returnErr error
@@ -198,20 +242,21 @@ func (o *Orchestrator) processModelsWithErrorHandling(ctx context.Context, stitc
 	skippedModelsCount, _ := ctx.Value(contextKey("skippedModelsCount")).(int)
 
 	// If no context values, fall back to current config (for backwards compatibility)
+	units := o.runUnits()
 	if totalModelsRequested == 0 {
-		totalModelsRequested = len(o.config.ModelNames)
+		totalModelsRequested = len(units)
 	}
 
 	if len(modelErrors) > 0 {
 		// If ALL attempted models failed (no outputs available), fail immediately
 		if len(modelOutputs) == 0 {
-			returnErr = o.aggregateErrors(modelErrors, len(o.config.ModelNames), 0)
+			returnErr = o.aggregateErrors(modelErrors, len(units), 0)
 			contextLogger.ErrorContext(ctx, returnErr.Error())
 
 			// Provide user-facing error message for complete failure
 			if skippedModelsCount > 0 {
 				o.consoleWriter.StatusMessage(fmt.Sprintf("All %d compatible models failed - no outputs generated (%d models were skipped due to input size)",
-					len(o.config.ModelNames), skippedModelsCount))
+					len(units), skippedModelsCount))
 			} else {
 				o.consoleWriter.StatusMessage("All models failed - no outputs generated")
 			}
@@ -228,7 +273,7 @@ func (o *Orchestrator) processModelsWithErrorHandling(ctx context.Context, stitc
 
 		// Log a warning with detailed counts and successful model names
 		contextLogger.WarnContext(ctx, "Some models failed but continuing with synthesis: %d/%d attempted models successful, %d failed. Successful models: %v",
-			len(modelOutputs), len(o.config.ModelNames), len(modelErrors), successfulModels)
+			len(modelOutputs), len(units), len(modelErrors), successfulModels)
 		if skippedModelsCount > 0 {
 			contextLogger.InfoContext(ctx, "Additionally, %d models were skipped due to input size being too large", skippedModelsCount)
 		}
@@ -239,7 +284,7 @@ func (o *Orchestrator) processModelsWithErrorHandling(ctx context.Context, stitc
 				len(modelOutputs), totalModelsRequested))
 		} else {
 			o.consoleWriter.StatusMessage(fmt.Sprintf("● %d/%d models succeeded, continuing with available outputs",
-				len(modelOutputs), len(o.config.ModelNames)))
+				len(modelOutputs), len(units)))
 		}
 
 		// Log individual error details
@@ -248,7 +293,7 @@ func (o *Orchestrator) processModelsWithErrorHandling(ctx context.Context, stitc
 		}
 
 		// Create a descriptive error to return after processing is complete
-		returnErr = o.aggregateErrors(modelErrors, len(o.config.ModelNames), len(modelOutputs))
+		returnErr = o.aggregateErrors(modelErrors, len(units), len(modelOutputs))
 	} else if skippedModelsCount > 0 {
 		// All attempted models succeeded, but some were skipped
 		contextLogger.InfoContext(ctx, "All %d attempted models succeeded (%d models were skipped due to input size)",
@@ -280,45 +325,75 @@ func (o *Orchestrator) processModelsWithErrorHandling(ctx context.Context, stitc
 // - A slice of errors encountered during processing (empty if all models were successful)
 func (o *Orchestrator) processModels(ctx context.Context, stitchedPrompt string) (map[string]string, []error) {
 	var wg sync.WaitGroup
-	resultChan := make(chan modelResult, len(o.config.ModelNames))
 
-	// Sort model names alphabetically for consistent, predictable display order
+	// Exhausting the rate limit wait budget, or a failure under --fail-fast,
+	// cancels every model still in flight
+	ctx, abort := context.WithCancel(ctx)
+	defer abort()
+	budget := newWaitBudget(o.config.RateLimitWaitBudget, abort)
+
+	// Sort model names alphabetically for consistent, predictable display order,
+	// keeping each model's repeats in number order
 	sortedModelNames := make([]string, len(o.config.ModelNames))
 	copy(sortedModelNames, o.config.ModelNames)
 	sort.Strings(sortedModelNames)
+	sortedModelNames = o.expandRepeats(sortedModelNames)
+	resultChan := make(chan modelResult, len(sortedModelNames))
 
 	// Start status tracking for in-place updates
 	o.consoleWriter.StartStatusTracking(sortedModelNames)
 
-	// Initialize all models to starting state
+	// Initialize all models to queued state
 	for _, modelName := range sortedModelNames {
-		o.consoleWriter.UpdateModelStatus(modelName, logutil.StatusStarting, 0, "")
+		o.transitionModel(ctx, modelName, ModelQueued, 0, nil)
 	}
 
+	// Periodic checkpoints make a stalled run obvious in logs
+	var completed atomic.Int64
+	stopCheckpoints := o.startCheckpoints(ctx, len(sortedModelNames), &completed)
+
 	// Launch a goroutine for each model, passing the index for progress tracking
 	for i, modelName := range sortedModelNames {
 		wg.Add(1)
 		// Pass 1-based index for user-friendly display
-		go o.processModelWithRateLimit(ctx, modelName, stitchedPrompt, i+1, &wg, resultChan)
+		go func(index int, modelName string) {
+			defer completed.Add(1)
+			o.processModelWithRateLimit(ctx, modelName, stitchedPrompt, index, budget, &wg, resultChan)
+		}(i+1, modelName)
 	}
 
-	// Wait for all goroutines to complete
-	wg.Wait()
-	close(resultChan)
+	// Close the channel once every goroutine has sent its result
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
 
-	// Collect outputs and errors from the channel
+	// Collect outputs and errors as models finish, so --fail-fast can stop the rest
 	modelOutputs := make(map[string]string)
 	var modelErrors []error
 
-	// We're processing a channel that's already closed, so there's no race condition here
 	for result := range resultChan {
+		provider, _ := models.GetProviderForModel(o.unitModel(result.modelName))
+		o.outcomeMetrics.record(ctx, result, provider)
+
 		// Store outputs and errors for return
 		if result.err == nil {
 			modelOutputs[result.modelName] = result.content
-		} else {
-			modelErrors = append(modelErrors, result.err)
+			o.recordProviderUsage(result.modelName, result.usage)
+			continue
 		}
+
+		modelErr := &modelError{model: result.modelName, err: result.err}
+		// Only a failure of its own stops the run; later cancellations don't count
+		if o.config.FailFast && ctx.Err() == nil {
+			o.logger.WarnContext(ctx, "Model %s failed; cancelling the remaining models (--fail-fast)", result.modelName)
+			modelErr.failFast = true
+			abort()
+		}
+		modelErrors = append(modelErrors, modelErr)
 	}
+	stopCheckpoints()
+	sortModelErrors(modelErrors)
 
 	// Finish status tracking and clean up display
 	o.consoleWriter.FinishStatusTracking()
@@ -331,20 +406,25 @@ func (o *Orchestrator) processModels(ctx context.Context, stitchedPrompt string)
 // This struct is crucial for the synthesis feature as it captures outputs
 // from multiple models so they can be combined by a synthesis model.
 type modelResult struct {
-	modelName string        // Name of the processed model
-	content   string        // Generated content from the model, which may be used for synthesis
-	err       error         // Any error encountered during processing
-	duration  time.Duration // Time taken to process this model
+	modelName string          // Name of the processed model
+	content   string          // Generated content from the model, which may be used for synthesis
+	err       error           // Any error encountered during processing
+	duration  time.Duration   // Time taken to process this model
+	usage     *llm.TokenUsage // Token usage reported by the provider, if any
 }
 
 // processModelWithRateLimit processes a single model with rate limiting.
 // It acquires a rate limiting token, processes the model, and sends the result
 // (containing model name, content, and any error) to the result channel.
+// Time spent waiting on the rate limiter is charged to budget (nil = unlimited).
+// With --repeat, unit names one generation of a model, which is rate limited and
+// reported as a model of its own.
 func (o *Orchestrator) processModelWithRateLimit(
 	ctx context.Context,
-	modelName string,
+	unit string,
 	stitchedPrompt string,
 	index int,
+	budget *waitBudget,
 	wg *sync.WaitGroup,
 	resultChan chan<- modelResult,
 ) {
@@ -355,7 +435,8 @@ func (o *Orchestrator) processModelWithRateLimit(
 
 	// Create a local variable to store the result to avoid accessing it from multiple goroutines
 	var result modelResult
-	result.modelName = modelName
+	result.modelName = unit
+	modelName := o.unitModel(unit)
 
 	// Track total time including rate limiting
 	totalStart := time.Now()
@@ -363,34 +444,28 @@ func (o *Orchestrator) processModelWithRateLimit(
 	// Get the appropriate rate limiter for this model (model-specific or global)
 	rateLimiter := o.getRateLimiterForModel(modelName)
 
+	// Providers are limited independently; unknown models fall back to the per-model limit
+	provider, _ := models.GetProviderForModel(modelName)
+	ctx, span := startModelSpan(ctx, unit, provider)
+	defer func() { endModelSpan(span, result) }()
+
 	// Acquire rate limiting permission
-	contextLogger.DebugContext(ctx, "Attempting to acquire rate limiter for model %s...", modelName)
-	acquireStart := time.Now()
-	if err := rateLimiter.Acquire(ctx, modelName); err != nil {
-		contextLogger.ErrorContext(ctx, "Rate limiting error for model %s: %v", modelName, err)
-		result.err = llm.Wrap(err, "orchestrator",
-			fmt.Sprintf("failed to acquire rate limiter for model %s", modelName),
-			llm.CategoryRateLimit)
+	acquireDuration, err := o.acquireRateLimit(ctx, unit, provider, rateLimiter, budget)
+	if err != nil {
+		result.err = err
 		result.duration = time.Since(totalStart)
+		o.transitionModel(ctx, unit, ModelFailed, result.duration, result.err)
 		resultChan <- result
 		return
 	}
-	acquireDuration := time.Since(acquireStart)
-	contextLogger.DebugContext(ctx, "Rate limiter acquired for model %s (waited %v)", modelName, acquireDuration)
 
 	// Report rate limiting delay if significant
 	if acquireDuration > 100*time.Millisecond {
-		o.consoleWriter.UpdateModelRateLimited(modelName, acquireDuration)
+		o.transitionModel(ctx, unit, ModelRateLimited, acquireDuration, nil)
 	}
 
-	// Release rate limiter when done
-	defer func() {
-		contextLogger.DebugContext(ctx, "Releasing rate limiter for model %s", modelName)
-		rateLimiter.Release()
-	}()
-
 	// Update status to processing
-	o.consoleWriter.UpdateModelStatus(modelName, logutil.StatusProcessing, 0, "")
+	o.transitionModel(ctx, unit, ModelStarted, 0, nil)
 
 	// Create API service adapter and model processor
 	apiServiceAdapter := &APIServiceAdapter{APIService: o.apiService}
@@ -401,25 +476,82 @@ func (o *Orchestrator) processModelWithRateLimit(
 		o.logger,
 		o.config,
 	)
+	// Repeats ask for fresh generations, so they never reuse a cached response
+	if responseCache := o.responseCache(ctx); responseCache != nil && o.config.Repeat <= 1 {
+		processor.SetCache(responseCache)
+	}
+	processor.SetOutputPath(func(string) string {
+		return o.outputNamer.unitPath(o.config.OutputDir, o.splitUnit(unit), "")
+	})
+	if onSave := o.postProcessOnSave(unit); onSave != nil {
+		processor.SetSaveHandler(onSave)
+	}
+	var stream *streamRelay
+	if o.shouldStreamOutput() {
+		stream = &streamRelay{consoleWriter: o.consoleWriter, unit: unit}
+		processor.SetStreamHandler(stream.write)
+	}
+
+	// --model-timeout bounds this model's generation, retries included, without
+	// cancelling the other models
+	modelCtx, cancelModel := o.modelContext(ctx)
+	defer cancelModel()
 
-	// Process the model and track timing
+	// Process the model and track timing, retrying transient failures
 	processingStart := time.Now()
-	content, err := processor.Process(ctx, modelName, stitchedPrompt)
+	// Each attempt holds a rate limiter slot only while it runs: the first uses the
+	// slot acquired above, and retries acquire a new one after their backoff, so
+	// waiting to retry never blocks other models and retries stay throttled
+	holdingSlot := true
+	content, err := o.processWithRetry(modelCtx, unit, func(attemptCtx context.Context) (string, error) {
+		if !holdingSlot {
+			if _, err := o.acquireRateLimit(attemptCtx, unit, provider, rateLimiter, budget); err != nil {
+				return "", err
+			}
+		}
+		holdingSlot = false
+		defer func() {
+			contextLogger.DebugContext(ctx, "Releasing rate limiter for model %s", unit)
+			rateLimiter.Release()
+		}()
+
+		// A retry streams its response from the start, so mark where a failed
+		// attempt's partial output ends
+		stream.restart()
+
+		content, err := processor.Process(attemptCtx, modelName, o.promptForModel(modelName, stitchedPrompt))
+
+		// Let an adaptive rate limiter tune the model's rate from the outcome
+		rateLimiter.RecordResult(modelName, llm.IsRateLimit(err))
+
+		// Honor the provider's Retry-After so later requests for this model wait it out
+		if retryAfter := llm.RetryAfterFromError(err); retryAfter > 0 {
+			contextLogger.WarnContext(ctx, "Provider asked to retry model %s after %v; pausing its rate limiter", modelName, retryAfter)
+			rateLimiter.PauseModel(modelName, retryAfter)
+		}
+		return content, err
+	})
 	processingDuration := time.Since(processingStart)
+
+	if err != nil && ctx.Err() == nil && errors.Is(modelCtx.Err(), context.DeadlineExceeded) {
+		err = llm.Wrap(err, "orchestrator",
+			fmt.Sprintf("model %s timed out after %v", unit, o.config.ModelTimeout),
+			llm.CategoryCancelled)
+	}
+
 	if err != nil {
-		contextLogger.ErrorContext(ctx, "Processing model %s failed: %v", modelName, err)
+		contextLogger.ErrorContext(ctx, "Processing model %s failed: %v", unit, err)
 
 		// Preserve the detailed error instead of wrapping with generic message
 		result.err = err
 		result.duration = time.Since(totalStart)
 
 		// Record per-model failure metrics
-		o.metricsCollector.RecordDuration("model_duration_ms", result.duration, "model", modelName, "status", "failed")
-		o.metricsCollector.IncrCounter("models_processed_total", "model", modelName, "status", "failed")
+		o.metricsCollector.RecordDuration("model_duration_ms", result.duration, "model", unit, "status", "failed")
+		o.metricsCollector.IncrCounter("models_processed_total", "model", unit, "status", "failed")
 
 		// Update status to failed
-		errorMsg := o.getUserFriendlyErrorMessage(err, modelName)
-		o.consoleWriter.UpdateModelStatus(modelName, logutil.StatusFailed, result.duration, errorMsg)
+		o.transitionModel(ctx, unit, ModelFailed, result.duration, err)
 
 		// Send result to channel
 		resultChan <- result
@@ -427,23 +559,68 @@ func (o *Orchestrator) processModelWithRateLimit(
 	}
 
 	// Log success
-	contextLogger.DebugContext(ctx, "Processing model %s completed successfully in %v", modelName, processingDuration)
+	contextLogger.DebugContext(ctx, "Processing model %s completed successfully in %v", unit, processingDuration)
 
-	// Store content and duration
+	// Store content, provider token usage, and duration
 	result.content = content
+	result.usage = processor.Usage()
 	result.duration = time.Since(totalStart)
 
 	// Record per-model metrics
-	o.metricsCollector.RecordDuration("model_duration_ms", result.duration, "model", modelName, "status", "success")
-	o.metricsCollector.IncrCounter("models_processed_total", "model", modelName, "status", "success")
+	o.metricsCollector.RecordDuration("model_duration_ms", result.duration, "model", unit, "status", "success")
+	o.metricsCollector.IncrCounter("models_processed_total", "model", unit, "status", "success")
 
 	// Update status to completed
-	o.consoleWriter.UpdateModelStatus(modelName, logutil.StatusCompleted, result.duration, "")
+	o.transitionModel(ctx, unit, ModelCompleted, result.duration, nil)
 
 	// Send result to channel
 	resultChan <- result
 }
 
+// acquireRateLimit waits for a rate limiter slot for unit's model, charging the
+// wait to budget (nil = unlimited). When the wait is cut short by the budget
+// rather than by ctx, the budget is marked exhausted, aborting the run.
+// The caller must release the slot once the request is done.
+func (o *Orchestrator) acquireRateLimit(
+	ctx context.Context,
+	unit string,
+	provider string,
+	rateLimiter *ratelimit.RateLimiter,
+	budget *waitBudget,
+) (time.Duration, error) {
+	contextLogger := o.logger.WithContext(ctx)
+	contextLogger.DebugContext(ctx, "Attempting to acquire rate limiter for model %s...", unit)
+
+	acquireStart := time.Now()
+	acquireCtx, endWait := budget.acquireContext(ctx)
+	err := rateLimiter.AcquireForProvider(acquireCtx, provider, o.unitModel(unit))
+	endWait()
+	waited := time.Since(acquireStart)
+
+	if err != nil {
+		contextLogger.ErrorContext(ctx, "Rate limiting error for model %s: %v", unit, err)
+		if budget != nil && ctx.Err() == nil {
+			// The run is still live, so the wait was cut short by the budget
+			budget.exhaust()
+			err = fmt.Errorf("%w (%v) while waiting for model %s", ErrRateLimitWaitBudgetExceeded, budget.limit, unit)
+		}
+		return waited, llm.Wrap(err, "orchestrator",
+			fmt.Sprintf("failed to acquire rate limiter for model %s", unit),
+			llm.CategoryRateLimit)
+	}
+	contextLogger.DebugContext(ctx, "Rate limiter acquired for model %s (waited %v)", unit, waited)
+	return waited, nil
+}
+
+// modelContext derives the context for one model's generation, bounded by
+// --model-timeout when it is set
+func (o *Orchestrator) modelContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o.config.ModelTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, o.config.ModelTimeout)
+}
+
 // getUserFriendlyErrorMessage creates a user-friendly error message with suggestions
 func (o *Orchestrator) getUserFriendlyErrorMessage(err error, modelName string) string {
 	if llmErr, ok := err.(*llm.LLMError); ok {
@@ -468,3 +645,44 @@ func (o *Orchestrator) getUserFriendlyErrorMessage(err error, modelName string)
 	}
 	return "error"
 }
+
+// shouldStreamOutput reports whether model output is streamed to the console as it is
+// generated: only for a single model, run once, without synthesis, writing to an interactive terminal.
+func (o *Orchestrator) shouldStreamOutput() bool {
+	return len(o.runUnits()) == 1 && o.config.SynthesisModel == "" && o.consoleWriter.IsInteractive()
+}
+
+// streamRetryNotice separates a failed attempt's partial streamed output from the
+// retry's response
+const streamRetryNotice = "[stream interrupted, retrying]"
+
+// streamRelay passes a model's streamed response to the console, one attempt at a time
+type streamRelay struct {
+	consoleWriter logutil.ConsoleWriter
+	unit          string
+	streamed      bool // The current attempt has written content
+	lineOpen      bool // The last content written did not end in a newline
+}
+
+// write passes a piece of the current attempt's response to the console
+func (s *streamRelay) write(content string) {
+	s.streamed = true
+	s.lineOpen = !strings.HasSuffix(content, "\n")
+	s.consoleWriter.StreamModelOutput(s.unit, content)
+}
+
+// restart starts a new attempt. When the previous attempt already wrote content, a
+// notice on its own line ends it, so the new response is not appended to the
+// fragment. A nil relay does nothing.
+func (s *streamRelay) restart() {
+	if s == nil || !s.streamed {
+		return
+	}
+	notice := "\n" + streamRetryNotice + "\n\n"
+	if s.lineOpen {
+		notice = "\n" + notice
+	}
+	s.consoleWriter.StreamModelOutput(s.unit, notice)
+	s.streamed = false
+	s.lineOpen = false
+}
@@ -0,0 +1,230 @@
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/misty-step/thinktank/internal/auditlog"
+	"github.com/misty-step/thinktank/internal/logutil"
+)
+
+// ModelStatus is the lifecycle state of a single model within a run.
+//
+// Models move from queued through started to exactly one terminal state:
+//
+//	queued → [rate-limited →] started → completed | failed
+//	queued → skipped | failed
+type ModelStatus int
+
+const (
+	ModelQueued      ModelStatus = iota // Waiting to be processed
+	ModelRateLimited                    // Delayed by the rate limiter before starting
+	ModelStarted                        // Request sent to the provider
+	ModelCompleted                      // Produced output
+	ModelFailed                         // Gave up with an error
+	ModelSkipped                        // Never attempted (e.g. input too large)
+)
+
+// String returns the status name recorded in audit logs
+func (s ModelStatus) String() string {
+	switch s {
+	case ModelQueued:
+		return "queued"
+	case ModelRateLimited:
+		return "rate_limited"
+	case ModelStarted:
+		return "started"
+	case ModelCompleted:
+		return "completed"
+	case ModelFailed:
+		return "failed"
+	case ModelSkipped:
+		return "skipped"
+	default:
+		return "unknown"
+	}
+}
+
+// IsTerminal reports whether no further transitions are allowed from s
+func (s ModelStatus) IsTerminal() bool {
+	return s == ModelCompleted || s == ModelFailed || s == ModelSkipped
+}
+
+// allowedModelTransitions lists the states reachable from each non-terminal state
+var allowedModelTransitions = map[ModelStatus][]ModelStatus{
+	ModelQueued:      {ModelRateLimited, ModelStarted, ModelFailed, ModelSkipped},
+	ModelRateLimited: {ModelStarted, ModelFailed},
+	ModelStarted:     {ModelCompleted, ModelFailed},
+}
+
+// canTransition reports whether a model may move from one status to another
+func canTransition(from, to ModelStatus) bool {
+	for _, allowed := range allowedModelTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// modelTiming records when a model was queued and started, so its completion
+// audit entry can report latency without readers pairing up transitions
+type modelTiming struct {
+	queuedAt  time.Time
+	startedAt time.Time
+}
+
+// transitionModel is the single place a model changes status. It validates the
+// transition, records it to the audit log, and mirrors it to the console.
+// A model with no recorded status may only enter ModelQueued or ModelSkipped.
+// duration is the elapsed time (or rate limit wait), and err is the failure or skip cause.
+// Returns false, leaving the status unchanged, if the transition is invalid.
+func (o *Orchestrator) transitionModel(ctx context.Context, modelName string, to ModelStatus, duration time.Duration, err error) bool {
+	now := time.Now().UTC()
+	var timing modelTiming
+
+	o.modelStatusMutex.Lock()
+	if o.modelStatuses == nil {
+		o.modelStatuses = make(map[string]ModelStatus)
+	}
+	from, known := o.modelStatuses[modelName]
+	valid := canTransition(from, to)
+	if !known {
+		valid = to == ModelQueued || to == ModelSkipped
+	}
+	if valid {
+		o.modelStatuses[modelName] = to
+		if err != nil && (to == ModelFailed || to == ModelSkipped) {
+			if o.modelReasons == nil {
+				o.modelReasons = make(map[string]string)
+			}
+			o.modelReasons[modelName] = err.Error()
+		}
+		timing = o.recordTransitionTimeLocked(modelName, to, now)
+	}
+	o.modelStatusMutex.Unlock()
+
+	fromName := from.String()
+	if !known {
+		fromName = "none"
+	}
+	if !valid {
+		o.logger.WarnContext(ctx, "Ignoring invalid status transition for model %s: %s -> %s", modelName, fromName, to)
+		return false
+	}
+
+	inputs := map[string]interface{}{
+		"model_name": modelName,
+		"from":       fromName,
+		"to":         to.String(),
+	}
+	var outputs map[string]interface{}
+	if duration > 0 {
+		outputs = map[string]interface{}{"duration_ms": duration.Milliseconds()}
+	}
+	auditStatus, auditErr := "Success", error(nil)
+	switch {
+	case to == ModelFailed:
+		auditStatus, auditErr = "Failure", err
+	case err != nil:
+		inputs["reason"] = err.Error()
+	}
+	if to == ModelCompleted || to == ModelFailed {
+		o.logCompletionAuditEvent(ctx, auditStatus, inputs, outputs, auditErr, duration, timing, now)
+	} else {
+		o.logAuditEvent(ctx, "ModelStatusTransition", auditStatus, inputs, outputs, auditErr)
+	}
+
+	switch to {
+	case ModelQueued:
+		o.consoleWriter.UpdateModelStatus(modelName, logutil.StatusStarting, 0, "")
+	case ModelRateLimited:
+		o.consoleWriter.UpdateModelRateLimited(modelName, duration)
+	case ModelStarted:
+		o.consoleWriter.UpdateModelStatus(modelName, logutil.StatusProcessing, 0, "")
+	case ModelCompleted:
+		o.consoleWriter.UpdateModelStatus(modelName, logutil.StatusCompleted, duration, "")
+	case ModelFailed:
+		errorMsg := ""
+		if err != nil {
+			errorMsg = o.getUserFriendlyErrorMessage(err, modelName)
+		}
+		o.consoleWriter.UpdateModelStatus(modelName, logutil.StatusFailed, duration, errorMsg)
+	case ModelSkipped:
+		// Skipped models are reported by the compatibility card, not the status display
+	}
+	return true
+}
+
+// recordTransitionTimeLocked notes when modelName was queued or started and
+// returns its timing so far. Callers must hold modelStatusMutex.
+func (o *Orchestrator) recordTransitionTimeLocked(modelName string, to ModelStatus, now time.Time) modelTiming {
+	if o.modelTimes == nil {
+		o.modelTimes = make(map[string]*modelTiming)
+	}
+	timing, ok := o.modelTimes[modelName]
+	if !ok {
+		timing = &modelTiming{}
+		o.modelTimes[modelName] = timing
+	}
+	switch to {
+	case ModelQueued:
+		timing.queuedAt = now
+	case ModelStarted:
+		timing.startedAt = now
+	}
+	return *timing
+}
+
+// logCompletionAuditEvent records a model's transition to completed or failed
+// with its duration and the queued, started, and completed timestamps as typed
+// fields, so per-model latency can be read straight from the audit log
+func (o *Orchestrator) logCompletionAuditEvent(
+	ctx context.Context,
+	status string,
+	inputs map[string]interface{},
+	outputs map[string]interface{},
+	err error,
+	duration time.Duration,
+	timing modelTiming,
+	completedAt time.Time,
+) {
+	entry := auditlog.NewOpEntry(ctx, "ModelStatusTransition", status, inputs, outputs, err)
+	durationMs := duration.Milliseconds()
+	entry.DurationMs = &durationMs
+	if !timing.queuedAt.IsZero() {
+		entry.QueuedAt = &timing.queuedAt
+	}
+	if !timing.startedAt.IsZero() {
+		entry.StartedAt = &timing.startedAt
+	}
+	entry.CompletedAt = &completedAt
+
+	if logErr := o.auditLogger.Log(ctx, entry); logErr != nil {
+		o.logger.WarnContext(ctx, "Failed to write audit log: %v", logErr)
+	}
+}
+
+// resetModelStatuses forgets all recorded statuses at the start of a processing run
+func (o *Orchestrator) resetModelStatuses() {
+	o.modelStatusMutex.Lock()
+	defer o.modelStatusMutex.Unlock()
+	o.modelStatuses = nil
+	o.modelReasons = nil
+	o.modelTimes = nil
+}
+
+// modelStatus returns the recorded status of a model, and false if it has none
+func (o *Orchestrator) modelStatus(modelName string) (ModelStatus, bool) {
+	o.modelStatusMutex.Lock()
+	defer o.modelStatusMutex.Unlock()
+	status, ok := o.modelStatuses[modelName]
+	return status, ok
+}
+
+// modelReason returns why a model failed or was skipped, or "" if no cause was recorded
+func (o *Orchestrator) modelReason(modelName string) string {
+	o.modelStatusMutex.Lock()
+	defer o.modelStatusMutex.Unlock()
+	return o.modelReasons[modelName]
+}
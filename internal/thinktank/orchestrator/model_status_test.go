@@ -0,0 +1,265 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/misty-step/thinktank/internal/config"
+	"github.com/misty-step/thinktank/internal/logutil"
+	"github.com/misty-step/thinktank/internal/testutil"
+)
+
+// statusRecordingConsoleWriter records the status updates and messages sent to the console
+type statusRecordingConsoleWriter struct {
+	MockConsoleWriter
+	updates  []logutil.ModelStatus
+	messages []string
+}
+
+func (w *statusRecordingConsoleWriter) UpdateModelStatus(modelName string, status logutil.ModelStatus, duration time.Duration, errorMsg string) {
+	w.updates = append(w.updates, status)
+}
+
+func (w *statusRecordingConsoleWriter) StatusMessage(message string) {
+	w.messages = append(w.messages, message)
+}
+
+func (w *statusRecordingConsoleWriter) UpdateModelRateLimited(modelName string, retryAfter time.Duration) {
+	w.updates = append(w.updates, logutil.StatusRateLimited)
+}
+
+func newStatusTestOrchestrator() (*Orchestrator, *MockAuditLogger, *statusRecordingConsoleWriter, *testutil.MockLogger) {
+	auditLogger := NewMockAuditLogger()
+	consoleWriter := &statusRecordingConsoleWriter{}
+	logger := testutil.NewMockLogger()
+	return &Orchestrator{
+		logger:        logger,
+		auditLogger:   auditLogger,
+		consoleWriter: consoleWriter,
+		config:        &config.CliConfig{},
+	}, auditLogger, consoleWriter, logger
+}
+
+func TestModelStatusString(t *testing.T) {
+	tests := []struct {
+		status   ModelStatus
+		expected string
+		terminal bool
+	}{
+		{ModelQueued, "queued", false},
+		{ModelRateLimited, "rate_limited", false},
+		{ModelStarted, "started", false},
+		{ModelCompleted, "completed", true},
+		{ModelFailed, "failed", true},
+		{ModelSkipped, "skipped", true},
+		{ModelStatus(99), "unknown", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := tt.status.String(); got != tt.expected {
+				t.Errorf("String() = %q, want %q", got, tt.expected)
+			}
+			if got := tt.status.IsTerminal(); got != tt.terminal {
+				t.Errorf("IsTerminal() = %v, want %v", got, tt.terminal)
+			}
+		})
+	}
+}
+
+func TestTransitionModel(t *testing.T) {
+	tests := []struct {
+		name        string
+		transitions []ModelStatus
+		final       ModelStatus
+		rejected    int
+		console     []logutil.ModelStatus
+	}{
+		{
+			name:        "successful model",
+			transitions: []ModelStatus{ModelQueued, ModelStarted, ModelCompleted},
+			final:       ModelCompleted,
+			console:     []logutil.ModelStatus{logutil.StatusStarting, logutil.StatusProcessing, logutil.StatusCompleted},
+		},
+		{
+			name:        "rate limited then failed",
+			transitions: []ModelStatus{ModelQueued, ModelRateLimited, ModelStarted, ModelFailed},
+			final:       ModelFailed,
+			console:     []logutil.ModelStatus{logutil.StatusStarting, logutil.StatusRateLimited, logutil.StatusProcessing, logutil.StatusFailed},
+		},
+		{
+			name:        "skipped before queueing",
+			transitions: []ModelStatus{ModelSkipped},
+			final:       ModelSkipped,
+		},
+		{
+			name:        "terminal state is final",
+			transitions: []ModelStatus{ModelQueued, ModelStarted, ModelCompleted, ModelFailed},
+			final:       ModelCompleted,
+			rejected:    1,
+			console:     []logutil.ModelStatus{logutil.StatusStarting, logutil.StatusProcessing, logutil.StatusCompleted},
+		},
+		{
+			name:        "cannot start before queueing",
+			transitions: []ModelStatus{ModelStarted, ModelQueued},
+			final:       ModelQueued,
+			rejected:    1,
+			console:     []logutil.ModelStatus{logutil.StatusStarting},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, auditLogger, consoleWriter, logger := newStatusTestOrchestrator()
+
+			rejected := 0
+			for _, to := range tt.transitions {
+				if !o.transitionModel(context.Background(), "model-a", to, time.Second, errors.New("boom")) {
+					rejected++
+				}
+			}
+
+			if status, ok := o.modelStatus("model-a"); !ok || status != tt.final {
+				t.Errorf("final status = %v (recorded %v), want %v", status, ok, tt.final)
+			}
+			if rejected != tt.rejected {
+				t.Errorf("rejected %d transitions, want %d", rejected, tt.rejected)
+			}
+			if tt.rejected > 0 && !logger.ContainsMessage("Ignoring invalid status transition") {
+				t.Error("expected a warning for the rejected transition")
+			}
+			if got := len(auditLogger.LogCalls); got != len(tt.transitions)-tt.rejected {
+				t.Errorf("recorded %d audit entries, want %d", got, len(tt.transitions)-tt.rejected)
+			}
+			for _, call := range auditLogger.LogCalls {
+				if call.Operation != "ModelStatusTransition" {
+					t.Errorf("audit operation = %q, want ModelStatusTransition", call.Operation)
+				}
+				if (call.Status == "Failure") != (call.Inputs["to"] == "failed") {
+					t.Errorf("audit status %q does not match transition to %v", call.Status, call.Inputs["to"])
+				}
+			}
+			if len(consoleWriter.updates) != len(tt.console) {
+				t.Fatalf("console updates = %v, want %v", consoleWriter.updates, tt.console)
+			}
+			for i, status := range tt.console {
+				if consoleWriter.updates[i] != status {
+					t.Errorf("console update %d = %v, want %v", i, consoleWriter.updates[i], status)
+				}
+			}
+		})
+	}
+}
+
+func TestTransitionModelRecordsSkipReason(t *testing.T) {
+	o, auditLogger, _, _ := newStatusTestOrchestrator()
+
+	o.transitionModel(context.Background(), "model-a", ModelSkipped, 0, errors.New("input too large"))
+
+	if len(auditLogger.LogCalls) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d", len(auditLogger.LogCalls))
+	}
+	call := auditLogger.LogCalls[0]
+	if call.Status != "Success" || call.Error != nil {
+		t.Errorf("skip should be audited as a successful transition, got status %q, error %v", call.Status, call.Error)
+	}
+	if call.Inputs["from"] != "none" || call.Inputs["reason"] != "input too large" {
+		t.Errorf("unexpected audit inputs: %v", call.Inputs)
+	}
+}
+
+func TestTransitionModelRecordsTiming(t *testing.T) {
+	o, auditLogger, _, _ := newStatusTestOrchestrator()
+	ctx := context.Background()
+
+	before := time.Now().UTC()
+	o.transitionModel(ctx, "model-a", ModelQueued, 0, nil)
+	o.transitionModel(ctx, "model-a", ModelStarted, 0, nil)
+	o.transitionModel(ctx, "model-a", ModelCompleted, 1500*time.Millisecond, nil)
+	o.transitionModel(ctx, "model-b", ModelQueued, 0, nil)
+	o.transitionModel(ctx, "model-b", ModelFailed, 0, errors.New("rate limited"))
+
+	var completions []*LogCall
+	for i := range auditLogger.LogCalls {
+		call := &auditLogger.LogCalls[i]
+		if call.Entry != nil {
+			completions = append(completions, call)
+		} else if call.Inputs["to"] == "completed" || call.Inputs["to"] == "failed" {
+			t.Errorf("completion of %v should carry typed timing fields", call.Inputs["model_name"])
+		}
+	}
+	if len(completions) != 2 {
+		t.Fatalf("expected 2 completion entries, got %d", len(completions))
+	}
+
+	completed := completions[0].Entry
+	if completed.DurationMs == nil || *completed.DurationMs != 1500 {
+		t.Errorf("DurationMs = %v, want 1500", completed.DurationMs)
+	}
+	if completed.QueuedAt == nil || completed.StartedAt == nil || completed.CompletedAt == nil {
+		t.Fatalf("expected queued, started, and completed timestamps, got %+v", completed)
+	}
+	if completed.QueuedAt.Before(before) || completed.StartedAt.Before(*completed.QueuedAt) ||
+		completed.CompletedAt.Before(*completed.StartedAt) {
+		t.Errorf("timestamps out of order: queued %v, started %v, completed %v",
+			completed.QueuedAt, completed.StartedAt, completed.CompletedAt)
+	}
+
+	// A model that failed before starting has no start time
+	failed := completions[1].Entry
+	if failed.Status != "Failure" || failed.StartedAt != nil || failed.QueuedAt == nil || failed.CompletedAt == nil {
+		t.Errorf("unexpected failure entry: status %q, queued %v, started %v, completed %v",
+			failed.Status, failed.QueuedAt, failed.StartedAt, failed.CompletedAt)
+	}
+}
+
+func TestGenerateResultsSummarySeparatesSkippedModels(t *testing.T) {
+	o, _, _, _ := newStatusTestOrchestrator()
+	o.config.ModelNames = []string{"model-a", "model-b", "model-c"}
+	o.fileWriter = &MockFileWriter{}
+
+	ctx := context.Background()
+	o.transitionModel(ctx, "model-a", ModelQueued, 0, nil)
+	o.transitionModel(ctx, "model-b", ModelQueued, 0, nil)
+	o.transitionModel(ctx, "model-b", ModelFailed, 0, errors.New("boom"))
+	o.transitionModel(ctx, "model-c", ModelSkipped, 0, errors.New("input too large"))
+
+	summary := o.generateResultsSummary(map[string]string{"model-a": "output"}, NewOutputInfo(), nil)
+
+	if summary.SuccessfulModels != 1 {
+		t.Errorf("SuccessfulModels = %d, want 1", summary.SuccessfulModels)
+	}
+	if len(summary.FailedModels) != 1 || summary.FailedModels[0] != "model-b" {
+		t.Errorf("FailedModels = %v, want [model-b]", summary.FailedModels)
+	}
+	if len(summary.SkippedModels) != 1 || summary.SkippedModels[0] != "model-c" {
+		t.Errorf("SkippedModels = %v, want [model-c]", summary.SkippedModels)
+	}
+}
+
+func TestGenerateResultsSummaryRecordsReasons(t *testing.T) {
+	o, _, _, _ := newStatusTestOrchestrator()
+	o.config.ModelNames = []string{"model-a", "model-b", "model-c"}
+	o.fileWriter = &MockFileWriter{}
+
+	ctx := context.Background()
+	o.transitionModel(ctx, "model-a", ModelQueued, 0, nil)
+	o.transitionModel(ctx, "model-b", ModelQueued, 0, nil)
+	o.transitionModel(ctx, "model-b", ModelFailed, 0, errors.New("boom"))
+	o.transitionModel(ctx, "model-c", ModelSkipped, 0, errors.New("input too large"))
+
+	summary := o.generateResultsSummary(map[string]string{"model-a": "output"}, NewOutputInfo(), nil)
+
+	expected := map[string]string{"model-b": "boom", "model-c": "input too large"}
+	if !reflect.DeepEqual(summary.FailureReasons, expected) {
+		t.Errorf("FailureReasons = %v, want %v", summary.FailureReasons, expected)
+	}
+
+	o.resetModelStatuses()
+	if reason := o.modelReason("model-b"); reason != "" {
+		t.Errorf("modelReason after reset = %q, want empty", reason)
+	}
+}
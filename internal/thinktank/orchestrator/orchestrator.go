@@ -7,10 +7,14 @@ package orchestrator
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/misty-step/thinktank/internal/auditlog"
+	"github.com/misty-step/thinktank/internal/cache"
 	"github.com/misty-step/thinktank/internal/config"
 	"github.com/misty-step/thinktank/internal/fileutil"
 	"github.com/misty-step/thinktank/internal/llm"
@@ -41,6 +45,21 @@ type Orchestrator struct {
 	metricsCollector     metrics.Collector                 // Optional metrics collector for observability
 	modelRateLimiters    map[string]*ratelimit.RateLimiter // Per-model rate limiters for models with specific concurrency limits
 	rateLimiterMutex     sync.RWMutex                      // Protects modelRateLimiters map
+	tokenAccounting      map[string]*TokenReconciliation   // Per-model token counts from each source, for reconciliation
+	modelStatuses        map[string]ModelStatus            // Lifecycle state of each model, updated via transitionModel
+	modelReasons         map[string]string                 // Failure or skip cause of each model that did not complete
+	modelTimes           map[string]*modelTiming           // When each model was queued and started, for its completion audit entry
+	modelStatusMutex     sync.Mutex                        // Protects modelStatuses, modelReasons, and modelTimes
+	cache                *cache.Cache                      // Response cache opened from config.CacheDir on first use
+	cacheOnce            sync.Once                         // Guards opening cache
+	trimmedPrompts       map[string]string                 // Per-model prompts shortened by --auto-trim (set before models run)
+	outputNamer          outputNamer                       // Names output files; shared with outputWriter so {timestamp} matches
+	retryBudget          *retryBudget                      // Retries left across all models (nil = unlimited)
+	outcomeMetrics       *outcomeMetrics                   // OpenTelemetry counters of model outcomes and tokens (nil = none)
+	postProcessedOutputs map[string]string                 // Output file of each unit post-processed when the model processor saved it
+	postProcessRuns      int                               // Output files the --post-process command has run on
+	postProcessFailures  int                               // Of those, the ones where the command failed
+	postProcessMutex     sync.Mutex                        // Protects postProcessedOutputs and the post-process counts
 }
 
 // OrchestratorDeps defines the runtime dependencies required to build an Orchestrator.
@@ -92,13 +111,20 @@ func NewOrchestrator(deps OrchestratorDeps) *Orchestrator {
 	}
 
 	// Create the output writer
-	outputWriter := NewOutputWriter(deps.FileWriter, deps.AuditLogger, deps.Logger)
+	namer := newOutputNamer(deps.Config.OutputNameTemplate, time.Now()).
+		forUnits(expandRepeatUnits(deps.Config.ModelNames, deps.Config.Repeat))
+	outputWriter := newOutputWriter(deps.FileWriter, deps.AuditLogger, deps.Logger, namer)
 	// Create the summary writer
 	summaryWriter := NewSummaryWriter(deps.Logger, deps.ConsoleWriter)
 	// Create a synthesis service only if synthesis model is specified
 	var synthesisService SynthesisService
 	if deps.Config.SynthesisModel != "" {
-		synthesisService = NewSynthesisService(deps.APIService, deps.AuditLogger, deps.Logger, deps.Config.SynthesisModel)
+		defaultSynthesis := newDefaultSynthesisService(deps.APIService, deps.AuditLogger, deps.Logger, deps.Config.SynthesisModel)
+		defaultSynthesis.SetAuditTextCapture(auditlog.TextCapture{
+			Full:          deps.Config.AuditVerbose,
+			PreviewLength: deps.Config.AuditPreviewLength,
+		})
+		synthesisService = defaultSynthesis
 	}
 	// Use noop collector if none provided
 	metricsCollector := deps.MetricsCollector
@@ -121,6 +147,9 @@ func NewOrchestrator(deps OrchestratorDeps) *Orchestrator {
 		tokenCountingService: deps.TokenCountingService,
 		metricsCollector:     metricsCollector,
 		modelRateLimiters:    make(map[string]*ratelimit.RateLimiter),
+		outputNamer:          namer,
+		retryBudget:          newRetryBudget(deps.Config.RetryBudget),
+		outcomeMetrics:       newOutcomeMetrics(),
 	}
 }
 
@@ -170,7 +199,7 @@ func (o *Orchestrator) getRateLimiterForModel(modelName string) *ratelimit.RateL
 			o.logger.DebugContext(context.Background(), "Using config rate limit for %s (%s): %d RPM", modelName, modelInfo.Provider, effectiveRateLimit)
 		} else {
 			// Use provider default
-			effectiveRateLimit = models.GetProviderDefaultRateLimit(modelInfo.Provider)
+			effectiveRateLimit = models.GetProviderRateLimit(modelInfo.Provider)
 			o.logger.DebugContext(context.Background(), "Using provider default rate limit for %s (%s): %d RPM", modelName, modelInfo.Provider, effectiveRateLimit)
 		}
 	}
@@ -191,14 +220,19 @@ func (o *Orchestrator) getRateLimiterForModel(modelName string) *ratelimit.RateL
 // 3. Handle dry run mode (if enabled)
 // 4. Build the complete prompt
 // 5. Process models concurrently with error handling
-// 6. Save outputs (either individually or via synthesis)
+// 6. Save outputs (either individually or via synthesis) and the run manifest
 // 7. Generate and display execution summary
 // 8. Handle and report any errors
 //
 // Each step is delegated to a specialized helper method, making the workflow
-// clear and maintainable.
-func (o *Orchestrator) Run(ctx context.Context, instructions string) error {
+// clear and maintainable. The run is traced as a span that is the parent of each
+// model's span, and is a no-op unless a tracer provider is registered.
+func (o *Orchestrator) Run(ctx context.Context, instructions string) (err error) {
+	ctx, span := o.startRunSpan(ctx)
+	defer func() { endSpan(span, err) }()
+
 	// Start total execution timer
+	startTime := time.Now()
 	stopTotalTimer := o.metricsCollector.StartTimer("total_duration_ms")
 	defer stopTotalTimer()
 
@@ -214,7 +248,7 @@ func (o *Orchestrator) Run(ctx context.Context, instructions string) error {
 
 	// Step 1: Gather file context for the prompt
 	stopContextTimer := o.metricsCollector.StartTimer("context_gather_duration_ms")
-	contextFiles, contextStats, err := o.gatherProjectContext(ctx)
+	contextFiles, contextStats, err := o.gatherProjectContext(ctx, instructions)
 	stopContextTimer()
 	if err != nil {
 		o.metricsCollector.IncrCounter("execution_errors_total", "phase", "context_gather")
@@ -234,8 +268,17 @@ func (o *Orchestrator) Run(ctx context.Context, instructions string) error {
 	} else if dryRunExecuted {
 		return nil
 	}
+	// Refuse to clobber earlier results before spending anything on models
+	if err := o.checkOutputConflicts(); err != nil {
+		o.metricsCollector.IncrCounter("execution_errors_total", "phase", "setup")
+		contextLogger.ErrorContext(ctx, "%v", err)
+		return err
+	}
 	// Step 3: Build the complete prompt
 	stitchedPrompt := o.buildPrompt(ctx, instructions, contextFiles)
+	if o.config.AutoTrim {
+		o.trimmedPrompts = o.trimContextToFit(ctx, instructions, contextFiles, stitchedPrompt)
+	}
 
 	// Step 4: Process all models and handle errors
 	stopModelTimer := o.metricsCollector.StartTimer("model_processing_duration_ms")
@@ -243,7 +286,7 @@ func (o *Orchestrator) Run(ctx context.Context, instructions string) error {
 	stopModelTimer()
 
 	// Record model processing metrics
-	o.metricsCollector.SetGauge("models_total", float64(len(o.config.ModelNames)))
+	o.metricsCollector.SetGauge("models_total", float64(len(o.runUnits())))
 	o.metricsCollector.SetGauge("models_successful", float64(len(modelOutputs)))
 
 	if criticalErr != nil {
@@ -254,16 +297,23 @@ func (o *Orchestrator) Run(ctx context.Context, instructions string) error {
 	// Step 5: Save outputs (via synthesis or individually)
 	stopOutputTimer := o.metricsCollector.StartTimer("output_save_duration_ms")
 	outputInfo, fileSaveErr := o.handleOutputFlow(ctx, instructions, modelOutputs)
+	o.reportPostProcessFailures()
+	o.writeManifest(ctx, modelOutputs, outputInfo)
 	stopOutputTimer()
 	// Step 6: Generate and display the execution summary
 	summary := o.generateResultsSummary(modelOutputs, outputInfo, processingErr)
+	summary.Duration = time.Since(startTime)
+	if contextStats != nil {
+		summary.ExcludeMatches = contextStats.ExcludeMatches
+	}
 	o.summaryWriter.DisplaySummary(ctx, summary)
+	o.writeSummaryFile(ctx, summary)
 	// Step 7: Final error processing and return
 	return o.handleProcessingOutcome(ctx, processingErr, fileSaveErr, contextLogger)
 }
 
 // gatherProjectContext collects relevant files from the project based on configuration.
-func (o *Orchestrator) gatherProjectContext(ctx context.Context) ([]fileutil.FileMeta, *interfaces.ContextStats, error) {
+func (o *Orchestrator) gatherProjectContext(ctx context.Context, instructions string) ([]fileutil.FileMeta, *interfaces.ContextStats, error) {
 	// Notify user that context gathering is starting (skip for dry run since it has its own display)
 	if !o.config.DryRun {
 		o.consoleWriter.StatusMessage("Gathering project files...")
@@ -277,6 +327,25 @@ func (o *Orchestrator) gatherProjectContext(ctx context.Context) ([]fileutil.Fil
 		Format:       o.config.Format,
 		Verbose:      o.config.Verbose,
 		LogLevel:     o.config.LogLevel,
+
+		NormalizeLineEndings: o.config.NormalizeLineEndings,
+		IncludeGlobs:         o.config.IncludeGlobs,
+		PriorityGlobs:        o.config.PriorityGlobs,
+		Workers:              o.config.GatherWorkers,
+		MaxFileSizeBytes:     o.config.MaxFileSize,
+		FollowSymlinks:       o.config.FollowSymlinks,
+		IncludeHidden:        o.config.IncludeHidden,
+		ExplainExcludes:      o.config.ExplainExcludes,
+		MaxContextTokens:     o.config.MaxContextTokens,
+		Instructions:         instructions,
+		Prompt:               o.promptBuilder(),
+		Timeout:              o.config.GatherTimeout,
+	}
+	if o.config.ExcludeGenerated {
+		gatherConfig.ExcludeContentPatterns = fileutil.GeneratedFilePatterns
+	}
+	if len(o.config.ModelNames) > 0 {
+		gatherConfig.TokenModel = o.config.ModelNames[0]
 	}
 
 	contextFiles, contextStats, err := o.contextGatherer.GatherContext(ctx, gatherConfig)
@@ -326,8 +395,30 @@ func (o *Orchestrator) runIndividualOutputFlow(ctx context.Context, modelOutputs
 	// Notify user that individual outputs are being saved
 	o.consoleWriter.ShowFileOperations("Saving individual outputs...")
 
+	// Files post-processed when the model processor saved them are kept as they
+	// are, since saving them again would undo the command's changes
+	pending := make(map[string]string, len(modelOutputs))
+	postProcessed := make(map[string]string)
+	for modelName, content := range modelOutputs {
+		if path, ok := o.postProcessedOutput(modelName); ok {
+			postProcessed[modelName] = path
+			continue
+		}
+		pending[modelName] = content
+	}
+
 	// Use the OutputWriter to save individual model outputs
-	savedCount, filePaths, err := o.outputWriter.SaveIndividualOutputs(ctx, modelOutputs, o.config.OutputDir)
+	savedCount, filePaths, err := o.outputWriter.SaveIndividualOutputs(ctx, pending, o.config.OutputDir)
+	for _, modelName := range slices.Sorted(maps.Keys(filePaths)) {
+		o.postProcessFile(ctx, filePaths[modelName])
+	}
+	if len(postProcessed) > 0 {
+		if filePaths == nil {
+			filePaths = make(map[string]string, len(postProcessed))
+		}
+		maps.Copy(filePaths, postProcessed)
+	}
+	savedCount += len(postProcessed)
 	if err != nil {
 		contextLogger.ErrorContext(ctx, "Completed with errors: %d files saved successfully, %d files failed",
 			savedCount, len(modelOutputs)-savedCount)
@@ -368,6 +459,10 @@ func (o *Orchestrator) runSynthesisFlow(ctx context.Context, instructions string
 	contextLogger.InfoContext(ctx, "Successfully synthesized results from %d model outputs", len(modelOutputs))
 	contextLogger.DebugContext(ctx, "Synthesis output length: %d characters", len(synthesisContent))
 
+	if o.config.EmbedInstructions {
+		synthesisContent = embedInstructions(instructions, synthesisContent)
+	}
+
 	// Save the synthesis output using the OutputWriter
 	outputPath, err := o.outputWriter.SaveSynthesisOutput(ctx, synthesisContent, o.config.SynthesisModel, o.config.OutputDir)
 	if err != nil {
@@ -376,6 +471,7 @@ func (o *Orchestrator) runSynthesisFlow(ctx context.Context, instructions string
 	}
 
 	contextLogger.InfoContext(ctx, "Successfully saved synthesis output to %s", outputPath)
+	o.postProcessFile(ctx, outputPath)
 
 	// Report synthesis completed
 	o.consoleWriter.SynthesisCompleted(outputPath)
@@ -398,12 +494,17 @@ func (o *Orchestrator) handleDryRun(ctx context.Context, stats *interfaces.Conte
 
 // buildPrompt creates the complete prompt by combining instructions with context files.
 func (o *Orchestrator) buildPrompt(ctx context.Context, instructions string, contextFiles []fileutil.FileMeta) string {
-	stitchedPrompt := prompt.StitchPrompt(instructions, contextFiles)
+	stitchedPrompt := o.promptBuilder().Build(instructions, contextFiles)
 	o.logger.InfoContext(ctx, "Prompt constructed successfully")
 	o.logger.DebugContext(ctx, "Stitched prompt length: %d characters", len(stitchedPrompt))
 	return stitchedPrompt
 }
 
+// promptBuilder returns the prompt layout selected in the configuration
+func (o *Orchestrator) promptBuilder() prompt.PromptBuilder {
+	return prompt.PromptBuilder{Order: prompt.Order(o.config.PromptOrder), FenceCode: o.config.FenceCode, FileSeparator: o.config.FileSeparator}
+}
+
 // logRateLimitingConfiguration logs information about concurrency and rate limits.
 func (o *Orchestrator) logRateLimitingConfiguration(ctx context.Context) {
 	// Get logger with context
@@ -421,7 +522,7 @@ func (o *Orchestrator) logRateLimitingConfiguration(ctx context.Context) {
 		contextLogger.InfoContext(ctx, "No rate limit applied")
 	}
 
-	contextLogger.InfoContext(ctx, "Processing %d models concurrently...", len(o.config.ModelNames))
+	contextLogger.InfoContext(ctx, "Processing %d models concurrently...", len(o.runUnits()))
 }
 
 // APIServiceAdapter adapts interfaces.APIService to modelproc.APIService.
@@ -499,13 +600,19 @@ func (o *Orchestrator) aggregateErrors(errs []error, totalCount, successCount in
 	// If all operations failed (no successes)
 	if successCount == 0 {
 		errorMsg := fmt.Sprintf("all models failed: %v", aggregateErrorMessages(errs))
-		return fmt.Errorf("%w: %s", ErrAllProcessingFailed, errorMsg)
+		return &modelFailuresError{
+			error:    fmt.Errorf("%w: %s", ErrAllProcessingFailed, errorMsg),
+			failures: modelFailures(errs),
+		}
 	}
 
 	// Some operations succeeded but others failed
-	return fmt.Errorf("%w: processed %d/%d models successfully; %d failed: %v",
-		ErrPartialProcessingFailure, successCount, totalCount, len(errs),
-		aggregateErrorMessages(errs))
+	return &modelFailuresError{
+		error: fmt.Errorf("%w: processed %d/%d models successfully; %d failed: %v",
+			ErrPartialProcessingFailure, successCount, totalCount, len(errs),
+			aggregateErrorMessages(errs)),
+		failures: modelFailures(errs),
+	}
 }
 
 // setupContext handles the initial setup of the context, validation, and logging.
@@ -537,9 +644,11 @@ func (o *Orchestrator) generateResultsSummary(
 	outputInfo *OutputInfo,
 	processingErr error,
 ) *ResultsSummary {
+	units := o.runUnits()
 	summary := &ResultsSummary{
-		TotalModels:      len(o.config.ModelNames),
-		SuccessfulModels: len(modelOutputs),
+		TotalModels:        len(units),
+		SuccessfulModels:   len(modelOutputs),
+		SynthesisRequested: o.config.SynthesisModel != "",
 	}
 
 	// Add successful model names
@@ -556,16 +665,43 @@ func (o *Orchestrator) generateResultsSummary(
 	for _, path := range outputInfo.IndividualFilePaths {
 		summary.OutputPaths = append(summary.OutputPaths, path)
 	}
+	if outputInfo.CombinedFilePath != "" {
+		summary.OutputPaths = append(summary.OutputPaths, outputInfo.CombinedFilePath)
+	}
+
+	// Report files cut short by the output size limit
+	if reporter, ok := o.fileWriter.(interfaces.TruncationReporter); ok {
+		summary.TruncatedFiles = reporter.TruncatedFiles()
+	}
 
-	// Determine failed models (those in config.ModelNames but not in modelOutputs)
+	// Flag models whose provider token accounting disagrees with ours
+	summary.TokenDiscrepancies = o.tokenDiscrepancies()
+
+	// Determine failed models (those run but not in modelOutputs).
+	// Models skipped before processing are reported separately rather than as failures.
 	successMap := make(map[string]bool)
 	for modelName := range modelOutputs {
 		successMap[modelName] = true
 	}
 
-	for _, modelName := range o.config.ModelNames {
-		if !successMap[modelName] {
-			summary.FailedModels = append(summary.FailedModels, modelName)
+	for _, modelName := range units {
+		if successMap[modelName] {
+			continue
+		}
+		if status, ok := o.modelStatus(modelName); ok && status == ModelSkipped {
+			summary.SkippedModels = append(summary.SkippedModels, modelName)
+			continue
+		}
+		summary.FailedModels = append(summary.FailedModels, modelName)
+	}
+
+	// Record why each model that did not complete failed or was skipped
+	summary.FailureReasons = make(map[string]string)
+	for _, names := range [][]string{summary.FailedModels, summary.SkippedModels} {
+		for _, modelName := range names {
+			if reason := o.modelReason(modelName); reason != "" {
+				summary.FailureReasons[modelName] = reason
+			}
 		}
 	}
 
@@ -578,12 +714,18 @@ func (o *Orchestrator) generateResultsSummary(
 func (o *Orchestrator) handleOutputFlow(ctx context.Context, instructions string, modelOutputs map[string]string) (*OutputInfo, error) {
 	outputInfo := NewOutputInfo()
 
+	// Files may carry the instructions, but synthesis always sees the raw outputs
+	savedOutputs := modelOutputs
+	if o.config.EmbedInstructions {
+		savedOutputs = make(map[string]string, len(modelOutputs))
+		for modelName, content := range modelOutputs {
+			savedOutputs[modelName] = embedInstructions(instructions, content)
+		}
+	}
+
 	if o.config.SynthesisModel == "" {
 		// No synthesis model specified - save individual model outputs
-		filePaths, err := o.runIndividualOutputFlow(ctx, modelOutputs)
-		if filePaths != nil {
-			outputInfo.IndividualFilePaths = filePaths
-		}
+		err := o.saveModelOutputs(ctx, instructions, modelOutputs, savedOutputs, outputInfo)
 		return outputInfo, err
 	}
 
@@ -592,10 +734,7 @@ func (o *Orchestrator) handleOutputFlow(ctx context.Context, instructions string
 	contextLogger := o.logger.WithContext(ctx)
 
 	// First, save individual model outputs
-	filePaths, individualErr := o.runIndividualOutputFlow(ctx, modelOutputs)
-	if filePaths != nil {
-		outputInfo.IndividualFilePaths = filePaths
-	}
+	individualErr := o.saveModelOutputs(ctx, instructions, modelOutputs, savedOutputs, outputInfo)
 
 	// Then, run synthesis flow
 	synthesisPath, synthesisErr := o.runSynthesisFlow(ctx, instructions, modelOutputs)
@@ -614,6 +753,42 @@ func (o *Orchestrator) handleOutputFlow(ctx context.Context, instructions string
 	return outputInfo, individualErr
 }
 
+// saveModelOutputs saves the model outputs to one file each, or to the single
+// --combined-output file when it is set, recording the paths in outputInfo
+func (o *Orchestrator) saveModelOutputs(ctx context.Context, instructions string, modelOutputs, savedOutputs map[string]string, outputInfo *OutputInfo) error {
+	if o.config.CombinedOutput != "" {
+		path, included, err := o.runCombinedOutputFlow(ctx, instructions, modelOutputs)
+		outputInfo.CombinedFilePath = path
+		outputInfo.CombinedModels = included
+		return err
+	}
+
+	filePaths, err := o.runIndividualOutputFlow(ctx, savedOutputs)
+	if filePaths != nil {
+		outputInfo.IndividualFilePaths = filePaths
+	}
+	return err
+}
+
+// embedInstructions prepends the instructions to an output file's content
+// so the file records what it was generated from. Every instruction line is
+// quoted, so the block cannot be mistaken for the model's own output even
+// when the instructions contain headings or rules.
+func embedInstructions(instructions, content string) string {
+	var b strings.Builder
+	b.WriteString("## Instructions\n\n")
+	for _, line := range strings.Split(strings.TrimSpace(instructions), "\n") {
+		if line == "" {
+			b.WriteString(">\n")
+			continue
+		}
+		b.WriteString("> " + line + "\n")
+	}
+	b.WriteString("\n---\n\n")
+	b.WriteString(content)
+	return b.String()
+}
+
 // handleProcessingOutcome combines and reports any errors from model processing and file saving.
 // It formats and logs appropriate error messages based on the types of errors encountered.
 // It also categorizes errors using the llm.ErrorCategory system for consistent handling.
@@ -1,13 +1,17 @@
 package orchestrator
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/misty-step/thinktank/internal/config"
 	"github.com/misty-step/thinktank/internal/llm"
+	"github.com/misty-step/thinktank/internal/metrics"
+	"github.com/misty-step/thinktank/internal/ratelimit"
 	"github.com/misty-step/thinktank/internal/testutil"
 )
 
@@ -384,3 +388,114 @@ func TestCategorizeOrchestratorError(t *testing.T) {
 		})
 	}
 }
+
+// TestModelFailures tests that aggregated errors keep each model's failure
+func TestModelFailures(t *testing.T) {
+	o := &Orchestrator{logger: testutil.NewMockLogger(), config: &config.CliConfig{}}
+
+	errs := []error{
+		&modelError{model: "model1", err: llm.Wrap(errors.New("bad key"), "openrouter", "authentication failed", llm.CategoryAuth)},
+		&modelError{model: "model2", err: errors.New("connection reset")},
+		errors.New("unattributed failure"),
+	}
+	result := o.aggregateErrors(errs, 4, 1)
+
+	// Wrapping the aggregate, as handleProcessingOutcome does, keeps the failures reachable
+	failures := ModelFailures(llm.Wrap(result, "orchestrator", "processing failed", llm.CategoryServer))
+	want := []ModelFailure{
+		{Model: "model1", Category: llm.CategoryAuth, Message: "authentication failed: bad key"},
+		{Model: "model2", Category: llm.CategoryUnknown, Message: "connection reset"},
+	}
+	if fmt.Sprint(failures) != fmt.Sprint(want) {
+		t.Errorf("ModelFailures() = %+v, want %+v", failures, want)
+	}
+
+	if !strings.Contains(result.Error(), "3 failed: authentication failed: bad key; connection reset; unattributed failure") {
+		t.Errorf("aggregate message changed: %q", result.Error())
+	}
+	if llm.IsCategory(result, llm.CategoryAuth) {
+		t.Errorf("a model's category should not leak into the aggregate error")
+	}
+	if ModelFailures(errors.New("other")) != nil || ModelFailures(nil) != nil {
+		t.Errorf("ModelFailures() should be nil for errors without model failures")
+	}
+}
+
+// delayedFailureAPIService fails every model after that model's delay
+type delayedFailureAPIService struct {
+	MockAPIService
+	delays map[string]time.Duration
+}
+
+func (m *delayedFailureAPIService) InitLLMClient(ctx context.Context, apiKey, modelName, apiEndpoint string) (llm.LLMClient, error) {
+	return &delayedFailureLLMClient{modelName: modelName, delay: m.delays[modelName]}, nil
+}
+
+type delayedFailureLLMClient struct {
+	MockLLMClient
+	modelName string
+	delay     time.Duration
+}
+
+func (c *delayedFailureLLMClient) GenerateContent(ctx context.Context, prompt string, params map[string]interface{}) (*llm.ProviderResult, error) {
+	time.Sleep(c.delay)
+	return nil, fmt.Errorf("%s failed", c.modelName)
+}
+
+func TestProcessModels_DeterministicErrorOrder(t *testing.T) {
+	modelNames := []string{"model-a", "model-b", "model-c"}
+	completionOrders := [][]string{
+		{"model-a", "model-b", "model-c"},
+		{"model-c", "model-b", "model-a"},
+		{"model-b", "model-c", "model-a"},
+	}
+
+	var messages []string
+	for _, order := range completionOrders {
+		delays := make(map[string]time.Duration)
+		for i, modelName := range order {
+			delays[modelName] = time.Duration(i) * 20 * time.Millisecond
+		}
+		o := &Orchestrator{
+			apiService:        &delayedFailureAPIService{delays: delays},
+			fileWriter:        &MockFileWriter{},
+			auditLogger:       NewMockAuditLogger(),
+			rateLimiter:       ratelimit.NewRateLimiter(0, 0),
+			config:            &config.CliConfig{ModelNames: modelNames},
+			logger:            testutil.NewMockLogger(),
+			consoleWriter:     &MockConsoleWriter{},
+			metricsCollector:  metrics.NewNoopCollector(),
+			modelRateLimiters: make(map[string]*ratelimit.RateLimiter),
+			outputNamer:       newOutputNamer("", time.Now()),
+		}
+
+		_, errs := o.processModels(context.Background(), "prompt")
+		messages = append(messages, o.aggregateErrors(errs, len(modelNames), 0).Error())
+	}
+
+	for i, message := range messages {
+		if message != messages[0] {
+			t.Errorf("completion order %v gave %q, want %q", completionOrders[i], message, messages[0])
+		}
+	}
+	a, b, c := strings.Index(messages[0], "model-a failed"), strings.Index(messages[0], "model-b failed"), strings.Index(messages[0], "model-c failed")
+	if a < 0 || a > b || b > c {
+		t.Errorf("errors should be listed by model name, got %q", messages[0])
+	}
+}
+
+func TestSortModelErrors(t *testing.T) {
+	unattributed := errors.New("unattributed failure")
+	errs := []error{
+		&modelError{model: "zeta", err: errors.New("z")},
+		unattributed,
+		&modelError{model: "alpha", err: errors.New("a")},
+		&modelError{model: "mu", err: errors.New("m")},
+	}
+
+	sortModelErrors(errs)
+
+	if got := aggregateErrorMessages(errs); got != "a; m; z; unattributed failure" {
+		t.Errorf("sorted messages = %q, want named errors by model then the rest", got)
+	}
+}
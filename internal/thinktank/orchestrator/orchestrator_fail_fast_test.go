@@ -0,0 +1,109 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/misty-step/thinktank/internal/config"
+	"github.com/misty-step/thinktank/internal/llm"
+	"github.com/misty-step/thinktank/internal/metrics"
+	"github.com/misty-step/thinktank/internal/ratelimit"
+	"github.com/misty-step/thinktank/internal/testutil"
+)
+
+// failFastAPIService fails "broken-model" at once; other models block until
+// cancelled, or finish after delay when one is set
+type failFastAPIService struct {
+	MockAPIService
+	delay time.Duration
+}
+
+func (m *failFastAPIService) InitLLMClient(ctx context.Context, apiKey, modelName, apiEndpoint string) (llm.LLMClient, error) {
+	return &failFastLLMClient{modelName: modelName, delay: m.delay}, nil
+}
+
+type failFastLLMClient struct {
+	MockLLMClient
+	modelName string
+	delay     time.Duration
+}
+
+func (c *failFastLLMClient) GenerateContent(ctx context.Context, prompt string, params map[string]interface{}) (*llm.ProviderResult, error) {
+	if c.modelName == "broken-model" {
+		return nil, &llm.LLMError{Message: "invalid request", ErrorCategory: llm.CategoryInvalidRequest}
+	}
+	if c.delay > 0 {
+		select {
+		case <-time.After(c.delay):
+			return &llm.ProviderResult{Content: "done"}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func newFailFastTestOrchestrator(apiService *failFastAPIService, failFast bool) *Orchestrator {
+	return &Orchestrator{
+		apiService:        apiService,
+		fileWriter:        &MockFileWriter{},
+		auditLogger:       NewMockAuditLogger(),
+		rateLimiter:       ratelimit.NewRateLimiter(0, 0),
+		config:            &config.CliConfig{ModelNames: []string{"broken-model", "slow-model"}, FailFast: failFast},
+		logger:            testutil.NewMockLogger(),
+		consoleWriter:     &MockConsoleWriter{},
+		metricsCollector:  metrics.NewNoopCollector(),
+		modelRateLimiters: make(map[string]*ratelimit.RateLimiter),
+		outputNamer:       newOutputNamer("", time.Now()),
+	}
+}
+
+func TestProcessModels_FailFastCancelsRemainingModels(t *testing.T) {
+	o := newFailFastTestOrchestrator(&failFastAPIService{}, true)
+
+	done := make(chan struct{})
+	var outputs map[string]string
+	var errs []error
+	go func() {
+		defer close(done)
+		outputs, errs = o.processModels(context.Background(), "prompt")
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the slow model was not cancelled after the first failure")
+	}
+
+	if len(outputs) != 0 || len(errs) != 2 {
+		t.Fatalf("got %d outputs and %d errors, want 0 and 2", len(outputs), len(errs))
+	}
+
+	err := failFastError(errs)
+	if !errors.Is(err, ErrFailFastAborted) {
+		t.Fatalf("failFastError() = %v, want ErrFailFastAborted", err)
+	}
+	if !strings.Contains(err.Error(), "after model broken-model failed") {
+		t.Errorf("error should name the model that triggered the abort, got %q", err.Error())
+	}
+	if failures := ModelFailures(err); len(failures) != 2 {
+		t.Errorf("ModelFailures() = %v, want both models", failures)
+	}
+}
+
+func TestProcessModels_WithoutFailFastRunsAllModels(t *testing.T) {
+	o := newFailFastTestOrchestrator(&failFastAPIService{delay: 20 * time.Millisecond}, false)
+
+	outputs, errs := o.processModels(context.Background(), "prompt")
+
+	if outputs["slow-model"] != "done" || len(errs) != 1 {
+		t.Fatalf("got outputs %v and errors %v, want slow-model to finish and broken-model to fail", outputs, errs)
+	}
+	if err := failFastError(errs); err != nil {
+		t.Errorf("failFastError() = %v, want nil without --fail-fast", err)
+	}
+}
@@ -10,9 +10,9 @@ import (
 
 	"github.com/misty-step/thinktank/internal/config"
 	"github.com/misty-step/thinktank/internal/fileutil"
+	"github.com/misty-step/thinktank/internal/models"
 	"github.com/misty-step/thinktank/internal/ratelimit"
 	"github.com/misty-step/thinktank/internal/thinktank/interfaces"
-	"github.com/misty-step/thinktank/internal/thinktank/modelproc"
 )
 
 // MockFileWriter that simulates failures based on file path
@@ -272,7 +272,7 @@ func (o *filesaveTestOrchestrator) Run(ctx context.Context, instructions string)
 		// Iterate over the model outputs and save each to a file
 		for modelName, content := range modelOutputs {
 			// Sanitize model name for use in filename
-			sanitizedModelName := modelproc.SanitizeFilename(modelName)
+			sanitizedModelName := models.SafeFilename(modelName)
 
 			// Construct output file path
 			outputFilePath := filepath.Join(o.config.OutputDir, sanitizedModelName+".md")
@@ -296,7 +296,7 @@ func (o *filesaveTestOrchestrator) Run(ctx context.Context, instructions string)
 			synthesisContent := "Synthesized content"
 
 			// Sanitize model name for use in filename
-			sanitizedModelName := modelproc.SanitizeFilename(o.config.SynthesisModel)
+			sanitizedModelName := models.SafeFilename(o.config.SynthesisModel)
 
 			// Construct output file path with -synthesis suffix
 			outputFilePath := filepath.Join(o.config.OutputDir, sanitizedModelName+"-synthesis.md")
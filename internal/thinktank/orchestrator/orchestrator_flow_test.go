@@ -491,3 +491,61 @@ func TestHandleOutputFlow_DualRoleModel(t *testing.T) {
 		})
 	}
 }
+
+// TestHandleOutputFlow_EmbedInstructions verifies that saved files carry the instructions
+// while the synthesis model still receives the raw outputs
+func TestHandleOutputFlow_EmbedInstructions(t *testing.T) {
+	modelOutputs := map[string]string{
+		"model1": "Output from model1",
+		"model2": "Output from model2",
+	}
+	instructions := "Review the error handling\n"
+
+	mockOutputWriter := &TestOutputWriter{
+		saveIndividualCount: len(modelOutputs),
+		saveSynthesisPath:   "/test/synth-synthesis.md",
+	}
+	mockSynthesisService := &MockSynthesisService{synthesizeContent: "Synthesized result"}
+
+	o := &Orchestrator{
+		logger:      testutil.NewMockLogger(),
+		auditLogger: NewMockAuditLogger(),
+		config: &config.CliConfig{
+			SynthesisModel:    "synth",
+			OutputDir:         "/test",
+			EmbedInstructions: true,
+		},
+		consoleWriter: logutil.NewConsoleWriterWithOptions(logutil.ConsoleWriterOptions{
+			IsTerminalFunc: func() bool { return false },
+		}),
+		outputWriter:     mockOutputWriter,
+		synthesisService: mockSynthesisService,
+	}
+
+	if _, err := o.handleOutputFlow(context.Background(), instructions, modelOutputs); err != nil {
+		t.Fatalf("handleOutputFlow() unexpected error: %v", err)
+	}
+
+	wantHeader := "## Instructions\n\n> Review the error handling\n\n---\n\n"
+	for model, content := range modelOutputs {
+		if got := mockOutputWriter.capturedIndividualOutputs[model]; got != wantHeader+content {
+			t.Errorf("saved output for %s = %q, want %q", model, got, wantHeader+content)
+		}
+		if got := mockSynthesisService.capturedOutputs[model]; got != content {
+			t.Errorf("synthesis input for %s = %q, want raw output %q", model, got, content)
+		}
+	}
+	if got := mockOutputWriter.capturedSynthesisContent; got != wantHeader+"Synthesized result" {
+		t.Errorf("saved synthesis = %q, want %q", got, wantHeader+"Synthesized result")
+	}
+}
+
+// TestEmbedInstructions verifies that every instruction line is quoted, so
+// headings and rules in the instructions stay inside the embedded block
+func TestEmbedInstructions(t *testing.T) {
+	got := embedInstructions("# Task\n\nReview it\n---\n", "Output")
+	want := "## Instructions\n\n> # Task\n>\n> Review it\n> ---\n\n---\n\nOutput"
+	if got != want {
+		t.Errorf("embedInstructions() = %q, want %q", got, want)
+	}
+}
@@ -3,10 +3,16 @@ package orchestrator
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/misty-step/thinktank/internal/config"
+	"github.com/misty-step/thinktank/internal/llm"
+	"github.com/misty-step/thinktank/internal/logutil"
+	"github.com/misty-step/thinktank/internal/metrics"
 	"github.com/misty-step/thinktank/internal/ratelimit"
+	"github.com/misty-step/thinktank/internal/testutil"
 	"github.com/misty-step/thinktank/internal/thinktank/interfaces"
 	"github.com/stretchr/testify/assert"
 )
@@ -242,3 +248,96 @@ func createTestOrchestrator(cfg *config.CliConfig, logger *MockLogger, tokenServ
 		TokenCountingService: tokenService,
 	})
 }
+
+// interactiveConsoleWriter reports an interactive terminal
+type interactiveConsoleWriter struct {
+	MockConsoleWriter
+}
+
+func (w *interactiveConsoleWriter) IsInteractive() bool { return true }
+
+// TestShouldStreamOutput tests that only single-model, non-synthesis runs on a terminal stream
+func TestShouldStreamOutput(t *testing.T) {
+	tests := []struct {
+		name           string
+		modelNames     []string
+		synthesisModel string
+		repeat         int
+		interactive    bool
+		expected       bool
+	}{
+		{name: "single model on a terminal", modelNames: []string{"model1"}, interactive: true, expected: true},
+		{name: "single model piped", modelNames: []string{"model1"}},
+		{name: "multiple models", modelNames: []string{"model1", "model2"}, interactive: true},
+		{name: "synthesis", modelNames: []string{"model1"}, synthesisModel: "model2", interactive: true},
+		{name: "repeated model", modelNames: []string{"model1"}, repeat: 2, interactive: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var consoleWriter logutil.ConsoleWriter = &MockConsoleWriter{}
+			if tt.interactive {
+				consoleWriter = &interactiveConsoleWriter{}
+			}
+			o := &Orchestrator{
+				config:        &config.CliConfig{ModelNames: tt.modelNames, SynthesisModel: tt.synthesisModel, Repeat: tt.repeat},
+				consoleWriter: consoleWriter,
+			}
+			assert.Equal(t, tt.expected, o.shouldStreamOutput())
+		})
+	}
+}
+
+// stallingAPIService returns clients that never answer for stallModel, blocking until cancelled
+type stallingAPIService struct {
+	MockAPIService
+	stallModel string
+}
+
+func (m *stallingAPIService) InitLLMClient(ctx context.Context, apiKey, modelName, apiEndpoint string) (llm.LLMClient, error) {
+	return &stallingLLMClient{stall: modelName == m.stallModel}, nil
+}
+
+type stallingLLMClient struct {
+	MockLLMClient
+	stall bool
+}
+
+func (c *stallingLLMClient) GenerateContent(ctx context.Context, prompt string, params map[string]interface{}) (*llm.ProviderResult, error) {
+	if c.stall {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return c.MockLLMClient.GenerateContent(ctx, prompt, params)
+}
+
+func TestProcessModelsModelTimeoutFailsOnlyThatModel(t *testing.T) {
+	o := &Orchestrator{
+		apiService:       &stallingAPIService{stallModel: "stalling-test-model"},
+		fileWriter:       &MockFileWriter{},
+		auditLogger:      NewMockAuditLogger(),
+		rateLimiter:      ratelimit.NewRateLimiter(0, 0),
+		logger:           testutil.NewMockLogger(),
+		consoleWriter:    &MockConsoleWriter{},
+		metricsCollector: metrics.NewNoopCollector(),
+		config: &config.CliConfig{
+			ModelNames:   []string{"stalling-test-model", "prompt-test-model"},
+			ModelTimeout: 20 * time.Millisecond,
+		},
+	}
+
+	outputs, errs := o.processModels(context.Background(), "prompt")
+
+	if _, ok := outputs["prompt-test-model"]; !ok || len(outputs) != 1 {
+		t.Errorf("outputs = %v, want only prompt-test-model", outputs)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("errors = %v, want one for the stalled model", errs)
+	}
+	if !llm.IsCategory(errs[0], llm.CategoryCancelled) {
+		t.Errorf("error %v should be categorized as cancelled", errs[0])
+	}
+	if !strings.Contains(errs[0].Error(), "stalling-test-model timed out after 20ms") {
+		t.Errorf("error %q should name the model and the timeout", errs[0])
+	}
+}
@@ -0,0 +1,58 @@
+package orchestrator
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// meterName identifies the instruments the orchestrator records to
+const meterName = "github.com/misty-step/thinktank/internal/thinktank/orchestrator"
+
+// attrTokenType distinguishes prompt from completion tokens on the token counter
+const attrTokenType = attribute.Key("thinktank.token_type")
+
+// outcomeMetrics counts model outcomes and provider-reported tokens through the
+// global OpenTelemetry meter, which is a no-op until telemetry.SetupMetrics
+// registers a provider
+type outcomeMetrics struct {
+	models metric.Int64Counter // Model results, by model, provider, and outcome
+	tokens metric.Int64Counter // Provider-reported tokens, by model, provider, and token type
+}
+
+// newOutcomeMetrics creates the orchestrator's counters. An instrument that can't
+// be created is reported to the OpenTelemetry error handler and records nothing.
+func newOutcomeMetrics() *outcomeMetrics {
+	meter := otel.Meter(meterName)
+	models, err := meter.Int64Counter("thinktank.models",
+		metric.WithDescription("Model results by outcome: success, failed, or rate_limited"),
+		metric.WithUnit("{model}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	tokens, err := meter.Int64Counter("thinktank.tokens",
+		metric.WithDescription("Tokens reported by providers for successful models"),
+		metric.WithUnit("{token}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	return &outcomeMetrics{models: models, tokens: tokens}
+}
+
+// record counts one model result and, when the provider reported usage, its tokens.
+// A nil outcomeMetrics records nothing.
+func (m *outcomeMetrics) record(ctx context.Context, result modelResult, provider string) {
+	if m == nil {
+		return
+	}
+	model := attrModel.String(result.modelName)
+	providerAttr := attrProvider.String(provider)
+	m.models.Add(ctx, 1, metric.WithAttributes(model, providerAttr, attrOutcome.String(modelOutcome(result.err))))
+	if result.usage == nil {
+		return
+	}
+	m.tokens.Add(ctx, int64(result.usage.PromptTokens), metric.WithAttributes(model, providerAttr, attrTokenType.String("prompt")))
+	m.tokens.Add(ctx, int64(result.usage.CompletionTokens), metric.WithAttributes(model, providerAttr, attrTokenType.String("completion")))
+}
@@ -0,0 +1,85 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/misty-step/thinktank/internal/llm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// collectCounters registers a global meter provider for the test and returns a
+// function that reads each counter's data points, keyed by counter name
+func collectCounters(t *testing.T) func() map[string][]metricdata.DataPoint[int64] {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	previous := otel.GetMeterProvider()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	t.Cleanup(func() { otel.SetMeterProvider(previous) })
+
+	return func() map[string][]metricdata.DataPoint[int64] {
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		counters := make(map[string][]metricdata.DataPoint[int64])
+		for _, scope := range rm.ScopeMetrics {
+			for _, m := range scope.Metrics {
+				if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+					counters[m.Name] = sum.DataPoints
+				}
+			}
+		}
+		return counters
+	}
+}
+
+// pointValue returns the value of the data point whose attributes include every kv
+func pointValue(points []metricdata.DataPoint[int64], kvs ...attribute.KeyValue) int64 {
+	for _, point := range points {
+		matches := true
+		for _, kv := range kvs {
+			if value, ok := point.Attributes.Value(kv.Key); !ok || value != kv.Value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return point.Value
+		}
+	}
+	return 0
+}
+
+func TestOutcomeMetricsRecord(t *testing.T) {
+	collect := collectCounters(t)
+	m := newOutcomeMetrics()
+	ctx := context.Background()
+
+	m.record(ctx, modelResult{modelName: "gpt-5.2", usage: &llm.TokenUsage{PromptTokens: 100, CompletionTokens: 20}}, "openrouter")
+	m.record(ctx, modelResult{modelName: "gpt-5.2", usage: &llm.TokenUsage{PromptTokens: 50, CompletionTokens: 5}}, "openrouter")
+	m.record(ctx, modelResult{modelName: "o4-mini", err: errors.New("boom")}, "openrouter")
+	m.record(ctx, modelResult{modelName: "o4-mini", err: llm.Wrap(errors.New("429"), "openrouter", "too many requests", llm.CategoryRateLimit)}, "openrouter")
+
+	counters := collect()
+	modelsCounter := counters["thinktank.models"]
+	assert.Equal(t, int64(2), pointValue(modelsCounter, attrModel.String("gpt-5.2"), attrOutcome.String("success")))
+	assert.Equal(t, int64(1), pointValue(modelsCounter, attrModel.String("o4-mini"), attrOutcome.String("failed")))
+	assert.Equal(t, int64(1), pointValue(modelsCounter, attrModel.String("o4-mini"), attrOutcome.String("rate_limited")))
+
+	tokensCounter := counters["thinktank.tokens"]
+	assert.Equal(t, int64(150), pointValue(tokensCounter, attrTokenType.String("prompt")))
+	assert.Equal(t, int64(25), pointValue(tokensCounter, attrTokenType.String("completion")))
+	assert.Equal(t, int64(0), pointValue(tokensCounter, attrModel.String("o4-mini")))
+}
+
+func TestOutcomeMetricsNilRecordsNothing(t *testing.T) {
+	var m *outcomeMetrics
+	assert.NotPanics(t, func() {
+		m.record(context.Background(), modelResult{modelName: "gpt-5.2"}, "openrouter")
+	})
+}
@@ -0,0 +1,54 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// plannedOutputPaths lists every file the run would write: each model's output
+// (or the combined output in its place), the synthesis output, the summary file,
+// and the manifest
+func (o *Orchestrator) plannedOutputPaths() []string {
+	units := expandRepeatUnits(o.config.ModelNames, o.config.Repeat)
+	paths := make([]string, 0, len(units)+4)
+	if o.config.CombinedOutput == "" {
+		for _, unit := range units {
+			paths = append(paths, o.outputNamer.unitPath(o.config.OutputDir, unit, ""))
+		}
+	}
+	if o.config.SynthesisModel != "" {
+		paths = append(paths, o.outputNamer.path(o.config.OutputDir, o.config.SynthesisModel, "-synthesis"))
+	}
+	if o.config.CombinedOutput != "" {
+		paths = append(paths, combinedOutputPath(o.config.OutputDir, o.config.CombinedOutput))
+	}
+	if o.config.SummaryFile != "" {
+		paths = append(paths, o.config.SummaryFile)
+	}
+	return append(paths, filepath.Join(o.config.OutputDir, ManifestFileName))
+}
+
+// checkOutputConflicts fails, before any model runs, when NoOverwrite is set and
+// files the run would write already exist, naming every one of them
+func (o *Orchestrator) checkOutputConflicts() error {
+	if !o.config.NoOverwrite {
+		return nil
+	}
+
+	var conflicts []string
+	for _, path := range o.plannedOutputPaths() {
+		if _, err := os.Lstat(path); err == nil {
+			conflicts = append(conflicts, path)
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	sort.Strings(conflicts)
+	return WrapOrchestratorError(ErrOutputFilesExist, fmt.Sprintf(
+		"output directory %s already has files this run would overwrite (pass --force to overwrite them): %s",
+		o.config.OutputDir, strings.Join(conflicts, ", ")))
+}
@@ -0,0 +1,75 @@
+package orchestrator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckOutputConflicts(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"model1.md", ManifestFileName, "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("earlier"), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+
+	o, _, _, _ := newStatusTestOrchestrator()
+	o.outputNamer = newOutputNamer("", time.Now())
+	o.config.OutputDir = dir
+	o.config.ModelNames = []string{"model1", "model2"}
+
+	// Without NoOverwrite existing files are replaced as before
+	if err := o.checkOutputConflicts(); err != nil {
+		t.Fatalf("checkOutputConflicts() without NoOverwrite = %v, want nil", err)
+	}
+
+	o.config.NoOverwrite = true
+	err := o.checkOutputConflicts()
+	if !errors.Is(err, ErrOutputFilesExist) {
+		t.Fatalf("checkOutputConflicts() = %v, want ErrOutputFilesExist", err)
+	}
+	for _, name := range []string{"model1.md", ManifestFileName} {
+		if !strings.Contains(err.Error(), filepath.Join(dir, name)) {
+			t.Errorf("error %q does not name %s", err, name)
+		}
+	}
+	if strings.Contains(err.Error(), "model2.md") || strings.Contains(err.Error(), "notes.txt") {
+		t.Errorf("error %q names files the run would not overwrite", err)
+	}
+
+	// Files the run doesn't write are no conflict
+	o.config.OutputDir = t.TempDir()
+	if err := os.WriteFile(filepath.Join(o.config.OutputDir, "notes.txt"), nil, 0644); err != nil {
+		t.Fatalf("Failed to create notes.txt: %v", err)
+	}
+	if err := o.checkOutputConflicts(); err != nil {
+		t.Errorf("checkOutputConflicts() with unrelated files = %v, want nil", err)
+	}
+}
+
+func TestPlannedOutputPaths(t *testing.T) {
+	o, _, _, _ := newStatusTestOrchestrator()
+	o.outputNamer = newOutputNamer("", time.Now())
+	o.config.OutputDir = "/out"
+	o.config.ModelNames = []string{"model1"}
+	o.config.SynthesisModel = "model2"
+	o.config.CombinedOutput = "all.md"
+	o.config.SummaryFile = "summary.md"
+
+	// The combined file replaces the per-model files
+	want := []string{
+		filepath.Join("/out", "model2-synthesis.md"),
+		filepath.Join("/out", "all.md"),
+		"summary.md",
+		filepath.Join("/out", ManifestFileName),
+	}
+	got := o.plannedOutputPaths()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("plannedOutputPaths() = %v, want %v", got, want)
+	}
+}
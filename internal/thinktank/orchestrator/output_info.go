@@ -8,6 +8,12 @@ type OutputInfo struct {
 
 	// Paths to individual model output files (if saving individual outputs)
 	IndividualFilePaths map[string]string
+
+	// Path to the single file holding every model's output (if --combined-output was used)
+	CombinedFilePath string
+
+	// Models whose output is in the combined file, in the order they appear
+	CombinedModels []string
 }
 
 // NewOutputInfo creates a new OutputInfo instance
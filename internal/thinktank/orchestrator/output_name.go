@@ -0,0 +1,125 @@
+package orchestrator
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/misty-step/thinktank/internal/models"
+)
+
+// DefaultOutputNameTemplate names output files <model>.md
+const DefaultOutputNameTemplate = "{model}.{ext}"
+
+// outputNameTimestampFormat is how {timestamp} renders, e.g. 20250619-143022
+const outputNameTimestampFormat = "20060102-150405"
+
+// outputNamePlaceholder matches a {name} placeholder in an output name template
+var outputNamePlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// outputNamePlaceholders are the placeholders an output name template may use
+var outputNamePlaceholders = map[string]bool{
+	"{model}":     true, // Model name with path separators and other unsafe characters replaced (models.SafeFilename)
+	"{provider}":  true, // Provider of the model, e.g. openrouter
+	"{timestamp}": true, // Start of the run, as 20060102-150405
+	"{ext}":       true, // File extension without the dot ("md")
+}
+
+// ValidateOutputNameTemplate checks that a --output-name-template value names
+// a file inside the output directory and gives every model its own file
+func ValidateOutputNameTemplate(template string) error {
+	if strings.TrimSpace(template) == "" {
+		return fmt.Errorf("output name template must not be empty")
+	}
+	for _, placeholder := range outputNamePlaceholder.FindAllString(template, -1) {
+		if !outputNamePlaceholders[placeholder] {
+			return fmt.Errorf("unknown placeholder %s in output name template %q (use {model}, {provider}, {timestamp}, or {ext})", placeholder, template)
+		}
+	}
+	if strings.ContainsAny(outputNamePlaceholder.ReplaceAllString(template, ""), "{}") {
+		return fmt.Errorf("unbalanced braces in output name template %q", template)
+	}
+	if !strings.Contains(template, "{model}") {
+		return fmt.Errorf("output name template %q must include {model} so each model gets its own file", template)
+	}
+	if filepath.IsAbs(template) || strings.HasPrefix(template, "/") {
+		return fmt.Errorf("output name template %q must be relative to the output directory", template)
+	}
+	for _, segment := range strings.FieldsFunc(template, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if segment == ".." {
+			return fmt.Errorf("output name template %q must not leave the output directory", template)
+		}
+	}
+	return nil
+}
+
+// outputNamer builds output file paths from a template. Model names are sanitized,
+// so an OpenRouter ID such as openai/gpt-5.2 never creates a subdirectory; only
+// a / written in the template itself does. An empty template means <model>.md.
+type outputNamer struct {
+	template  string
+	timestamp string
+	filenames map[string]string  // Sanitized names for the run's models (nil = models.SafeFilename)
+	units     map[string]runUnit // The run's units by name; other names are unrepeated models
+}
+
+func newOutputNamer(template string, now time.Time) outputNamer {
+	return outputNamer{template: template, timestamp: now.Format(outputNameTimestampFormat)}
+}
+
+// forUnits returns a copy of n for a run of the given units. It sanitizes their
+// models' names with models.SafeFilenames, so models whose names sanitize alike
+// still get their own files, and numbers the files of repeated units.
+func (n outputNamer) forUnits(units []runUnit) outputNamer {
+	modelNames := make([]string, 0, len(units))
+	n.units = make(map[string]runUnit, len(units))
+	for _, unit := range units {
+		modelNames = append(modelNames, unit.model)
+		n.units[unit.name()] = unit
+	}
+	n.filenames = models.SafeFilenames(modelNames)
+	return n
+}
+
+// filename returns the sanitized form of modelName
+func (n outputNamer) filename(modelName string) string {
+	if filename, ok := n.filenames[modelName]; ok {
+		return filename
+	}
+	return models.SafeFilename(modelName)
+}
+
+// path returns the output file path for the run unit named name; see unitPath
+func (n outputNamer) path(outputDir, name, nameSuffix string) string {
+	unit, ok := n.units[name]
+	if !ok {
+		unit = runUnit{model: name}
+	}
+	return n.unitPath(outputDir, unit, nameSuffix)
+}
+
+// unitPath returns the output file path for a model, or for one --repeat generation
+// of it, which gets its number after the model name (<model>.2). nameSuffix is
+// appended to the sanitized model name, as "-synthesis" is for the synthesis output.
+func (n outputNamer) unitPath(outputDir string, unit runUnit, nameSuffix string) string {
+	if unit.repeat > 0 {
+		nameSuffix = fmt.Sprintf(".%d%s", unit.repeat, nameSuffix)
+	}
+	provider := "unknown"
+	if info, err := models.GetModelInfo(unit.model); err == nil && info.Provider != "" {
+		provider = info.Provider
+	}
+	replacer := strings.NewReplacer(
+		"{model}", n.filename(unit.model)+nameSuffix,
+		"{provider}", models.SafeFilename(provider),
+		"{timestamp}", n.timestamp,
+		"{ext}", "md",
+	)
+	template := n.template
+	if template == "" {
+		template = DefaultOutputNameTemplate
+	}
+	return filepath.Join(outputDir, filepath.FromSlash(replacer.Replace(template)))
+}
@@ -0,0 +1,124 @@
+package orchestrator
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/misty-step/thinktank/internal/auditlog"
+	"github.com/misty-step/thinktank/internal/testutil"
+)
+
+func TestValidateOutputNameTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		template string
+		wantErr  string
+	}{
+		{template: "{model}.{ext}"},
+		{template: "{timestamp}-{model}.txt"},
+		{template: "{provider}/{model}.md"},
+		{template: "", wantErr: "must not be empty"},
+		{template: "{timestamp}.txt", wantErr: "must include {model}"},
+		{template: "{model}-{date}.md", wantErr: "unknown placeholder {date}"},
+		{template: "{model.md", wantErr: "unbalanced braces"},
+		{template: "/tmp/{model}.md", wantErr: "must be relative"},
+		{template: "../{model}.md", wantErr: "must not leave the output directory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateOutputNameTemplate(tt.template)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateOutputNameTemplate(%q) = %v, want nil", tt.template, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateOutputNameTemplate(%q) = %v, want error containing %q", tt.template, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOutputNamerPath(t *testing.T) {
+	t.Parallel()
+
+	runStart := time.Date(2025, 6, 19, 14, 30, 22, 0, time.UTC)
+	tests := []struct {
+		name       string
+		template   string
+		modelName  string
+		nameSuffix string
+		want       string
+	}{
+		{name: "default template", modelName: "gpt-5.2", want: "gpt-5.2.md"},
+		{name: "timestamp and fixed extension", template: "{timestamp}-{model}.txt", modelName: "gpt-5.2", want: "20250619-143022-gpt-5.2.txt"},
+		{name: "slash in model name is replaced", template: "{model}.{ext}", modelName: "openai/gpt-5.2", want: "openai-gpt-5.2.md"},
+		{name: "slash in template creates a subdirectory", template: "{provider}/{model}.{ext}", modelName: "gemini-3-flash", want: filepath.Join("openrouter", "gemini-3-flash.md")},
+		{name: "unknown model provider", template: "{provider}-{model}.{ext}", modelName: "my-model", want: "unknown-my-model.md"},
+		{name: "number past the repeat count", modelName: "gpt-5.2#4", want: "gpt-5.2#4.md"},
+		{name: "repeat number", modelName: "gpt-5.2#2", want: "gpt-5.2.2.md"},
+		{name: "model ID containing the repeat separator", modelName: "vendor/model#3", want: "vendor-model#3.md"},
+		{name: "synthesis suffix", template: "{timestamp}-{model}.txt", modelName: "gpt-5.2", nameSuffix: "-synthesis", want: "20250619-143022-gpt-5.2-synthesis.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			namer := newOutputNamer(tt.template, runStart).forUnits(expandRepeatUnits([]string{"gpt-5.2"}, 3))
+			got := namer.path("out", tt.modelName, tt.nameSuffix)
+			if want := filepath.Join("out", tt.want); got != want {
+				t.Errorf("path() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestOutputWriterWithNameTemplate(t *testing.T) {
+	fileWriter := newMockFileWriter()
+	writer := NewOutputWriterWithNameTemplate(fileWriter, auditlog.NewNoOpAuditLogger(), testutil.NewMockLogger(), "{model}.txt")
+
+	_, paths, err := writer.SaveIndividualOutputs(context.Background(), map[string]string{"openai/gpt-5.2": "content"}, "/out")
+	if err != nil {
+		t.Fatalf("SaveIndividualOutputs() error = %v", err)
+	}
+	want := filepath.Join("/out", "openai-gpt-5.2.txt")
+	if paths["openai/gpt-5.2"] != want || fileWriter.savedFiles[want] != "content" {
+		t.Errorf("paths = %v, saved = %v; want content at %s", paths, fileWriter.savedFiles, want)
+	}
+
+	synthesisPath, err := writer.SaveSynthesisOutput(context.Background(), "combined", "gpt-5.2", "/out")
+	if err != nil || synthesisPath != filepath.Join("/out", "gpt-5.2-synthesis.txt") {
+		t.Errorf("SaveSynthesisOutput() = %q, %v", synthesisPath, err)
+	}
+}
+
+func TestOutputNamerForUnitsDisambiguatesCollisions(t *testing.T) {
+	t.Parallel()
+
+	namer := newOutputNamer("", time.Now()).forUnits([]runUnit{
+		{model: "openai/gpt-5.2"}, {model: "openai-gpt-5.2"}, {model: "openai:gpt-5.2", repeat: 1}, {model: "openai:gpt-5.2", repeat: 2}, {model: "gemini-3-flash"},
+	})
+
+	if got, want := namer.path("out", "gemini-3-flash", ""), filepath.Join("out", "gemini-3-flash.md"); got != want {
+		t.Errorf("path() = %q, want %q for a name that doesn't collide", got, want)
+	}
+	if got, want := namer.path("out", "openai-gpt-5.2", ""), filepath.Join("out", "openai-gpt-5.2.md"); got != want {
+		t.Errorf("path() = %q, want %q for the name that needed no sanitizing", got, want)
+	}
+
+	seen := make(map[string]string)
+	for _, modelName := range []string{"openai/gpt-5.2", "openai-gpt-5.2", "openai:gpt-5.2#1", "openai:gpt-5.2#2", "gemini-3-flash"} {
+		path := namer.path("out", modelName, "")
+		if other, ok := seen[path]; ok {
+			t.Errorf("%q and %q both write %s", other, modelName, path)
+		}
+		seen[path] = modelName
+	}
+}
@@ -7,12 +7,11 @@ package orchestrator
 import (
 	"context"
 	"fmt"
-	"path/filepath"
+	"time"
 
 	"github.com/misty-step/thinktank/internal/auditlog"
 	"github.com/misty-step/thinktank/internal/logutil"
 	"github.com/misty-step/thinktank/internal/thinktank/interfaces"
-	"github.com/misty-step/thinktank/internal/thinktank/modelproc"
 )
 
 // OutputWriter handles writing model outputs to files
@@ -31,6 +30,7 @@ type DefaultOutputWriter struct {
 	fileWriter  interfaces.FileWriter
 	auditLogger auditlog.AuditLogger
 	logger      logutil.LoggerInterface
+	namer       outputNamer
 }
 
 // LegacyOutputWriter is used for backward compatibility with tests
@@ -62,11 +62,33 @@ func NewOutputWriter(
 	fileWriter interfaces.FileWriter,
 	auditLogger auditlog.AuditLogger,
 	logger logutil.LoggerInterface,
+) OutputWriter {
+	return NewOutputWriterWithNameTemplate(fileWriter, auditLogger, logger, DefaultOutputNameTemplate)
+}
+
+// NewOutputWriterWithNameTemplate creates an OutputWriter that names files using
+// nameTemplate (see ValidateOutputNameTemplate). An empty template means <model>.md.
+func NewOutputWriterWithNameTemplate(
+	fileWriter interfaces.FileWriter,
+	auditLogger auditlog.AuditLogger,
+	logger logutil.LoggerInterface,
+	nameTemplate string,
+) OutputWriter {
+	return newOutputWriter(fileWriter, auditLogger, logger, newOutputNamer(nameTemplate, time.Now()))
+}
+
+// newOutputWriter creates an OutputWriter that names files with namer
+func newOutputWriter(
+	fileWriter interfaces.FileWriter,
+	auditLogger auditlog.AuditLogger,
+	logger logutil.LoggerInterface,
+	namer outputNamer,
 ) OutputWriter {
 	return &DefaultOutputWriter{
 		fileWriter:  fileWriter,
 		auditLogger: auditLogger,
 		logger:      logger,
+		namer:       namer,
 	}
 }
 
@@ -92,13 +114,20 @@ func (w *DefaultOutputWriter) SaveIndividualOutputs(
 	contextLogger.InfoContext(ctx, "Saving individual model outputs")
 	contextLogger.DebugContext(ctx, "Preparing to save %d model outputs", totalCount)
 
+	// Without the run's units, disambiguate among the models being saved
+	namer := w.namer
+	if namer.filenames == nil {
+		units := make([]runUnit, 0, len(modelOutputs))
+		for modelName := range modelOutputs {
+			units = append(units, runUnit{model: modelName})
+		}
+		namer = namer.forUnits(units)
+	}
+
 	// Iterate over the model outputs and save each to a file
 	for modelName, content := range modelOutputs {
-		// Sanitize model name for use in filename
-		sanitizedModelName := modelproc.SanitizeFilename(modelName)
-
-		// Construct output file path
-		outputFilePath := filepath.Join(outputDir, sanitizedModelName+".md")
+		// Construct output file path from the sanitized model name
+		outputFilePath := namer.path(outputDir, modelName, "")
 
 		// Save the output to file
 		contextLogger.DebugContext(ctx, "Saving output for model %s to %s", modelName, outputFilePath)
@@ -141,11 +170,8 @@ func (w *DefaultOutputWriter) SaveSynthesisOutput(
 	// Get logger with context
 	contextLogger := w.logger.WithContext(ctx)
 
-	// Sanitize model name for use in filename
-	sanitizedModelName := modelproc.SanitizeFilename(modelName)
-
 	// Construct output file path with -synthesis suffix
-	outputFilePath := filepath.Join(outputDir, sanitizedModelName+"-synthesis.md")
+	outputFilePath := w.namer.path(outputDir, modelName, "-synthesis")
 
 	// Save the synthesis output to file
 	contextLogger.DebugContext(ctx, "Saving synthesis output to %s", outputFilePath)
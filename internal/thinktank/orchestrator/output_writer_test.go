@@ -8,8 +8,8 @@ import (
 	"testing"
 
 	"github.com/misty-step/thinktank/internal/auditlog"
+	"github.com/misty-step/thinktank/internal/models"
 	"github.com/misty-step/thinktank/internal/testutil"
-	"github.com/misty-step/thinktank/internal/thinktank/modelproc"
 )
 
 // mockFileWriter implements the interfaces.FileWriter interface for testing
@@ -121,7 +121,7 @@ func TestDefaultOutputWriter_SaveIndividualOutputs(t *testing.T) {
 			// Verify each file was saved correctly (except the failing one)
 			for modelName, content := range tt.modelOutputs {
 				// Sanitize the model name as expected
-				sanitizedModelName := modelproc.SanitizeFilename(modelName)
+				sanitizedModelName := models.SafeFilename(modelName)
 				expectedPath := filepath.Join(tt.outputDir, sanitizedModelName+".md")
 
 				// Skip checking the file that was set up to fail
@@ -219,7 +219,7 @@ func TestDefaultOutputWriter_SaveSynthesisOutput(t *testing.T) {
 			// Verify the correct file path and content if no error is expected
 			if !tt.expectedError {
 				// Sanitize the model name as expected
-				sanitizedModelName := modelproc.SanitizeFilename(tt.modelName)
+				sanitizedModelName := models.SafeFilename(tt.modelName)
 				expectedPath := filepath.Join(tt.outputDir, sanitizedModelName+"-synthesis.md")
 
 				// Check if the file was saved with the correct content
@@ -239,68 +239,6 @@ func TestDefaultOutputWriter_SaveSynthesisOutput(t *testing.T) {
 	}
 }
 
-// TestSanitizeFilename tests the SanitizeFilename function
-func TestSanitizeFilename(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected string
-	}{
-		{
-			input:    "normal-model-name",
-			expected: "normal-model-name",
-		},
-		{
-			input:    "model/with/slashes",
-			expected: "model-with-slashes",
-		},
-		{
-			input:    "model:with:colons",
-			expected: "model-with-colons",
-		},
-		{
-			input:    "model*with*stars",
-			expected: "model-with-stars",
-		},
-		{
-			input:    "model?with?questions",
-			expected: "model-with-questions",
-		},
-		{
-			input:    "model\"with\"quotes",
-			expected: "model-with-quotes",
-		},
-		{
-			input:    "model<with>brackets",
-			expected: "model-with-brackets",
-		},
-		{
-			input:    "model|with|pipes",
-			expected: "model-with-pipes",
-		},
-		{
-			input:    "model with spaces",
-			expected: "model_with_spaces",
-		},
-		{
-			input:    "model'with'apostrophes",
-			expected: "model-with-apostrophes",
-		},
-		{
-			input:    "model.with.dots",
-			expected: "model.with.dots", // dots are allowed in filenames
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			result := modelproc.SanitizeFilename(tt.input)
-			if result != tt.expected {
-				t.Errorf("SanitizeFilename(%q) = %q, expected %q", tt.input, result, tt.expected)
-			}
-		})
-	}
-}
-
 // TestLegacyOutputWriterAdapter tests the LegacyOutputWriterAdapter methods
 func TestLegacyOutputWriterAdapter(t *testing.T) {
 	// Create a mock OutputWriter using the test implementation
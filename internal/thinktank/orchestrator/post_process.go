@@ -0,0 +1,113 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// postProcessFile runs the --post-process command on an output file right after
+// it is written: a model's file as soon as that model finishes, and the combined
+// and synthesis files once they are saved. A failing command is logged as a
+// warning and never fails the run.
+func (o *Orchestrator) postProcessFile(ctx context.Context, path string) {
+	if o.config.PostProcess == "" {
+		return
+	}
+	if ctx.Err() != nil {
+		o.logger.WarnContext(ctx, "Post-processing cancelled before %s: %v", path, ctx.Err())
+		return
+	}
+
+	err := runPostProcess(ctx, o.config.PostProcess, path)
+	o.postProcessMutex.Lock()
+	o.postProcessRuns++
+	if err != nil {
+		o.postProcessFailures++
+	}
+	o.postProcessMutex.Unlock()
+
+	inputs := map[string]interface{}{"command": o.config.PostProcess, "path": path}
+	if err != nil {
+		o.logger.WarnContext(ctx, "Post-processing %s failed: %v", path, err)
+		o.logAuditEvent(ctx, "PostProcess", "Failure", inputs, nil, err)
+		return
+	}
+	o.logger.DebugContext(ctx, "Post-processed %s", path)
+	o.logAuditEvent(ctx, "PostProcess", "Success", inputs, nil, nil)
+}
+
+// postProcessOnSave returns a handler for the model processor that post-processes
+// unit's output file as soon as it is saved, or nil when that file is not final:
+// --embed-instructions rewrites it once every model finishes, and post-processing
+// runs after that write instead. Files handled here are recorded so the
+// individual output flow does not rewrite them and undo the command's changes.
+func (o *Orchestrator) postProcessOnSave(unit string) func(ctx context.Context, path string) {
+	if o.config.PostProcess == "" || o.config.EmbedInstructions {
+		return nil
+	}
+	return func(ctx context.Context, path string) {
+		o.postProcessMutex.Lock()
+		if o.postProcessedOutputs == nil {
+			o.postProcessedOutputs = make(map[string]string)
+		}
+		o.postProcessedOutputs[unit] = path
+		o.postProcessMutex.Unlock()
+		o.postProcessFile(ctx, path)
+	}
+}
+
+// postProcessedOutput returns the output file of unit that was post-processed when
+// the model processor saved it, if any
+func (o *Orchestrator) postProcessedOutput(unit string) (string, bool) {
+	o.postProcessMutex.Lock()
+	defer o.postProcessMutex.Unlock()
+	path, ok := o.postProcessedOutputs[unit]
+	return path, ok
+}
+
+// reportPostProcessFailures tells the user how many output files the
+// --post-process command failed on, if any
+func (o *Orchestrator) reportPostProcessFailures() {
+	o.postProcessMutex.Lock()
+	runs, failures := o.postProcessRuns, o.postProcessFailures
+	o.postProcessMutex.Unlock()
+	if failures > 0 {
+		o.consoleWriter.StatusMessage(fmt.Sprintf("Post-processing failed for %d of %d output files (see log)", failures, runs))
+	}
+}
+
+// runPostProcess runs command through the shell with path as its last argument and
+// the file's content on stdin, killing it if ctx is cancelled. A nonzero exit is
+// returned as an error carrying the command's stderr.
+func runPostProcess(ctx context.Context, command, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command+` "`+path+`"`)
+	} else {
+		// The path is passed as $1 so the shell never interprets it
+		cmd = exec.CommandContext(ctx, "sh", "-c", command+` "$1"`, "sh", path)
+	}
+	cmd.Stdin = bytes.NewReader(content)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if msg := strings.TrimSpace(stderr.String()); msg != "" && errors.As(err, &exitErr) {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
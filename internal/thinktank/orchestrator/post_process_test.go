@@ -0,0 +1,171 @@
+package orchestrator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/misty-step/thinktank/internal/config"
+	"github.com/misty-step/thinktank/internal/metrics"
+	"github.com/misty-step/thinktank/internal/ratelimit"
+	"github.com/misty-step/thinktank/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeOutputs writes each model's content to <dir>/<model>.md, returning the paths by model
+func writeOutputs(t *testing.T, dir string, outputs map[string]string) map[string]string {
+	t.Helper()
+	paths := make(map[string]string)
+	for model, content := range outputs {
+		path := filepath.Join(dir, model+".md")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		paths[model] = path
+	}
+	return paths
+}
+
+// diskFileWriter saves files to disk, so post-process commands can read and rewrite them
+type diskFileWriter struct{}
+
+func (diskFileWriter) SaveToFile(ctx context.Context, content, outputFile string) error {
+	return os.WriteFile(outputFile, []byte(content), 0o644)
+}
+
+// upperFormatter writes a script that reads a file's content on stdin and writes it
+// back upper-cased to the path argument, returning the post-process command
+func upperFormatter(t *testing.T) string {
+	t.Helper()
+	formatter := filepath.Join(t.TempDir(), "upper.sh")
+	require.NoError(t, os.WriteFile(formatter, []byte("tr a-z A-Z > \"$1\"\n"), 0o644))
+	return "sh " + formatter
+}
+
+func TestPostProcessFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("post-process commands are run with sh")
+	}
+	dir := t.TempDir()
+	paths := writeOutputs(t, dir, map[string]string{"gpt-5.2": "first", "o4-mini": "second"})
+
+	o, auditLogger, _, logger := newStatusTestOrchestrator()
+	o.config.PostProcess = upperFormatter(t)
+	for _, path := range paths {
+		o.postProcessFile(context.Background(), path)
+	}
+
+	for model, want := range map[string]string{"gpt-5.2": "FIRST", "o4-mini": "SECOND"} {
+		got, err := os.ReadFile(paths[model])
+		require.NoError(t, err)
+		assert.Equal(t, want, string(got))
+	}
+	assert.Empty(t, logger.GetWarnMessages())
+	require.Len(t, auditLogger.LogCalls, 2)
+	assert.Equal(t, "PostProcess", auditLogger.LogCalls[0].Operation)
+	assert.Equal(t, "Success", auditLogger.LogCalls[0].Status)
+}
+
+func TestPostProcessFileFailureIsWarning(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("post-process commands are run with sh")
+	}
+	dir := t.TempDir()
+	paths := writeOutputs(t, dir, map[string]string{"gpt-5.2": "content"})
+
+	o, auditLogger, consoleWriter, logger := newStatusTestOrchestrator()
+	o.config.PostProcess = `sh -c "echo formatter broke >&2; exit 3"`
+	o.postProcessFile(context.Background(), paths["gpt-5.2"])
+	o.reportPostProcessFailures()
+
+	warnings := logger.GetWarnMessages()
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "exit status 3")
+	assert.Contains(t, warnings[0], "formatter broke")
+	require.Len(t, auditLogger.LogCalls, 1)
+	assert.Equal(t, "Failure", auditLogger.LogCalls[0].Status)
+
+	assert.Equal(t, []string{"Post-processing failed for 1 of 1 output files (see log)"}, consoleWriter.messages)
+
+	got, err := os.ReadFile(paths["gpt-5.2"])
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(got))
+}
+
+func TestPostProcessFileHonorsCancellation(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("post-process commands are run with sh")
+	}
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "ran")
+	paths := writeOutputs(t, dir, map[string]string{"gpt-5.2": "content"})
+
+	o, _, _, logger := newStatusTestOrchestrator()
+	o.config.PostProcess = "touch " + marker
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	o.postProcessFile(ctx, paths["gpt-5.2"])
+
+	assert.NoFileExists(t, marker)
+	require.Len(t, logger.GetWarnMessages(), 1)
+	assert.Contains(t, logger.GetWarnMessages()[0], "cancelled")
+}
+
+func TestPostProcessFileDisabled(t *testing.T) {
+	o, auditLogger, _, _ := newStatusTestOrchestrator()
+	o.postProcessFile(context.Background(), "/nonexistent/gpt-5.2.md")
+	assert.Empty(t, auditLogger.LogCalls)
+	assert.Nil(t, o.postProcessOnSave("gpt-5.2"))
+}
+
+func TestProcessModelsPostProcessesEachOutputAsItIsSaved(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("post-process commands are run with sh")
+	}
+	outputDir := t.TempDir()
+	auditLogger := NewMockAuditLogger()
+	logger := testutil.NewMockLogger()
+	o := &Orchestrator{
+		apiService:       &MockAPIService{},
+		fileWriter:       diskFileWriter{},
+		auditLogger:      auditLogger,
+		rateLimiter:      ratelimit.NewRateLimiter(1, 0),
+		logger:           logger,
+		consoleWriter:    &MockConsoleWriter{},
+		metricsCollector: metrics.NewNoopCollector(),
+		config: &config.CliConfig{
+			ModelNames:  []string{"prompt-test-model"},
+			OutputDir:   outputDir,
+			PostProcess: upperFormatter(t),
+		},
+	}
+	o.outputWriter = newOutputWriter(diskFileWriter{}, auditLogger, logger, o.outputNamer)
+	path := filepath.Join(outputDir, "prompt-test-model.md")
+
+	outputs, errs := o.processModels(context.Background(), "prompt")
+	require.Empty(t, errs)
+	require.Contains(t, outputs, "prompt-test-model")
+
+	// The file is post-processed by the time its model finishes
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, strings.ToUpper(outputs["prompt-test-model"]), string(got))
+
+	// Saving the individual outputs keeps the post-processed file
+	filePaths, err := o.runIndividualOutputFlow(context.Background(), outputs)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"prompt-test-model": path}, filePaths)
+	got, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, strings.ToUpper(outputs["prompt-test-model"]), string(got))
+
+	var runs int
+	for _, call := range auditLogger.LogCalls {
+		if call.Operation == "PostProcess" {
+			runs++
+		}
+	}
+	assert.Equal(t, 1, runs, "the file should be post-processed once")
+}
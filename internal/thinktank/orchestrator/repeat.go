@@ -0,0 +1,91 @@
+package orchestrator
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// repeatSeparator joins a model name and a repeat number in the name of one of
+// its --repeat generations, e.g. gpt-5.2#2. A model ID may contain it too, so
+// only names that expandRepeats produced are ever split at it (see splitUnit).
+const repeatSeparator = "#"
+
+// runUnit is one generation the run makes: a model, and with --repeat N the
+// number of this run of it, from 1 to N (0 when the model isn't repeated)
+type runUnit struct {
+	model  string
+	repeat int
+}
+
+// name returns the name outputs, statuses, and the summary key the unit by:
+// the model name, or <model>#<repeat> for a repeated model
+func (u runUnit) name() string {
+	if u.repeat == 0 {
+		return u.model
+	}
+	return fmt.Sprintf("%s%s%d", u.model, repeatSeparator, u.repeat)
+}
+
+// expandRepeatUnits returns the run units for modelNames, keeping their order:
+// the models themselves, or with repeat > 1, each model repeat times
+func expandRepeatUnits(modelNames []string, repeat int) []runUnit {
+	if repeat <= 1 {
+		units := make([]runUnit, 0, len(modelNames))
+		for _, modelName := range modelNames {
+			units = append(units, runUnit{model: modelName})
+		}
+		return units
+	}
+	units := make([]runUnit, 0, len(modelNames)*repeat)
+	for _, modelName := range modelNames {
+		for n := 1; n <= repeat; n++ {
+			units = append(units, runUnit{model: modelName, repeat: n})
+		}
+	}
+	return units
+}
+
+// runUnits returns the names of the generations the run makes, in configuration
+// order: the models themselves, or with --repeat N, each model N times as
+// <model>#1 through <model>#N. Outputs, statuses, and the summary are keyed by them.
+func (o *Orchestrator) runUnits() []string {
+	return o.expandRepeats(o.config.ModelNames)
+}
+
+// expandRepeats returns the run unit names for modelNames, keeping their order
+func (o *Orchestrator) expandRepeats(modelNames []string) []string {
+	if o.config.Repeat <= 1 {
+		return modelNames
+	}
+	units := expandRepeatUnits(modelNames, o.config.Repeat)
+	names := make([]string, 0, len(units))
+	for _, unit := range units {
+		names = append(names, unit.name())
+	}
+	return names
+}
+
+// splitUnit returns the run unit a name from runUnits refers to. Only a name
+// expandRepeats produces for a configured model is split; any other name, such
+// as a model ID that itself contains '#', is a model that isn't repeated.
+func (o *Orchestrator) splitUnit(name string) runUnit {
+	if o.config.Repeat <= 1 {
+		return runUnit{model: name}
+	}
+	i := strings.LastIndex(name, repeatSeparator)
+	if i < 0 {
+		return runUnit{model: name}
+	}
+	n, err := strconv.Atoi(name[i+len(repeatSeparator):])
+	if err != nil || n < 1 || n > o.config.Repeat || !slices.Contains(o.config.ModelNames, name[:i]) {
+		return runUnit{model: name}
+	}
+	return runUnit{model: name[:i], repeat: n}
+}
+
+// unitModel returns the model a run unit generates with
+func (o *Orchestrator) unitModel(name string) string {
+	return o.splitUnit(name).model
+}
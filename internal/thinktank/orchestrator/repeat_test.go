@@ -0,0 +1,92 @@
+package orchestrator
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/misty-step/thinktank/internal/config"
+	"github.com/misty-step/thinktank/internal/metrics"
+	"github.com/misty-step/thinktank/internal/ratelimit"
+	"github.com/misty-step/thinktank/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandRepeats(t *testing.T) {
+	tests := []struct {
+		name   string
+		repeat int
+		want   []string
+	}{
+		{name: "unset", want: []string{"gpt-5.2", "o4-mini"}},
+		{name: "once", repeat: 1, want: []string{"gpt-5.2", "o4-mini"}},
+		{name: "three times", repeat: 3, want: []string{"gpt-5.2#1", "gpt-5.2#2", "gpt-5.2#3", "o4-mini#1", "o4-mini#2", "o4-mini#3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Orchestrator{config: &config.CliConfig{ModelNames: []string{"gpt-5.2", "o4-mini"}, Repeat: tt.repeat}}
+			assert.Equal(t, tt.want, o.runUnits())
+		})
+	}
+}
+
+func TestSplitUnit(t *testing.T) {
+	tests := []struct {
+		name   string
+		repeat int
+		unit   string
+		want   runUnit
+	}{
+		{name: "model", repeat: 3, unit: "gpt-5.2", want: runUnit{model: "gpt-5.2"}},
+		{name: "repeat", repeat: 3, unit: "gpt-5.2#2", want: runUnit{model: "gpt-5.2", repeat: 2}},
+		{name: "model ID with a separator, repeated", repeat: 3, unit: "vendor/model#1#3", want: runUnit{model: "vendor/model#1", repeat: 3}},
+		{name: "model ID with a separator", repeat: 3, unit: "vendor/model#1", want: runUnit{model: "vendor/model#1"}},
+		{name: "not repeating", unit: "gpt-5.2#2", want: runUnit{model: "gpt-5.2#2"}},
+		{name: "past the repeat count", repeat: 3, unit: "gpt-5.2#4", want: runUnit{model: "gpt-5.2#4"}},
+		{name: "not a number", repeat: 3, unit: "gpt-5.2#x", want: runUnit{model: "gpt-5.2#x"}},
+		{name: "zero", repeat: 3, unit: "gpt-5.2#0", want: runUnit{model: "gpt-5.2#0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Orchestrator{config: &config.CliConfig{ModelNames: []string{"gpt-5.2", "vendor/model#1"}, Repeat: tt.repeat}}
+			got := o.splitUnit(tt.unit)
+			assert.Equal(t, tt.want, got)
+			if tt.want.repeat > 0 {
+				assert.Equal(t, tt.unit, got.name())
+			}
+		})
+	}
+}
+
+func TestProcessModelsRepeatsEachModel(t *testing.T) {
+	fileWriter := &MockFileWriter{}
+	outputDir := t.TempDir()
+	o := &Orchestrator{
+		apiService:       &MockAPIService{},
+		fileWriter:       fileWriter,
+		auditLogger:      NewMockAuditLogger(),
+		rateLimiter:      ratelimit.NewRateLimiter(1, 0),
+		logger:           testutil.NewMockLogger(),
+		consoleWriter:    &MockConsoleWriter{},
+		metricsCollector: metrics.NewNoopCollector(),
+		config: &config.CliConfig{
+			ModelNames: []string{"prompt-test-model"},
+			Repeat:     3,
+			OutputDir:  outputDir,
+		},
+	}
+
+	outputs, errs := o.processModels(context.Background(), "prompt")
+
+	require.Empty(t, errs)
+	assert.Len(t, outputs, 3)
+	for _, unit := range []string{"prompt-test-model#1", "prompt-test-model#2", "prompt-test-model#3"} {
+		assert.Contains(t, outputs, unit)
+	}
+	for _, name := range []string{"prompt-test-model.1.md", "prompt-test-model.2.md", "prompt-test-model.3.md"} {
+		assert.Contains(t, fileWriter.savedFiles, filepath.Join(outputDir, name))
+	}
+}
@@ -0,0 +1,27 @@
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/misty-step/thinktank/internal/cache"
+)
+
+// responseCache opens the response cache in config.CacheDir the first time it is needed.
+// Returns nil when caching is disabled or the directory can't be created, in which
+// case models are processed without a cache.
+func (o *Orchestrator) responseCache(ctx context.Context) *cache.Cache {
+	if o.config.CacheDir == "" {
+		return nil
+	}
+
+	o.cacheOnce.Do(func() {
+		c, err := cache.New(o.config.CacheDir)
+		if err != nil {
+			o.logger.WarnContext(ctx, "Response caching disabled: %v", err)
+			return
+		}
+		o.logger.DebugContext(ctx, "Caching model responses in %s", c.Dir())
+		o.cache = c
+	})
+	return o.cache
+}
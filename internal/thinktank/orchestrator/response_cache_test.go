@@ -0,0 +1,40 @@
+package orchestrator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/misty-step/thinktank/internal/config"
+	"github.com/misty-step/thinktank/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCache(t *testing.T) {
+	t.Run("disabled without a cache dir", func(t *testing.T) {
+		o := &Orchestrator{config: &config.CliConfig{}, logger: testutil.NewMockLogger()}
+		assert.Nil(t, o.responseCache(context.Background()))
+	})
+
+	t.Run("opened once from the cache dir", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "cache")
+		o := &Orchestrator{config: &config.CliConfig{CacheDir: dir}, logger: testutil.NewMockLogger()}
+
+		first := o.responseCache(context.Background())
+		require.NotNil(t, first)
+		assert.Same(t, first, o.responseCache(context.Background()))
+		assert.DirExists(t, dir)
+	})
+
+	t.Run("unusable cache dir disables caching", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(file, nil, 0600))
+		logger := testutil.NewMockLogger()
+		o := &Orchestrator{config: &config.CliConfig{CacheDir: filepath.Join(file, "cache")}, logger: logger}
+
+		assert.Nil(t, o.responseCache(context.Background()))
+		assert.True(t, logger.ContainsMessage("Response caching disabled"))
+	})
+}
@@ -0,0 +1,96 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/misty-step/thinktank/internal/config"
+	"github.com/misty-step/thinktank/internal/llm"
+	"github.com/misty-step/thinktank/internal/logutil"
+)
+
+// maxRetryDelay caps the exponential backoff between attempts
+const maxRetryDelay = 30 * time.Second
+
+// isRetryableError reports whether a failed model request is worth retrying.
+// Transient server, network, and rate limit errors are; auth, invalid request,
+// content filtering, and everything else fail immediately, as does running out
+// of the rate limit wait budget.
+func isRetryableError(err error) bool {
+	if errors.Is(err, ErrRateLimitWaitBudgetExceeded) {
+		return false
+	}
+	return llm.IsServer(err) || llm.IsNetwork(err) || llm.IsRateLimit(err)
+}
+
+// retryDelay returns the wait before retry number attempt (1-based): base doubled
+// for each earlier retry and capped at maxRetryDelay, but never shorter than the
+// provider's Retry-After
+func retryDelay(base time.Duration, attempt int, retryAfter time.Duration) time.Duration {
+	delay := maxRetryDelay
+	if attempt < 32 {
+		if backoff := base << (attempt - 1); backoff > 0 && backoff < maxRetryDelay {
+			delay = backoff
+		}
+	}
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+	return delay
+}
+
+// processWithRetry calls process until it succeeds, fails with a non-retryable
+// error, or config.MaxRetries retries are used up. Retries also come out of the
+// run's shared retry budget; when that is spent the last error is returned at
+// once. Each retry is audited with its attempt number and delay. Waiting stops
+// early if ctx is cancelled. process is responsible for any rate limiting, so it
+// should hold a rate limiter slot only for the duration of one attempt.
+//
+// process receives ctx tagged with logutil.WithAttempt, so its log lines say
+// which attempt produced them.
+func (o *Orchestrator) processWithRetry(ctx context.Context, modelName string, process func(ctx context.Context) (string, error)) (string, error) {
+	baseDelay := o.config.RetryBaseDelay
+	if baseDelay <= 0 {
+		baseDelay = config.DefaultRetryBaseDelay
+	}
+
+	content, err := process(logutil.WithAttempt(ctx, 1))
+	for attempt := 1; err != nil && attempt <= o.config.MaxRetries && isRetryableError(err); attempt++ {
+		if ok, exhausted := o.retryBudget.take(); !ok {
+			if exhausted {
+				o.logger.WarnContext(ctx, "Retry budget of %d exhausted; model failures are no longer retried", o.config.RetryBudget)
+				o.logAuditEvent(ctx, "RetryBudgetExhausted", "Failure", map[string]interface{}{
+					"model_name":   modelName,
+					"retry_budget": o.config.RetryBudget,
+				}, nil, err)
+			}
+			o.logger.DebugContext(ctx, "Not retrying model %s: the retry budget is spent", modelName)
+			break
+		}
+
+		delay := retryDelay(baseDelay, attempt, llm.RetryAfterFromError(err))
+		o.logger.WarnContext(ctx, "Model %s failed with a transient error, retrying in %v (attempt %d of %d): %v",
+			modelName, delay, attempt+1, o.config.MaxRetries+1, err)
+		o.logAuditEvent(ctx, "ModelRetry", "InProgress", map[string]interface{}{
+			"model_name":   modelName,
+			"attempt":      attempt + 1,
+			"max_attempts": o.config.MaxRetries + 1,
+			"delay_ms":     delay.Milliseconds(),
+		}, nil, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", llm.Wrap(ctx.Err(), "orchestrator",
+				fmt.Sprintf("cancelled while waiting to retry model %s (last error: %v)", modelName, err),
+				llm.CategoryCancelled)
+		case <-timer.C:
+		}
+
+		content, err = process(logutil.WithAttempt(ctx, attempt+1))
+	}
+	return content, err
+}
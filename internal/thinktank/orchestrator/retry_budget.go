@@ -0,0 +1,31 @@
+package orchestrator
+
+import (
+	"sync/atomic"
+)
+
+// retryBudget caps the retries taken by all models combined, so a provider
+// outage can't multiply into a retry storm. Once it is spent, further failures
+// are final. A nil retryBudget is unlimited.
+type retryBudget struct {
+	limit int64
+	used  atomic.Int64 // Retries claimed, summed across models
+}
+
+// newRetryBudget returns a budget of limit retries, or nil when limit is 0
+func newRetryBudget(limit int) *retryBudget {
+	if limit <= 0 {
+		return nil
+	}
+	return &retryBudget{limit: int64(limit)}
+}
+
+// take claims a retry. It reports whether the budget allowed it, and whether this
+// call was the first to find the budget spent.
+func (b *retryBudget) take() (ok, exhausted bool) {
+	if b == nil {
+		return true, false
+	}
+	claimed := b.used.Add(1)
+	return claimed <= b.limit, claimed == b.limit+1
+}
@@ -0,0 +1,437 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/misty-step/thinktank/internal/config"
+	"github.com/misty-step/thinktank/internal/llm"
+	"github.com/misty-step/thinktank/internal/logutil"
+	"github.com/misty-step/thinktank/internal/metrics"
+	"github.com/misty-step/thinktank/internal/ratelimit"
+	"github.com/misty-step/thinktank/internal/testutil"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		category  llm.ErrorCategory
+		retryable bool
+	}{
+		{llm.CategoryServer, true},
+		{llm.CategoryNetwork, true},
+		{llm.CategoryRateLimit, true},
+		{llm.CategoryAuth, false},
+		{llm.CategoryInvalidRequest, false},
+		{llm.CategoryContentFiltered, false},
+		{llm.CategoryCancelled, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.category.String(), func(t *testing.T) {
+			err := llm.Wrap(errors.New("boom"), "test", "request failed", tt.category)
+			if got := isRetryableError(err); got != tt.retryable {
+				t.Errorf("isRetryableError(%v) = %v, want %v", tt.category, got, tt.retryable)
+			}
+		})
+	}
+
+	if isRetryableError(errors.New("uncategorized")) {
+		t.Error("uncategorized errors should not be retried")
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter time.Duration
+		expected   time.Duration
+	}{
+		{"first retry uses base", 1, 0, time.Second},
+		{"doubles each retry", 3, 0, 4 * time.Second},
+		{"capped", 10, 0, maxRetryDelay},
+		{"huge attempt stays capped", 100, 0, maxRetryDelay},
+		{"retry-after wins when longer", 1, 5 * time.Second, 5 * time.Second},
+		{"backoff wins when longer", 3, 2 * time.Second, 4 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryDelay(time.Second, tt.attempt, tt.retryAfter); got != tt.expected {
+				t.Errorf("retryDelay() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProcessWithRetry(t *testing.T) {
+	serverErr := llm.Wrap(errors.New("502"), "test", "bad gateway", llm.CategoryServer)
+	authErr := llm.Wrap(errors.New("401"), "test", "unauthorized", llm.CategoryAuth)
+
+	tests := []struct {
+		name          string
+		maxRetries    int
+		failures      []error
+		expectedCalls int
+		expectErr     error
+	}{
+		{
+			name:          "transient failure then success",
+			maxRetries:    2,
+			failures:      []error{serverErr},
+			expectedCalls: 2,
+		},
+		{
+			name:          "retries exhausted",
+			maxRetries:    2,
+			failures:      []error{serverErr, serverErr, serverErr, serverErr},
+			expectedCalls: 3,
+			expectErr:     serverErr,
+		},
+		{
+			name:          "non-retryable fails immediately",
+			maxRetries:    2,
+			failures:      []error{authErr},
+			expectedCalls: 1,
+			expectErr:     authErr,
+		},
+		{
+			name:          "retries disabled",
+			maxRetries:    0,
+			failures:      []error{serverErr},
+			expectedCalls: 1,
+			expectErr:     serverErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditLogger := NewMockAuditLogger()
+			o := &Orchestrator{
+				logger:      testutil.NewMockLogger(),
+				auditLogger: auditLogger,
+				config:      &config.CliConfig{MaxRetries: tt.maxRetries, RetryBaseDelay: time.Millisecond},
+			}
+
+			calls := 0
+			content, err := o.processWithRetry(context.Background(), "model-a", func(ctx context.Context) (string, error) {
+				calls++
+				if attempt := logutil.GetAttempt(ctx); attempt != calls {
+					t.Errorf("call %d has attempt %d in its context", calls, attempt)
+				}
+				if calls <= len(tt.failures) {
+					return "", tt.failures[calls-1]
+				}
+				return "output", nil
+			})
+
+			if calls != tt.expectedCalls {
+				t.Errorf("process called %d times, want %d", calls, tt.expectedCalls)
+			}
+			if !errors.Is(err, tt.expectErr) {
+				t.Errorf("error = %v, want %v", err, tt.expectErr)
+			}
+			if tt.expectErr == nil && content != "output" {
+				t.Errorf("content = %q, want output", content)
+			}
+
+			if len(auditLogger.LogCalls) != calls-1 {
+				t.Fatalf("recorded %d retry audit entries, want %d", len(auditLogger.LogCalls), calls-1)
+			}
+			for i, call := range auditLogger.LogCalls {
+				if call.Operation != "ModelRetry" || call.Inputs["attempt"] != i+2 {
+					t.Errorf("audit entry %d = %s attempt %v, want ModelRetry attempt %d", i, call.Operation, call.Inputs["attempt"], i+2)
+				}
+				if _, ok := call.Inputs["delay_ms"]; !ok {
+					t.Errorf("audit entry %d missing delay_ms", i)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessWithRetryHonorsCancellation(t *testing.T) {
+	o := &Orchestrator{
+		logger:      testutil.NewMockLogger(),
+		auditLogger: NewMockAuditLogger(),
+		config:      &config.CliConfig{MaxRetries: 3, RetryBaseDelay: time.Hour},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, err := o.processWithRetry(ctx, "model-a", func(context.Context) (string, error) {
+		calls++
+		cancel()
+		return "", llm.Wrap(errors.New("timeout"), "test", "network error", llm.CategoryNetwork)
+	})
+
+	if calls != 1 {
+		t.Errorf("process called %d times, want 1", calls)
+	}
+	if !llm.IsCancelled(err) || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a cancellation error, got %v", err)
+	}
+}
+
+func TestProcessWithRetryScriptedClient(t *testing.T) {
+	client := llm.NewScriptedClient("model-a",
+		llm.ScriptedError(llm.CategoryRateLimit),
+		llm.ScriptedError(llm.CategoryServer),
+		llm.ScriptedResponse("third time lucky"),
+	)
+	o := &Orchestrator{
+		logger:      testutil.NewMockLogger(),
+		auditLogger: NewMockAuditLogger(),
+		config:      &config.CliConfig{MaxRetries: 2, RetryBaseDelay: time.Millisecond},
+	}
+
+	ctx := context.Background()
+	content, err := o.processWithRetry(ctx, "model-a", func(attemptCtx context.Context) (string, error) {
+		result, err := client.GenerateContent(attemptCtx, "prompt", nil)
+		if err != nil {
+			return "", err
+		}
+		return result.Content, nil
+	})
+
+	if err != nil || content != "third time lucky" {
+		t.Errorf("processWithRetry() = %q, %v; want the scripted response", content, err)
+	}
+	if client.Calls() != 3 {
+		t.Errorf("client called %d times, want 3", client.Calls())
+	}
+}
+
+func TestProcessWithRetryBudget(t *testing.T) {
+	serverErr := llm.Wrap(errors.New("503"), "test", "unavailable", llm.CategoryServer)
+	auditLogger := NewMockAuditLogger()
+	o := &Orchestrator{
+		logger:      testutil.NewMockLogger(),
+		auditLogger: auditLogger,
+		config:      &config.CliConfig{MaxRetries: 3, RetryBudget: 2, RetryBaseDelay: time.Millisecond},
+		retryBudget: newRetryBudget(2),
+	}
+
+	// Models share the budget: the first spends it, the rest fail on their first error
+	models := []struct {
+		name      string
+		wantCalls int
+	}{{"model-a", 3}, {"model-b", 1}, {"model-c", 1}}
+	for _, model := range models {
+		calls := 0
+		_, err := o.processWithRetry(context.Background(), model.name, func(context.Context) (string, error) {
+			calls++
+			return "", serverErr
+		})
+		if !errors.Is(err, serverErr) {
+			t.Errorf("%s: error = %v, want %v", model.name, err, serverErr)
+		}
+		if calls != model.wantCalls {
+			t.Errorf("%s: process called %d times, want %d", model.name, calls, model.wantCalls)
+		}
+	}
+
+	exhausted := 0
+	for _, call := range auditLogger.LogCalls {
+		if call.Operation == "RetryBudgetExhausted" {
+			exhausted++
+			if call.Inputs["model_name"] != "model-a" || call.Inputs["retry_budget"] != 2 {
+				t.Errorf("RetryBudgetExhausted inputs = %v, want model-a and a budget of 2", call.Inputs)
+			}
+		}
+	}
+	if exhausted != 1 {
+		t.Errorf("recorded %d RetryBudgetExhausted entries, want 1", exhausted)
+	}
+}
+
+func TestRetryBudgetNilIsUnlimited(t *testing.T) {
+	var budget *retryBudget
+	if newRetryBudget(0) != nil {
+		t.Error("newRetryBudget(0) should be unlimited (nil)")
+	}
+	for i := 0; i < 100; i++ {
+		if ok, exhausted := budget.take(); !ok || exhausted {
+			t.Fatalf("take() on a nil budget = %v, %v; want true, false", ok, exhausted)
+		}
+	}
+}
+
+// scriptedAPIService hands out the same scripted client for every model
+type scriptedAPIService struct {
+	MockAPIService
+	client *llm.ScriptedClient
+}
+
+func (m *scriptedAPIService) InitLLMClient(ctx context.Context, apiKey, modelName, apiEndpoint string) (llm.LLMClient, error) {
+	return m.client, nil
+}
+
+// newRetryTestOrchestrator returns an orchestrator that runs every model on client
+// through rateLimiter, retrying twice with a short fixed backoff
+func newRetryTestOrchestrator(t *testing.T, client *llm.ScriptedClient, rateLimiter *ratelimit.RateLimiter, baseDelay time.Duration) *Orchestrator {
+	return &Orchestrator{
+		apiService:       &scriptedAPIService{client: client},
+		fileWriter:       &MockFileWriter{},
+		auditLogger:      NewMockAuditLogger(),
+		rateLimiter:      rateLimiter,
+		config:           &config.CliConfig{MaxRetries: 2, RetryBaseDelay: baseDelay, OutputDir: t.TempDir()},
+		logger:           testutil.NewMockLogger(),
+		consoleWriter:    &MockConsoleWriter{},
+		metricsCollector: metrics.NewNoopCollector(),
+	}
+}
+
+func TestProcessModelWithRateLimit_RetriesAcquireRateLimiter(t *testing.T) {
+	const modelName = "unregistered-test-model" // Unknown models use the per-model limit
+
+	// One request per 100ms with no burst, so every attempt after the first waits
+	// for a fresh token
+	rateLimiter := ratelimit.NewRateLimiter(0, 600)
+	client := llm.NewScriptedClient(modelName,
+		llm.ScriptedError(llm.CategoryServer),
+		llm.ScriptedError(llm.CategoryServer),
+		llm.ScriptedResponse("third time lucky"),
+	)
+	o := newRetryTestOrchestrator(t, client, rateLimiter, time.Millisecond)
+
+	var wg sync.WaitGroup
+	resultChan := make(chan modelResult, 1)
+	wg.Add(1)
+	start := time.Now()
+	o.processModelWithRateLimit(context.Background(), modelName, "prompt", 1, nil, &wg, resultChan)
+	elapsed := time.Since(start)
+
+	result := <-resultChan
+	if result.err != nil || result.content != "third time lucky" {
+		t.Fatalf("result = %q, %v; want the third response", result.content, result.err)
+	}
+	if calls := client.Calls(); calls != 3 {
+		t.Fatalf("client called %d times, want 3", calls)
+	}
+	// Two retries, each waiting for a token, take at least two refill intervals
+	// even though the backoff itself is only a millisecond
+	if elapsed < 150*time.Millisecond {
+		t.Errorf("three attempts took %v; retries should each acquire the rate limiter", elapsed)
+	}
+}
+
+func TestProcessModelWithRateLimit_ReleasesSlotDuringBackoff(t *testing.T) {
+	const modelName = "unregistered-test-model"
+
+	// A single concurrency slot, shared by every model
+	rateLimiter := ratelimit.NewRateLimiter(1, 0)
+	client := llm.NewScriptedClient(modelName,
+		llm.ScriptedError(llm.CategoryServer),
+		llm.ScriptedResponse("second time lucky"),
+	)
+	o := newRetryTestOrchestrator(t, client, rateLimiter, 500*time.Millisecond)
+
+	var wg sync.WaitGroup
+	resultChan := make(chan modelResult, 1)
+	wg.Add(1)
+	go o.processModelWithRateLimit(context.Background(), modelName, "prompt", 1, nil, &wg, resultChan)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for client.Calls() < 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("the first attempt never ran")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// While the model backs off, another model can take the slot
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	if err := rateLimiter.Acquire(ctx, "other-model"); err != nil {
+		t.Fatalf("the slot should be free during the retry backoff: %v", err)
+	}
+	rateLimiter.Release()
+
+	result := <-resultChan
+	if result.err != nil || result.content != "second time lucky" {
+		t.Fatalf("result = %q, %v; want the retried response", result.content, result.err)
+	}
+	if calls := client.Calls(); calls != 2 {
+		t.Errorf("client called %d times, want 2", calls)
+	}
+}
+
+// flakyStreamingClient streams a fragment that is cut short on its first call and a
+// complete response on later calls
+type flakyStreamingClient struct {
+	MockLLMClient
+	calls int
+}
+
+func (c *flakyStreamingClient) GenerateContentStream(ctx context.Context, prompt string, params map[string]interface{}) (<-chan llm.Chunk, error) {
+	c.calls++
+	chunks := []llm.Chunk{{Content: "The answer "}, {Content: "is 42.", FinishReason: "stop"}, {Done: true}}
+	if c.calls == 1 {
+		chunks = []llm.Chunk{{Content: "The ans"}}
+	}
+	stream := make(chan llm.Chunk, len(chunks))
+	for _, chunk := range chunks {
+		stream <- chunk
+	}
+	close(stream)
+	return stream, nil
+}
+
+// streamingAPIService returns the same client for every model
+type streamingAPIService struct {
+	MockAPIService
+	client llm.LLMClient
+}
+
+func (m *streamingAPIService) InitLLMClient(ctx context.Context, apiKey, modelName, apiEndpoint string) (llm.LLMClient, error) {
+	return m.client, nil
+}
+
+// streamRecordingConsoleWriter records streamed output on an interactive terminal
+type streamRecordingConsoleWriter struct {
+	interactiveConsoleWriter
+	streamed strings.Builder
+}
+
+func (w *streamRecordingConsoleWriter) StreamModelOutput(modelName string, content string) {
+	w.streamed.WriteString(content)
+}
+
+func TestProcessModelWithRateLimit_RetriedStreamRestartsOutput(t *testing.T) {
+	const modelName = "unregistered-test-model"
+
+	client := &flakyStreamingClient{}
+	consoleWriter := &streamRecordingConsoleWriter{}
+	o := &Orchestrator{
+		apiService:       &streamingAPIService{client: client},
+		fileWriter:       &MockFileWriter{},
+		auditLogger:      NewMockAuditLogger(),
+		rateLimiter:      ratelimit.NewRateLimiter(1, 0),
+		config:           &config.CliConfig{ModelNames: []string{modelName}, MaxRetries: 2, RetryBaseDelay: time.Millisecond, OutputDir: t.TempDir()},
+		logger:           testutil.NewMockLogger(),
+		consoleWriter:    consoleWriter,
+		metricsCollector: metrics.NewNoopCollector(),
+	}
+
+	var wg sync.WaitGroup
+	resultChan := make(chan modelResult, 1)
+	wg.Add(1)
+	o.processModelWithRateLimit(context.Background(), modelName, "prompt", 1, nil, &wg, resultChan)
+
+	result := <-resultChan
+	if result.err != nil || result.content != "The answer is 42." {
+		t.Fatalf("result = %q, %v; want the retried response", result.content, result.err)
+	}
+	if client.calls != 2 {
+		t.Fatalf("client called %d times, want 2", client.calls)
+	}
+	want := "The ans\n\n" + streamRetryNotice + "\n\nThe answer is 42."
+	if got := consoleWriter.streamed.String(); got != want {
+		t.Errorf("console received %q, want %q", got, want)
+	}
+}
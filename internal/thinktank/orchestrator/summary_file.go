@@ -0,0 +1,23 @@
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/misty-step/thinktank/internal/logutil"
+)
+
+// writeSummaryFile writes the run summary as markdown to --summary-file, whatever
+// --output-format the console uses. A failed write is logged, not returned.
+func (o *Orchestrator) writeSummaryFile(ctx context.Context, summary *ResultsSummary) {
+	if o.config.SummaryFile == "" {
+		return
+	}
+	contextLogger := o.logger.WithContext(ctx)
+
+	content := logutil.FormatSummaryMarkdown(toSummaryData(summary))
+	if err := o.fileWriter.SaveToFile(ctx, content, o.config.SummaryFile); err != nil {
+		contextLogger.WarnContext(ctx, "Failed to write summary to %s: %v", o.config.SummaryFile, err)
+		return
+	}
+	contextLogger.DebugContext(ctx, "Wrote summary to %s", o.config.SummaryFile)
+}
@@ -0,0 +1,56 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSummaryFile(t *testing.T) {
+	o, _, _, logger := newStatusTestOrchestrator()
+	fileWriter := &MockFileWriter{}
+	o.fileWriter = fileWriter
+	o.config.SummaryFile = "summary.md"
+
+	// A partial success still gets its summary
+	o.writeSummaryFile(context.Background(), &ResultsSummary{
+		TotalModels:      2,
+		SuccessfulModels: 1,
+		SuccessfulNames:  []string{"gpt-5.2"},
+		FailedModels:     []string{"o4-mini"},
+		FailureReasons:   map[string]string{"o4-mini": "rate limited"},
+	})
+
+	content, ok := fileWriter.savedFiles["summary.md"]
+	require.True(t, ok, "summary file was not written")
+	assert.True(t, strings.HasPrefix(content, "# Summary\n"))
+	assert.Contains(t, content, "- **Models:** 2 processed, 1 succeeded, 1 failed")
+	assert.Contains(t, content, "- o4-mini: rate limited")
+	assert.Empty(t, logger.GetWarnMessages())
+}
+
+func TestWriteSummaryFileFailureIsWarning(t *testing.T) {
+	o, _, _, logger := newStatusTestOrchestrator()
+	o.fileWriter = &MockFileWriter{saveError: errors.New("disk full")}
+	o.config.SummaryFile = "summary.md"
+
+	o.writeSummaryFile(context.Background(), &ResultsSummary{TotalModels: 1, SuccessfulModels: 1})
+
+	warnings := logger.GetWarnMessages()
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "disk full")
+}
+
+func TestWriteSummaryFileDisabled(t *testing.T) {
+	o, _, _, _ := newStatusTestOrchestrator()
+	fileWriter := &MockFileWriter{}
+	o.fileWriter = fileWriter
+
+	o.writeSummaryFile(context.Background(), &ResultsSummary{TotalModels: 1, SuccessfulModels: 1})
+
+	assert.Empty(t, fileWriter.savedFiles)
+}
@@ -4,8 +4,13 @@ package orchestrator
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/misty-step/thinktank/internal/fileutil"
 	"github.com/misty-step/thinktank/internal/logutil"
 )
 
@@ -26,6 +31,19 @@ type ResultsSummary struct {
 	SuccessfulNames  []string
 	SynthesisPath    string
 	OutputPaths      []string
+	SkippedModels    []string // Models never attempted, e.g. because the input was too large
+	TruncatedFiles   []string // Files cut short by the output size limit
+
+	FailureReasons     map[string]string // Why each failed or skipped model did not complete
+	SynthesisRequested bool              // A synthesis model was configured, so a missing synthesis file means it failed
+	Duration           time.Duration     // Total run time
+
+	// TokenDiscrepancies describes models whose provider-reported token usage
+	// differs substantially from our own counts
+	TokenDiscrepancies []string
+
+	// ExcludeMatches reports how many paths each configured exclude rule skipped
+	ExcludeMatches []fileutil.ExcludeRuleMatch
 }
 
 // SummaryWriter handles generating and displaying summaries of processing results
@@ -114,6 +132,12 @@ func (w *DefaultSummaryWriter) GenerateSummary(summary *ResultsSummary) string {
 			colorRed, truncateList(summary.FailedModels, 60), colorReset))
 	}
 
+	// Add skipped models if any
+	if len(summary.SkippedModels) > 0 {
+		sb.WriteString(fmt.Sprintf("⏭️ Skipped models: %s%s%s\n",
+			colorYellow, truncateList(summary.SkippedModels, 60), colorReset))
+	}
+
 	sb.WriteString("\n")
 
 	return sb.String()
@@ -130,6 +154,19 @@ func (w *DefaultSummaryWriter) DisplaySummary(ctx context.Context, summary *Resu
 		w.logger.InfoContext(ctx, "Synthesis output saved to: %s", summary.SynthesisPath)
 	}
 
+	if len(summary.SkippedModels) > 0 {
+		w.logger.InfoContext(ctx, "Skipped %d models: %v", len(summary.SkippedModels), summary.SkippedModels)
+	}
+
+	if len(summary.TruncatedFiles) > 0 {
+		w.logger.WarnContext(ctx, "Truncated %d output files at the size limit: %v",
+			len(summary.TruncatedFiles), summary.TruncatedFiles)
+	}
+
+	for _, discrepancy := range summary.TokenDiscrepancies {
+		w.logger.WarnContext(ctx, "Token accounting mismatch: %s", discrepancy)
+	}
+
 	// Convert to SummaryData format and display using modern clean output
 	summaryData := w.convertToSummaryData(summary)
 	w.consoleWriter.ShowSummarySection(summaryData)
@@ -137,10 +174,18 @@ func (w *DefaultSummaryWriter) DisplaySummary(ctx context.Context, summary *Resu
 
 // convertToSummaryData converts ResultsSummary to SummaryData format
 func (w *DefaultSummaryWriter) convertToSummaryData(summary *ResultsSummary) logutil.SummaryData {
+	return toSummaryData(summary)
+}
+
+// toSummaryData converts ResultsSummary to the SummaryData shown by the console and
+// written by --summary-file
+func toSummaryData(summary *ResultsSummary) logutil.SummaryData {
 	// Determine synthesis status
 	synthesisStatus := "skipped"
 	if summary.SynthesisPath != "" {
 		synthesisStatus = "completed"
+	} else if summary.SynthesisRequested {
+		synthesisStatus = "failed"
 	}
 
 	// Determine output directory from synthesis path or output paths
@@ -163,7 +208,53 @@ func (w *DefaultSummaryWriter) convertToSummaryData(summary *ResultsSummary) log
 		FailedModels:     len(summary.FailedModels),
 		SynthesisStatus:  synthesisStatus,
 		OutputDirectory:  outputDirectory,
+		TruncatedFiles:   len(summary.TruncatedFiles),
+		TokenMismatches:  len(summary.TokenDiscrepancies),
+		SuccessfulNames:  summary.SuccessfulNames,
+		Failures:         modelReasons(summary.FailedModels, summary.FailureReasons),
+		Skipped:          modelReasons(summary.SkippedModels, summary.FailureReasons),
+		OutputFiles:      outputFiles(summary),
+		Duration:         summary.Duration,
+		ExcludeRules:     excludeRuleCounts(summary.ExcludeMatches),
+	}
+}
+
+// excludeRuleCounts converts the gatherer's exclude rule matches for the summary
+func excludeRuleCounts(matches []fileutil.ExcludeRuleMatch) []logutil.ExcludeRuleCount {
+	var counts []logutil.ExcludeRuleCount
+	for _, match := range matches {
+		counts = append(counts, logutil.ExcludeRuleCount{Kind: match.Kind, Rule: match.Rule, Matched: match.Matched})
+	}
+	return counts
+}
+
+// modelReasons pairs each model with its recorded failure or skip reason
+func modelReasons(names []string, reasons map[string]string) []logutil.FailedModel {
+	var models []logutil.FailedModel
+	for _, name := range names {
+		models = append(models, logutil.FailedModel{Name: name, Reason: reasons[name]})
+	}
+	return models
+}
+
+// outputFiles lists the run's output files sorted by path, with their sizes on disk.
+// Files that can no longer be read are left out.
+func outputFiles(summary *ResultsSummary) []logutil.OutputFile {
+	paths := append([]string(nil), summary.OutputPaths...)
+	if summary.SynthesisPath != "" {
+		paths = append(paths, summary.SynthesisPath)
+	}
+	sort.Strings(paths)
+
+	var files []logutil.OutputFile
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		files = append(files, logutil.OutputFile{Name: filepath.Base(path), Path: path, Size: info.Size()})
 	}
+	return files
 }
 
 // Helper functions
@@ -2,12 +2,15 @@ package orchestrator
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/misty-step/thinktank/internal/fileutil"
 	"github.com/misty-step/thinktank/internal/logutil"
 )
 
@@ -54,6 +57,7 @@ func (m *MockConsoleWriter) SynthesisCompleted(outputPath string)
 func (m *MockConsoleWriter) StatusMessage(message string)                         {}
 func (m *MockConsoleWriter) SetQuiet(quiet bool)                                  {}
 func (m *MockConsoleWriter) SetNoProgress(noProgress bool)                        {}
+func (m *MockConsoleWriter) SetSilent(silent bool)                                {}
 func (m *MockConsoleWriter) IsInteractive() bool                                  { return false }
 func (m *MockConsoleWriter) GetTerminalWidth() int                                { return 80 }
 func (m *MockConsoleWriter) FormatMessage(message string) string                  { return message }
@@ -66,6 +70,7 @@ func (m *MockConsoleWriter) UpdateModelStatus(modelName string, status logutil.M
 func (m *MockConsoleWriter) UpdateModelRateLimited(modelName string, retryAfter time.Duration) {}
 func (m *MockConsoleWriter) RefreshStatusDisplay()                                             {}
 func (m *MockConsoleWriter) FinishStatusTracking()                                             {}
+func (m *MockConsoleWriter) StreamModelOutput(modelName string, content string)                {}
 func (l *SimpleTestLogger) WithContext(ctx context.Context) logutil.LoggerInterface            { return l }
 
 // stripAnsiColors removes ANSI color codes from a string
@@ -304,6 +309,7 @@ func TestConvertToSummaryData(t *testing.T) {
 				FailedModels:     1,
 				SynthesisStatus:  "completed",
 				OutputDirectory:  "/path/to/output/",
+				Failures:         []logutil.FailedModel{{Name: "model3"}},
 			},
 		},
 		{
@@ -337,6 +343,7 @@ func TestConvertToSummaryData(t *testing.T) {
 				FailedModels:     1,
 				SynthesisStatus:  "skipped",
 				OutputDirectory:  "",
+				Failures:         []logutil.FailedModel{{Name: "model1"}},
 			},
 		},
 		{
@@ -385,6 +392,62 @@ func TestConvertToSummaryData(t *testing.T) {
 	}
 }
 
+func TestConvertToSummaryDataDetails(t *testing.T) {
+	dir := t.TempDir()
+	modelB := filepath.Join(dir, "model-b.md")
+	modelA := filepath.Join(dir, "model-a.md")
+	if err := os.WriteFile(modelB, []byte("bb"), 0640); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.WriteFile(modelA, []byte("aaaa"), 0640); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	summaryWriter := &DefaultSummaryWriter{logger: NewSimpleTestLogger(), consoleWriter: &MockConsoleWriter{}}
+	result := summaryWriter.convertToSummaryData(&ResultsSummary{
+		TotalModels:        4,
+		SuccessfulModels:   2,
+		SuccessfulNames:    []string{"model-a", "model-b"},
+		FailedModels:       []string{"model-c"},
+		SkippedModels:      []string{"model-d"},
+		OutputPaths:        []string{modelB, modelA, filepath.Join(dir, "missing.md")},
+		FailureReasons:     map[string]string{"model-c": "rate limited", "model-d": "input too large"},
+		SynthesisRequested: true,
+		Duration:           1500 * time.Millisecond,
+		ExcludeMatches: []fileutil.ExcludeRuleMatch{
+			{Kind: fileutil.ExcludeKindName, Rule: "vendor", Matched: 3},
+			{Kind: fileutil.ExcludeKindExtension, Rule: ".log"},
+		},
+	})
+
+	if result.SynthesisStatus != "failed" {
+		t.Errorf("SynthesisStatus = %q, want failed when synthesis was requested but produced no file", result.SynthesisStatus)
+	}
+	if !reflect.DeepEqual(result.Failures, []logutil.FailedModel{{Name: "model-c", Reason: "rate limited"}}) {
+		t.Errorf("Failures = %+v", result.Failures)
+	}
+	if !reflect.DeepEqual(result.Skipped, []logutil.FailedModel{{Name: "model-d", Reason: "input too large"}}) {
+		t.Errorf("Skipped = %+v", result.Skipped)
+	}
+	expectedFiles := []logutil.OutputFile{
+		{Name: "model-a.md", Path: modelA, Size: 4},
+		{Name: "model-b.md", Path: modelB, Size: 2},
+	}
+	if !reflect.DeepEqual(result.OutputFiles, expectedFiles) {
+		t.Errorf("OutputFiles = %+v, want %+v (sorted, missing files left out)", result.OutputFiles, expectedFiles)
+	}
+	if result.Duration != 1500*time.Millisecond {
+		t.Errorf("Duration = %v, want 1.5s", result.Duration)
+	}
+	expectedRules := []logutil.ExcludeRuleCount{
+		{Kind: "name", Rule: "vendor", Matched: 3},
+		{Kind: "extension", Rule: ".log"},
+	}
+	if !reflect.DeepEqual(result.ExcludeRules, expectedRules) {
+		t.Errorf("ExcludeRules = %+v, want %+v", result.ExcludeRules, expectedRules)
+	}
+}
+
 // TestTruncateListComprehensive tests the truncateList function with comprehensive edge cases
 func TestTruncateListComprehensive(t *testing.T) {
 	tests := []struct {
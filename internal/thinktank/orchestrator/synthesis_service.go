@@ -30,6 +30,9 @@ type DefaultSynthesisService struct {
 	auditLogger auditlog.AuditLogger
 	logger      logutil.LoggerInterface
 	modelName   string // The name of the synthesis model to use
+
+	// textCapture is how much of the synthesis prompt and response audit entries keep
+	textCapture auditlog.TextCapture
 }
 
 // NewSynthesisService creates a new SynthesisService instance with the specified dependencies
@@ -39,6 +42,17 @@ func NewSynthesisService(
 	logger logutil.LoggerInterface,
 	modelName string,
 ) SynthesisService {
+	return newDefaultSynthesisService(apiService, auditLogger, logger, modelName)
+}
+
+// newDefaultSynthesisService is NewSynthesisService with the concrete type, for
+// callers that go on to configure it
+func newDefaultSynthesisService(
+	apiService interfaces.APIService,
+	auditLogger auditlog.AuditLogger,
+	logger logutil.LoggerInterface,
+	modelName string,
+) *DefaultSynthesisService {
 	return &DefaultSynthesisService{
 		apiService:  apiService,
 		auditLogger: auditLogger,
@@ -47,6 +61,12 @@ func NewSynthesisService(
 	}
 }
 
+// SetAuditTextCapture sets how much of the synthesis prompt and response the
+// audit log records (default: lengths only)
+func (s *DefaultSynthesisService) SetAuditTextCapture(capture auditlog.TextCapture) {
+	s.textCapture = capture
+}
+
 // SynthesizeResults processes multiple model outputs through a synthesis model.
 // It builds a prompt that includes the original instructions and all model outputs,
 // then sends this to the synthesis model to generate a consolidated result.
@@ -218,14 +238,15 @@ func (s *DefaultSynthesisService) SynthesizeResults(
 
 	// Log API call start
 	apiCallStartTime := time.Now()
+	apiCallInputs := map[string]interface{}{
+		"synthesis_model": s.modelName,
+	}
+	s.textCapture.Record(apiCallInputs, "prompt", synthesisPrompt)
 	s.logAuditEvent(ctx, auditlog.AuditEntry{
 		Operation: "SynthesisAPICall",
 		Status:    "InProgress",
-		Inputs: map[string]interface{}{
-			"synthesis_model": s.modelName,
-			"prompt_length":   len(synthesisPrompt),
-		},
-		Message: fmt.Sprintf("Calling synthesis model API: %s", s.modelName),
+		Inputs:    apiCallInputs,
+		Message:   fmt.Sprintf("Calling synthesis model API: %s", s.modelName),
 	})
 
 	// Call model API
@@ -337,6 +358,10 @@ func (s *DefaultSynthesisService) SynthesizeResults(
 	}
 
 	// Log successful response processing
+	responseOutputs := map[string]interface{}{
+		"output_length": len(synthesisOutput),
+	}
+	s.textCapture.Record(responseOutputs, "response", synthesisOutput)
 	s.logAuditEvent(ctx, auditlog.AuditEntry{
 		Operation:  "SynthesisResponseProcessing",
 		Status:     "Success",
@@ -344,9 +369,7 @@ func (s *DefaultSynthesisService) SynthesizeResults(
 		Inputs: map[string]interface{}{
 			"synthesis_model": s.modelName,
 		},
-		Outputs: map[string]interface{}{
-			"output_length": len(synthesisOutput),
-		},
+		Outputs: responseOutputs,
 		Message: "Successfully processed synthesis model response",
 	})
 
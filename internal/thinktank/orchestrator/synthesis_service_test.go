@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/misty-step/thinktank/internal/auditlog"
 	"github.com/misty-step/thinktank/internal/llm"
 )
 
@@ -429,3 +430,34 @@ func TestHandleSynthesisError(t *testing.T) {
 		})
 	}
 }
+
+func TestSynthesizeResults_AuditTextCapture(t *testing.T) {
+	mockAPIService := &MockSynthesisAPIService{ProcessResponseResult: "Synthesized content"}
+	mockAuditLogger := NewMockAuditLogger()
+	synthesisService := newDefaultSynthesisService(mockAPIService, mockAuditLogger, &MockLogger{}, "synthesis-model")
+	synthesisService.SetAuditTextCapture(auditlog.TextCapture{PreviewLength: 11})
+
+	_, err := synthesisService.SynthesizeResults(context.Background(), "Instructions", map[string]string{"model1": "Output"})
+	if err != nil {
+		t.Fatalf("SynthesizeResults() error = %v", err)
+	}
+
+	var prompt, response interface{}
+	for _, call := range mockAuditLogger.LogCalls {
+		switch {
+		case call.Operation == "SynthesisAPICall" && call.Status == "InProgress":
+			prompt = call.Inputs["prompt"]
+			if call.Inputs["prompt_length"] != len(mockAPIService.capturedPrompt) || call.Inputs["prompt_truncated"] != true {
+				t.Errorf("prompt inputs = %v, want full length and truncation flag", call.Inputs)
+			}
+		case call.Operation == "SynthesisResponseProcessing" && call.Status == "Success":
+			response = call.Outputs["response"]
+		}
+	}
+	if prompt != mockAPIService.capturedPrompt[:11] {
+		t.Errorf("prompt preview = %v, want %q", prompt, mockAPIService.capturedPrompt[:11])
+	}
+	if response != "Synthesized" {
+		t.Errorf("response preview = %v, want %q", response, "Synthesized")
+	}
+}
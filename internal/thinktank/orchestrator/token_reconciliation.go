@@ -0,0 +1,136 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/misty-step/thinktank/internal/llm"
+	"github.com/misty-step/thinktank/internal/logutil"
+)
+
+// tokenDiscrepancyThreshold is the relative difference between provider-reported
+// and pre-flight prompt tokens beyond which a model is flagged in the summary
+const tokenDiscrepancyThreshold = 0.2
+
+// TokenReconciliation compares the prompt token counts for one model from every
+// source that produced one. Zero means the source was unavailable.
+type TokenReconciliation struct {
+	ModelName              string
+	EstimatedTokens        int    // Generic estimate used for the token analysis
+	CountedTokens          int    // Model-specific CountTokensForModel result
+	CountMethod            string // Tokenizer behind CountedTokens, e.g. "tiktoken"
+	ProviderPromptTokens   int    // Prompt tokens the provider reported
+	ProviderOutputTokens   int    // Completion tokens the provider reported
+	ProviderReportedTokens bool   // Whether the provider reported usage at all
+}
+
+// Discrepancy returns the relative difference between the provider-reported prompt
+// tokens and our most accurate pre-flight count. ok is false when either is missing.
+func (r TokenReconciliation) Discrepancy() (ratio float64, ok bool) {
+	ours := r.CountedTokens
+	if ours == 0 {
+		ours = r.EstimatedTokens
+	}
+	if ours == 0 || !r.ProviderReportedTokens {
+		return 0, false
+	}
+	return float64(r.ProviderPromptTokens-ours) / float64(ours), true
+}
+
+// recordTokenEstimate notes our pre-flight counts for a model
+func (o *Orchestrator) recordTokenEstimate(modelName string, estimated, counted int, method string) {
+	entry := o.tokenReconciliation(modelName)
+	entry.EstimatedTokens = estimated
+	entry.CountedTokens = counted
+	entry.CountMethod = method
+}
+
+// recordProviderUsage notes the token usage a provider reported for a model
+func (o *Orchestrator) recordProviderUsage(modelName string, usage *llm.TokenUsage) {
+	if usage == nil {
+		return
+	}
+	entry := o.tokenReconciliation(modelName)
+	entry.ProviderPromptTokens = usage.PromptTokens
+	entry.ProviderOutputTokens = usage.CompletionTokens
+	entry.ProviderReportedTokens = true
+}
+
+// tokenReconciliation returns the mutable record for a model, creating it on first use.
+// Records are only touched from the goroutine driving Run, so no locking is needed.
+func (o *Orchestrator) tokenReconciliation(modelName string) *TokenReconciliation {
+	if o.tokenAccounting == nil {
+		o.tokenAccounting = make(map[string]*TokenReconciliation)
+	}
+	entry, ok := o.tokenAccounting[modelName]
+	if !ok {
+		entry = &TokenReconciliation{ModelName: modelName}
+		o.tokenAccounting[modelName] = entry
+	}
+	return entry
+}
+
+// reconcileTokenUsage writes each model's token accounting to the audit log and
+// warns about models whose provider-reported usage strays far from our counts
+func (o *Orchestrator) reconcileTokenUsage(ctx context.Context, contextLogger logutil.LoggerInterface) {
+	for _, entry := range o.sortedTokenReconciliations() {
+		inputs := map[string]interface{}{
+			"model_name": entry.ModelName,
+		}
+		outputs := map[string]interface{}{
+			"estimated_tokens": entry.EstimatedTokens,
+			"counted_tokens":   entry.CountedTokens,
+			"count_method":     entry.CountMethod,
+		}
+		if entry.ProviderReportedTokens {
+			outputs["provider_prompt_tokens"] = entry.ProviderPromptTokens
+			outputs["provider_completion_tokens"] = entry.ProviderOutputTokens
+		}
+		if ratio, ok := entry.Discrepancy(); ok {
+			outputs["discrepancy_pct"] = math.Round(ratio*1000) / 10
+			if math.Abs(ratio) > tokenDiscrepancyThreshold {
+				contextLogger.WarnContext(ctx, "Token accounting mismatch for %s: %s",
+					entry.ModelName, formatTokenDiscrepancy(entry, ratio))
+			}
+		}
+
+		if logErr := o.auditLogger.LogOp(ctx, "TokenReconciliation", "Success", inputs, outputs, nil); logErr != nil {
+			contextLogger.ErrorContext(ctx, "Failed to write audit log: %v", logErr)
+		}
+	}
+}
+
+// tokenDiscrepancies describes every model whose provider-reported prompt tokens
+// differ from our counts by more than tokenDiscrepancyThreshold
+func (o *Orchestrator) tokenDiscrepancies() []string {
+	var discrepancies []string
+	for _, entry := range o.sortedTokenReconciliations() {
+		if ratio, ok := entry.Discrepancy(); ok && math.Abs(ratio) > tokenDiscrepancyThreshold {
+			discrepancies = append(discrepancies,
+				fmt.Sprintf("%s: %s", entry.ModelName, formatTokenDiscrepancy(entry, ratio)))
+		}
+	}
+	return discrepancies
+}
+
+// sortedTokenReconciliations returns the recorded accounting ordered by model name
+func (o *Orchestrator) sortedTokenReconciliations() []TokenReconciliation {
+	entries := make([]TokenReconciliation, 0, len(o.tokenAccounting))
+	for _, entry := range o.tokenAccounting {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ModelName < entries[j].ModelName })
+	return entries
+}
+
+// formatTokenDiscrepancy renders a reconciliation as "provider reported X prompt tokens, we counted Y (+Z%)"
+func formatTokenDiscrepancy(entry TokenReconciliation, ratio float64) string {
+	ours := entry.CountedTokens
+	if ours == 0 {
+		ours = entry.EstimatedTokens
+	}
+	return fmt.Sprintf("provider reported %s prompt tokens, we counted %s (%+.0f%%)",
+		formatWithCommas(entry.ProviderPromptTokens), formatWithCommas(ours), ratio*100)
+}
@@ -0,0 +1,113 @@
+package orchestrator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/misty-step/thinktank/internal/llm"
+	"github.com/misty-step/thinktank/internal/testutil"
+)
+
+func TestTokenReconciliation_Discrepancy(t *testing.T) {
+	tests := []struct {
+		name      string
+		entry     TokenReconciliation
+		wantRatio float64
+		wantOK    bool
+	}{
+		{
+			name:      "prefers model-specific count",
+			entry:     TokenReconciliation{EstimatedTokens: 500, CountedTokens: 1000, ProviderPromptTokens: 1250, ProviderReportedTokens: true},
+			wantRatio: 0.25,
+			wantOK:    true,
+		},
+		{
+			name:      "falls back to estimate",
+			entry:     TokenReconciliation{EstimatedTokens: 1000, ProviderPromptTokens: 900, ProviderReportedTokens: true},
+			wantRatio: -0.1,
+			wantOK:    true,
+		},
+		{
+			name:   "no provider usage",
+			entry:  TokenReconciliation{EstimatedTokens: 1000, CountedTokens: 1000},
+			wantOK: false,
+		},
+		{
+			name:   "no pre-flight counts",
+			entry:  TokenReconciliation{ProviderPromptTokens: 1000, ProviderReportedTokens: true},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ratio, ok := tt.entry.Discrepancy()
+			if ok != tt.wantOK {
+				t.Fatalf("Discrepancy() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && (ratio < tt.wantRatio-1e-9 || ratio > tt.wantRatio+1e-9) {
+				t.Errorf("Discrepancy() = %v, want %v", ratio, tt.wantRatio)
+			}
+		})
+	}
+}
+
+func TestReconcileTokenUsage(t *testing.T) {
+	auditLogger := NewMockAuditLogger()
+	logger := testutil.NewMockLogger()
+	o := &Orchestrator{auditLogger: auditLogger, logger: logger}
+
+	// model-a is close to our count, model-b is billed far above it, model-c never reported usage
+	o.recordTokenEstimate("model-b", 1000, 1000, "tiktoken")
+	o.recordTokenEstimate("model-a", 1000, 1000, "tiktoken")
+	o.recordTokenEstimate("model-c", 1000, 1000, "estimation")
+	o.recordProviderUsage("model-a", &llm.TokenUsage{PromptTokens: 1050, CompletionTokens: 200, TotalTokens: 1250})
+	o.recordProviderUsage("model-b", &llm.TokenUsage{PromptTokens: 1500, CompletionTokens: 300, TotalTokens: 1800})
+	o.recordProviderUsage("model-c", nil)
+
+	o.reconcileTokenUsage(context.Background(), logger)
+
+	var models []string
+	for _, call := range auditLogger.LogCalls {
+		if call.Operation != "TokenReconciliation" {
+			continue
+		}
+		models = append(models, call.Inputs["model_name"].(string))
+		if call.Inputs["model_name"] == "model-b" {
+			if call.Outputs["provider_prompt_tokens"] != 1500 || call.Outputs["counted_tokens"] != 1000 {
+				t.Errorf("model-b outputs = %v", call.Outputs)
+			}
+			if call.Outputs["discrepancy_pct"] != 50.0 {
+				t.Errorf("model-b discrepancy_pct = %v, want 50", call.Outputs["discrepancy_pct"])
+			}
+		}
+		if call.Inputs["model_name"] == "model-c" {
+			if _, ok := call.Outputs["provider_prompt_tokens"]; ok {
+				t.Errorf("model-c should have no provider usage, got %v", call.Outputs)
+			}
+		}
+	}
+	if got := strings.Join(models, ","); got != "model-a,model-b,model-c" {
+		t.Errorf("audited models = %s, want model-a,model-b,model-c", got)
+	}
+
+	if !logger.ContainsMessage("Token accounting mismatch for model-b") {
+		t.Error("expected a warning for model-b")
+	}
+	if logger.ContainsMessage("Token accounting mismatch for model-a") {
+		t.Error("model-a is within the threshold and should not be flagged")
+	}
+
+	discrepancies := o.tokenDiscrepancies()
+	if len(discrepancies) != 1 || !strings.HasPrefix(discrepancies[0], "model-b: provider reported 1,500 prompt tokens, we counted 1,000 (+50%)") {
+		t.Errorf("tokenDiscrepancies() = %v", discrepancies)
+	}
+}
+
+func TestTokenDiscrepancies_NoAccounting(t *testing.T) {
+	o := &Orchestrator{}
+	if got := o.tokenDiscrepancies(); len(got) != 0 {
+		t.Errorf("tokenDiscrepancies() = %v, want none", got)
+	}
+}
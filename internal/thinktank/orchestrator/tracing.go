@@ -0,0 +1,78 @@
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/misty-step/thinktank/internal/llm"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans the orchestrator emits
+const tracerName = "github.com/misty-step/thinktank/internal/thinktank/orchestrator"
+
+// Span attribute keys for the run and model spans
+const (
+	attrModelCount       = attribute.Key("thinktank.model_count")
+	attrModel            = attribute.Key("thinktank.model")
+	attrProvider         = attribute.Key("thinktank.provider")
+	attrOutcome          = attribute.Key("thinktank.outcome")
+	attrPromptTokens     = attribute.Key("thinktank.tokens.prompt")
+	attrCompletionTokens = attribute.Key("thinktank.tokens.completion")
+	attrTotalTokens      = attribute.Key("thinktank.tokens.total")
+)
+
+// tracer returns the orchestrator's tracer from the global provider, which is a
+// no-op until telemetry.SetupTracing registers one
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// startRunSpan starts the span covering a whole run
+func (o *Orchestrator) startRunSpan(ctx context.Context) (context.Context, trace.Span) {
+	return tracer().Start(ctx, "thinktank.run",
+		trace.WithAttributes(attrModelCount.Int(len(o.runUnits()))))
+}
+
+// startModelSpan starts the child span covering one model's generation, rate limit
+// wait and retries included
+func startModelSpan(ctx context.Context, modelName, provider string) (context.Context, trace.Span) {
+	return tracer().Start(ctx, "thinktank.model",
+		trace.WithAttributes(attrModel.String(modelName), attrProvider.String(provider)))
+}
+
+// endModelSpan records a model's outcome and provider token usage, then ends its span
+func endModelSpan(span trace.Span, result modelResult) {
+	span.SetAttributes(attrOutcome.String(modelOutcome(result.err)))
+	if result.usage != nil {
+		span.SetAttributes(
+			attrPromptTokens.Int(result.usage.PromptTokens),
+			attrCompletionTokens.Int(result.usage.CompletionTokens),
+			attrTotalTokens.Int(result.usage.TotalTokens),
+		)
+	}
+	endSpan(span, result.err)
+}
+
+// modelOutcome classifies a model's result as "success", "failed", or "rate_limited"
+func modelOutcome(err error) string {
+	switch {
+	case err == nil:
+		return "success"
+	case llm.IsRateLimit(err):
+		return "rate_limited"
+	default:
+		return "failed"
+	}
+}
+
+// endSpan records err on span, marking it failed, then ends the span
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
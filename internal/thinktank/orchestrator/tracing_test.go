@@ -0,0 +1,116 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/misty-step/thinktank/internal/config"
+	"github.com/misty-step/thinktank/internal/llm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans registers a global tracer provider that records ended spans for the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+// spanAttributes indexes a span's attributes by key
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestModelSpanIsChildOfRunSpan(t *testing.T) {
+	recorder := recordSpans(t)
+	orch := &Orchestrator{config: &config.CliConfig{ModelNames: []string{"gpt-5.2", "o4-mini"}}}
+
+	ctx, runSpan := orch.startRunSpan(context.Background())
+	_, modelSpan := startModelSpan(ctx, "gpt-5.2", "openrouter")
+	endModelSpan(modelSpan, modelResult{modelName: "gpt-5.2"})
+	endSpan(runSpan, nil)
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 2)
+	model, run := ended[0], ended[1]
+	assert.Equal(t, "thinktank.run", run.Name())
+	assert.Equal(t, int64(2), spanAttributes(run)[attrModelCount].AsInt64())
+	assert.Equal(t, "thinktank.model", model.Name())
+	assert.Equal(t, run.SpanContext().SpanID(), model.Parent().SpanID())
+	assert.Equal(t, codes.Unset, run.Status().Code)
+}
+
+func TestEndModelSpan(t *testing.T) {
+	tests := []struct {
+		name        string
+		result      modelResult
+		wantOutcome string
+		wantTokens  bool
+		wantError   bool
+	}{
+		{
+			name:        "success with usage",
+			result:      modelResult{content: "ok", usage: &llm.TokenUsage{PromptTokens: 120, CompletionTokens: 30, TotalTokens: 150}},
+			wantOutcome: "success",
+			wantTokens:  true,
+		},
+		{
+			name:        "failure",
+			result:      modelResult{err: errors.New("boom")},
+			wantOutcome: "failed",
+			wantError:   true,
+		},
+		{
+			name:        "rate limited",
+			result:      modelResult{err: llm.Wrap(errors.New("429"), "openrouter", "too many requests", llm.CategoryRateLimit)},
+			wantOutcome: "rate_limited",
+			wantError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := recordSpans(t)
+
+			_, span := startModelSpan(context.Background(), "gpt-5.2", "openrouter")
+			endModelSpan(span, tt.result)
+
+			ended := recorder.Ended()
+			require.Len(t, ended, 1)
+			attrs := spanAttributes(ended[0])
+			assert.Equal(t, "gpt-5.2", attrs[attrModel].AsString())
+			assert.Equal(t, "openrouter", attrs[attrProvider].AsString())
+			assert.Equal(t, tt.wantOutcome, attrs[attrOutcome].AsString())
+
+			if tt.wantTokens {
+				assert.Equal(t, int64(120), attrs[attrPromptTokens].AsInt64())
+				assert.Equal(t, int64(30), attrs[attrCompletionTokens].AsInt64())
+				assert.Equal(t, int64(150), attrs[attrTotalTokens].AsInt64())
+			} else {
+				assert.NotContains(t, attrs, attrTotalTokens)
+			}
+
+			if tt.wantError {
+				assert.Equal(t, codes.Error, ended[0].Status().Code)
+				require.Len(t, ended[0].Events(), 1)
+				assert.Equal(t, "exception", ended[0].Events()[0].Name)
+			} else {
+				assert.Equal(t, codes.Unset, ended[0].Status().Code)
+			}
+		})
+	}
+}
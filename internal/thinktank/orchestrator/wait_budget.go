@@ -0,0 +1,125 @@
+package orchestrator
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// waitBudget bounds the cumulative time models spend waiting on rate limiters.
+// Once the budget is spent, it aborts the remaining model processing so a
+// throttled run fails fast instead of hanging. A nil waitBudget is unlimited.
+//
+// Waits still in progress count against the budget as they elapse, so models
+// waiting at the same time share what is left instead of each getting all of it.
+type waitBudget struct {
+	limit     time.Duration
+	abort     context.CancelFunc
+	abortOnce sync.Once
+
+	mu      sync.Mutex
+	waited  time.Duration     // Completed waits, summed across models
+	waiting map[int]time.Time // Start of each wait still in progress, by id
+	nextID  int
+	changed chan struct{} // Closed and replaced whenever a wait starts or ends
+}
+
+// newWaitBudget returns a budget that cancels abort once exhausted, or nil when limit is 0
+func newWaitBudget(limit time.Duration, abort context.CancelFunc) *waitBudget {
+	if limit <= 0 {
+		return nil
+	}
+	return &waitBudget{
+		limit:   limit,
+		abort:   abort,
+		waiting: make(map[int]time.Time),
+		changed: make(chan struct{}),
+	}
+}
+
+// remaining returns the wait time left in the budget
+func (b *waitBudget) remaining() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.remainingLocked(time.Now())
+}
+
+// remainingLocked returns the budget left at now, charging waits in progress
+func (b *waitBudget) remainingLocked(now time.Time) time.Duration {
+	left := b.limit - b.waited
+	for _, start := range b.waiting {
+		left -= now.Sub(start)
+	}
+	return left
+}
+
+// notifyLocked wakes the watchers so they recompute their deadlines
+func (b *waitBudget) notifyLocked() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+// exhaust aborts the remaining model processing; safe to call more than once
+func (b *waitBudget) exhaust() {
+	b.abortOnce.Do(b.abort)
+}
+
+// acquireContext bounds a rate limiter wait by the remaining budget. The
+// returned context is cancelled as soon as the budget runs out, counting the
+// other waits in progress. The returned function ends the wait, charges its
+// duration to the budget, and must always be called.
+func (b *waitBudget) acquireContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if b == nil {
+		return ctx, func() {}
+	}
+
+	waitCtx, cancel := context.WithCancel(ctx)
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.waiting[id] = time.Now()
+	b.notifyLocked()
+	b.mu.Unlock()
+
+	go b.watch(waitCtx, cancel)
+
+	var once sync.Once
+	return waitCtx, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			b.waited += time.Since(b.waiting[id])
+			delete(b.waiting, id)
+			b.notifyLocked()
+			b.mu.Unlock()
+			cancel()
+		})
+	}
+}
+
+// watch cancels a wait once the budget is spent. While n waits are in
+// progress the budget drains n times faster than the clock, so the deadline
+// is recomputed whenever a wait starts or ends.
+func (b *waitBudget) watch(ctx context.Context, cancel context.CancelFunc) {
+	for {
+		b.mu.Lock()
+		left := b.remainingLocked(time.Now())
+		waiters := time.Duration(max(len(b.waiting), 1))
+		changed := b.changed
+		b.mu.Unlock()
+
+		if left <= 0 {
+			cancel()
+			return
+		}
+
+		timer := time.NewTimer(left / waiters)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-changed:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
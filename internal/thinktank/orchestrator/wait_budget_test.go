@@ -0,0 +1,175 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/misty-step/thinktank/internal/config"
+	"github.com/misty-step/thinktank/internal/llm"
+	"github.com/misty-step/thinktank/internal/metrics"
+	"github.com/misty-step/thinktank/internal/ratelimit"
+	"github.com/misty-step/thinktank/internal/testutil"
+)
+
+func TestWaitBudget_Unlimited(t *testing.T) {
+	budget := newWaitBudget(0, func() {})
+	if budget != nil {
+		t.Fatalf("newWaitBudget(0) = %v, want nil", budget)
+	}
+
+	// A nil budget must not bound waits
+	ctx := context.Background()
+	acquireCtx, endWait := budget.acquireContext(ctx)
+	defer endWait()
+	if acquireCtx != ctx {
+		t.Error("nil budget should return the parent context")
+	}
+}
+
+func TestWaitBudget_ChargesCompletedWaits(t *testing.T) {
+	budget := newWaitBudget(time.Hour, func() {})
+
+	_, endWait := budget.acquireContext(context.Background())
+	time.Sleep(20 * time.Millisecond)
+	endWait()
+	endWait() // Ending a wait twice charges it once
+
+	if left := budget.remaining(); left > time.Hour-20*time.Millisecond || left < time.Hour-time.Second {
+		t.Errorf("remaining() = %v, want about an hour less the 20ms wait", left)
+	}
+}
+
+func TestWaitBudget_ConcurrentWaitsShareTheBudget(t *testing.T) {
+	const limit = 400 * time.Millisecond
+	budget := newWaitBudget(limit, func() {})
+
+	// Two waits that never finish on their own drain the budget together,
+	// so both are cut off after about half the limit
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			waitCtx, endWait := budget.acquireContext(context.Background())
+			defer endWait()
+			<-waitCtx.Done()
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed >= limit {
+		t.Errorf("concurrent waits ran for %v; together they may not exceed the %v budget", elapsed, limit)
+	}
+	if left := budget.remaining(); left > 50*time.Millisecond {
+		t.Errorf("remaining() = %v, want the budget spent", left)
+	}
+
+	// Once spent, a new wait is cut off at once
+	waitCtx, endWait := budget.acquireContext(context.Background())
+	defer endWait()
+	select {
+	case <-waitCtx.Done():
+	case <-time.After(time.Second):
+		t.Error("a wait started after the budget ran out should end immediately")
+	}
+}
+
+func TestProcessModelWithRateLimit_WaitBudgetExceeded(t *testing.T) {
+	const modelName = "unregistered-test-model" // Unknown models use the global limiter
+
+	// One request per minute, with the only token already spent
+	rateLimiter := ratelimit.NewRateLimiter(0, 1)
+	if err := rateLimiter.Acquire(context.Background(), modelName); err != nil {
+		t.Fatalf("priming acquire failed: %v", err)
+	}
+
+	o := &Orchestrator{
+		rateLimiter:   rateLimiter,
+		config:        &config.CliConfig{},
+		logger:        testutil.NewMockLogger(),
+		consoleWriter: &MockConsoleWriter{},
+	}
+
+	ctx, abort := context.WithCancel(context.Background())
+	defer abort()
+	budget := newWaitBudget(20*time.Millisecond, abort)
+
+	var wg sync.WaitGroup
+	resultChan := make(chan modelResult, 1)
+	wg.Add(1)
+	start := time.Now()
+	o.processModelWithRateLimit(ctx, modelName, "prompt", 1, budget, &wg, resultChan)
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("waited %v despite a 20ms budget", elapsed)
+	}
+
+	result := <-resultChan
+	if !errors.Is(result.err, ErrRateLimitWaitBudgetExceeded) {
+		t.Fatalf("error = %v, want ErrRateLimitWaitBudgetExceeded", result.err)
+	}
+	if catErr, ok := llm.IsCategorizedError(result.err); !ok || catErr.Category() != llm.CategoryRateLimit {
+		t.Errorf("error should be categorized as rate limit, got %v", result.err)
+	}
+	if ctx.Err() == nil {
+		t.Error("exhausting the budget should abort remaining model processing")
+	}
+}
+
+// retryAfterAPIService fails generation with a rate limit error carrying Retry-After
+type retryAfterAPIService struct {
+	MockAPIService
+	retryAfter time.Duration
+}
+
+func (m *retryAfterAPIService) InitLLMClient(ctx context.Context, apiKey, modelName, apiEndpoint string) (llm.LLMClient, error) {
+	return &retryAfterLLMClient{retryAfter: m.retryAfter}, nil
+}
+
+type retryAfterLLMClient struct {
+	MockLLMClient
+	retryAfter time.Duration
+}
+
+func (c *retryAfterLLMClient) GenerateContent(ctx context.Context, prompt string, params map[string]interface{}) (*llm.ProviderResult, error) {
+	return nil, &llm.LLMError{Message: "rate limited", ErrorCategory: llm.CategoryRateLimit, RetryAfter: c.retryAfter}
+}
+
+func TestProcessModelWithRateLimit_HonorsRetryAfter(t *testing.T) {
+	const modelName = "unregistered-test-model"
+
+	rateLimiter := ratelimit.NewRateLimiter(0, 0)
+	o := &Orchestrator{
+		apiService:       &retryAfterAPIService{retryAfter: time.Minute},
+		fileWriter:       &MockFileWriter{},
+		auditLogger:      NewMockAuditLogger(),
+		rateLimiter:      rateLimiter,
+		config:           &config.CliConfig{},
+		logger:           testutil.NewMockLogger(),
+		consoleWriter:    &MockConsoleWriter{},
+		metricsCollector: metrics.NewNoopCollector(),
+	}
+
+	var wg sync.WaitGroup
+	resultChan := make(chan modelResult, 1)
+	wg.Add(1)
+	o.processModelWithRateLimit(context.Background(), modelName, "prompt", 1, nil, &wg, resultChan)
+
+	if result := <-resultChan; result.err == nil {
+		t.Fatal("expected the model to fail")
+	}
+
+	// The model is paused for the provider's Retry-After; other models are not
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	if err := rateLimiter.Acquire(ctx, modelName); err == nil {
+		t.Error("acquire for the rate limited model should wait out the Retry-After")
+	}
+	if err := rateLimiter.Acquire(context.Background(), "other-model"); err != nil {
+		t.Errorf("other models should not be paused: %v", err)
+	}
+}
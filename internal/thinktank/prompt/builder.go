@@ -0,0 +1,196 @@
+package prompt
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/misty-step/thinktank/internal/fileutil"
+)
+
+// Order arranges the instructions and context files in a prompt
+type Order string
+
+const (
+	// OrderDefault puts the instructions first, then the files in gather order
+	OrderDefault Order = ""
+	// OrderInstructionsLast puts the files first and the instructions after them
+	OrderInstructionsLast Order = "instructions-last"
+	// OrderByDirectory puts the instructions first, then the files grouped by
+	// directory in name order, keeping gather order within each directory
+	OrderByDirectory Order = "by-directory"
+)
+
+// Orders lists the orders accepted by ParseOrder
+var Orders = []Order{OrderInstructionsLast, OrderByDirectory}
+
+// ParseOrder converts a --prompt-order value into an Order.
+// An empty string or "default" selects OrderDefault.
+func ParseOrder(value string) (Order, error) {
+	order := Order(strings.ToLower(strings.TrimSpace(value)))
+	if order == OrderDefault || order == "default" {
+		return OrderDefault, nil
+	}
+	for _, known := range Orders {
+		if order == known {
+			return order, nil
+		}
+	}
+	names := []string{"default"}
+	for _, known := range Orders {
+		names = append(names, string(known))
+	}
+	return "", fmt.Errorf("unknown prompt order %q (use %s)", value, strings.Join(names, ", "))
+}
+
+// DefaultFileSeparator is placed between context files when no other is set
+const DefaultFileSeparator = "\n\n"
+
+// ParseFileSeparator converts a --file-separator value into the separator, decoding
+// Go escape sequences such as \n, \t, and \u2500. It must not be empty.
+func ParseFileSeparator(value string) (string, error) {
+	var sb strings.Builder
+	for rest := value; rest != ""; {
+		r, multibyte, tail, err := strconv.UnquoteChar(rest, 0)
+		if err != nil {
+			return "", fmt.Errorf("invalid escape sequence in %q", value)
+		}
+		if multibyte {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteByte(byte(r))
+		}
+		rest = tail
+	}
+	if sb.Len() == 0 {
+		return "", fmt.Errorf("separator must not be empty")
+	}
+	return sb.String(), nil
+}
+
+// PromptBuilder assembles the prompt sent to each model from the instructions and
+// the gathered context files. The zero value builds exactly what StitchPrompt
+// always has.
+type PromptBuilder struct {
+	Order     Order // Arrangement of instructions and files (zero value = OrderDefault)
+	FenceCode bool  // Wrap each file's content in a fenced code block tagged with its language
+
+	// FileSeparator is placed between context files (empty = DefaultFileSeparator).
+	// The last file is always followed by DefaultFileSeparator before </context>.
+	FileSeparator string
+}
+
+// Build returns the prompt for instructions and contextFiles
+func (b PromptBuilder) Build(instructions string, contextFiles []fileutil.FileMeta) string {
+	var sb strings.Builder
+
+	if b.Order == OrderInstructionsLast {
+		b.writeContext(&sb, contextFiles)
+		sb.WriteString("\n")
+		writeInstructions(&sb, instructions)
+		return strings.TrimSuffix(sb.String(), "\n")
+	}
+
+	if b.Order == OrderByDirectory {
+		contextFiles = groupByDirectory(contextFiles)
+	}
+	writeInstructions(&sb, instructions)
+	b.writeContext(&sb, contextFiles)
+	return sb.String()
+}
+
+// writeInstructions writes the instructions block, ending with a newline
+func writeInstructions(sb *strings.Builder, instructions string) {
+	sb.WriteString("<instructions>\n")
+	if instructions != "" {
+		sb.WriteString(instructions)
+		sb.WriteString("\n")
+	}
+	sb.WriteString("</instructions>\n")
+}
+
+// writeContext writes the context block, without a trailing newline
+func (b PromptBuilder) writeContext(sb *strings.Builder, contextFiles []fileutil.FileMeta) {
+	sb.WriteString("<context>\n")
+	for i, file := range contextFiles {
+		if i > 0 {
+			sb.WriteString(b.separator())
+		}
+		b.writeFile(sb, file)
+	}
+	if len(contextFiles) > 0 {
+		sb.WriteString(DefaultFileSeparator)
+	}
+	sb.WriteString("</context>")
+}
+
+// FileEntry returns file as it appears in the context block of a built prompt:
+// its <path> header and content, followed by the file separator. Summing entries
+// estimates the context without building the whole prompt.
+func (b PromptBuilder) FileEntry(file fileutil.FileMeta) string {
+	var sb strings.Builder
+	b.writeFile(&sb, file)
+	sb.WriteString(b.separator())
+	return sb.String()
+}
+
+// separator returns the text placed between context files
+func (b PromptBuilder) separator() string {
+	if b.FileSeparator == "" {
+		return DefaultFileSeparator
+	}
+	return b.FileSeparator
+}
+
+// writeFile writes a file's <path> header line and its content
+func (b PromptBuilder) writeFile(sb *strings.Builder, file fileutil.FileMeta) {
+	sb.WriteString("<path>")
+	sb.WriteString(file.Path)
+	sb.WriteString("</path>\n")
+
+	if b.FenceCode {
+		writeFenced(sb, file)
+	} else {
+		sb.WriteString(EscapeContent(file.Content))
+	}
+}
+
+// writeFenced writes a file's content as a fenced code block tagged with the
+// language for its extension, under the <path> header line. The fence is longer
+// than any backtick run in the content, so embedded fences can't close it early.
+func writeFenced(sb *strings.Builder, file fileutil.FileMeta) {
+	fence := strings.Repeat("`", max(3, longestBacktickRun(file.Content)+1))
+	sb.WriteString(fence)
+	sb.WriteString(fileutil.LanguageForPath(file.Path))
+	sb.WriteString("\n")
+	sb.WriteString(file.Content)
+	if !strings.HasSuffix(file.Content, "\n") {
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fence)
+}
+
+func longestBacktickRun(content string) int {
+	longest, run := 0, 0
+	for _, r := range content {
+		if r != '`' {
+			run = 0
+			continue
+		}
+		run++
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// groupByDirectory returns the files sorted by directory; the sort is stable, so
+// files in the same directory keep their gather order
+func groupByDirectory(contextFiles []fileutil.FileMeta) []fileutil.FileMeta {
+	grouped := append([]fileutil.FileMeta(nil), contextFiles...)
+	sort.SliceStable(grouped, func(i, j int) bool {
+		return filepath.Dir(grouped[i].Path) < filepath.Dir(grouped[j].Path)
+	})
+	return grouped
+}
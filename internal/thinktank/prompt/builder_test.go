@@ -0,0 +1,191 @@
+package prompt_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/misty-step/thinktank/internal/fileutil"
+	"github.com/misty-step/thinktank/internal/thinktank/prompt"
+)
+
+func TestPromptBuilderBuild(t *testing.T) {
+	files := []fileutil.FileMeta{
+		{Path: "src/main.go", Content: "package main"},
+		{Path: "docs/guide.md", Content: "# Guide\n"},
+		{Path: "src/util.py", Content: "x = 1"},
+	}
+
+	tests := []struct {
+		name    string
+		builder prompt.PromptBuilder
+		want    string
+	}{
+		{
+			name:    "zero value keeps the default layout",
+			builder: prompt.PromptBuilder{},
+			want: "<instructions>\nDo it\n</instructions>\n<context>\n" +
+				"<path>src/main.go</path>\npackage main\n\n" +
+				"<path>docs/guide.md</path>\n# Guide\n\n\n" +
+				"<path>src/util.py</path>\nx = 1\n\n" +
+				"</context>",
+		},
+		{
+			name:    "instructions last",
+			builder: prompt.PromptBuilder{Order: prompt.OrderInstructionsLast},
+			want: "<context>\n" +
+				"<path>src/main.go</path>\npackage main\n\n" +
+				"<path>docs/guide.md</path>\n# Guide\n\n\n" +
+				"<path>src/util.py</path>\nx = 1\n\n" +
+				"</context>\n<instructions>\nDo it\n</instructions>",
+		},
+		{
+			name:    "grouped by directory",
+			builder: prompt.PromptBuilder{Order: prompt.OrderByDirectory},
+			want: "<instructions>\nDo it\n</instructions>\n<context>\n" +
+				"<path>docs/guide.md</path>\n# Guide\n\n\n" +
+				"<path>src/main.go</path>\npackage main\n\n" +
+				"<path>src/util.py</path>\nx = 1\n\n" +
+				"</context>",
+		},
+		{
+			name:    "fenced by extension",
+			builder: prompt.PromptBuilder{FenceCode: true},
+			want: "<instructions>\nDo it\n</instructions>\n<context>\n" +
+				"<path>src/main.go</path>\n```go\npackage main\n```\n\n" +
+				"<path>docs/guide.md</path>\n```markdown\n# Guide\n```\n\n" +
+				"<path>src/util.py</path>\n```python\nx = 1\n```\n\n" +
+				"</context>",
+		},
+		{
+			name:    "custom file separator",
+			builder: prompt.PromptBuilder{FileSeparator: "\n---\n"},
+			want: "<instructions>\nDo it\n</instructions>\n<context>\n" +
+				"<path>src/main.go</path>\npackage main\n---\n" +
+				"<path>docs/guide.md</path>\n# Guide\n\n---\n" +
+				"<path>src/util.py</path>\nx = 1\n\n" +
+				"</context>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.builder.Build("Do it", files)
+			if got != tt.want {
+				t.Errorf("Build() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromptBuilderMatchesStitchPrompt(t *testing.T) {
+	files := []fileutil.FileMeta{{Path: "a.txt", Content: "alpha"}, {Path: "b/c.txt", Content: ""}}
+	for _, instructions := range []string{"", "Review this"} {
+		if got, want := (prompt.PromptBuilder{}).Build(instructions, files), prompt.StitchPrompt(instructions, files); got != want {
+			t.Errorf("Build(%q) = %q, want StitchPrompt output %q", instructions, got, want)
+		}
+	}
+}
+
+func TestPromptBuilderDefaultFileSeparatorUnchanged(t *testing.T) {
+	files := []fileutil.FileMeta{{Path: "a.txt", Content: "alpha"}, {Path: "b/c.txt", Content: "beta"}}
+
+	want := "<instructions>\nReview this\n</instructions>\n<context>\n" +
+		"<path>a.txt</path>\nalpha\n\n" +
+		"<path>b/c.txt</path>\nbeta\n\n" +
+		"</context>"
+	for _, builder := range []prompt.PromptBuilder{{}, {FileSeparator: prompt.DefaultFileSeparator}} {
+		if got := builder.Build("Review this", files); got != want {
+			t.Errorf("Build() with FileSeparator %q = %q, want %q", builder.FileSeparator, got, want)
+		}
+	}
+}
+
+func TestParseFileSeparator(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: `\n\n`, want: "\n\n"},
+		{value: `\n---\n`, want: "\n---\n"},
+		{value: `\t|`, want: "\t|"},
+		{value: `\u2500\u2500`, want: "──"},
+		{value: `"quoted"`, want: `"quoted"`},
+		{value: `===`, want: "==="},
+		{value: `\q`, wantErr: true},
+		{value: ``, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := prompt.ParseFileSeparator(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFileSeparator(%q) = %q, %v; want %q, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestPromptBuilderFenceOutlastsEmbeddedBackticks(t *testing.T) {
+	files := []fileutil.FileMeta{{Path: "README", Content: "Example:\n```sh\nmake\n```\n"}}
+
+	got := prompt.PromptBuilder{FenceCode: true}.Build("", files)
+
+	want := "<path>README</path>\n````\nExample:\n```sh\nmake\n```\n````\n\n"
+	if !strings.Contains(got, want) {
+		t.Errorf("Build() = %q, want a four-backtick fence around the file: %q", got, want)
+	}
+}
+
+func TestParseOrder(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    prompt.Order
+		wantErr bool
+	}{
+		{value: "", want: prompt.OrderDefault},
+		{value: "default", want: prompt.OrderDefault},
+		{value: "instructions-last", want: prompt.OrderInstructionsLast},
+		{value: " By-Directory ", want: prompt.OrderByDirectory},
+		{value: "random", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := prompt.ParseOrder(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseOrder(%q) = %q, %v; want %q, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+		if err != nil && !strings.Contains(err.Error(), "default, instructions-last, by-directory") {
+			t.Errorf("error should list the valid orders, got %q", err)
+		}
+	}
+}
+
+func TestPromptBuilderFenceLanguages(t *testing.T) {
+	files := []fileutil.FileMeta{
+		{Path: "web/App.TSX", Content: "export {}"},
+		{Path: "Makefile", Content: "all:"},
+		{Path: "LICENSE", Content: "MIT"},
+	}
+
+	got := prompt.PromptBuilder{FenceCode: true}.Build("", files)
+
+	for _, want := range []string{"<path>web/App.TSX</path>\n```tsx\n", "<path>Makefile</path>\n```makefile\nall:\n```", "<path>LICENSE</path>\n```\nMIT\n```"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Build() = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestPromptBuilderFileEntryMatchesBuild(t *testing.T) {
+	files := []fileutil.FileMeta{
+		{Path: "main.go", Content: "package main"},
+		{Path: "README.md", Content: "# Title"},
+	}
+
+	for _, b := range []prompt.PromptBuilder{{}, {FenceCode: true}, {FileSeparator: "\n----\n"}} {
+		built := b.Build("", files)
+		// Every file but the last is followed by the builder's separator
+		if entry := b.FileEntry(files[0]); !strings.Contains(built, entry) {
+			t.Errorf("Build() = %q, want it to contain FileEntry() = %q", built, entry)
+		}
+	}
+}
@@ -18,33 +18,10 @@ func EscapeContent(content string) string {
 	return content
 }
 
-// StitchPrompt combines instructions and file context into the final prompt string with XML-like tags
+// StitchPrompt combines instructions and file context into the final prompt string with XML-like tags.
+// It is the default PromptBuilder layout.
 func StitchPrompt(instructions string, contextFiles []fileutil.FileMeta) string {
-	var sb strings.Builder
-
-	// Add instructions block
-	sb.WriteString("<instructions>\n")
-	if instructions != "" {
-		sb.WriteString(instructions)
-		sb.WriteString("\n")
-	}
-	sb.WriteString("</instructions>\n")
-
-	// Add context block
-	sb.WriteString("<context>\n")
-	for _, file := range contextFiles {
-		// Add file path tag
-		sb.WriteString("<path>")
-		sb.WriteString(file.Path)
-		sb.WriteString("</path>\n")
-
-		// Add file content with escaping
-		sb.WriteString(EscapeContent(file.Content))
-		sb.WriteString("\n\n")
-	}
-	sb.WriteString("</context>")
-
-	return sb.String()
+	return PromptBuilder{}.Build(instructions, contextFiles)
 }
 
 // StitchSynthesisPrompt combines original instructions and multiple model outputs
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/misty-step/thinktank/internal/llm"
 	"github.com/misty-step/thinktank/internal/logutil"
@@ -18,15 +19,42 @@ import (
 
 // registryAPIService implements the APIService interface using the models package
 type registryAPIService struct {
-	logger logutil.LoggerInterface
+	logger      logutil.LoggerInterface
+	baseURLs    map[string]string         // Per-provider base URL overrides, keyed by provider name
+	modelParams map[string]map[string]any // Per-model parameter overrides, keyed by canonical model name
+}
+
+// RegistryAPIServiceOptions configures NewRegistryAPIServiceWithOptions
+type RegistryAPIServiceOptions struct {
+	// BaseURLs sends each provider's requests to a custom base URL, keyed by provider name
+	BaseURLs map[string]string
+
+	// ModelParams overrides model parameters, keyed by canonical model name and then
+	// parameter name. Parameters not listed keep the model's defaults.
+	ModelParams map[string]map[string]any
 }
 
 // NewRegistryAPIService creates a new models-based API service
 // This implementation uses the models package for model and provider information,
 // providing a simplified approach with hardcoded model definitions.
 func NewRegistryAPIService(logger logutil.LoggerInterface) interfaces.APIService {
+	return NewRegistryAPIServiceWithBaseURLs(logger, nil)
+}
+
+// NewRegistryAPIServiceWithBaseURLs creates a models-based API service that sends
+// each provider's requests to the base URL given for it in baseURLs, such as a
+// self-hosted gateway or proxy. Providers without an entry use their default.
+func NewRegistryAPIServiceWithBaseURLs(logger logutil.LoggerInterface, baseURLs map[string]string) interfaces.APIService {
+	return NewRegistryAPIServiceWithOptions(logger, RegistryAPIServiceOptions{BaseURLs: baseURLs})
+}
+
+// NewRegistryAPIServiceWithOptions creates a models-based API service with custom
+// provider base URLs and per-model parameter overrides
+func NewRegistryAPIServiceWithOptions(logger logutil.LoggerInterface, options RegistryAPIServiceOptions) interfaces.APIService {
 	return &registryAPIService{
-		logger: logger,
+		logger:      logger,
+		baseURLs:    options.BaseURLs,
+		modelParams: options.ModelParams,
 	}
 }
 
@@ -64,8 +92,18 @@ func (s *registryAPIService) InitLLMClient(ctx context.Context, apiKey, modelNam
 	providerName := modelInfo.Provider
 	s.logger.DebugContext(ctx, "Model '%s' uses provider '%s'", modelName, providerName)
 
-	// Determine which API endpoint to use
+	// Determine which API endpoint to use: the caller's, then the provider's
+	// configured base URL, then the provider default
 	effectiveEndpoint := apiEndpoint
+	if effectiveEndpoint == "" && s.baseURLs[providerName] != "" {
+		effectiveEndpoint = s.baseURLs[providerName]
+		s.logger.DebugContext(ctx, "Using configured base URL for provider '%s': %s",
+			providerName, openrouterprovider.SanitizeURL(effectiveEndpoint))
+	}
+	if effectiveEndpoint == "" && modelInfo.BaseURL != "" {
+		// Models registered with their own base URL, such as custom provider models
+		effectiveEndpoint = modelInfo.BaseURL
+	}
 	if effectiveEndpoint == "" {
 		// Set provider-specific base URLs
 		switch providerName {
@@ -80,6 +118,8 @@ func (s *registryAPIService) InitLLMClient(ctx context.Context, apiKey, modelNam
 	switch providerName {
 	case "openrouter":
 		providerImpl = openrouterprovider.NewProvider(s.logger)
+	case models.CustomProvider:
+		providerImpl = openrouterprovider.NewCompatibleProvider(s.logger)
 	default:
 		return nil, llm.Wrap(
 			fmt.Errorf("unsupported provider: %s", providerName),
@@ -93,7 +133,8 @@ func (s *registryAPIService) InitLLMClient(ctx context.Context, apiKey, modelNam
 	// ------------------------
 	// The system follows this precedence order for API keys:
 	// 1. Environment variables specific to each provider (highest priority)
-	//    - For OpenRouter: OPENROUTER_API_KEY (only supported provider)
+	//    - For OpenRouter: OPENROUTER_API_KEY
+	//    - For custom models: the variable given when the model was registered
 	// 2. Explicitly provided API key parameter (fallback only)
 	//
 	// After provider consolidation, only OpenRouter is supported. All models
@@ -104,7 +145,7 @@ func (s *registryAPIService) InitLLMClient(ctx context.Context, apiKey, modelNam
 
 	// STEP 1: First try to get the key from environment variable based on provider
 	// This is the recommended and preferred method for providing API keys
-	envVar := models.GetAPIKeyEnvVar(providerName)
+	envVar := models.GetAPIKeyEnvVarForModel(modelName)
 	if envVar != "" {
 		envApiKey := os.Getenv(envVar) // TODO: Replace with cached lookup for startup performance
 		if envApiKey != "" {
@@ -125,7 +166,7 @@ func (s *registryAPIService) InitLLMClient(ctx context.Context, apiKey, modelNam
 	// STEP 3: If no API key is available from either source, reject the request
 	// API keys are required for all providers
 	if effectiveApiKey == "" {
-		envVarName := models.GetAPIKeyEnvVar(providerName)
+		envVarName := models.GetAPIKeyEnvVarForModel(modelName)
 		return nil, fmt.Errorf("%w: API key is required for model '%s' with provider '%s'. Please set the %s environment variable",
 			llm.ErrClientInitialization, modelName, providerName, envVarName)
 	}
@@ -159,11 +200,95 @@ func (s *registryAPIService) InitLLMClient(ctx context.Context, apiKey, modelNam
 	return client, nil
 }
 
+// Preflight runs a lightweight check against each provider used by modelNames, one
+// model per provider and all providers concurrently, so a missing key or an outage
+// fails the run before any context is gathered. Models that aren't in the registry
+// are skipped; they are reported when the run initializes their clients. Custom
+// provider models each have their own endpoint and key, so every one is checked.
+// Failures are joined in provider order.
+func (s *registryAPIService) Preflight(ctx context.Context, modelNames []string) error {
+	type check struct{ providerName, modelName string }
+	var checks []check
+	seen := make(map[string]bool)
+	for _, modelName := range modelNames {
+		providerName, err := models.GetProviderForModel(modelName)
+		if err != nil {
+			continue
+		}
+		key := providerName
+		if providerName == models.CustomProvider {
+			key = providerName + "/" + modelName
+		}
+		if !seen[key] {
+			seen[key] = true
+			checks = append(checks, check{providerName, modelName})
+		}
+	}
+
+	errs := make([]error, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c check) {
+			defer wg.Done()
+			errs[i] = s.preflightProvider(ctx, c.providerName, c.modelName)
+		}(i, c)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// preflightProvider checks one provider by creating a client for modelName and,
+// if the client supports it, asking it to confirm the API key with the provider
+func (s *registryAPIService) preflightProvider(ctx context.Context, providerName, modelName string) error {
+	client, err := s.InitLLMClient(ctx, "", modelName, "")
+	if err != nil {
+		// Client creation fails before any request is made, almost always for a missing API key
+		return preflightError(err, providerName, llm.CategoryAuth)
+	}
+	defer func() { _ = client.Close() }()
+
+	// A custom endpoint has no standard key check, so creating the client, which
+	// needs the key to be set, is all that can be confirmed without generating
+	if providerName == models.CustomProvider {
+		s.logger.DebugContext(ctx, "Model '%s' uses a custom endpoint; skipping its health check", modelName)
+		return nil
+	}
+
+	checker, ok := client.(llm.HealthChecker)
+	if !ok {
+		s.logger.DebugContext(ctx, "Provider '%s' has no preflight check; skipping", providerName)
+		return nil
+	}
+	if err := checker.CheckHealth(ctx); err != nil {
+		return preflightError(err, providerName, llm.CategoryNetwork)
+	}
+	s.logger.DebugContext(ctx, "Provider '%s' passed preflight check", providerName)
+	return nil
+}
+
+// preflightError names the provider in a preflight failure, keeping err's category
+// when it has one and using fallback otherwise
+func preflightError(err error, providerName string, fallback llm.ErrorCategory) error {
+	message := fmt.Sprintf("preflight check failed for provider '%s'", providerName)
+	category := fallback
+	var llmErr *llm.LLMError
+	if errors.As(err, &llmErr) {
+		message = fmt.Sprintf("%s: %s", message, llmErr.Message)
+	}
+	if catErr, ok := llm.IsCategorizedError(err); ok && catErr.Category() != llm.CategoryUnknown {
+		category = catErr.Category()
+	}
+	return llm.Wrap(err, providerName, message, category)
+}
+
 // The remaining methods are carried over from the existing APIService implementation
 // since they don't depend on the provider initialization logic
 
 // GetModelParameters retrieves parameter values for a given model
-// It returns a map of parameter name to parameter value, applying defaults from the model definition
+// It returns a map of parameter name to parameter value: the defaults from the model
+// definition, with any overrides configured for the model applied on top
 func (s *registryAPIService) GetModelParameters(ctx context.Context, modelName string) (map[string]interface{}, error) {
 	// Look up the model in the models package
 	modelInfo, err := models.GetModelInfo(modelName)
@@ -172,16 +297,19 @@ func (s *registryAPIService) GetModelParameters(ctx context.Context, modelName s
 		return make(map[string]interface{}), llm.Wrap(err, "", fmt.Sprintf("model '%s' not supported", modelName), llm.CategoryInvalidRequest)
 	}
 
-	// Return the default parameters from the model info
-	if modelInfo.DefaultParams == nil {
-		return make(map[string]interface{}), nil
-	}
-
 	// Create a copy of the default parameters map to avoid modifying the original
 	params := make(map[string]interface{})
 	for key, value := range modelInfo.DefaultParams {
 		params[key] = value
 	}
+	for key, value := range s.modelParams[modelInfo.Name] {
+		params[key] = value
+	}
+
+	// Reject out-of-range values here so they fail before any API call
+	if err := models.ValidateModelParams(modelName, params); err != nil {
+		return nil, llm.Wrap(err, "", err.Error(), llm.CategoryInvalidRequest)
+	}
 
 	return params, nil
 }
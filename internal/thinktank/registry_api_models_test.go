@@ -2,6 +2,7 @@ package thinktank
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
 
@@ -135,6 +136,49 @@ func TestRegistryAPIErrorHandling(t *testing.T) {
 	}
 }
 
+// TestRegistryAPIModelParamOverrides verifies overrides merge over a model's defaults
+func TestRegistryAPIModelParamOverrides(t *testing.T) {
+	ctx := context.Background()
+	service := NewRegistryAPIServiceWithOptions(testutil.NewMockLogger(), RegistryAPIServiceOptions{
+		ModelParams: map[string]map[string]any{
+			"gpt-5.2":         {"temperature": 0.2, "max_tokens": 4000},
+			"claude-opus-4.5": {"temperature": 1.5},
+		},
+	})
+
+	params, err := service.GetModelParameters(ctx, "gpt")
+	if err != nil {
+		t.Fatalf("GetModelParameters() error = %v", err)
+	}
+	if params["temperature"] != 0.2 || params["max_tokens"] != 4000 {
+		t.Errorf("overrides not applied: %v", params)
+	}
+	info, _ := models.GetModelInfo("gpt-5.2")
+	if info.DefaultParams["temperature"] == 0.2 {
+		t.Error("overrides modified the model's defaults")
+	}
+	for key, value := range info.DefaultParams {
+		if _, ok := params[key]; !ok {
+			t.Errorf("default %s = %v missing from %v", key, value, params)
+		}
+	}
+
+	// Overrides for other models leave this one's defaults alone
+	defaults, err := service.GetModelParameters(ctx, "gemini-3-flash")
+	if err != nil {
+		t.Fatalf("GetModelParameters() error = %v", err)
+	}
+	flash, _ := models.GetModelInfo("gemini-3-flash")
+	if len(defaults) != len(flash.DefaultParams) {
+		t.Errorf("GetModelParameters(gemini-3-flash) = %v, want defaults %v", defaults, flash.DefaultParams)
+	}
+
+	// Overrides are validated like defaults
+	if _, err := service.GetModelParameters(ctx, "claude-opus-4.5"); !errors.Is(err, models.ErrInvalidModelParams) {
+		t.Errorf("GetModelParameters(claude-opus-4.5) error = %v, want ErrInvalidModelParams", err)
+	}
+}
+
 // TestProviderDistribution verifies correct provider mapping
 func TestProviderDistribution(t *testing.T) {
 	openaiModels := models.ListModelsForProvider("openai")
@@ -0,0 +1,157 @@
+package thinktank
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/misty-step/thinktank/internal/llm"
+	"github.com/misty-step/thinktank/internal/models"
+	"github.com/misty-step/thinktank/internal/testutil"
+	"github.com/misty-step/thinktank/internal/thinktank/interfaces"
+)
+
+func TestRegistryAPIService_Preflight(t *testing.T) {
+	service, ok := NewRegistryAPIService(testutil.NewMockLogger()).(interfaces.ProviderPreflighter)
+	if !ok {
+		t.Fatal("registry API service should implement ProviderPreflighter")
+	}
+
+	t.Run("missing API key is an auth error naming the provider", func(t *testing.T) {
+		t.Setenv("OPENROUTER_API_KEY", "")
+
+		err := service.Preflight(context.Background(), []string{"gpt-5.2", "gemini-3-flash"})
+		catErr, ok := llm.IsCategorizedError(err)
+		if !ok || catErr.Category() != llm.CategoryAuth {
+			t.Fatalf("Preflight() = %v, want a CategoryAuth error", err)
+		}
+		if !strings.Contains(err.Error(), "provider 'openrouter'") {
+			t.Errorf("Preflight() error %q should name the provider", err)
+		}
+	})
+
+	t.Run("unknown models are left to client initialization", func(t *testing.T) {
+		if err := service.Preflight(context.Background(), []string{"not-a-model"}); err != nil {
+			t.Errorf("Preflight() = %v, want nil", err)
+		}
+	})
+}
+
+func TestPreflightError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		fallback     llm.ErrorCategory
+		wantCategory llm.ErrorCategory
+		wantMessage  string
+	}{
+		{
+			name:         "categorized error keeps its category",
+			err:          llm.New("openrouter", "", 503, "service unavailable", "", nil, llm.CategoryServer),
+			fallback:     llm.CategoryNetwork,
+			wantCategory: llm.CategoryServer,
+			wantMessage:  "preflight check failed for provider 'openrouter': service unavailable",
+		},
+		{
+			name:         "plain error uses the fallback",
+			err:          errors.New("API key is required"),
+			fallback:     llm.CategoryAuth,
+			wantCategory: llm.CategoryAuth,
+			wantMessage:  "preflight check failed for provider 'openrouter': API key is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := preflightError(tt.err, "openrouter", tt.fallback)
+			catErr, ok := llm.IsCategorizedError(err)
+			if !ok || catErr.Category() != tt.wantCategory {
+				t.Errorf("category = %v, want %v", err, tt.wantCategory)
+			}
+			if err.Error() != tt.wantMessage {
+				t.Errorf("Error() = %q, want %q", err.Error(), tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestRegistryAPIService_ProviderBaseURL(t *testing.T) {
+	t.Setenv("OPENROUTER_API_KEY", "sk-or-test-key")
+
+	var requestedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	service := NewRegistryAPIServiceWithBaseURLs(testutil.NewMockLogger(), map[string]string{
+		"openrouter": server.URL + "/gateway/v1",
+	}).(interfaces.ProviderPreflighter)
+
+	if err := service.Preflight(context.Background(), []string{"gpt-5.2"}); err != nil {
+		t.Fatalf("Preflight() = %v, want the configured gateway to answer", err)
+	}
+	if requestedPath != "/gateway/v1/key" {
+		t.Errorf("request path = %q, want the configured base URL", requestedPath)
+	}
+}
+
+func TestRegistryAPIService_CustomProviderModel(t *testing.T) {
+	t.Setenv("INTERNAL_LLM_KEY", "internal-key")
+
+	var requestedPath, authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"hello"},"finish_reason":"stop"}]}`))
+	}))
+	defer server.Close()
+
+	err := models.RegisterModel(models.ModelInfo{
+		Name:            "internal-llm",
+		APIModelID:      "llm-1",
+		BaseURL:         server.URL + "/v1",
+		APIKeyEnvVar:    "INTERNAL_LLM_KEY",
+		ContextWindow:   32000,
+		MaxOutputTokens: 4000,
+	})
+	if err != nil {
+		t.Fatalf("RegisterModel() = %v", err)
+	}
+	t.Cleanup(func() { models.UnregisterModel("internal-llm") })
+
+	service := NewRegistryAPIService(testutil.NewMockLogger())
+	if err := service.(interfaces.ProviderPreflighter).Preflight(context.Background(), []string{"internal-llm"}); err != nil {
+		t.Fatalf("Preflight() = %v, want the key to be found", err)
+	}
+
+	client, err := service.InitLLMClient(context.Background(), "", "internal-llm", "")
+	if err != nil {
+		t.Fatalf("InitLLMClient() = %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	result, err := client.GenerateContent(context.Background(), "hi", nil)
+	if err != nil {
+		t.Fatalf("GenerateContent() = %v", err)
+	}
+	if result.Content != "hello" {
+		t.Errorf("Content = %q, want the custom endpoint's response", result.Content)
+	}
+	if requestedPath != "/v1/chat/completions" {
+		t.Errorf("request path = %q, want the registered base URL", requestedPath)
+	}
+	if authorization != "Bearer internal-key" {
+		t.Errorf("Authorization = %q, want the registered key variable", authorization)
+	}
+
+	t.Setenv("INTERNAL_LLM_KEY", "")
+	if _, err := service.InitLLMClient(context.Background(), "", "internal-llm", ""); err == nil || !strings.Contains(err.Error(), "INTERNAL_LLM_KEY") {
+		t.Errorf("InitLLMClient() without a key = %v, want an error naming INTERNAL_LLM_KEY", err)
+	}
+}
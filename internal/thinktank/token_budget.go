@@ -0,0 +1,105 @@
+package thinktank
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/misty-step/thinktank/internal/fileutil"
+	"github.com/misty-step/thinktank/internal/models"
+	"github.com/misty-step/thinktank/internal/thinktank/interfaces"
+	"github.com/misty-step/thinktank/internal/thinktank/prompt"
+)
+
+// estimateTokens estimates text's tokens with the estimator for model, falling back
+// to the model-agnostic ratio EstimateTokensFromText uses when the model is unknown
+func estimateTokens(model, text string) int {
+	if tokens, err := models.CountTokensEstimate(model, text); err == nil {
+		return tokens
+	}
+	return int(float64(len(text)) * 0.75)
+}
+
+// splitByTokenBudget keeps files, highest priority first and otherwise in gather order,
+// while the running estimate of the instructions plus the files kept so far stays
+// within budget. Once a file doesn't fit, it and every later file are dropped, so the
+// cut is predictable. Kept files stay in gather order. Each file is estimated as
+// builder writes it into the prompt, with its path header, any code fence, and the
+// file separator.
+func splitByTokenBudget(files []fileutil.FileMeta, instructions, model string, budget int, builder prompt.PromptBuilder) (kept, dropped []fileutil.FileMeta, tokens int) {
+	order := make([]fileutil.FileMeta, len(files))
+	copy(order, files)
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].Priority > order[j].Priority
+	})
+
+	tokens = estimateTokens(model, instructions)
+	fits := make(map[string]bool, len(order))
+	for i, file := range order {
+		fileTokens := estimateTokens(model, builder.FileEntry(file))
+		if tokens+fileTokens > budget {
+			dropped = order[i:]
+			break
+		}
+		tokens += fileTokens
+		fits[file.Path] = true
+	}
+	if len(dropped) == 0 {
+		return files, nil, tokens
+	}
+
+	for _, file := range files {
+		if fits[file.Path] {
+			kept = append(kept, file)
+		}
+	}
+	return kept, dropped, tokens
+}
+
+// applyTokenBudget drops the files past config.MaxContextTokens, logging and auditing
+// each one and removing them from stats
+func (cg *contextGatherer) applyTokenBudget(ctx context.Context, files []fileutil.FileMeta, stats *interfaces.ContextStats, config interfaces.GatherConfig) []fileutil.FileMeta {
+	kept, dropped, tokens := splitByTokenBudget(files, config.Instructions, config.TokenModel, config.MaxContextTokens, config.Prompt)
+	if len(dropped) == 0 {
+		cg.logger.DebugContext(ctx, "Context uses ~%d of the %d token budget", tokens, config.MaxContextTokens)
+		return kept
+	}
+
+	droppedPaths := make(map[string]bool, len(dropped))
+	for _, file := range dropped {
+		droppedPaths[file.Path] = true
+		stats.TokenBudgetDropped = append(stats.TokenBudgetDropped, file.Path)
+		cg.logger.InfoContext(ctx, "Dropped %s: the context token budget of %d is reached", file.Path, config.MaxContextTokens)
+
+		inputs := map[string]interface{}{
+			"path":               file.Path,
+			"max_context_tokens": config.MaxContextTokens,
+			"reason":             "max_context_tokens",
+		}
+		if logErr := cg.auditLogger.LogOp(ctx, "SkipFile", "Skipped", inputs, nil, nil); logErr != nil {
+			cg.logger.ErrorContext(ctx, "Failed to write audit log: %v", logErr)
+		}
+		if config.ExplainExcludes {
+			stats.SkippedPaths = append(stats.SkippedPaths, fileutil.SkippedPath{Path: file.Path, Reason: fileutil.SkipTokenBudget})
+		}
+	}
+	sort.Slice(stats.SkippedPaths, func(i, j int) bool {
+		return stats.SkippedPaths[i].Path < stats.SkippedPaths[j].Path
+	})
+
+	// The dry-run file list was collected before the budget applied
+	processed := stats.ProcessedFiles[:0]
+	for _, path := range stats.ProcessedFiles {
+		if !droppedPaths[path] {
+			processed = append(processed, path)
+		}
+	}
+	stats.ProcessedFiles = processed
+
+	cg.logger.WarnContext(ctx, "Context token budget of %d reached at ~%d tokens: dropped %d files",
+		config.MaxContextTokens, tokens, len(dropped))
+	if !cg.dryRun {
+		cg.consoleWriter.StatusMessage(fmt.Sprintf("Context token budget reached: dropped %d files", len(dropped)))
+	}
+	return kept
+}
@@ -0,0 +1,152 @@
+package thinktank
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/misty-step/thinktank/internal/fileutil"
+	"github.com/misty-step/thinktank/internal/llm"
+	"github.com/misty-step/thinktank/internal/testutil"
+	"github.com/misty-step/thinktank/internal/thinktank/interfaces"
+	"github.com/misty-step/thinktank/internal/thinktank/prompt"
+)
+
+func TestSplitByTokenBudget(t *testing.T) {
+	// With its path header each file is 100 characters of prompt
+	content := strings.Repeat("x", 83)
+	files := []fileutil.FileMeta{{Path: "a", Content: content}, {Path: "b", Content: content}, {Path: "c", Content: content}}
+
+	tests := []struct {
+		name         string
+		instructions string
+		model        string
+		budget       int
+		wantKept     int
+		wantTokens   int
+	}{
+		// Unknown models use 0.75 tokens per character: 75 tokens per file
+		{name: "everything fits", budget: 225, wantKept: 3, wantTokens: 225},
+		{name: "stops at the first file over budget", budget: 224, wantKept: 2, wantTokens: 150},
+		{name: "instructions count toward the budget", instructions: strings.Repeat("i", 40), budget: 150, wantKept: 1, wantTokens: 105},
+		{name: "instructions alone over budget", instructions: strings.Repeat("i", 400), budget: 100, wantKept: 0, wantTokens: 300},
+		// gpt-5.2 uses 4 characters per token: 25 tokens per file
+		{name: "estimates for the model", model: "gpt-5.2", budget: 50, wantKept: 2, wantTokens: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, dropped, tokens := splitByTokenBudget(files, tt.instructions, tt.model, tt.budget, prompt.PromptBuilder{})
+			if len(kept) != tt.wantKept || len(dropped) != len(files)-tt.wantKept {
+				t.Errorf("kept %d and dropped %d files, want %d kept", len(kept), len(dropped), tt.wantKept)
+			}
+			if tokens != tt.wantTokens {
+				t.Errorf("tokens = %d, want %d", tokens, tt.wantTokens)
+			}
+			if len(kept) > 0 && kept[0].Path != "a" {
+				t.Errorf("kept files out of gather order: %v", kept)
+			}
+		})
+	}
+}
+
+func TestSplitByTokenBudgetPromptLayout(t *testing.T) {
+	files := []fileutil.FileMeta{{Path: "a.go", Content: strings.Repeat("x", 80)}}
+	plain := prompt.PromptBuilder{}.FileEntry(files[0])
+
+	tests := []struct {
+		name    string
+		builder prompt.PromptBuilder
+	}{
+		{name: "fenced code", builder: prompt.PromptBuilder{FenceCode: true}},
+		{name: "long file separator", builder: prompt.PromptBuilder{FileSeparator: strings.Repeat("=", 40)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Budget exactly what the plain layout needs; the larger layout no longer fits
+			budget := estimateTokens("", plain)
+			if _, dropped, _ := splitByTokenBudget(files, "", "", budget, prompt.PromptBuilder{}); len(dropped) != 0 {
+				t.Fatalf("plain layout dropped %d files", len(dropped))
+			}
+			_, dropped, tokens := splitByTokenBudget(files, "", "", budget, tt.builder)
+			if len(dropped) != 1 {
+				t.Errorf("dropped %d files, want the file to exceed the budget", len(dropped))
+			}
+			if want := estimateTokens("", tt.builder.FileEntry(files[0])); tokens != 0 || want <= budget {
+				t.Errorf("tokens = %d, entry estimate %d, want 0 kept and an estimate over %d", tokens, want, budget)
+			}
+		})
+	}
+}
+
+func TestSplitByTokenBudgetPriority(t *testing.T) {
+	// With its path header each file is 100 characters of prompt, 75 tokens
+	content := strings.Repeat("x", 83)
+	files := []fileutil.FileMeta{
+		{Path: "a", Content: content},
+		{Path: "b", Content: content, Priority: 1},
+		{Path: "c", Content: content},
+		{Path: "d", Content: content, Priority: 2},
+	}
+
+	kept, dropped, tokens := splitByTokenBudget(files, "", "", 225, prompt.PromptBuilder{})
+
+	var keptPaths, droppedPaths []string
+	for _, file := range kept {
+		keptPaths = append(keptPaths, file.Path)
+	}
+	for _, file := range dropped {
+		droppedPaths = append(droppedPaths, file.Path)
+	}
+	if want := []string{"a", "b", "d"}; !reflect.DeepEqual(keptPaths, want) {
+		t.Errorf("kept %v, want %v in gather order", keptPaths, want)
+	}
+	if want := []string{"c"}; !reflect.DeepEqual(droppedPaths, want) {
+		t.Errorf("dropped %v, want %v", droppedPaths, want)
+	}
+	if tokens != 225 {
+		t.Errorf("tokens = %d, want 225", tokens)
+	}
+}
+
+func TestGatherContextMaxContextTokens(t *testing.T) {
+	tempDir := testutil.SetupTempDir(t, "token-budget-test-")
+	testutil.CreateTestFiles(t, tempDir, map[string][]byte{
+		"a.go": []byte(strings.Repeat("a", 4000)),
+		"b.go": []byte(strings.Repeat("b", 4000)),
+		"c.go": []byte(strings.Repeat("c", 4000)),
+	})
+
+	mockLogger := testutil.NewMockLogger()
+	consoleWriter := &mockConsoleWriter{}
+	gatherer := NewContextGatherer(mockLogger, consoleWriter, true, &llm.MockLLMClient{}, mockLogger)
+
+	files, stats, err := gatherer.GatherContext(context.Background(), interfaces.GatherConfig{
+		Paths:            []string{tempDir},
+		ExplainExcludes:  true,
+		MaxContextTokens: 2500,
+		Instructions:     "Review",
+		TokenModel:       "gpt-5.2",
+	})
+	if err != nil {
+		t.Fatalf("GatherContext() error = %v", err)
+	}
+
+	// Each file is about 1,000 tokens for gpt-5.2, so only the first two fit
+	if len(files) != 2 || stats.ProcessedFilesCount != 2 || len(stats.ProcessedFiles) != 2 {
+		t.Fatalf("kept %d files (count %d, listed %d), want 2", len(files), stats.ProcessedFilesCount, len(stats.ProcessedFiles))
+	}
+	dropped := filepath.Join(tempDir, "c.go")
+	if want := []string{dropped}; !reflect.DeepEqual(stats.TokenBudgetDropped, want) {
+		t.Errorf("TokenBudgetDropped = %v, want %v", stats.TokenBudgetDropped, want)
+	}
+	if want := []fileutil.SkippedPath{{Path: dropped, Reason: fileutil.SkipTokenBudget}}; !reflect.DeepEqual(stats.SkippedPaths, want) {
+		t.Errorf("SkippedPaths = %v, want %v", stats.SkippedPaths, want)
+	}
+	if !strings.Contains(stats.ProcessedFiles[1], "b.go") {
+		t.Errorf("ProcessedFiles = %v, want a.go and b.go", stats.ProcessedFiles)
+	}
+}
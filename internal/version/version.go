@@ -2,7 +2,10 @@
 // Values are injected via ldflags during build.
 package version
 
-import "fmt"
+import (
+	"fmt"
+	"runtime/debug"
+)
 
 // Build-time variables injected via ldflags:
 //
@@ -20,12 +23,48 @@ var (
 	BuildDate = "unknown"
 )
 
+// readBuildInfo is a variable to allow mocking in tests
+var readBuildInfo = debug.ReadBuildInfo
+
 // String returns a formatted version string for display.
 func String() string {
-	return fmt.Sprintf("thinktank %s (%s, %s)", Version, Commit, BuildDate)
+	v, commit, date := resolve()
+	return fmt.Sprintf("thinktank %s (%s, %s)", v, commit, date)
 }
 
 // Short returns just the version number.
 func Short() string {
-	return Version
+	v, _, _ := resolve()
+	return v
+}
+
+// resolve returns the ldflags values, filling any left at their defaults from
+// the Go toolchain's embedded build info (e.g. binaries built with `go install`).
+func resolve() (v, commit, date string) {
+	v, commit, date = Version, Commit, BuildDate
+
+	info, ok := readBuildInfo()
+	if !ok || info == nil {
+		return v, commit, date
+	}
+
+	if v == "dev" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		v = info.Main.Version
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if commit == "unknown" && setting.Value != "" {
+				commit = setting.Value
+				if len(commit) > 7 {
+					commit = commit[:7]
+				}
+			}
+		case "vcs.time":
+			if date == "unknown" && setting.Value != "" {
+				date = setting.Value
+			}
+		}
+	}
+	return v, commit, date
 }
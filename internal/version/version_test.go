@@ -0,0 +1,77 @@
+package version
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   string
+		commit    string
+		buildDate string
+		buildInfo *debug.BuildInfo
+		want      string
+	}{
+		{
+			name:      "ldflags values take precedence",
+			version:   "v1.2.3",
+			commit:    "abc1234",
+			buildDate: "2025-01-13T10:00:00Z",
+			buildInfo: &debug.BuildInfo{
+				Main:     debug.Module{Version: "v9.9.9"},
+				Settings: []debug.BuildSetting{{Key: "vcs.revision", Value: "ffffffffffff"}},
+			},
+			want: "thinktank v1.2.3 (abc1234, 2025-01-13T10:00:00Z)",
+		},
+		{
+			name:      "falls back to embedded build info",
+			version:   "dev",
+			commit:    "unknown",
+			buildDate: "unknown",
+			buildInfo: &debug.BuildInfo{
+				Main: debug.Module{Version: "v1.4.0"},
+				Settings: []debug.BuildSetting{
+					{Key: "vcs.revision", Value: "0123456789abcdef"},
+					{Key: "vcs.time", Value: "2025-02-01T12:00:00Z"},
+				},
+			},
+			want: "thinktank v1.4.0 (0123456, 2025-02-01T12:00:00Z)",
+		},
+		{
+			name:      "devel module version is ignored",
+			version:   "dev",
+			commit:    "unknown",
+			buildDate: "unknown",
+			buildInfo: &debug.BuildInfo{Main: debug.Module{Version: "(devel)"}},
+			want:      "thinktank dev (unknown, unknown)",
+		},
+		{
+			name:      "no build info available",
+			version:   "dev",
+			commit:    "unknown",
+			buildDate: "unknown",
+			buildInfo: nil,
+			want:      "thinktank dev (unknown, unknown)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origVersion, origCommit, origDate, origRead := Version, Commit, BuildDate, readBuildInfo
+			defer func() {
+				Version, Commit, BuildDate, readBuildInfo = origVersion, origCommit, origDate, origRead
+			}()
+
+			Version, Commit, BuildDate = tt.version, tt.commit, tt.buildDate
+			readBuildInfo = func() (*debug.BuildInfo, bool) {
+				return tt.buildInfo, tt.buildInfo != nil
+			}
+
+			if got := String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Package thinktank is the public API for programs that embed thinktank: they
+// register their own models at start-up and then run the command-line tool.
+//
+//	func main() {
+//		err := thinktank.RegisterModel(thinktank.ModelInfo{
+//			Name:            "internal-llm",
+//			BaseURL:         "https://llm.internal.example/v1",
+//			APIKeyEnvVar:    "INTERNAL_LLM_KEY",
+//			ContextWindow:   32000,
+//			MaxOutputTokens: 4000,
+//		})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		thinktank.Main()
+//	}
+package thinktank
+
+import (
+	"github.com/misty-step/thinktank/internal/cli"
+	"github.com/misty-step/thinktank/internal/models"
+)
+
+// ModelInfo describes a model to register; see RegisterModel for the required fields
+type ModelInfo = models.ModelInfo
+
+// ParameterConstraint limits a parameter a registered model accepts
+type ParameterConstraint = models.ParameterConstraint
+
+// CustomProvider serves registered models from an OpenAI-compatible API at their
+// BaseURL, using the key in their APIKeyEnvVar
+const CustomProvider = models.CustomProvider
+
+// ErrInvalidModelRegistration is wrapped by RegisterModel errors
+var ErrInvalidModelRegistration = models.ErrInvalidModelRegistration
+
+// RegisterModel adds a model to the catalog so it can be selected with --model
+// like a built-in one. info.Name is the model ID and must not contain '#';
+// Provider defaults to CustomProvider, which requires BaseURL and APIKeyEnvVar,
+// and "openrouter" is also accepted. ContextWindow and MaxOutputTokens must be
+// positive. Call it before Main, never concurrently with a run.
+func RegisterModel(info ModelInfo) error {
+	return models.RegisterModel(info)
+}
+
+// UnregisterModel removes a model added with RegisterModel, reporting whether it
+// was registered
+func UnregisterModel(name string) bool {
+	return models.UnregisterModel(name)
+}
+
+// Main runs the thinktank command line with os.Args and exits the process, just
+// as the thinktank binary does
+func Main() {
+	cli.Main()
+}
@@ -0,0 +1,35 @@
+package thinktank_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/misty-step/thinktank/pkg/thinktank"
+)
+
+func TestRegisterModel(t *testing.T) {
+	info := thinktank.ModelInfo{
+		Name:            "embedded-llm",
+		BaseURL:         "https://llm.internal.example/v1",
+		APIKeyEnvVar:    "EMBEDDED_LLM_KEY",
+		ContextWindow:   32000,
+		MaxOutputTokens: 4000,
+	}
+	if err := thinktank.RegisterModel(info); err != nil {
+		t.Fatalf("RegisterModel() = %v, want nil", err)
+	}
+	t.Cleanup(func() { thinktank.UnregisterModel(info.Name) })
+
+	if err := thinktank.RegisterModel(info); !errors.Is(err, thinktank.ErrInvalidModelRegistration) {
+		t.Errorf("registering %s twice = %v, want ErrInvalidModelRegistration", info.Name, err)
+	}
+
+	info.Name = "embedded-llm#2"
+	if err := thinktank.RegisterModel(info); !errors.Is(err, thinktank.ErrInvalidModelRegistration) {
+		t.Errorf("RegisterModel(%q) = %v, want ErrInvalidModelRegistration", info.Name, err)
+	}
+
+	if !thinktank.UnregisterModel("embedded-llm") || thinktank.UnregisterModel("embedded-llm") {
+		t.Error("UnregisterModel() should remove the model exactly once")
+	}
+}